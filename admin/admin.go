@@ -63,6 +63,12 @@ func RegisterAdminHandler(name string, handler interface{}) {
 	instance.rpcServer.RegisterName(name, handler)
 }
 
+// RegisterHTTPHandler is used by other packages to
+// expose http endpoints, eg. for streaming responses, over the unix socket
+func RegisterHTTPHandler(pattern string, handler http.Handler) {
+	instance.srvMux.Handle(pattern, handler)
+}
+
 // RegisterStatusHandler expects object implementing PackageStatusHandler interface
 func RegisterStatusHandler(name string, handler PackageStatusHandler) {
 	instance.statushandlers[strings.ToLower(name)] = handler
@@ -71,6 +77,7 @@ func RegisterStatusHandler(name string, handler PackageStatusHandler) {
 type Admin struct {
 	statushandlers map[string]PackageStatusHandler
 	rpcServer      *rpc.Server
+	srvMux         *http.ServeMux
 }
 
 var instance Admin
@@ -80,8 +87,10 @@ func Init() {
 	instance = Admin{
 		statushandlers: make(map[string]PackageStatusHandler),
 		rpcServer:      rpc.NewServer(),
+		srvMux:         http.NewServeMux(),
 	}
 	instance.rpcServer.Register(instance)
+	instance.srvMux.Handle(rpc.DefaultRPCPath, instance.rpcServer)
 	pkgLogger = logger.NewLogger().Child("admin")
 }
 
@@ -230,7 +239,7 @@ func (a Admin) GetFormattedEnv(env string, reply *string) (err error) {
 	return nil
 }
 
-// StartServer starts an http server listening on unix socket and serving rpc communication
+// StartServer starts an http server listening on unix socket and serving rpc communication & registered http handlers
 func StartServer(ctx context.Context) error {
 	tmpDirPath, err := misc.CreateTMPDIR()
 	if err != nil {
@@ -257,10 +266,7 @@ func StartServer(ctx context.Context) error {
 	}()
 
 	pkgLogger.Info("Serving on admin interface @ ", sockAddr)
-	srvMux := http.NewServeMux()
-	srvMux.Handle(rpc.DefaultRPCPath, instance.rpcServer)
-
-	srv := &http.Server{Handler: srvMux}
+	srv := &http.Server{Handler: instance.srvMux}
 	go func() {
 		<-ctx.Done()
 		srv.Shutdown(context.Background())
@@ -18,6 +18,7 @@ import (
 	"github.com/rudderlabs/rudder-server/router/batchrouter"
 	"github.com/rudderlabs/rudder-server/services/db"
 	destinationdebugger "github.com/rudderlabs/rudder-server/services/debugger/destination"
+	taildebugger "github.com/rudderlabs/rudder-server/services/debugger/tail"
 	transformationdebugger "github.com/rudderlabs/rudder-server/services/debugger/transformation"
 	"github.com/rudderlabs/rudder-server/utils/misc"
 	"github.com/rudderlabs/rudder-server/utils/types"
@@ -86,6 +87,7 @@ func (processor *ProcessorApp) StartRudderCore(ctx context.Context, options *app
 
 	transformationdebugger.Setup()
 	destinationdebugger.Setup(backendconfig.DefaultBackendConfig)
+	taildebugger.Setup()
 
 	migrationMode := processor.App.Options().MigrationMode
 
@@ -103,6 +105,8 @@ func (processor *ProcessorApp) StartRudderCore(ctx context.Context, options *app
 
 		procErrorDB.Setup(jobsdb.ReadWrite, options.ClearDB, "proc_error", routerDBRetention, migrationMode, false, jobsdb.QueryFiltersT{})
 		defer procErrorDB.TearDown()
+
+		setupDeadLetterQueue(&procErrorDB, &routerDB, &batchRouterDB)
 	}
 
 	var reportingI types.ReportingI
@@ -15,6 +15,7 @@ import (
 	"github.com/rudderlabs/rudder-server/router"
 	"github.com/rudderlabs/rudder-server/router/batchrouter"
 	"github.com/rudderlabs/rudder-server/services/diagnostics"
+	"github.com/rudderlabs/rudder-server/services/dlq"
 	"github.com/rudderlabs/rudder-server/services/validators"
 	"github.com/rudderlabs/rudder-server/utils"
 	"github.com/rudderlabs/rudder-server/utils/logger"
@@ -111,6 +112,21 @@ func rudderCoreBaseSetup() {
 	router.RegisterAdminHandlers(&readonlyRouterDB, &readonlyBatchRouterDB)
 }
 
+//setupDeadLetterQueue sets up the dead letter queue of aborted router & batch router jobs, if it is enabled.
+//Otherwise jobs held while it was enabled are aborted, so that they don't stay waiting forever.
+func setupDeadLetterQueue(procErrorDB, routerDB, batchRouterDB jobsdb.JobsDB) {
+	if !dlq.IsEnabled() {
+		count, err := dlq.New(procErrorDB, routerDB, batchRouterDB).AbortHeld()
+		if err != nil {
+			pkgLogger.Errorf("Failed to abort jobs held in dead letter queue: %v", err)
+		} else if count > 0 {
+			pkgLogger.Infof("Aborted %d jobs held in dead letter queue, as it is disabled", count)
+		}
+		return
+	}
+	dlq.RegisterAdminHandlers(dlq.Setup(procErrorDB, routerDB, batchRouterDB))
+}
+
 //StartProcessor atomically starts processor process if not already started
 func StartProcessor(ctx context.Context, clearDB *bool, enableProcessor bool, gatewayDB, routerDB, batchRouterDB *jobsdb.HandleT, procErrorDB *jobsdb.HandleT, reporting types.ReportingI) {
 	if !enableProcessor {
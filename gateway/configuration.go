@@ -31,6 +31,8 @@ func loadConfig() {
 	config.RegisterBoolConfigVariable(false, &enableRateLimit, true, "Gateway.enableRateLimit")
 	// Enable suppress user feature. false by default
 	config.RegisterBoolConfigVariable(true, &enableSuppressUserFeature, false, "Gateway.enableSuppressUserFeature")
+	// Suppress users & drop their pending events once regulation-worker deletes their data. false by default
+	config.RegisterBoolConfigVariable(false, &enableRegulationPropagation, false, "Gateway.enableRegulationPropagation")
 	// EventSchemas feature. false by default
 	config.RegisterBoolConfigVariable(false, &enableEventSchemasFeature, false, "EventSchemas.enableEventSchemasFeature")
 	// Time period for diagnosis ticker
@@ -38,6 +40,12 @@ func loadConfig() {
 	// Enables accepting requests without user id and anonymous id. This is added to prevent client 4xx retries.
 	config.RegisterBoolConfigVariable(false, &allowReqsWithoutUserIDAndAnonymousID, true, "Gateway.allowReqsWithoutUserIDAndAnonymousID")
 	config.RegisterBoolConfigVariable(true, &gwAllowPartialWriteWithErrors, true, "Gateway.allowPartialWriteWithErrors")
+	// Deduplicates retried requests by their Idempotency-Key header. false by default
+	config.RegisterBoolConfigVariable(false, &enableIdempotencyKeys, true, "Gateway.Idempotency.enabled")
+	// Time for which the responses of requests are remembered by their idempotency keys
+	config.RegisterDurationConfigVariable(time.Duration(10), &idempotencyKeyTTL, true, time.Minute, "Gateway.Idempotency.ttl")
+	// Maximum number of idempotency keys remembered by a gateway, least recently used ones are evicted beyond it
+	config.RegisterIntConfigVariable(100000, &maxIdempotencyKeys, true, 1, "Gateway.Idempotency.maxKeys")
 	config.RegisterDurationConfigVariable(time.Duration(0), &ReadTimeout, false, time.Second, []string{"ReadTimeout", "ReadTimeOutInSec"}...)
 	config.RegisterDurationConfigVariable(time.Duration(0), &ReadHeaderTimeout, false, time.Second, []string{"ReadHeaderTimeout", "ReadHeaderTimeoutInSec"}...)
 	config.RegisterDurationConfigVariable(time.Duration(10), &WriteTimeout, false, time.Second, []string{"WriteTimeout", "WriteTimeOutInSec"}...)
@@ -69,6 +77,13 @@ func SetEnableRateLimit(b bool) bool {
 	return prev
 }
 
+//SetEnableIdempotencyKeys overrides enableIdempotencyKeys configuration and returns previous value
+func SetEnableIdempotencyKeys(b bool) bool {
+	prev := enableIdempotencyKeys
+	enableIdempotencyKeys = b
+	return prev
+}
+
 //SetEnableSuppressUserFeature overrides enableSuppressUserFeature configuration and returns previous value
 func SetEnableSuppressUserFeature(b bool) bool {
 	prev := enableSuppressUserFeature
@@ -3,6 +3,7 @@ package gateway
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -25,6 +26,8 @@ import (
 	"github.com/rudderlabs/rudder-server/router"
 	recovery "github.com/rudderlabs/rudder-server/services/db"
 	"github.com/rudderlabs/rudder-server/services/diagnostics"
+	"github.com/rudderlabs/rudder-server/services/dlq"
+	"github.com/rudderlabs/rudder-server/services/regulation"
 	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
 	"golang.org/x/sync/errgroup"
 
@@ -39,6 +42,7 @@ import (
 	ratelimiter "github.com/rudderlabs/rudder-server/rate-limiter"
 	"github.com/rudderlabs/rudder-server/rruntime"
 	sourcedebugger "github.com/rudderlabs/rudder-server/services/debugger/source"
+	taildebugger "github.com/rudderlabs/rudder-server/services/debugger/tail"
 	"github.com/rudderlabs/rudder-server/services/stats"
 	"github.com/rudderlabs/rudder-server/utils"
 	"github.com/rudderlabs/rudder-server/utils/logger"
@@ -85,6 +89,7 @@ var (
 	maxReqSize                                                                int
 	enableRateLimit                                                           bool
 	enableSuppressUserFeature                                                 bool
+	enableRegulationPropagation                                               bool
 	enableEventSchemasFeature                                                 bool
 	diagnosisTickerTime                                                       time.Duration
 	ReadTimeout                                                               time.Duration
@@ -93,6 +98,9 @@ var (
 	IdleTimeout                                                               time.Duration
 	allowReqsWithoutUserIDAndAnonymousID                                      bool
 	gwAllowPartialWriteWithErrors                                             bool
+	enableIdempotencyKeys                                                     bool
+	idempotencyKeyTTL                                                         time.Duration
+	maxIdempotencyKeys                                                        int
 	pkgLogger                                                                 logger.LoggerI
 	Diagnostics                                                               diagnostics.DiagnosticsI
 )
@@ -163,6 +171,7 @@ type HandleT struct {
 	userWebRequestWorkers                                      []*userWebRequestWorkerT
 	webhookHandler                                             *webhook.HandleT
 	suppressUserHandler                                        types.SuppressUserI
+	regulationSuppressions                                     *regulation.SuppressionStoreT
 	eventSchemaHandler                                         types.EventSchemasI
 	versionHandler                                             func(w http.ResponseWriter, r *http.Request)
 	logger                                                     logger.LoggerI
@@ -172,6 +181,7 @@ type HandleT struct {
 	netHandle                                                  *http.Client
 	httpTimeout                                                time.Duration
 	httpWebServer                                              *http.Server
+	idempotencyKeys                                            *idempotencyStoreT
 
 	backgroundCancel context.CancelFunc
 	backgroundWait   func() error
@@ -515,6 +525,22 @@ func (gateway *HandleT) userWebRequestWorkerProcess(userWebRequestWorker *userWe
 				}
 			}
 
+			// only the events of users suppressed as per regulations are dropped, the rest of the batch is accepted
+			if enableRegulationPropagation && gateway.regulationSuppressions != nil {
+				allowedEvents := dropSuppressedEvents(out, func(userID string) bool {
+					return gateway.regulationSuppressions.IsSuppressedUser(userID, sourceID, writeKey)
+				})
+				if len(allowedEvents) == 0 {
+					req.done <- ""
+					preDbStoreCount++
+					continue
+				}
+				if len(allowedEvents) < len(out) {
+					totalEventsInReq = len(allowedEvents)
+					body, _ = sjson.SetBytes(body, "batch", allowedEvents)
+				}
+			}
+
 			body, _ = sjson.SetBytes(body, "requestIP", ipAddr)
 			body, _ = sjson.SetBytes(body, "writeKey", writeKey)
 			body, _ = sjson.SetBytes(body, "receivedAt", time.Now().Format(misc.RFC3339Milli))
@@ -577,6 +603,9 @@ func (gateway *HandleT) userWebRequestWorkerProcess(userWebRequestWorker *userWe
 		for _, eventBatch := range eventBatchesToRecord {
 			writeKey := gjson.Get(eventBatch, "writeKey").Str
 			sourcedebugger.RecordEvent(writeKey, eventBatch)
+			if taildebugger.HasSubscribers() {
+				taildebugger.RecordGatewayEvents(gateway.getSourceIDForWriteKey(writeKey), eventBatch)
+			}
 		}
 
 		userWebRequestWorker.batchTimeStat.End()
@@ -596,6 +625,24 @@ func (gateway *HandleT) userWebRequestWorkerProcess(userWebRequestWorker *userWe
 
 }
 
+// dropSuppressedEvents returns the events whose userId & anonymousId aren't suppressed as per isSuppressed
+func dropSuppressedEvents(events []map[string]interface{}, isSuppressed func(userID string) bool) []map[string]interface{} {
+	allowedEvents := make([]map[string]interface{}, 0, len(events))
+	for _, event := range events {
+		var suppressed bool
+		for _, key := range []string{"userId", "anonymousId"} {
+			if id, ok := event[key].(string); ok && isSuppressed(strings.TrimSpace(id)) {
+				suppressed = true
+				break
+			}
+		}
+		if !suppressed {
+			allowedEvents = append(allowedEvents, event)
+		}
+	}
+	return allowedEvents
+}
+
 func (gateway *HandleT) isWriteKeyEnabled(writeKey string) bool {
 	configSubscriberLock.RLock()
 	defer configSubscriberLock.RUnlock()
@@ -799,7 +846,7 @@ func (gateway *HandleT) ClearHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	opID, err := operationmanager.GetOperationManager().InsertOperation(payload)
+	opID, err := operationmanager.GetOperationManager().InsertOperation(operationmanager.ClearOperation, payload)
 	if err != nil {
 		errorMessage = err.Error()
 		return
@@ -808,6 +855,177 @@ func (gateway *HandleT) ClearHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(fmt.Sprintf(`{"op_id": %d}`, opID)))
 }
 
+//RegulationHandler suppresses users whose data has been deleted by regulation-worker
+//and queues an operation to drop their pending events from the pipeline
+func (gateway *HandleT) RegulationHandler(w http.ResponseWriter, r *http.Request) {
+	gateway.logger.LogRequest(r)
+	var errorMessage string
+	defer func() {
+		if errorMessage != "" {
+			gateway.logger.Info(fmt.Sprintf("IP: %s -- %s -- Response: 400, %s", misc.GetIPFromReq(r), r.URL.Path, errorMessage))
+			http.Error(w, errorMessage, 400)
+		}
+	}()
+
+	if !enableRegulationPropagation || gateway.regulationSuppressions == nil {
+		errorMessage = "regulation propagation is not enabled"
+		return
+	}
+
+	workspaceToken, _, ok := r.BasicAuth()
+	if !ok || workspaceToken == "" || workspaceToken != config.GetWorkspaceToken() {
+		gateway.logger.Info(fmt.Sprintf("IP: %s -- %s -- Response: 401, invalid workspace token", misc.GetIPFromReq(r), r.URL.Path))
+		http.Error(w, "invalid workspace token", 401)
+		return
+	}
+
+	payload, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		errorMessage = response.GetStatus(response.RequestBodyReadFailed)
+		return
+	}
+
+	if !gjson.ValidBytes(payload) {
+		errorMessage = response.GetStatus(response.InvalidJSON)
+		return
+	}
+
+	var reqPayload regulation.PropagationRequestT
+	err = json.Unmarshal(payload, &reqPayload)
+	if err != nil {
+		errorMessage = err.Error()
+		return
+	}
+
+	if err = reqPayload.Validate(); err != nil {
+		errorMessage = err.Error()
+		return
+	}
+
+	suppressedCount, err := gateway.regulationSuppressions.Suppress(reqPayload)
+	if err != nil {
+		errorMessage = err.Error()
+		return
+	}
+	err = regulation.RecordAction(gateway.regulationSuppressions.DBHandle(), reqPayload, regulation.SuppressUsersAction, map[string]interface{}{
+		"count": suppressedCount,
+	})
+	if err != nil {
+		errorMessage = err.Error()
+		return
+	}
+
+	opID, err := operationmanager.GetOperationManager().InsertOperation(operationmanager.RegulationOperation, payload)
+	if err != nil {
+		errorMessage = err.Error()
+		return
+	}
+
+	w.Write([]byte(fmt.Sprintf(`{"op_id": %d}`, opID)))
+}
+
+func (gateway *HandleT) dlqListHandler(w http.ResponseWriter, r *http.Request) {
+	gateway.dlqHandler(w, r, "list")
+}
+
+func (gateway *HandleT) dlqRedriveHandler(w http.ResponseWriter, r *http.Request) {
+	gateway.dlqHandler(w, r, "redrive")
+}
+
+func (gateway *HandleT) dlqDiscardHandler(w http.ResponseWriter, r *http.Request) {
+	gateway.dlqHandler(w, r, "discard")
+}
+
+//dlqHandler lists, redrives or discards aborted router & batch router jobs held in the dead letter queue
+func (gateway *HandleT) dlqHandler(w http.ResponseWriter, r *http.Request, reqType string) {
+	gateway.logger.LogRequest(r)
+	var errorMessage string
+	defer func() {
+		if errorMessage != "" {
+			gateway.logger.Info(fmt.Sprintf("IP: %s -- %s -- Response: 400, %s", misc.GetIPFromReq(r), r.URL.Path, errorMessage))
+			http.Error(w, errorMessage, 400)
+		}
+	}()
+
+	deadLetterQueue := dlq.GetSetupInstance()
+	if deadLetterQueue == nil {
+		errorMessage = "dead letter queue is not enabled"
+		return
+	}
+
+	workspaceToken, _, ok := r.BasicAuth()
+	if !ok || workspaceToken == "" || workspaceToken != config.GetWorkspaceToken() {
+		gateway.logger.Info(fmt.Sprintf("IP: %s -- %s -- Response: 401, invalid workspace token", misc.GetIPFromReq(r), r.URL.Path))
+		http.Error(w, "invalid workspace token", 401)
+		return
+	}
+
+	var resp interface{}
+	var err error
+	switch reqType {
+	case "list":
+		query := r.URL.Query()
+		filter := dlq.FilterT{
+			Stage:         query.Get("stage"),
+			DestinationID: query.Get("destination_id"),
+			ErrorCode:     query.Get("error_code"),
+		}
+		if afterJobID := query.Get("after_job_id"); afterJobID != "" {
+			if filter.AfterJobID, err = strconv.ParseInt(afterJobID, 10, 64); err != nil {
+				errorMessage = "invalid after_job_id"
+				return
+			}
+		}
+		if limit := query.Get("limit"); limit != "" {
+			if filter.Limit, err = strconv.Atoi(limit); err != nil {
+				errorMessage = "invalid limit"
+				return
+			}
+		}
+		resp, err = deadLetterQueue.List(filter)
+	case "redrive", "discard":
+		var payload []byte
+		payload, err = io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			errorMessage = response.GetStatus(response.RequestBodyReadFailed)
+			return
+		}
+		if !gjson.ValidBytes(payload) {
+			errorMessage = response.GetStatus(response.InvalidJSON)
+			return
+		}
+		if reqType == "redrive" {
+			var reqPayload dlq.RedriveRequestT
+			if err = json.Unmarshal(payload, &reqPayload); err != nil {
+				errorMessage = err.Error()
+				return
+			}
+			resp, err = deadLetterQueue.Redrive(reqPayload)
+		} else {
+			var reqPayload dlq.DiscardRequestT
+			if err = json.Unmarshal(payload, &reqPayload); err != nil {
+				errorMessage = err.Error()
+				return
+			}
+			resp, err = deadLetterQueue.Discard(reqPayload)
+		}
+	}
+	if err != nil {
+		errorMessage = err.Error()
+		return
+	}
+
+	respJSON, err := json.Marshal(resp)
+	if err != nil {
+		errorMessage = err.Error()
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(respJSON)
+}
+
 type pendingEventsRequestPayload struct {
 	SourceID      string `json:"source_id"`
 	DestinationID string `json:"destination_id"`
@@ -1108,7 +1326,13 @@ func (gateway *HandleT) webRequestHandler(rh RequestHandler, w http.ResponseWrit
 		errorMessage = err.Error()
 		return
 	}
+	idempotencyKey, handled := gateway.beginIdempotentRequest(w, r, reqType, writeKey, payload)
+	if handled {
+		atomic.AddUint64(&gateway.ackCount, 1)
+		return
+	}
 	errorMessage = rh.ProcessRequest(gateway, &w, r, reqType, payload, writeKey)
+	gateway.completeIdempotentRequest(idempotencyKey, errorMessage)
 	atomic.AddUint64(&gateway.ackCount, 1)
 	gateway.trackRequestMetrics(errorMessage)
 	if errorMessage != "" {
@@ -1379,6 +1603,10 @@ func (gateway *HandleT) StartWebHandler(ctx context.Context) error {
 	//todo: remove in next release
 	srvMux.HandleFunc("/v1/pending-events", gateway.stat(gateway.pendingEventsHandler)).Methods("POST")
 	srvMux.HandleFunc("/v1/clear", gateway.stat(gateway.ClearHandler)).Methods("POST")
+	srvMux.HandleFunc("/v1/regulations", gateway.stat(gateway.RegulationHandler)).Methods("POST")
+	srvMux.HandleFunc("/v1/dlq", gateway.stat(gateway.dlqListHandler)).Methods("GET")
+	srvMux.HandleFunc("/v1/dlq/redrive", gateway.stat(gateway.dlqRedriveHandler)).Methods("POST")
+	srvMux.HandleFunc("/v1/dlq/discard", gateway.stat(gateway.dlqDiscardHandler)).Methods("POST")
 	srvMux.HandleFunc("/v1/failed-events", gateway.stat(gateway.fetchFailedEventsHandler)).Methods("POST")
 	srvMux.HandleFunc("/v1/clear-failed-events", gateway.stat(gateway.clearFailedEventsHandler)).Methods("POST")
 
@@ -1427,6 +1655,10 @@ func (gateway *HandleT) StartAdminHandler(ctx context.Context) error {
 	srvMux.Use(headerMiddleware)
 	srvMux.HandleFunc("/v1/clear", gateway.stat(gateway.ClearHandler)).Methods("POST")
 	srvMux.HandleFunc("/v1/clear", gateway.stat(gateway.OperationStatusHandler)).Methods("GET")
+	srvMux.HandleFunc("/v1/regulations", gateway.stat(gateway.RegulationHandler)).Methods("POST")
+	srvMux.HandleFunc("/v1/dlq", gateway.stat(gateway.dlqListHandler)).Methods("GET")
+	srvMux.HandleFunc("/v1/dlq/redrive", gateway.stat(gateway.dlqRedriveHandler)).Methods("POST")
+	srvMux.HandleFunc("/v1/dlq/discard", gateway.stat(gateway.dlqDiscardHandler)).Methods("POST")
 	srvMux.HandleFunc("/v1/pending-events", gateway.stat(gateway.pendingEventsHandler)).Methods("POST")
 
 	srv := &http.Server{
@@ -1532,6 +1764,16 @@ func (gateway *HandleT) GetWebhookSourceDefName(writeKey string) (name string, o
 	return
 }
 
+// GetWebhookSourceConfig returns the config of the webhook source by write key
+func (gateway *HandleT) GetWebhookSourceConfig(writeKey string) (config map[string]interface{}, ok bool) {
+	configSubscriberLock.RLock()
+	defer configSubscriberLock.RUnlock()
+	if _, ok = enabledWriteKeyWebhookMap[writeKey]; !ok {
+		return nil, false
+	}
+	return enabledWriteKeysSourceMap[writeKey].Config, true
+}
+
 func (gateway *HandleT) SetReadonlyDBs(readonlyGatewayDB, readonlyRouterDB, readonlyBatchRouterDB jobsdb.ReadonlyJobsDB) {
 	gateway.readonlyGatewayDB = readonlyGatewayDB
 	gateway.readonlyRouterDB = readonlyRouterDB
@@ -1579,6 +1821,7 @@ func (gateway *HandleT) Setup(application app.Interface, backendConfig backendco
 
 	gateway.irh = &ImportRequestHandler{}
 	gateway.rrh = &RegularRequestHandler{}
+	gateway.idempotencyKeys = newIdempotencyStore()
 
 	gateway.webhookHandler = webhook.Setup(gateway)
 	gatewayAdmin := GatewayAdmin{handle: gateway}
@@ -1604,6 +1847,18 @@ func (gateway *HandleT) Setup(application app.Interface, backendConfig backendco
 	ctx, cancel := context.WithCancel(context.Background())
 	g, ctx := errgroup.WithContext(ctx)
 
+	if enableRegulationPropagation {
+		dbHandle, err := sql.Open("postgres", jobsdb.GetConnectionString())
+		if err != nil {
+			panic(err)
+		}
+		gateway.regulationSuppressions = regulation.NewSuppressionStore(dbHandle, gateway.backendConfig.GetWorkspaceIDForWriteKey)
+		g.Go(misc.WithBugsnag(func() error {
+			gateway.regulationSuppressions.StartRefreshLoop(ctx)
+			return nil
+		}))
+	}
+
 	if err := gateway.backendConfig.WaitForConfig(ctx); err != nil {
 		cancel()
 		return
@@ -1634,6 +1889,10 @@ func (gateway *HandleT) Setup(application app.Interface, backendConfig backendco
 		gateway.collectMetrics(ctx)
 		return nil
 	}))
+	g.Go(misc.WithBugsnag(func() error {
+		gateway.idempotencyKeys.cleanupLoop(ctx)
+		return nil
+	}))
 }
 
 func (gateway *HandleT) Shutdown() {
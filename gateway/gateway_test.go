@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"time"
 
 	uuid "github.com/gofrs/uuid"
@@ -166,6 +168,23 @@ var _ = Describe("Reconstructing JSON for ServerSide SDK", func() {
 	)
 })
 
+var _ = Describe("Dropping events of suppressed users", func() {
+	It("should drop only the events whose userId or anonymousId is suppressed", func() {
+		events := []map[string]interface{}{
+			{"userId": NormalUserID, "anonymousId": "anon-1", "messageId": "m1"},
+			{"userId": SuppressedUserID, "anonymousId": "anon-2", "messageId": "m2"},
+			{"anonymousId": SuppressedUserID, "messageId": "m3"},
+			{"anonymousId": "anon-3", "messageId": "m4"},
+		}
+		allowedEvents := dropSuppressedEvents(events, func(userID string) bool {
+			return userID == SuppressedUserID
+		})
+		Expect(allowedEvents).To(HaveLen(2))
+		Expect(allowedEvents[0]["messageId"]).To(Equal("m1"))
+		Expect(allowedEvents[1]["messageId"]).To(Equal("m4"))
+	})
+})
+
 func initGW() {
 	config.Load()
 	admin.Init()
@@ -466,6 +485,84 @@ var _ = Describe("Gateway", func() {
 		})
 	})
 
+	Context("Idempotency keys", func() {
+		var (
+			gateway                   = &HandleT{}
+			prevEnableIdempotencyKeys bool
+		)
+
+		BeforeEach(func() {
+			prevEnableIdempotencyKeys = SetEnableIdempotencyKeys(true)
+			gateway.Setup(c.mockApp, c.mockBackendConfig, c.mockJobsDB, nil, c.mockVersionHandler)
+		})
+
+		AfterEach(func() {
+			SetEnableIdempotencyKeys(prevEnableIdempotencyKeys)
+		})
+
+		idempotentRequest := func(key string, body string) *http.Request {
+			req := authorizedRequest(WriteKeyEnabled, bytes.NewBufferString(body))
+			req.Header.Set("Idempotency-Key", key)
+			return req
+		}
+
+		It("should store retried requests once and replay their response", func() {
+			c.mockJobsDB.EXPECT().StoreWithRetryEach(gomock.Any()).DoAndReturn(jobsToEmptyErrors).Times(1).Do(c.asyncHelper.ExpectAndNotifyCallbackWithName(""))
+
+			expectHandlerResponse(gateway.webBatchHandler, idempotentRequest("key-1", `{"batch": [{"userId": "dummyId"}]}`), 200, "OK")
+
+			rr := httptest.NewRecorder()
+			gateway.webBatchHandler(rr, idempotentRequest("key-1", `{"batch": [{"userId": "dummyId"}]}`))
+			Expect(rr.Code).To(Equal(200))
+			Expect(rr.Body.String()).To(Equal("OK"))
+			Expect(rr.Header().Get("Idempotent-Replayed")).To(Equal("true"))
+		})
+
+		It("should reject requests reusing idempotency keys with different payloads", func() {
+			c.mockJobsDB.EXPECT().StoreWithRetryEach(gomock.Any()).DoAndReturn(jobsToEmptyErrors).Times(1).Do(c.asyncHelper.ExpectAndNotifyCallbackWithName(""))
+
+			expectHandlerResponse(gateway.webTrackHandler, idempotentRequest("key-2", `{"userId": "dummyId"}`), 200, "OK")
+			expectHandlerResponse(gateway.webTrackHandler, idempotentRequest("key-2", `{"userId": "otherId"}`), 422, response.IdempotencyKeyReused+"\n")
+			expectHandlerResponse(gateway.webIdentifyHandler, idempotentRequest("key-2", `{"userId": "dummyId"}`), 422, response.IdempotencyKeyReused+"\n")
+		})
+
+		It("should process retries of failed requests again", func() {
+			expectHandlerResponse(gateway.webTrackHandler, idempotentRequest("key-3", `{}`), 400, response.NonIdentifiableRequest+"\n")
+
+			c.mockJobsDB.EXPECT().StoreWithRetryEach(gomock.Any()).DoAndReturn(jobsToEmptyErrors).Times(1).Do(c.asyncHelper.ExpectAndNotifyCallbackWithName(""))
+			expectHandlerResponse(gateway.webTrackHandler, idempotentRequest("key-3", `{"userId": "dummyId"}`), 200, "OK")
+		})
+
+		It("should evict least recently used keys beyond the maximum number of keys", func() {
+			prevMaxIdempotencyKeys := maxIdempotencyKeys
+			maxIdempotencyKeys = 2
+			defer func() { maxIdempotencyKeys = prevMaxIdempotencyKeys }()
+
+			store := newIdempotencyStore()
+			now := time.Now()
+			for _, key := range []string{"key-1", "key-2"} {
+				_, seen := store.begin(key, sha256.Sum256([]byte(key)), now)
+				Expect(seen).To(BeFalse())
+				store.complete(key, "", now)
+			}
+			_, seen := store.begin("key-1", sha256.Sum256([]byte("key-1")), now)
+			Expect(seen).To(BeTrue())
+
+			_, seen = store.begin("key-3", sha256.Sum256([]byte("key-3")), now)
+			Expect(seen).To(BeFalse())
+			Expect(store.size()).To(Equal(2))
+			_, seen = store.begin("key-1", sha256.Sum256([]byte("key-1")), now)
+			Expect(seen).To(BeTrue(), "recently used key should be kept")
+
+			store.removeExpired(now.Add(idempotencyKeyTTL))
+			Expect(store.size()).To(Equal(0))
+		})
+
+		It("should reject too long idempotency keys", func() {
+			expectHandlerResponse(gateway.webTrackHandler, idempotentRequest(strings.Repeat("k", 256), `{"userId": "dummyId"}`), 400, response.InvalidIdempotencyKey+"\n")
+		})
+	})
+
 	Context("Invalid requests", func() {
 		var (
 			gateway = &HandleT{}
@@ -0,0 +1,171 @@
+package gateway
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rudderlabs/rudder-server/gateway/response"
+	"github.com/rudderlabs/rudder-server/services/stats"
+)
+
+/*
+Clients can send an Idempotency-Key header with requests, so that retried requests aren't stored again.
+The response of successful requests is remembered by write key & idempotency key for Gateway.Idempotency.ttl,
+and retries within it get an "OK" response, with the Idempotent-Replayed header, without the events being stored again.
+The replayed response is always "OK", which is the response of every successful request, rather than a copy of the original one.
+Failed requests are forgotten, so that their retries are processed again.
+Retries while the request is in progress are rejected, as are requests reusing idempotency keys with different payloads.
+Keys are remembered in memory by each gateway, hence retries are deduplicated only if they reach the same gateway replica,
+and not at all across restarts. At most Gateway.Idempotency.maxKeys keys are remembered, evicting the least recently used ones,
+so a retry may not be deduplicated if many other keys are seen in between.
+The feature is off by default, see Gateway.Idempotency.enabled.
+*/
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyCleanupMinTick = time.Second
+)
+
+type idempotencyEntryT struct {
+	key string
+	//requestHash is the hash of the type & payload of the request, to detect keys reused for other requests
+	requestHash [sha256.Size]byte
+	done        bool
+	response    string
+	expiresAt   time.Time
+}
+
+//idempotencyStoreT remembers the responses of requests by their idempotency keys, until they expire or are evicted.
+//Entries are kept in order of their last use, so that the least recently used ones are evicted once maxIdempotencyKeys are stored.
+type idempotencyStoreT struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+func newIdempotencyStore() *idempotencyStoreT {
+	return &idempotencyStoreT{entries: make(map[string]*list.Element), lru: list.New()}
+}
+
+//begin returns the entry of the key if it was seen before, otherwise reserves the key for the request
+func (store *idempotencyStoreT) begin(key string, requestHash [sha256.Size]byte, now time.Time) (entry idempotencyEntryT, seen bool) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if element, ok := store.entries[key]; ok {
+		existing := element.Value.(*idempotencyEntryT)
+		if now.Before(existing.expiresAt) {
+			store.lru.MoveToBack(element)
+			return *existing, true
+		}
+		store.remove(element)
+	}
+	for store.lru.Len() > 0 && store.lru.Len() >= maxIdempotencyKeys {
+		store.remove(store.lru.Front())
+	}
+	store.entries[key] = store.lru.PushBack(&idempotencyEntryT{key: key, requestHash: requestHash, expiresAt: now.Add(idempotencyKeyTTL)})
+	return idempotencyEntryT{}, false
+}
+
+//complete remembers the response of the successful request, and forgets the key of the failed one
+func (store *idempotencyStoreT) complete(key, errorMessage string, now time.Time) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	element, ok := store.entries[key]
+	if !ok {
+		return
+	}
+	if errorMessage != "" {
+		store.remove(element)
+		return
+	}
+	entry := element.Value.(*idempotencyEntryT)
+	entry.done = true
+	entry.response = response.GetStatus(response.Ok)
+	entry.expiresAt = now.Add(idempotencyKeyTTL)
+	store.lru.MoveToBack(element)
+}
+
+func (store *idempotencyStoreT) remove(element *list.Element) {
+	delete(store.entries, element.Value.(*idempotencyEntryT).key)
+	store.lru.Remove(element)
+}
+
+func (store *idempotencyStoreT) removeExpired(now time.Time) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	for _, element := range store.entries {
+		if !now.Before(element.Value.(*idempotencyEntryT).expiresAt) {
+			store.remove(element)
+		}
+	}
+}
+
+func (store *idempotencyStoreT) size() int {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.lru.Len()
+}
+
+func (store *idempotencyStoreT) cleanupLoop(ctx context.Context) {
+	for {
+		tick := idempotencyKeyTTL
+		if tick < idempotencyCleanupMinTick {
+			tick = idempotencyCleanupMinTick
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(tick):
+			store.removeExpired(time.Now())
+		}
+	}
+}
+
+/*
+beginIdempotentRequest checks the idempotency key of the request, if it has one.
+It returns the key of the request in the store, to complete it with, or handled if the response was already written,
+either replaying the response of the original request or rejecting the request.
+*/
+func (gateway *HandleT) beginIdempotentRequest(w http.ResponseWriter, r *http.Request, reqType, writeKey string, payload []byte) (storeKey string, handled bool) {
+	idempotencyKey := r.Header.Get(idempotencyKeyHeader)
+	if !enableIdempotencyKeys || idempotencyKey == "" {
+		return "", false
+	}
+	rejectWith := func(status string) {
+		gateway.stats.NewTaggedStat("gateway.idempotency_key_rejected_requests", stats.CountType, stats.Tags{"reqType": reqType, "reason": status}).Increment()
+		http.Error(w, response.GetStatus(status), response.GetStatusCode(status))
+	}
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		rejectWith(response.InvalidIdempotencyKey)
+		return "", true
+	}
+
+	storeKey = writeKey + "|" + idempotencyKey
+	requestHash := sha256.Sum256(append([]byte(reqType+"|"), payload...))
+	entry, seen := gateway.idempotencyKeys.begin(storeKey, requestHash, time.Now())
+	switch {
+	case !seen:
+		return storeKey, false
+	case entry.requestHash != requestHash:
+		rejectWith(response.IdempotencyKeyReused)
+	case !entry.done:
+		rejectWith(response.IdempotencyKeyInProgress)
+	default:
+		gateway.stats.NewTaggedStat("gateway.idempotent_replayed_requests", stats.CountType, stats.Tags{"reqType": reqType}).Increment()
+		w.Header().Set(idempotentReplayedHeader, "true")
+		_, _ = w.Write([]byte(entry.response))
+	}
+	return "", true
+}
+
+func (gateway *HandleT) completeIdempotentRequest(storeKey, errorMessage string) {
+	if storeKey != "" {
+		gateway.idempotencyKeys.complete(storeKey, errorMessage, time.Now())
+	}
+}
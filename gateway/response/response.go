@@ -48,6 +48,16 @@ const (
 	ErrorInParseForm = "Error during parsing form"
 	//ErrorInParseMultiform - Error during parsing multiform
 	ErrorInParseMultiform = "Error during parsing multiform"
+	//InvalidWebhookSignature - Signature of the webhook is missing or invalid
+	InvalidWebhookSignature = "Invalid webhook signature"
+	//ExpiredWebhookTimestamp - Timestamp of the signed webhook is outside of the tolerance
+	ExpiredWebhookTimestamp = "Webhook timestamp outside tolerance"
+	//InvalidIdempotencyKey - Idempotency key is too long
+	InvalidIdempotencyKey = "Idempotency-Key exceeds 255 characters"
+	//IdempotencyKeyInProgress - Request with the same idempotency key is in progress
+	IdempotencyKeyInProgress = "Request with the same Idempotency-Key is in progress"
+	//IdempotencyKeyReused - Idempotency key was used for another request
+	IdempotencyKeyReused = "Idempotency-Key was used for a different request"
 )
 
 var (
@@ -88,6 +98,11 @@ func loadStatusMap() {
 	statusMap[ErrorInMarshal] = ResponseStatus{message: ErrorInMarshal, code: http.StatusBadRequest}
 	statusMap[ErrorInParseForm] = ResponseStatus{message: ErrorInParseForm, code: http.StatusBadRequest}
 	statusMap[ErrorInParseMultiform] = ResponseStatus{message: ErrorInParseMultiform, code: http.StatusBadRequest}
+	statusMap[InvalidWebhookSignature] = ResponseStatus{message: InvalidWebhookSignature, code: http.StatusUnauthorized}
+	statusMap[ExpiredWebhookTimestamp] = ResponseStatus{message: ExpiredWebhookTimestamp, code: http.StatusUnauthorized}
+	statusMap[InvalidIdempotencyKey] = ResponseStatus{message: InvalidIdempotencyKey, code: http.StatusBadRequest}
+	statusMap[IdempotencyKeyInProgress] = ResponseStatus{message: IdempotencyKeyInProgress, code: http.StatusConflict}
+	statusMap[IdempotencyKeyReused] = ResponseStatus{message: IdempotencyKeyReused, code: http.StatusUnprocessableEntity}
 }
 
 func GetStatus(key string) string {
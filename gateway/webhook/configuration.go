@@ -23,6 +23,8 @@ func loadConfig() {
 	config.RegisterIntConfigVariable(5, &webhookRetryMax, false, 1, "Gateway.webhook.maxRetry")
 	// Parse all query params from sources mentioned in this list
 	config.RegisterStringSliceConfigVariable(make([]string, 0), &sourceListForParsingParams, true, "Gateway.webhook.sourceListForParsingParams")
+	// Max difference between the timestamp of signed webhooks & now, older or newer webhooks are rejected as replays
+	config.RegisterDurationConfigVariable(time.Duration(300), &signatureTolerance, true, time.Second, "Gateway.webhook.signatureTolerance")
 	// lowercasing the strings in sourceListForParsingParams
 	for i, s := range sourceListForParsingParams {
 		sourceListForParsingParams[i] = strings.ToLower(s)
@@ -0,0 +1,171 @@
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/rudderlabs/rudder-server/utils/misc"
+)
+
+/*
+Generic webhook sources are converted to events in the gateway, as per the mappings in their config, instead of the source transformer:
+
+	"mappings": [
+		{"from": "$.data.user.id", "to": "userId"},
+		{"from": "$.data.user.email", "to": "context.traits.email"}
+	],
+	"eventType": "track",
+	"eventName": "{{type}} {{data.action}}",
+	"eventsPath": "$.events"
+
+from is the JSONPath of the value in the webhook and to is the path of the field of the event.
+eventName is the template of the name of track events, with the values of the paths in {{ }}.
+If eventsPath is set, each of the objects in the array at it is converted to an event, otherwise the webhook is.
+The webhook is sent as the properties of the event unless properties are mapped,
+and events which neither have userId nor anonymousId get a random anonymousId.
+*/
+
+const genericWebhookSourceType = "GenericWebhook"
+
+var (
+	templatePlaceholderRegex = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+	jsonPathIndexRegex       = regexp.MustCompile(`\[(\d+)\]`)
+)
+
+type genericMappingT struct {
+	from string
+	to   string
+}
+
+type genericWebhookSettingsT struct {
+	eventType  string
+	eventName  string
+	eventsPath string
+	mappings   []genericMappingT
+}
+
+func isGenericWebhookSource(sourceType string) bool {
+	return strings.EqualFold(sourceType, genericWebhookSourceType)
+}
+
+//toGJSONPath converts JSONPaths like $.data.items[0].id to the paths of gjson, like data.items.0.id
+func toGJSONPath(path string) string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	return jsonPathIndexRegex.ReplaceAllString(path, ".$1")
+}
+
+func genericWebhookSettingsOf(sourceConfig map[string]interface{}) genericWebhookSettingsT {
+	settings := genericWebhookSettingsT{eventType: "track"}
+	if eventType, ok := sourceConfig["eventType"].(string); ok && eventType != "" {
+		settings.eventType = eventType
+	}
+	settings.eventName, _ = sourceConfig["eventName"].(string)
+	if eventsPath, ok := sourceConfig["eventsPath"].(string); ok {
+		settings.eventsPath = toGJSONPath(eventsPath)
+	}
+	mappings, _ := sourceConfig["mappings"].([]interface{})
+	for _, mapping := range mappings {
+		mappingConfig, _ := mapping.(map[string]interface{})
+		from, _ := mappingConfig["from"].(string)
+		to, _ := mappingConfig["to"].(string)
+		if from != "" && to != "" {
+			settings.mappings = append(settings.mappings, genericMappingT{from: toGJSONPath(from), to: toGJSONPath(to)})
+		}
+	}
+	return settings
+}
+
+//transformGeneric converts the generic webhooks to events, like the source transformer
+func (bt *batchWebhookTransformerT) transformGeneric(payloads [][]byte, requests []*webhookT) transformerBatchResponseT {
+	bt.stats.sentStat.Count(len(payloads))
+	bt.stats.transformTimerStat.Start()
+	defer bt.stats.transformTimerStat.End()
+
+	batchResponse := transformerBatchResponseT{responses: make([]transformerResponseT, len(payloads))}
+	for idx, payload := range payloads {
+		output, err := mapGenericWebhook(genericWebhookSettingsOf(requests[idx].sourceConfig), payload)
+		if err != nil {
+			batchResponse.responses[idx] = transformerResponseT{err: err.Error(), statusCode: http.StatusBadRequest}
+			bt.stats.failedStat.Count(1)
+			continue
+		}
+		bt.stats.receivedStat.Count(1)
+		batchResponse.responses[idx] = transformerResponseT{output: output}
+	}
+	return batchResponse
+}
+
+//mapGenericWebhook returns the batch of the events of the webhook, as per the settings of its source
+func mapGenericWebhook(settings genericWebhookSettingsT, payload []byte) ([]byte, error) {
+	webhooks := []gjson.Result{gjson.ParseBytes(payload)}
+	if settings.eventsPath != "" {
+		events := gjson.GetBytes(payload, settings.eventsPath)
+		if !events.IsArray() {
+			return nil, fmt.Errorf("webhook doesn't have an array of events at %s", settings.eventsPath)
+		}
+		webhooks = events.Array()
+	}
+
+	batch := []byte(`{"batch":[]}`)
+	for idx, webhook := range webhooks {
+		event, err := mapGenericEvent(settings, webhook)
+		if err != nil {
+			return nil, err
+		}
+		if batch, err = sjson.SetRawBytes(batch, fmt.Sprintf("batch.%d", idx), event); err != nil {
+			return nil, err
+		}
+	}
+	return batch, nil
+}
+
+func mapGenericEvent(settings genericWebhookSettingsT, webhook gjson.Result) ([]byte, error) {
+	if !webhook.IsObject() {
+		return nil, fmt.Errorf("webhook event is not an object: %s", misc.TruncateStr(webhook.Raw, 100))
+	}
+	event := []byte(`{}`)
+	var err error
+	if event, err = sjson.SetBytes(event, "type", settings.eventType); err != nil {
+		return nil, err
+	}
+	if settings.eventType == "track" {
+		name := templatePlaceholderRegex.ReplaceAllStringFunc(settings.eventName, func(placeholder string) string {
+			return webhook.Get(toGJSONPath(templatePlaceholderRegex.FindStringSubmatch(placeholder)[1])).String()
+		})
+		if name == "" {
+			name = "webhook_source_event"
+		}
+		if event, err = sjson.SetBytes(event, "event", name); err != nil {
+			return nil, err
+		}
+	}
+
+	propertiesMapped := false
+	for _, mapping := range settings.mappings {
+		value := webhook.Get(mapping.from)
+		if !value.Exists() {
+			continue
+		}
+		if event, err = sjson.SetRawBytes(event, mapping.to, []byte(value.Raw)); err != nil {
+			return nil, fmt.Errorf("mapping %s to %s: %w", mapping.from, mapping.to, err)
+		}
+		propertiesMapped = propertiesMapped || mapping.to == "properties" || strings.HasPrefix(mapping.to, "properties.")
+	}
+	if !propertiesMapped {
+		if event, err = sjson.SetRawBytes(event, "properties", []byte(webhook.Raw)); err != nil {
+			return nil, err
+		}
+	}
+	if gjson.GetBytes(event, "userId").String() == "" && gjson.GetBytes(event, "anonymousId").String() == "" {
+		if event, err = sjson.SetBytes(event, "anonymousId", uuid.Must(uuid.NewV4()).String()); err != nil {
+			return nil, err
+		}
+	}
+	return event, nil
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func Test_MapGenericWebhook(t *testing.T) {
+	sourceConfig := map[string]interface{}{
+		"mappings": []interface{}{
+			map[string]interface{}{"from": "$.data.user.id", "to": "userId"},
+			map[string]interface{}{"from": "$.data.user.emails[0]", "to": "context.traits.email"},
+			map[string]interface{}{"from": "$.data.missing", "to": "context.traits.missing"},
+		},
+		"eventName": "{{type}} {{ data.action }}",
+	}
+
+	t.Run("maps the webhook to a track event with the webhook as properties", func(t *testing.T) {
+		webhook := `{"type": "order", "data": {"action": "created", "user": {"id": "u1", "emails": ["a@b.com"]}}}`
+		output, err := mapGenericWebhook(genericWebhookSettingsOf(sourceConfig), []byte(webhook))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"batch": [{
+			"type": "track",
+			"event": "order created",
+			"userId": "u1",
+			"context": {"traits": {"email": "a@b.com"}},
+			"properties": `+webhook+`
+		}]}`, string(output))
+	})
+
+	t.Run("maps each of the events of the webhook", func(t *testing.T) {
+		settings := genericWebhookSettingsOf(map[string]interface{}{
+			"eventType":  "identify",
+			"eventsPath": "$.users",
+			"mappings": []interface{}{
+				map[string]interface{}{"from": "id", "to": "userId"},
+				map[string]interface{}{"from": "name", "to": "traits.name"},
+			},
+		})
+		output, err := mapGenericWebhook(settings, []byte(`{"users": [{"id": "u1", "name": "A"}, {"name": "B"}]}`))
+		require.NoError(t, err)
+		events := gjson.GetBytes(output, "batch").Array()
+		require.Len(t, events, 2)
+		require.JSONEq(t, `{"type": "identify", "userId": "u1", "traits": {"name": "A"}, "properties": {"id": "u1", "name": "A"}}`, events[0].Raw)
+		require.False(t, events[1].Get("event").Exists())
+		require.NotEmpty(t, events[1].Get("anonymousId").String())
+
+		_, err = mapGenericWebhook(settings, []byte(`{"users": {"id": "u1"}}`))
+		require.Error(t, err)
+	})
+
+	t.Run("names events without a name template", func(t *testing.T) {
+		output, err := mapGenericWebhook(genericWebhookSettingsOf(nil), []byte(`{"id": 1}`))
+		require.NoError(t, err)
+		require.Equal(t, "webhook_source_event", gjson.GetBytes(output, "batch.0.event").String())
+		require.Equal(t, `{"id": 1}`, gjson.GetBytes(output, "batch.0.properties").Raw)
+	})
+}
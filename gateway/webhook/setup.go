@@ -17,6 +17,7 @@ type GatewayI interface {
 	TrackRequestMetrics(errorMessage string)
 	ProcessWebRequest(writer *http.ResponseWriter, req *http.Request, reqType string, requestPayload []byte, writeKey string) string
 	GetWebhookSourceDefName(writeKey string) (name string, ok bool)
+	GetWebhookSourceConfig(writeKey string) (config map[string]interface{}, ok bool)
 }
 
 type WebHookI interface {
@@ -28,6 +29,7 @@ func Setup(gwHandle GatewayI) *HandleT {
 	webhook := &HandleT{gwHandle: gwHandle}
 	webhook.requestQ = make(map[string](chan *webhookT))
 	webhook.batchRequestQ = make(chan *batchWebhookT)
+	webhook.sourceStats = make(map[string]*webhookSourceStatT)
 	webhook.netClient = retryablehttp.NewClient()
 	webhook.netClient.Logger = nil // to avoid debug logs
 	webhook.netClient.RetryWaitMin = webhookRetryWaitMin
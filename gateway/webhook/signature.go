@@ -0,0 +1,209 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Webhook sources can require the requests to be signed with a secret shared with the sender.
+Sources enable it with the signature key in their config:
+
+	"signature": {
+		"secret": "whsec_...",
+		"scheme": "stripe",
+		"timestampTolerance": 300
+	}
+
+The stripe, shopify & github schemes verify the signatures of those senders.
+The hmac scheme verifies the hmac of the body, with the algorithm (sha1, sha256 or sha512) & encoding (hex or base64) of the signature in the header,
+optionally prefixed, like sha256=. If timestampHeader is set, the timestamp in it is signed along with the body, as timestamp.body
+Requests with timestamps older or newer than the tolerance, in seconds, are rejected to prevent replays, Gateway.webhook.signatureTolerance by default.
+*/
+
+const (
+	stripeSignatureScheme  = "stripe"
+	shopifySignatureScheme = "shopify"
+	githubSignatureScheme  = "github"
+	hmacSignatureScheme    = "hmac"
+)
+
+var (
+	errMissingSignature  = errors.New("signature missing")
+	errInvalidSignature  = errors.New("signature mismatch")
+	errMissingTimestamp  = errors.New("timestamp missing")
+	errExpiredTimestamp  = errors.New("timestamp outside tolerance")
+	errSignatureSettings = errors.New("invalid signature settings")
+)
+
+type signatureSettingsT struct {
+	secret             string
+	scheme             string
+	algorithm          string
+	encoding           string
+	header             string
+	prefix             string
+	timestampHeader    string
+	timestampTolerance time.Duration
+}
+
+//signatureSettingsOf returns the signature settings of the source, if it requires signed requests
+func signatureSettingsOf(sourceConfig map[string]interface{}) (settings signatureSettingsT, enabled bool) {
+	signatureConfig, ok := sourceConfig["signature"].(map[string]interface{})
+	if !ok {
+		return settings, false
+	}
+	getString := func(key, defaultValue string) string {
+		if value, ok := signatureConfig[key].(string); ok && value != "" {
+			return value
+		}
+		return defaultValue
+	}
+	settings.secret = getString("secret", "")
+	settings.scheme = strings.ToLower(getString("scheme", hmacSignatureScheme))
+	settings.algorithm = strings.ToLower(getString("algorithm", "sha256"))
+	settings.encoding = strings.ToLower(getString("encoding", "hex"))
+	settings.header = getString("header", "X-Signature")
+	settings.prefix = getString("prefix", "")
+	settings.timestampHeader = getString("timestampHeader", "")
+	settings.timestampTolerance = signatureTolerance
+	if tolerance, ok := signatureConfig["timestampTolerance"].(float64); ok && tolerance > 0 {
+		settings.timestampTolerance = time.Duration(tolerance) * time.Second
+	}
+	return settings, settings.secret != ""
+}
+
+//verifySignature verifies the signature of the request with the raw body, as per the scheme of the source
+func verifySignature(settings signatureSettingsT, header http.Header, body []byte, now time.Time) error {
+	switch settings.scheme {
+	case stripeSignatureScheme:
+		return verifyStripeSignature(settings, header.Get("Stripe-Signature"), body, now)
+	case shopifySignatureScheme:
+		return verifyHMAC(sha256.New, settings.secret, body, header.Get("X-Shopify-Hmac-Sha256"), base64.StdEncoding.DecodeString)
+	case githubSignatureScheme:
+		signature := header.Get("X-Hub-Signature-256")
+		if !strings.HasPrefix(signature, "sha256=") {
+			return errMissingSignature
+		}
+		return verifyHMAC(sha256.New, settings.secret, body, strings.TrimPrefix(signature, "sha256="), hex.DecodeString)
+	case hmacSignatureScheme:
+		return verifyHMACSignature(settings, header, body, now)
+	}
+	return errSignatureSettings
+}
+
+//verifyStripeSignature verifies signature headers like t=1492774577,v1=5257a869...,v1=..., signed as timestamp.body
+func verifyStripeSignature(settings signatureSettingsT, signatureHeader string, body []byte, now time.Time) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(signatureHeader, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "t":
+			timestamp = kv[1]
+		case "v1":
+			signatures = append(signatures, kv[1])
+		}
+	}
+	if len(signatures) == 0 {
+		return errMissingSignature
+	}
+	if timestamp == "" {
+		return errMissingTimestamp
+	}
+	signedPayload := append([]byte(timestamp+"."), body...)
+	for _, signature := range signatures {
+		if verifyHMAC(sha256.New, settings.secret, signedPayload, signature, hex.DecodeString) == nil {
+			return verifyTimestamp(timestamp, settings.timestampTolerance, now)
+		}
+	}
+	return errInvalidSignature
+}
+
+func verifyHMACSignature(settings signatureSettingsT, header http.Header, body []byte, now time.Time) error {
+	var newHash func() hash.Hash
+	switch settings.algorithm {
+	case "sha1":
+		newHash = sha1.New
+	case "sha256":
+		newHash = sha256.New
+	case "sha512":
+		newHash = sha512.New
+	default:
+		return errSignatureSettings
+	}
+	var decode func(string) ([]byte, error)
+	switch settings.encoding {
+	case "hex":
+		decode = hex.DecodeString
+	case "base64":
+		decode = base64.StdEncoding.DecodeString
+	default:
+		return errSignatureSettings
+	}
+
+	signedPayload := body
+	timestamp := ""
+	if settings.timestampHeader != "" {
+		if timestamp = header.Get(settings.timestampHeader); timestamp == "" {
+			return errMissingTimestamp
+		}
+		signedPayload = append([]byte(timestamp+"."), body...)
+	}
+	signature := header.Get(settings.header)
+	if settings.prefix != "" {
+		if !strings.HasPrefix(signature, settings.prefix) {
+			return errMissingSignature
+		}
+		signature = strings.TrimPrefix(signature, settings.prefix)
+	}
+	if err := verifyHMAC(newHash, settings.secret, signedPayload, signature, decode); err != nil {
+		return err
+	}
+	if timestamp != "" {
+		return verifyTimestamp(timestamp, settings.timestampTolerance, now)
+	}
+	return nil
+}
+
+func verifyHMAC(newHash func() hash.Hash, secret string, payload []byte, signature string, decode func(string) ([]byte, error)) error {
+	if signature == "" {
+		return errMissingSignature
+	}
+	expected, err := decode(signature)
+	if err != nil {
+		return errInvalidSignature
+	}
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(payload)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+//verifyTimestamp verifies that the unix timestamp of the signed request is within the tolerance of now, if there is one
+func verifyTimestamp(timestamp string, tolerance time.Duration, now time.Time) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errMissingTimestamp
+	}
+	if tolerance > 0 && math.Abs(float64(now.Unix()-seconds)) > tolerance.Seconds() {
+		return errExpiredTimestamp
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-server/config"
+	"github.com/rudderlabs/rudder-server/gateway/response"
+	"github.com/rudderlabs/rudder-server/services/stats"
+	"github.com/rudderlabs/rudder-server/utils/logger"
+)
+
+func sign(secret, payload string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+func TestMain(m *testing.M) {
+	config.Load()
+	logger.Init()
+	stats.Setup()
+	Init()
+	os.Exit(m.Run())
+}
+
+func Test_VerifySignature(t *testing.T) {
+	const secret = "secret"
+	const body = `{"id": "evt_1"}`
+	now := time.Unix(1640000000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	settingsOf := func(signature map[string]interface{}) signatureSettingsT {
+		settings, enabled := signatureSettingsOf(map[string]interface{}{"signature": signature})
+		require.True(t, enabled)
+		return settings
+	}
+
+	t.Run("stripe", func(t *testing.T) {
+		settings := settingsOf(map[string]interface{}{"secret": secret, "scheme": "stripe", "timestampTolerance": float64(300)})
+		signature := hex.EncodeToString(sign(secret, timestamp+"."+body))
+		header := http.Header{}
+		header.Set("Stripe-Signature", "t="+timestamp+",v1=bad,v1="+signature)
+		require.NoError(t, verifySignature(settings, header, []byte(body), now))
+		require.Equal(t, errInvalidSignature, verifySignature(settings, header, []byte(`{"id": "evt_2"}`), now))
+		require.Equal(t, errExpiredTimestamp, verifySignature(settings, header, []byte(body), now.Add(301*time.Second)))
+		require.Equal(t, errMissingSignature, verifySignature(settings, http.Header{}, []byte(body), now))
+	})
+
+	t.Run("shopify", func(t *testing.T) {
+		settings := settingsOf(map[string]interface{}{"secret": secret, "scheme": "shopify"})
+		header := http.Header{}
+		header.Set("X-Shopify-Hmac-Sha256", base64.StdEncoding.EncodeToString(sign(secret, body)))
+		require.NoError(t, verifySignature(settings, header, []byte(body), now))
+		header.Set("X-Shopify-Hmac-Sha256", base64.StdEncoding.EncodeToString(sign("other", body)))
+		require.Equal(t, errInvalidSignature, verifySignature(settings, header, []byte(body), now))
+	})
+
+	t.Run("github", func(t *testing.T) {
+		settings := settingsOf(map[string]interface{}{"secret": secret, "scheme": "github"})
+		header := http.Header{}
+		header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(sign(secret, body)))
+		require.NoError(t, verifySignature(settings, header, []byte(body), now))
+		header.Set("X-Hub-Signature-256", hex.EncodeToString(sign(secret, body)))
+		require.Equal(t, errMissingSignature, verifySignature(settings, header, []byte(body), now))
+	})
+
+	t.Run("hmac with timestamp", func(t *testing.T) {
+		settings := settingsOf(map[string]interface{}{
+			"secret":          secret,
+			"header":          "X-Webhook-Signature",
+			"prefix":          "v1=",
+			"encoding":        "base64",
+			"timestampHeader": "X-Webhook-Timestamp",
+		})
+		require.Equal(t, 300*time.Second, settings.timestampTolerance)
+		header := http.Header{}
+		header.Set("X-Webhook-Signature", "v1="+base64.StdEncoding.EncodeToString(sign(secret, timestamp+"."+body)))
+		require.Equal(t, errMissingTimestamp, verifySignature(settings, header, []byte(body), now))
+		header.Set("X-Webhook-Timestamp", timestamp)
+		require.NoError(t, verifySignature(settings, header, []byte(body), now))
+		require.Equal(t, errExpiredTimestamp, verifySignature(settings, header, []byte(body), now.Add(-time.Hour)))
+	})
+
+	t.Run("sources without secrets don't require signatures", func(t *testing.T) {
+		_, enabled := signatureSettingsOf(map[string]interface{}{"signature": map[string]interface{}{"scheme": "stripe"}})
+		require.False(t, enabled)
+		_, enabled = signatureSettingsOf(nil)
+		require.False(t, enabled)
+	})
+}
+
+//fakeGateway is a gateway with a single webhook source
+type fakeGateway struct {
+	GatewayI
+	sourceConfig map[string]interface{}
+}
+
+func (*fakeGateway) IncrementAckCount(uint64)                                    {}
+func (*fakeGateway) UpdateSourceStats(map[string]int, string, map[string]string) {}
+func (*fakeGateway) GetWebhookSourceDefName(string) (string, bool)               { return "Stripe", true }
+func (gw *fakeGateway) GetWebhookSourceConfig(string) (map[string]interface{}, bool) {
+	return gw.sourceConfig, true
+}
+
+func Test_VerifyRequest(t *testing.T) {
+	webhook := &HandleT{
+		gwHandle:    &fakeGateway{sourceConfig: map[string]interface{}{"signature": map[string]interface{}{"secret": "secret", "scheme": "stripe"}}},
+		sourceStats: make(map[string]*webhookSourceStatT),
+	}
+
+	body := `{"id": "evt_1"}`
+	timestamp := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	for signatureHeader, expectedStatus := range map[string]string{
+		"":                            response.InvalidWebhookSignature,
+		"t=" + timestamp + ",v1=0123": response.InvalidWebhookSignature,
+		"t=" + timestamp + ",v1=" + hex.EncodeToString(sign("secret", timestamp+"."+body)): response.ExpiredWebhookTimestamp,
+	} {
+		r := httptest.NewRequest(http.MethodPost, "/v1/webhook?writeKey=writeKey", strings.NewReader(body))
+		r.Header.Set("Stripe-Signature", signatureHeader)
+		w := httptest.NewRecorder()
+		require.False(t, webhook.verifyRequest(w, r, webhook.gwHandle.(*fakeGateway).sourceConfig, "Stripe"))
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+		require.Equal(t, expectedStatus+"\n", w.Body.String())
+	}
+
+	timestamp = strconv.FormatInt(time.Now().Unix(), 10)
+	r := httptest.NewRequest(http.MethodPost, "/v1/webhook?writeKey=writeKey", strings.NewReader(body))
+	r.Header.Set("Stripe-Signature", "t="+timestamp+",v1="+hex.EncodeToString(sign("secret", timestamp+"."+body)))
+	require.True(t, webhook.verifyRequest(httptest.NewRecorder(), r, webhook.gwHandle.(*fakeGateway).sourceConfig, "Stripe"))
+	read := make([]byte, len(body))
+	_, _ = r.Body.Read(read)
+	require.Equal(t, body, string(read))
+}
@@ -31,6 +31,7 @@ var (
 	webhookRetryWaitMin        time.Duration
 	pkgLogger                  logger.LoggerI
 	sourceListForParsingParams []string
+	signatureTolerance         time.Duration
 )
 
 func Init() {
@@ -44,6 +45,8 @@ type webhookT struct {
 	done       chan<- webhookErrorRespT
 	sourceType string
 	writeKey   string
+	//sourceConfig is the config of the source, set only for generic webhook sources
+	sourceConfig map[string]interface{}
 }
 
 type batchWebhookT struct {
@@ -59,6 +62,9 @@ type HandleT struct {
 	ackCount      uint64
 	recvCount     uint64
 
+	sourceStats   map[string]*webhookSourceStatT
+	sourceStatsMu sync.Mutex
+
 	batchRequestsWg  sync.WaitGroup
 	backgroundWait   func() error
 	backgroundCancel context.CancelFunc
@@ -69,6 +75,7 @@ type webhookSourceStatT struct {
 	numEvents       stats.RudderStats
 	numOutputEvents stats.RudderStats
 	sourceTransform stats.RudderStats
+	numUnauthorized stats.RudderStats
 }
 
 type webhookStatsT struct {
@@ -138,6 +145,12 @@ func (webhook *HandleT) RequestHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "GET" {
 		return
 	}
+
+	sourceConfig, _ := webhook.gwHandle.GetWebhookSourceConfig(writeKey)
+	if !webhook.verifyRequest(w, r, sourceConfig, sourceDefName) {
+		atomic.AddUint64(&webhook.ackCount, 1)
+		return
+	}
 	contentType := r.Header.Get("Content-Type")
 	if strings.Contains(strings.ToLower(contentType), "application/x-www-form-urlencoded") {
 		if err := r.ParseForm(); err != nil {
@@ -181,6 +194,9 @@ func (webhook *HandleT) RequestHandler(w http.ResponseWriter, r *http.Request) {
 
 	done := make(chan webhookErrorRespT)
 	req := webhookT{request: r, writer: &w, done: done, sourceType: sourceDefName, writeKey: writeKey}
+	if isGenericWebhookSource(sourceDefName) {
+		req.sourceConfig = sourceConfig
+	}
 	webhook.requestQ[sourceDefName] <- &req
 
 	//Wait for batcher process to be done
@@ -285,7 +301,12 @@ func (bt *batchWebhookTransformerT) batchTransformLoop() {
 		bt.stats.sourceStats[breq.sourceType].numEvents.Count(len(payloadArr))
 		bt.stats.sourceStats[breq.sourceType].sourceTransform.Start()
 
-		batchResponse := bt.transform(payloadArr, breq.sourceType)
+		var batchResponse transformerBatchResponseT
+		if isGenericWebhookSource(breq.sourceType) {
+			batchResponse = bt.transformGeneric(payloadArr, webRequests)
+		} else {
+			batchResponse = bt.transform(payloadArr, breq.sourceType)
+		}
 
 		// stats
 		bt.stats.sourceStats[breq.sourceType].sourceTransform.End()
@@ -362,6 +383,43 @@ func (webhook *HandleT) Shutdown() {
 	webhook.backgroundWait()
 }
 
+//verifyRequest verifies the signature of the request if its source requires signed requests, failing the request otherwise
+func (webhook *HandleT) verifyRequest(w http.ResponseWriter, r *http.Request, sourceConfig map[string]interface{}, sourceDefName string) bool {
+	settings, enabled := signatureSettingsOf(sourceConfig)
+	if !enabled {
+		return true
+	}
+
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		webhook.failRequest(w, r, response.GetStatus(response.RequestBodyReadFailed), response.GetStatusCode(response.RequestBodyReadFailed), "requestBodyReadFailed")
+		return false
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := verifySignature(settings, r.Header, body, time.Now()); err != nil {
+		pkgLogger.Debugf("Webhook: Signature verification of %s request failed: %v", sourceDefName, err)
+		webhook.sourceStat(sourceDefName).numUnauthorized.Increment()
+		key := response.InvalidWebhookSignature
+		if err == errExpiredTimestamp {
+			key = response.ExpiredWebhookTimestamp
+		}
+		webhook.failRequest(w, r, response.GetStatus(key), response.GetStatusCode(key), "invalidSignature")
+		return false
+	}
+	return true
+}
+
+func (webhook *HandleT) sourceStat(sourceType string) *webhookSourceStatT {
+	webhook.sourceStatsMu.Lock()
+	defer webhook.sourceStatsMu.Unlock()
+	if _, ok := webhook.sourceStats[sourceType]; !ok {
+		webhook.sourceStats[sourceType] = newWebhookStat(sourceType)
+	}
+	return webhook.sourceStats[sourceType]
+}
+
 //TODO: Check if correct
 func newWebhookStat(sourceType string) *webhookSourceStatT {
 	tags := map[string]string{
@@ -370,11 +428,13 @@ func newWebhookStat(sourceType string) *webhookSourceStatT {
 	numEvents := stats.NewTaggedStat("webhook_num_events", stats.CountType, tags)
 	numOutputEvents := stats.NewTaggedStat("webhook_num_output_events", stats.CountType, tags)
 	sourceTransform := stats.NewTaggedStat("webhook_dest_transform", stats.TimerType, tags)
+	numUnauthorized := stats.NewTaggedStat("webhook_num_unauthorized_requests", stats.CountType, tags)
 	return &webhookSourceStatT{
 		id:              sourceType,
 		numEvents:       numEvents,
 		numOutputEvents: numOutputEvents,
 		sourceTransform: sourceTransform,
+		numUnauthorized: numUnauthorized,
 	}
 }
 
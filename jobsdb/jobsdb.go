@@ -77,6 +77,8 @@ type GetQueryParamsT struct {
 	IgnoreCustomValFiltersInQuery bool
 	UseTimeFilter                 bool
 	Before                        time.Time
+	//AfterJobID returns only jobs with job_id greater than it, used to page through jobs which aren't updated by the caller
+	AfterJobID int64
 }
 
 //StatTagsT is a struct to hold tags for stats
@@ -95,6 +97,7 @@ JobsDB interface contains public methods to access JobsDB data
 */
 type JobsDB interface {
 	Store(jobList []*JobT) error
+	StoreInTxn(txHandler *sql.Tx, jobList []*JobT) error
 	BeginGlobalTransaction() *sql.Tx
 	CommitTransaction(txn *sql.Tx)
 	AcquireStoreLock()
@@ -2021,8 +2024,12 @@ func (jd *HandleT) getProcessedJobsDS(ds dataSetT, getAll bool, limitCount int,
 	queryStat.Start()
 	defer queryStat.End()
 
+	//results of a page don't tell if the ds is empty, so empty result cache isn't set while paging
+	setEmptyResult := params.AfterJobID == 0
 	// We don't reset this in case of error for now, as any error in this function causes panic
-	jd.markClearEmptyResult(ds, stateFilters, customValFilters, parameterFilters, willTryToSet, nil)
+	if setEmptyResult {
+		jd.markClearEmptyResult(ds, stateFilters, customValFilters, parameterFilters, willTryToSet, nil)
+	}
 
 	var stateQuery, customValQuery, limitQuery, sourceQuery string
 
@@ -2045,6 +2052,10 @@ func (jd *HandleT) getProcessedJobsDS(ds dataSetT, getAll bool, limitCount int,
 	} else {
 		sourceQuery = ""
 	}
+	if params.AfterJobID > 0 {
+		jd.assert(!getAll, "getAll is true")
+		sourceQuery += fmt.Sprintf(" AND jobs.job_id > %d", params.AfterJobID)
+	}
 
 	if limitCount > 0 {
 		jd.assert(!getAll, "getAll is true")
@@ -2121,6 +2132,9 @@ func (jd *HandleT) getProcessedJobsDS(ds dataSetT, getAll bool, limitCount int,
 		jobList = append(jobList, &job)
 	}
 
+	if !setEmptyResult {
+		return jobList
+	}
 	result := hasJobs
 	if len(jobList) == 0 {
 		jd.logger.Debugf("[getProcessedJobsDS] Setting empty cache for ds: %v, stateFilters: %v, customValFilters: %v, parameterFilters: %v", ds, stateFilters, customValFilters, parameterFilters)
@@ -2151,8 +2165,12 @@ func (jd *HandleT) getUnprocessedJobsDS(ds dataSetT, order bool, count int, para
 	queryStat.Start()
 	defer queryStat.End()
 
+	//results of a page don't tell if the ds is empty, so empty result cache isn't set while paging
+	setEmptyResult := params.AfterJobID == 0
 	// We don't reset this in case of error for now, as any error in this function causes panic
-	jd.markClearEmptyResult(ds, []string{NotProcessed.State}, customValFilters, parameterFilters, willTryToSet, nil)
+	if setEmptyResult {
+		jd.markClearEmptyResult(ds, []string{NotProcessed.State}, customValFilters, parameterFilters, willTryToSet, nil)
+	}
 
 	var rows *sql.Rows
 	var err error
@@ -2191,6 +2209,11 @@ func (jd *HandleT) getUnprocessedJobsDS(ds dataSetT, order bool, count int, para
 		args = append(args, params.Before)
 	}
 
+	if params.AfterJobID > 0 {
+		sqlStatement += fmt.Sprintf(" AND jobs.job_id > $%d", len(args)+1)
+		args = append(args, params.AfterJobID)
+	}
+
 	if order {
 		sqlStatement += " ORDER BY jobs.job_id"
 	}
@@ -2226,6 +2249,9 @@ func (jd *HandleT) getUnprocessedJobsDS(ds dataSetT, order bool, count int, para
 		jobList = append(jobList, &job)
 	}
 
+	if !setEmptyResult {
+		return jobList
+	}
 	result := hasJobs
 	dsList := jd.getDSList(false)
 	//if jobsdb owner is a reader and if ds is the right most one, ignoring setting result as noJobs
@@ -3248,6 +3274,38 @@ func (jd *HandleT) store(jobList []*JobT) error {
 	return err
 }
 
+/*
+StoreInTxn stores the jobs in the passed transaction, so that jobs can be stored along with writes to other jobsdb instances
+using global db handle. Jobs are stored in the passed transaction directly, bypassing the writer queue.
+The transaction is to be rolled back by the caller if an error is returned.
+IMP NOTE: AcquireStoreLock Should be called before calling this function
+*/
+func (jd *HandleT) StoreInTxn(txn *sql.Tx, jobList []*JobT) error {
+	totalWriteTime := jd.storeTimerStat("store_in_txn_total_time")
+	totalWriteTime.Start()
+	defer totalWriteTime.End()
+
+	dsList := jd.getDSList(false)
+	ds := dsList[len(dsList)-1]
+
+	// Always clear cache even in case of an error,
+	// since we are not sure about the state of the db
+	defer func() {
+		customValParamMap := make(map[string]map[string]struct{})
+		for _, job := range jobList {
+			jd.populateCustomValParamMap(customValParamMap, job.CustomVal, job.Parameters)
+		}
+
+		if useNewCacheBurst {
+			jd.clearCache(ds, customValParamMap)
+		} else {
+			jd.markClearEmptyResult(ds, []string{}, []string{}, nil, hasJobs, nil)
+		}
+	}()
+
+	return jd.storeJobsDSInTxn(txn, ds, false, jobList)
+}
+
 func (jd *HandleT) StoreWithRetryEach(jobList []*JobT) map[uuid.UUID]string {
 	totalWriteTime := jd.storeTimerStat("store_retry_each_total_time")
 	totalWriteTime.Start()
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/lib/pq"
 	"github.com/rudderlabs/rudder-server/utils/logger"
 
 	"time"
@@ -728,6 +729,49 @@ func (jd *ReadonlyHandleT) GetJobIDsForUser(args []string) (string, error) {
 	return response, nil
 }
 
+/*
+GetJobsOfUsers returns upto `count` jobs, having events of any of the users, with job_id greater than afterJobID ordered by job_id.
+user_id column holds the rudderId derived from userId & anonymousId, so users are found by userId & email in event payloads
+of gateway batches as well as of router & batch router jobs.
+*/
+func (jd *ReadonlyHandleT) GetJobsOfUsers(userIDs, emails []string, afterJobID int64, count int) ([]*JobT, error) {
+	eventMatches := func(event string) string {
+		return fmt.Sprintf(`(%[1]s->>'userId' = ANY($2) OR %[1]s->'message'->>'userId' = ANY($2) OR %[1]s->'context'->'traits'->>'email' = ANY($3) OR %[1]s->'traits'->>'email' = ANY($3))`, event)
+	}
+
+	var jobs []*JobT
+	for _, ds := range jd.getDSList() {
+		if len(jobs) >= count {
+			break
+		}
+		sqlStatement := fmt.Sprintf(`SELECT job_id, uuid, user_id, parameters, custom_val, event_payload, event_count, created_at, expire_at FROM %[1]s
+			WHERE job_id > $1 AND (%[2]s OR EXISTS (
+				SELECT 1 FROM jsonb_array_elements(CASE jsonb_typeof(event_payload->'batch') WHEN 'array' THEN event_payload->'batch' ELSE '[]'::jsonb END) AS batch_event
+				WHERE %[3]s))
+			ORDER BY job_id LIMIT $4`, ds.JobTable, eventMatches("event_payload"), eventMatches("batch_event"))
+		rows, err := jd.DbHandle.Query(sqlStatement, afterJobID, pq.Array(userIDs), pq.Array(emails), count-len(jobs))
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var job JobT
+			err = rows.Scan(&job.JobID, &job.UUID, &job.UserID, &job.Parameters, &job.CustomVal,
+				&job.EventPayload, &job.EventCount, &job.CreatedAt, &job.ExpireAt)
+			if err != nil {
+				rows.Close()
+				return nil, err
+			}
+			jobs = append(jobs, &job)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return jobs, nil
+}
+
 func (jd *ReadonlyHandleT) GetFailedStatusErrorCodeCountsByDestination(args []string) (string, error) {
 	var response []byte
 	statusPrefix := getStatusPrefix(args[0])
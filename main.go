@@ -31,6 +31,7 @@ import (
 	"github.com/rudderlabs/rudder-server/processor/integrations"
 	"github.com/rudderlabs/rudder-server/processor/stash"
 	"github.com/rudderlabs/rudder-server/processor/transformer"
+	"github.com/rudderlabs/rudder-server/processor/transformer/native"
 
 	ratelimiter "github.com/rudderlabs/rudder-server/rate-limiter"
 
@@ -58,6 +59,7 @@ import (
 
 	destinationdebugger "github.com/rudderlabs/rudder-server/services/debugger/destination"
 	sourcedebugger "github.com/rudderlabs/rudder-server/services/debugger/source"
+	taildebugger "github.com/rudderlabs/rudder-server/services/debugger/tail"
 	transformationdebugger "github.com/rudderlabs/rudder-server/services/debugger/transformation"
 
 	"github.com/rudderlabs/rudder-server/services/dedup"
@@ -65,7 +67,9 @@ import (
 
 	destination_connection_tester "github.com/rudderlabs/rudder-server/services/destination-connection-tester"
 	"github.com/rudderlabs/rudder-server/services/diagnostics"
+	"github.com/rudderlabs/rudder-server/services/dlq"
 	"github.com/rudderlabs/rudder-server/services/pgnotifier"
+	"github.com/rudderlabs/rudder-server/services/regulation"
 	"github.com/rudderlabs/rudder-server/services/stats"
 
 	"github.com/rudderlabs/rudder-server/utils/logger"
@@ -182,16 +186,19 @@ func runAllInit() {
 	snowflake.Init()
 	deltalake.Init()
 	transformer.Init()
+	native.Init()
 	webhook.Init()
 	batchrouter.Init()
 	batchrouter.Init2()
 	asyncdestinationmanager.Init()
 	batchrouterutils.Init()
 	dedup.Init()
+	dlq.Init()
 	event_schema.Init()
 	event_schema.Init2()
 	stash.Init()
 	transformationdebugger.Init()
+	taildebugger.Init()
 	processor.Init()
 	kafka.Init()
 	customdestinationmanager.Init()
@@ -200,6 +207,7 @@ func runAllInit() {
 	router.Init2()
 	operationmanager.Init()
 	operationmanager.Init2()
+	regulation.Init()
 	ratelimiter.Init()
 	sourcedebugger.Init()
 	gateway.Init()
@@ -33,6 +33,18 @@ func (m *MockDedupI) EXPECT() *MockDedupIMockRecorder {
 	return m.recorder
 }
 
+// DeleteMessageIDs mocks base method.
+func (m *MockDedupI) DeleteMessageIDs(arg0 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteMessageIDs", arg0)
+}
+
+// DeleteMessageIDs indicates an expected call of DeleteMessageIDs.
+func (mr *MockDedupIMockRecorder) DeleteMessageIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessageIDs", reflect.TypeOf((*MockDedupI)(nil).DeleteMessageIDs), arg0)
+}
+
 // FindDuplicates mocks base method.
 func (m *MockDedupI) FindDuplicates(arg0 []string, arg1 map[string]struct{}) []int {
 	m.ctrl.T.Helper()
@@ -80,7 +80,23 @@ func (handler *ClearOperationHandlerT) Exec(payload []byte) error {
 		)
 	}
 
+	resumePipeline := pausePipeline()
+	defer resumePipeline()
+
+	//Clear From GatewayDB
+	handler.clearFromJobsdb(clearOperationHandler.gatewayDB, parameterFilters, false, false)
+	//Clear From RouterDB
+	handler.clearFromJobsdb(clearOperationHandler.routerDB, parameterFilters, true, true)
+	//Clear From BatchRouterDB
+	handler.clearFromJobsdb(clearOperationHandler.batchRouterDB, parameterFilters, false, true)
+
+	return nil
+}
+
+//pausePipeline pauses processor, routers & batch routers, waiting for them to be ready & returns a function to resume them
+func pausePipeline() (resume func()) {
 	var pm processor.ProcessorManagerI
+	var err error
 	for {
 		pm, err = processor.GetProcessorManager()
 		if err == nil {
@@ -113,18 +129,11 @@ func (handler *ClearOperationHandlerT) Exec(payload []byte) error {
 	}
 	brm.PauseAll()
 
-	//Clear From GatewayDB
-	handler.clearFromJobsdb(clearOperationHandler.gatewayDB, parameterFilters, false, false)
-	//Clear From RouterDB
-	handler.clearFromJobsdb(clearOperationHandler.routerDB, parameterFilters, true, true)
-	//Clear From BatchRouterDB
-	handler.clearFromJobsdb(clearOperationHandler.batchRouterDB, parameterFilters, false, true)
-
-	pm.Resume()
-	rm.ResumeAll()
-	brm.ResumeAll()
-
-	return nil
+	return func() {
+		pm.Resume()
+		rm.ResumeAll()
+		brm.ResumeAll()
+	}
 }
 
 func (handler *ClearOperationHandlerT) clearFromJobsdb(db jobsdb.JobsDB, parameterFilters []jobsdb.ParameterFilterT, throttled, waiting bool) {
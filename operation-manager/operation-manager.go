@@ -12,6 +12,12 @@ import (
 	"github.com/rudderlabs/rudder-server/utils/logger"
 )
 
+//operations handled by operation manager
+const (
+	ClearOperation      = "CLEAR"
+	RegulationOperation = "REGULATION"
+)
+
 var (
 	OperationManager        OperationManagerI
 	pkgLogger               logger.LoggerI
@@ -27,7 +33,7 @@ type OperationtT struct {
 }
 
 type OperationManagerI interface {
-	InsertOperation(payload []byte) (int64, error)
+	InsertOperation(operation string, payload []byte) (int64, error)
 	StartProcessLoop(ctx context.Context) error
 	GetOperationStatus(opID int64) (bool, string)
 }
@@ -70,7 +76,7 @@ func GetOperationManager() OperationManagerI {
 	return OperationManager
 }
 
-func (om *OperationManagerT) InsertOperation(payload []byte) (int64, error) {
+func (om *OperationManagerT) InsertOperation(operation string, payload []byte) (int64, error) {
 	if !enableOperationsManager {
 		return -1, fmt.Errorf("operation manager is disabled")
 	}
@@ -85,7 +91,7 @@ func (om *OperationManagerT) InsertOperation(payload []byte) (int64, error) {
 	}
 	defer stmt.Close()
 
-	row := stmt.QueryRow("CLEAR", payload, false, "queued")
+	row := stmt.QueryRow(operation, payload, false, "queued")
 	var opID int64
 	err = row.Scan(&opID)
 	if err != nil {
@@ -204,8 +210,11 @@ func (om *OperationManagerT) processOperation() {
 }
 
 func (om *OperationManagerT) getHandler(operation string) OperationHandlerI {
-	if operation == "CLEAR" {
+	switch operation {
+	case ClearOperation:
 		return GetClearOperationHandlerInstance(om.gatewayDB, om.routerDB, om.batchRouterDB)
+	case RegulationOperation:
+		return GetRegulationOperationHandlerInstance(om.dbHandle, om.gatewayDB, om.routerDB, om.batchRouterDB)
 	}
 
 	return nil
@@ -0,0 +1,243 @@
+package operationmanager
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+	"github.com/rudderlabs/rudder-server/jobsdb"
+	"github.com/rudderlabs/rudder-server/services/dedup"
+	"github.com/rudderlabs/rudder-server/services/regulation"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// RegulationOperationHandlerT drops pending events of the users of a regulation from the pipeline,
+// once their data has been deleted by regulation-worker.
+// Only jobs of the workspace of the regulation are considered, as user ids aren't unique across workspaces.
+type RegulationOperationHandlerT struct {
+	dbHandle               *sql.DB
+	gatewayDB              jobsdb.JobsDB
+	routerDB               jobsdb.JobsDB
+	batchRouterDB          jobsdb.JobsDB
+	workspaceIDForWriteKey func(writeKey string) string
+}
+
+var regulationOperationHandler *RegulationOperationHandlerT
+
+func GetRegulationOperationHandlerInstance(dbHandle *sql.DB, gatewayDB, routerDB, batchRouterDB jobsdb.JobsDB) *RegulationOperationHandlerT {
+	if regulationOperationHandler == nil {
+		regulationOperationHandler = &RegulationOperationHandlerT{
+			dbHandle:               dbHandle,
+			gatewayDB:              gatewayDB,
+			routerDB:               routerDB,
+			batchRouterDB:          batchRouterDB,
+			workspaceIDForWriteKey: backendconfig.GetWorkspaceIDForWriteKey,
+		}
+	}
+
+	return regulationOperationHandler
+}
+
+// Exec drops pending events of the users from gateway, router & batch router jobs & purges their message ids from dedup store.
+// Every action is recorded in regulation_audit_log.
+func (handler *RegulationOperationHandlerT) Exec(payload []byte) error {
+	var req regulation.PropagationRequestT
+	err := json.Unmarshal(payload, &req)
+	if err != nil {
+		return err
+	}
+	if err = req.Validate(); err != nil {
+		return err
+	}
+	matcher := regulation.NewUserMatcher(req.Users)
+
+	resumePipeline := pausePipeline()
+	defer resumePipeline()
+
+	var messageIDs []string
+	for _, db := range []jobsdb.JobsDB{handler.gatewayDB, handler.routerDB, handler.batchRouterDB} {
+		abortedCount, rewrittenCount, droppedMessageIDs := handler.dropUserEvents(db, req, matcher)
+		messageIDs = append(messageIDs, droppedMessageIDs...)
+		err = regulation.RecordAction(handler.dbHandle, req, regulation.AbortJobsAction, map[string]interface{}{
+			"db":        db.GetIdentifier(),
+			"count":     abortedCount,
+			"rewritten": rewrittenCount,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	//dedup store is only set up by processor, if dedup is enabled
+	dedupManager := dedup.GetSetupInstance()
+	if dedupManager == nil {
+		return nil
+	}
+	dedupManager.DeleteMessageIDs(messageIDs)
+	return regulation.RecordAction(handler.dbHandle, req, regulation.PurgeDedupAction, map[string]interface{}{
+		"count": len(messageIDs),
+	})
+}
+
+/*
+dropUserEvents aborts pending jobs having events of the users of the workspace.
+Jobs with events of other users too, i.e. gateway jobs of batch requests, are replaced by new jobs without the events of the users,
+which are stored before the original jobs are aborted, so that events of other users are never lost.
+The replacing jobs are processed after the jobs stored since the original ones, and their events are deduplicated by message id if dedup is enabled.
+It returns the number of jobs aborted & replaced, with message ids of the events dropped.
+Jobs are paged through by job id, since jobs of other users are left as is.
+*/
+func (handler *RegulationOperationHandlerT) dropUserEvents(db jobsdb.JobsDB, req regulation.PropagationRequestT, matcher *regulation.UserMatcherT) (abortedCount, rewrittenCount int, messageIDs []string) {
+	startTime := time.Now().UTC()
+	errorResponse := []byte(fmt.Sprintf(`{"reason": "aborted as per regulation job %d"}`, req.JobID))
+
+	getJobs := []func(afterJobID int64) []*jobsdb.JobT{
+		func(afterJobID int64) []*jobsdb.JobT {
+			return db.GetProcessed(jobsdb.GetQueryParamsT{
+				StateFilters: []string{jobsdb.Failed.State, jobsdb.Waiting.State, jobsdb.Importing.State},
+				JobCount:     jobQueryBatchSize,
+				AfterJobID:   afterJobID,
+			})
+		},
+		func(afterJobID int64) []*jobsdb.JobT {
+			return db.GetUnprocessed(jobsdb.GetQueryParamsT{
+				JobCount:      jobQueryBatchSize,
+				UseTimeFilter: true,
+				Before:        startTime,
+				AfterJobID:    afterJobID,
+			})
+		},
+	}
+	for _, get := range getJobs {
+		var afterJobID int64
+		for {
+			jobs := get(afterJobID)
+			if len(jobs) == 0 {
+				break
+			}
+			afterJobID = jobs[len(jobs)-1].JobID
+
+			var statusList []*jobsdb.JobStatusT
+			var replacingJobs []*jobsdb.JobT
+			for _, job := range jobs {
+				if handler.jobWorkspaceID(job) != req.WorkspaceID {
+					continue
+				}
+				jobMessageIDs, replacingJob, ok := userEventsOf(job, matcher)
+				if !ok {
+					continue
+				}
+				messageIDs = append(messageIDs, jobMessageIDs...)
+				if replacingJob != nil {
+					replacingJobs = append(replacingJobs, replacingJob)
+				}
+				statusList = append(statusList, &jobsdb.JobStatusT{
+					JobID:         job.JobID,
+					AttemptNum:    job.LastJobStatus.AttemptNum,
+					JobState:      jobsdb.Aborted.State,
+					ExecTime:      time.Now(),
+					RetryTime:     time.Now(),
+					ErrorCode:     "",
+					ErrorResponse: errorResponse,
+					Parameters:    []byte(`{}`),
+				})
+			}
+			if len(statusList) == 0 {
+				continue
+			}
+			replaceJobs(db, req, replacingJobs, statusList)
+			abortedCount += len(statusList) - len(replacingJobs)
+			rewrittenCount += len(replacingJobs)
+		}
+	}
+
+	pkgLogger.Infof("RegulationOperationHandler: aborted %d jobs & replaced %d jobs from %s db for regulation job %d", abortedCount, rewrittenCount, db.GetIdentifier(), req.JobID)
+	return abortedCount, rewrittenCount, messageIDs
+}
+
+// replaceJobs stores the replacing jobs & aborts the original jobs in a single transaction,
+// so that events of other users are neither lost nor delivered twice if either of the writes fails.
+func replaceJobs(db jobsdb.JobsDB, req regulation.PropagationRequestT, replacingJobs []*jobsdb.JobT, statusList []*jobsdb.JobStatusT) {
+	txn := db.BeginGlobalTransaction()
+	db.AcquireStoreLock()
+	defer db.ReleaseStoreLock()
+	if len(replacingJobs) > 0 {
+		err := db.StoreInTxn(txn, replacingJobs)
+		if err != nil {
+			_ = txn.Rollback()
+			pkgLogger.Errorf("RegulationOperationHandler: Error occurred while storing jobs without events of the users. Panicking. RegulationJobID:%d, Err: %v", req.JobID, err)
+			panic(err)
+		}
+	}
+	db.AcquireUpdateJobStatusLocks()
+	defer db.ReleaseUpdateJobStatusLocks()
+	//UpdateJobStatusInTxn rolls back the transaction on error
+	err := db.UpdateJobStatusInTxn(txn, statusList, []string{}, []jobsdb.ParameterFilterT{})
+	if err != nil {
+		pkgLogger.Errorf("RegulationOperationHandler: Error occurred while marking jobs statuses as aborted. Panicking. RegulationJobID:%d, Err: %v", req.JobID, err)
+		panic(err)
+	}
+	db.CommitTransaction(txn)
+}
+
+// jobWorkspaceID returns the workspace of the job, from the write key of gateway jobs & parameters of router & batch router jobs
+func (handler *RegulationOperationHandlerT) jobWorkspaceID(job *jobsdb.JobT) string {
+	if workspaceID := gjson.GetBytes(job.Parameters, "workspaceId").String(); workspaceID != "" {
+		return workspaceID
+	}
+	if writeKey := gjson.GetBytes(job.EventPayload, "writeKey").String(); writeKey != "" {
+		return handler.workspaceIDForWriteKey(writeKey)
+	}
+	return ""
+}
+
+// userEventsOf returns message ids of the events of the users in the job & true, if any of the events belongs to the users.
+// Gateway jobs have a batch of events, of which only the events of the users are dropped, returning a job with the rest of the events to replace it.
+// Router & batch router jobs have a single event with message id in parameters.
+func userEventsOf(job *jobsdb.JobT, matcher *regulation.UserMatcherT) (messageIDs []string, replacingJob *jobsdb.JobT, matches bool) {
+	payload := gjson.ParseBytes(job.EventPayload)
+	if batch := payload.Get("batch"); batch.IsArray() {
+		var otherEvents []string
+		for _, event := range batch.Array() {
+			if !matcher.Matches(event) {
+				otherEvents = append(otherEvents, event.Raw)
+				continue
+			}
+			matches = true
+			if messageID := event.Get("messageId").String(); messageID != "" {
+				messageIDs = append(messageIDs, messageID)
+			}
+		}
+		if !matches || len(otherEvents) == 0 {
+			return messageIDs, nil, matches
+		}
+		eventPayload, err := sjson.SetRawBytes(job.EventPayload, "batch", []byte("["+strings.Join(otherEvents, ",")+"]"))
+		if err != nil {
+			panic(err)
+		}
+		replacingJob = &jobsdb.JobT{
+			UUID:         uuid.Must(uuid.NewV4()),
+			UserID:       job.UserID,
+			Parameters:   job.Parameters,
+			CustomVal:    job.CustomVal,
+			EventPayload: eventPayload,
+			EventCount:   len(otherEvents),
+			CreatedAt:    time.Now(),
+			ExpireAt:     time.Now(),
+		}
+		return messageIDs, replacingJob, true
+	}
+
+	if !matcher.Matches(payload) {
+		return nil, nil, false
+	}
+	if messageID := gjson.GetBytes(job.Parameters, "message_id").String(); messageID != "" {
+		messageIDs = append(messageIDs, messageID)
+	}
+	return messageIDs, nil, true
+}
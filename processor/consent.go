@@ -0,0 +1,122 @@
+package processor
+
+import (
+	"fmt"
+	"strings"
+
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+	"github.com/rudderlabs/rudder-server/processor/transformer"
+	"github.com/rudderlabs/rudder-server/services/stats"
+	"github.com/rudderlabs/rudder-server/utils/misc"
+	"github.com/rudderlabs/rudder-server/utils/types"
+)
+
+/*
+Events are sent only to the destinations whose consent categories the user granted.
+Destinations declare the categories they need in their config, and whether events without consent are dropped:
+
+	"consentCategories": ["analytics", "marketing"],
+	"dropEventsWithoutConsent": true
+
+Events carry the consent of the user, as set by consent managers like OneTrust or Google Consent Mode:
+
+	"context": {"consentManagement": {"provider": "oneTrust", "allowedConsentIds": ["analytics"], "deniedConsentIds": ["marketing"]}}
+
+A category is granted unless it is denied, or missing from the allowed categories when the event has them.
+*/
+
+//consentDropReason returns why the event can't be sent to the destination as per the consent of the user, or empty if it can be
+func consentDropReason(event types.SingularEventT, destination *backendconfig.DestinationT) string {
+	categories := consentCategoriesOf(destination)
+	if len(categories) == 0 {
+		return ""
+	}
+
+	consent, ok := getEventField(event, "context.consentManagement")
+	consentManagement, isObject := consent.(map[string]interface{})
+	if !ok || !isObject {
+		if dropWithoutConsent, _ := destination.Config["dropEventsWithoutConsent"].(bool); dropWithoutConsent {
+			return "consent missing"
+		}
+		return ""
+	}
+
+	allowed := stringsOf(consentManagement["allowedConsentIds"])
+	denied := stringsOf(consentManagement["deniedConsentIds"])
+	var notGranted []string
+	for _, category := range categories {
+		if misc.ContainsString(denied, category) || (len(allowed) > 0 && !misc.ContainsString(allowed, category)) {
+			notGranted = append(notGranted, category)
+		}
+	}
+	if len(notGranted) > 0 {
+		return fmt.Sprintf("consent denied for categories: %s", strings.Join(notGranted, ","))
+	}
+	return ""
+}
+
+func consentCategoriesOf(destination *backendconfig.DestinationT) []string {
+	return stringsOf(destination.Config["consentCategories"])
+}
+
+func stringsOf(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		var values []string
+		for _, value := range v {
+			if s, ok := value.(string); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+//consentDropsT counts the events dropped for lack of consent, by connection & reason, for reporting
+type consentDropsT struct {
+	connectionDetailsMap map[string]*types.ConnectionDetails
+	statusDetailsMap     map[string]*types.StatusDetail
+	inPUs                map[string]string
+}
+
+func newConsentDrops() *consentDropsT {
+	return &consentDropsT{
+		connectionDetailsMap: make(map[string]*types.ConnectionDetails),
+		statusDetailsMap:     make(map[string]*types.StatusDetail),
+		inPUs:                make(map[string]string),
+	}
+}
+
+func (proc *HandleT) recordConsentDrop(drops *consentDropsT, metadata *transformer.MetadataT, reason, inPU string) {
+	proc.stats.NewTaggedStat("processor.consent_dropped_events", stats.CountType, stats.Tags{
+		"source":      metadata.SourceID,
+		"destination": metadata.DestinationID,
+		"destType":    metadata.DestinationType,
+	}).Increment()
+	if !proc.isReportingEnabled() {
+		return
+	}
+
+	key := strings.Join([]string{metadata.SourceID, metadata.DestinationID, metadata.SourceBatchID, metadata.EventName, metadata.EventType, reason}, METRICKEYDELIMITER)
+	if _, ok := drops.connectionDetailsMap[key]; !ok {
+		drops.connectionDetailsMap[key] = types.CreateConnectionDetail(metadata.SourceID, metadata.DestinationID, metadata.SourceBatchID, metadata.SourceTaskID, metadata.SourceTaskRunID, metadata.SourceJobID, metadata.SourceJobRunID, metadata.SourceDefinitionID, metadata.DestinationDefinitionID, metadata.SourceCategory)
+		drops.statusDetailsMap[key] = types.CreateStatusDetail(types.FilteredStatus, 0, 0, reason, []byte(`{}`), metadata.EventName, metadata.EventType)
+		drops.inPUs[key] = inPU
+	}
+	drops.statusDetailsMap[key].Count++
+}
+
+func (drops *consentDropsT) reportMetrics() []*types.PUReportedMetric {
+	var metrics []*types.PUReportedMetric
+	for key, cd := range drops.connectionDetailsMap {
+		metrics = append(metrics, &types.PUReportedMetric{
+			ConnectionDetails: *cd,
+			PUDetails:         *types.CreatePUDetails(drops.inPUs[key], types.CONSENT_FILTER, false, false),
+			StatusDetail:      drops.statusDetailsMap[key],
+		})
+	}
+	return metrics
+}
@@ -0,0 +1,64 @@
+package processor
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+	"github.com/rudderlabs/rudder-server/utils/types"
+)
+
+var _ = Describe("Consent", func() {
+	newDestination := func(config map[string]interface{}) *backendconfig.DestinationT {
+		return &backendconfig.DestinationT{ID: "destination-1", Config: config}
+	}
+	newEvent := func(consentManagement map[string]interface{}) types.SingularEventT {
+		return types.SingularEventT{"context": map[string]interface{}{"consentManagement": consentManagement}}
+	}
+
+	It("sends events to destinations without consent categories", func() {
+		event := newEvent(map[string]interface{}{"deniedConsentIds": []interface{}{"analytics"}})
+		Expect(consentDropReason(event, newDestination(map[string]interface{}{}))).To(BeEmpty())
+	})
+
+	It("drops events if a category of the destination is denied", func() {
+		destination := newDestination(map[string]interface{}{"consentCategories": []interface{}{"analytics", "marketing"}})
+		event := newEvent(map[string]interface{}{"deniedConsentIds": []interface{}{"marketing", "ads"}})
+		Expect(consentDropReason(event, destination)).To(Equal("consent denied for categories: marketing"))
+
+		event = newEvent(map[string]interface{}{"allowedConsentIds": []interface{}{"analytics"}})
+		Expect(consentDropReason(event, destination)).To(Equal("consent denied for categories: marketing"))
+	})
+
+	It("sends events if all the categories of the destination are granted", func() {
+		destination := newDestination(map[string]interface{}{"consentCategories": []interface{}{"analytics", "marketing"}})
+		event := newEvent(map[string]interface{}{"allowedConsentIds": []interface{}{"analytics", "marketing", "ads"}})
+		Expect(consentDropReason(event, destination)).To(BeEmpty())
+
+		event = newEvent(map[string]interface{}{"deniedConsentIds": []interface{}{"ads"}})
+		Expect(consentDropReason(event, destination)).To(BeEmpty())
+	})
+
+	It("drops events without consent only if the destination requires it", func() {
+		event := types.SingularEventT{"context": map[string]interface{}{}}
+		destination := newDestination(map[string]interface{}{"consentCategories": []interface{}{"analytics"}})
+		Expect(consentDropReason(event, destination)).To(BeEmpty())
+
+		destination.Config["dropEventsWithoutConsent"] = true
+		Expect(consentDropReason(event, destination)).To(Equal("consent missing"))
+	})
+
+	It("reports dropped events by connection & reason", func() {
+		drops := newConsentDrops()
+		drops.connectionDetailsMap["key"] = types.CreateConnectionDetail("source-1", "destination-1", "", "", "", "", "", "", "", "")
+		drops.statusDetailsMap["key"] = types.CreateStatusDetail(types.FilteredStatus, 2, 0, "consent missing", []byte(`{}`), "", "track")
+		drops.inPUs["key"] = types.GATEWAY
+
+		metrics := drops.reportMetrics()
+		Expect(metrics).To(HaveLen(1))
+		Expect(metrics[0].PUDetails.InPU).To(Equal(types.GATEWAY))
+		Expect(metrics[0].PUDetails.PU).To(Equal(types.CONSENT_FILTER))
+		Expect(metrics[0].StatusDetail.Status).To(Equal(types.FilteredStatus))
+		Expect(metrics[0].StatusDetail.Count).To(Equal(int64(2)))
+	})
+})
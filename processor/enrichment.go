@@ -0,0 +1,275 @@
+package processor
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mssola/user_agent"
+	"github.com/oschwald/geoip2-golang"
+
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+	"github.com/rudderlabs/rudder-server/utils/misc"
+	"github.com/rudderlabs/rudder-server/utils/types"
+)
+
+/*
+The enrichment stage adds information to the events of the sources which enable it, before they are sent to destinations.
+Sources enable it with the enrichment key in their config:
+
+	"enrichment": {
+		"geo": true,
+		"userAgent": true,
+		"lookups": [{"table": "plans", "key": "context.traits.planId", "target": "context.plan"}]
+	}
+
+geo adds context.location from the MaxMind database at Processor.Enrichment.geoDatabasePath, for context.ip or the ip of the request.
+userAgent adds context.os, context.browser & context.device.type by parsing context.userAgent.
+Fields already present in the event are kept as they are.
+lookups set target to the row of the lookup table with the value of key, from the tables in Processor.Enrichment.lookupTablesDir:
+csv files are tables keyed by their first column, with rows of the rest of the columns, json files are objects of the values by key.
+*/
+
+//geoLocatorI looks up the location of ips, like *geoip2.Reader
+type geoLocatorI interface {
+	City(ip net.IP) (*geoip2.City, error)
+}
+
+type enricherT struct {
+	geoLocator   geoLocatorI
+	lookupTables map[string]map[string]interface{}
+}
+
+//lookupT sets target to the row of the table with the value of key
+type lookupT struct {
+	table  string
+	key    string
+	target string
+}
+
+type enrichmentSettingsT struct {
+	geo       bool
+	userAgent bool
+	lookups   []lookupT
+}
+
+func newEnricher() *enricherT {
+	enricher := &enricherT{lookupTables: make(map[string]map[string]interface{})}
+	if geoDatabasePath != "" {
+		reader, err := geoip2.Open(geoDatabasePath)
+		if err != nil {
+			pkgLogger.Errorf("Failed to open geo database %s, events won't be enriched with their location: %v", geoDatabasePath, err)
+		} else {
+			enricher.geoLocator = reader
+		}
+	}
+	if lookupTablesDir != "" {
+		tables, err := loadLookupTables(lookupTablesDir)
+		if err != nil {
+			pkgLogger.Errorf("Failed to load lookup tables from %s: %v", lookupTablesDir, err)
+		}
+		enricher.lookupTables = tables
+	}
+	return enricher
+}
+
+//loadLookupTables loads the csv & json lookup tables in the directory, named after their files
+func loadLookupTables(dir string) (map[string]map[string]interface{}, error) {
+	tables := make(map[string]map[string]interface{})
+	paths, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		return tables, err
+	}
+	for _, path := range paths {
+		ext := filepath.Ext(path)
+		name := strings.TrimSuffix(filepath.Base(path), ext)
+		var table map[string]interface{}
+		switch ext {
+		case ".csv":
+			table, err = loadCSVLookupTable(path)
+		case ".json":
+			table, err = loadJSONLookupTable(path)
+		default:
+			continue
+		}
+		if err != nil {
+			return tables, fmt.Errorf("lookup table %s: %w", path, err)
+		}
+		tables[name] = table
+	}
+	return tables, nil
+}
+
+func loadCSVLookupTable(path string) (map[string]interface{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	table := make(map[string]interface{})
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return table, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		row := make(map[string]interface{}, len(header)-1)
+		for i := 1; i < len(header); i++ {
+			row[header[i]] = record[i]
+		}
+		table[record[0]] = row
+	}
+}
+
+func loadJSONLookupTable(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var table map[string]interface{}
+	return table, json.Unmarshal(data, &table)
+}
+
+func enrichmentSettingsOf(source *backendconfig.SourceT) (settings enrichmentSettingsT, enabled bool) {
+	enrichmentConfig, ok := source.Config["enrichment"].(map[string]interface{})
+	if !ok {
+		return settings, false
+	}
+	settings.geo, _ = enrichmentConfig["geo"].(bool)
+	settings.userAgent, _ = enrichmentConfig["userAgent"].(bool)
+	lookups, _ := enrichmentConfig["lookups"].([]interface{})
+	for _, lookup := range lookups {
+		lookupConfig, _ := lookup.(map[string]interface{})
+		table, _ := lookupConfig["table"].(string)
+		key, _ := lookupConfig["key"].(string)
+		target, _ := lookupConfig["target"].(string)
+		if table != "" && key != "" && target != "" {
+			settings.lookups = append(settings.lookups, lookupT{table: table, key: key, target: target})
+		}
+	}
+	return settings, settings.geo || settings.userAgent || len(settings.lookups) > 0
+}
+
+//enrich enriches the event as per the enrichment settings of its source
+func (enricher *enricherT) enrich(source *backendconfig.SourceT, event types.SingularEventT, requestIP string) {
+	settings, enabled := enrichmentSettingsOf(source)
+	if !enabled {
+		return
+	}
+	if settings.geo && enricher.geoLocator != nil {
+		ip, _ := getEventField(event, "context.ip")
+		ipString, _ := ip.(string)
+		if ipString == "" {
+			ipString = requestIP
+		}
+		if location := enricher.locate(ipString); len(location) > 0 {
+			mergeEventField(event, "context.location", location)
+		}
+	}
+	if settings.userAgent {
+		userAgent, _ := getEventField(event, "context.userAgent")
+		if userAgentString, ok := userAgent.(string); ok && userAgentString != "" {
+			for field, value := range parseUserAgent(userAgentString) {
+				mergeEventField(event, "context."+field, value)
+			}
+		}
+	}
+	for _, lookup := range settings.lookups {
+		table, ok := enricher.lookupTables[lookup.table]
+		if !ok {
+			continue
+		}
+		key, ok := getEventField(event, lookup.key)
+		if !ok {
+			continue
+		}
+		if row, ok := table[misc.GetStringifiedData(key)]; ok {
+			setEventField(event, lookup.target, copyEventValue(row))
+		}
+	}
+}
+
+func (enricher *enricherT) locate(ip string) map[string]interface{} {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return nil
+	}
+	city, err := enricher.geoLocator.City(parsedIP)
+	if err != nil {
+		pkgLogger.Debugf("Failed to locate ip %s: %v", ip, err)
+		return nil
+	}
+
+	location := make(map[string]interface{})
+	setIfNotEmpty := func(key, value string) {
+		if value != "" {
+			location[key] = value
+		}
+	}
+	setIfNotEmpty("city", city.City.Names["en"])
+	setIfNotEmpty("country", city.Country.Names["en"])
+	setIfNotEmpty("countryCode", city.Country.IsoCode)
+	if len(city.Subdivisions) > 0 {
+		setIfNotEmpty("region", city.Subdivisions[0].Names["en"])
+	}
+	setIfNotEmpty("postalCode", city.Postal.Code)
+	setIfNotEmpty("timezone", city.Location.TimeZone)
+	if city.Location.Latitude != 0 || city.Location.Longitude != 0 {
+		location["latitude"] = city.Location.Latitude
+		location["longitude"] = city.Location.Longitude
+	}
+	return location
+}
+
+//parseUserAgent returns the os, browser & device of the user agent, by their fields in the context of events
+func parseUserAgent(userAgent string) map[string]map[string]interface{} {
+	ua := user_agent.New(userAgent)
+	fields := make(map[string]map[string]interface{})
+
+	osInfo := ua.OSInfo()
+	if osInfo.Name != "" {
+		fields["os"] = map[string]interface{}{"name": osInfo.Name, "version": osInfo.Version}
+	}
+	if name, version := ua.Browser(); name != "" {
+		fields["browser"] = map[string]interface{}{"name": name, "version": version}
+	}
+	deviceType := "desktop"
+	if ua.Bot() {
+		deviceType = "bot"
+	} else if ua.Mobile() {
+		deviceType = "mobile"
+	}
+	fields["device"] = map[string]interface{}{"type": deviceType}
+	return fields
+}
+
+//mergeEventField sets the fields of value missing in the object at the path of the event
+func mergeEventField(event map[string]interface{}, path string, value map[string]interface{}) {
+	existing, ok := getEventField(event, path)
+	if !ok || existing == nil {
+		setEventField(event, path, value)
+		return
+	}
+	object, ok := existing.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for key, v := range value {
+		if _, ok := object[key]; !ok {
+			object[key] = v
+		}
+	}
+}
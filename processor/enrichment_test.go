@@ -0,0 +1,118 @@
+package processor
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/oschwald/geoip2-golang"
+
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+	"github.com/rudderlabs/rudder-server/utils/types"
+)
+
+//fakeGeoLocator locates 1.2.3.4 in Berlin
+type fakeGeoLocator struct{}
+
+func (fakeGeoLocator) City(ip net.IP) (*geoip2.City, error) {
+	if !ip.Equal(net.ParseIP("1.2.3.4")) {
+		return nil, errors.New("not found")
+	}
+	city := &geoip2.City{}
+	city.City.Names = map[string]string{"en": "Berlin"}
+	city.Country.Names = map[string]string{"en": "Germany"}
+	city.Country.IsoCode = "DE"
+	city.Location.Latitude = 52.52
+	city.Location.Longitude = 13.4
+	city.Location.TimeZone = "Europe/Berlin"
+	return city, nil
+}
+
+var _ = Describe("Enrichment", func() {
+	const chromeOnWindows = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/96.0.4664.110 Safari/537.36"
+
+	newSource := func(enrichment map[string]interface{}) *backendconfig.SourceT {
+		return &backendconfig.SourceT{ID: "source-1", Config: map[string]interface{}{"enrichment": enrichment}}
+	}
+
+	It("doesn't enrich the events of sources without enrichment", func() {
+		enricher := &enricherT{geoLocator: fakeGeoLocator{}}
+		event := types.SingularEventT{"context": map[string]interface{}{"ip": "1.2.3.4", "userAgent": chromeOnWindows}}
+		enricher.enrich(&backendconfig.SourceT{}, event, "1.2.3.4")
+		Expect(event).To(Equal(types.SingularEventT{"context": map[string]interface{}{"ip": "1.2.3.4", "userAgent": chromeOnWindows}}))
+	})
+
+	It("adds the location of the ip of the event or of its request", func() {
+		enricher := &enricherT{geoLocator: fakeGeoLocator{}}
+		source := newSource(map[string]interface{}{"geo": true})
+
+		event := types.SingularEventT{"context": map[string]interface{}{"ip": "1.2.3.4"}}
+		enricher.enrich(source, event, "5.6.7.8")
+		Expect(event["context"].(map[string]interface{})["location"]).To(Equal(map[string]interface{}{
+			"city":        "Berlin",
+			"country":     "Germany",
+			"countryCode": "DE",
+			"timezone":    "Europe/Berlin",
+			"latitude":    52.52,
+			"longitude":   13.4,
+		}))
+
+		event = types.SingularEventT{"context": map[string]interface{}{"location": map[string]interface{}{"city": "Potsdam"}}}
+		enricher.enrich(source, event, "1.2.3.4")
+		location := event["context"].(map[string]interface{})["location"].(map[string]interface{})
+		Expect(location["city"]).To(Equal("Potsdam"))
+		Expect(location["countryCode"]).To(Equal("DE"))
+
+		event = types.SingularEventT{}
+		enricher.enrich(source, event, "5.6.7.8")
+		Expect(event).To(BeEmpty())
+	})
+
+	It("parses the user agent into the os, browser & device of the event", func() {
+		enricher := &enricherT{}
+		event := types.SingularEventT{"context": map[string]interface{}{
+			"userAgent": chromeOnWindows,
+			"device":    map[string]interface{}{"id": "device-1"},
+		}}
+		enricher.enrich(newSource(map[string]interface{}{"userAgent": true}), event, "")
+
+		context := event["context"].(map[string]interface{})
+		Expect(context["os"]).To(Equal(map[string]interface{}{"name": "Windows", "version": "10"}))
+		Expect(context["browser"]).To(Equal(map[string]interface{}{"name": "Chrome", "version": "96.0.4664.110"}))
+		Expect(context["device"]).To(Equal(map[string]interface{}{"id": "device-1", "type": "desktop"}))
+	})
+
+	It("joins lookup tables", func() {
+		dir, err := os.MkdirTemp("", "lookup-tables")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		Expect(os.WriteFile(filepath.Join(dir, "plans.csv"), []byte("id,name,tier\np1,Starter,1\np2,Growth,2\n"), 0o644)).To(BeNil())
+		Expect(os.WriteFile(filepath.Join(dir, "regions.json"), []byte(`{"DE": "EU", "US": "NA"}`), 0o644)).To(BeNil())
+		Expect(os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a table"), 0o644)).To(BeNil())
+
+		tables, err := loadLookupTables(dir)
+		Expect(err).To(BeNil())
+		Expect(tables).To(HaveLen(2))
+
+		enricher := &enricherT{lookupTables: tables}
+		source := newSource(map[string]interface{}{"lookups": []interface{}{
+			map[string]interface{}{"table": "plans", "key": "context.traits.planId", "target": "context.plan"},
+			map[string]interface{}{"table": "regions", "key": "properties.country", "target": "properties.region"},
+			map[string]interface{}{"table": "missing", "key": "properties.country", "target": "properties.missing"},
+		}})
+		event := types.SingularEventT{
+			"context":    map[string]interface{}{"traits": map[string]interface{}{"planId": "p2"}},
+			"properties": map[string]interface{}{"country": "FR"},
+		}
+		enricher.enrich(source, event, "")
+		Expect(event["context"].(map[string]interface{})["plan"]).To(Equal(map[string]interface{}{"name": "Growth", "tier": "2"}))
+		Expect(event["properties"]).To(Equal(map[string]interface{}{"country": "FR"}))
+
+		event["properties"] = map[string]interface{}{"country": "DE"}
+		enricher.enrich(source, event, "")
+		Expect(event["properties"]).To(Equal(map[string]interface{}{"country": "DE", "region": "EU"}))
+	})
+})
@@ -0,0 +1,372 @@
+package processor
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+	"github.com/rudderlabs/rudder-server/processor/transformer"
+	"github.com/rudderlabs/rudder-server/utils/misc"
+)
+
+/*
+Event rules of a connection filter, sample & map its events natively, without a user transformation.
+They are configured with the eventRules key in the config of the destination, as a list of rule sets:
+
+	"eventRules": [{
+		"sourceIds": ["<source id>"],
+		"filters": [{"action": "deny", "conditions": [{"field": "event", "operator": "in", "value": ["Ping", "Heartbeat"]}]}],
+		"sampling": {"rate": 0.1, "key": "userId"},
+		"mappings": [{"action": "rename", "field": "properties.revenue", "to": "properties.value"}]
+	}]
+
+Rule sets without sourceIds apply to the connections of all the sources of the destination, others only to the connections of their sources.
+Each rule set first filters, then samples and then maps the events it lets through, in the order of the rule sets.
+*/
+
+const (
+	AllowEventRule = "allow"
+	DenyEventRule  = "deny"
+
+	RenameEventMapping = "rename"
+	DropEventMapping   = "drop"
+	SetEventMapping    = "set"
+)
+
+//EventRuleSetT is a rule set of the eventRules config of a destination
+type EventRuleSetT struct {
+	SourceIDs []string            `json:"sourceIds"`
+	Filters   []EventFilterRuleT  `json:"filters"`
+	Sampling  *EventSamplingT     `json:"sampling"`
+	Mappings  []EventMappingRuleT `json:"mappings"`
+}
+
+/*
+EventFilterRuleT allows or denies the events matching all of its conditions.
+The first matching filter of a rule set decides for an event.
+Events matching no filter are denied if the rule set has allow filters, and allowed otherwise.
+*/
+type EventFilterRuleT struct {
+	Action     string            `json:"action"`
+	Conditions []EventConditionT `json:"conditions"`
+}
+
+//EventConditionT is a predicate on a field of the event, addressed by its dot separated path, like properties.price
+type EventConditionT struct {
+	Field    string      `json:"field"`
+	Operator string      `json:"operator"`
+	Value    interface{} `json:"value"`
+}
+
+//EventSamplingT lets through the given rate of events, sampled by a field of the event, messageId by default
+type EventSamplingT struct {
+	Rate float64 `json:"rate"`
+	Key  string  `json:"key"`
+}
+
+//EventMappingRuleT renames, drops or sets a field of the event
+type EventMappingRuleT struct {
+	Action string      `json:"action"`
+	Field  string      `json:"field"`
+	To     string      `json:"to"`
+	Value  interface{} `json:"value"`
+}
+
+const samplingBuckets = 10000
+
+var eventConditionOperators = map[string]func(value interface{}, exists bool, expected interface{}) bool{
+	"eq": func(value interface{}, exists bool, expected interface{}) bool {
+		return exists && eventValuesEqual(value, expected)
+	},
+	"neq": func(value interface{}, exists bool, expected interface{}) bool {
+		return !exists || !eventValuesEqual(value, expected)
+	},
+	"in": func(value interface{}, exists bool, expected interface{}) bool {
+		return exists && eventValueIn(value, expected)
+	},
+	"nin": func(value interface{}, exists bool, expected interface{}) bool {
+		return !exists || !eventValueIn(value, expected)
+	},
+	"exists":    func(value interface{}, exists bool, _ interface{}) bool { return exists && value != nil },
+	"notExists": func(value interface{}, exists bool, _ interface{}) bool { return !exists || value == nil },
+	"contains":  containsEventCondition,
+	"gt":        numericEventCondition(func(a, b float64) bool { return a > b }),
+	"gte":       numericEventCondition(func(a, b float64) bool { return a >= b }),
+	"lt":        numericEventCondition(func(a, b float64) bool { return a < b }),
+	"lte":       numericEventCondition(func(a, b float64) bool { return a <= b }),
+}
+
+func containsEventCondition(value interface{}, exists bool, expected interface{}) bool {
+	s, ok := value.(string)
+	substr, expectedOk := expected.(string)
+	return exists && ok && expectedOk && strings.Contains(s, substr)
+}
+
+func numericEventCondition(compare func(a, b float64) bool) func(value interface{}, exists bool, expected interface{}) bool {
+	return func(value interface{}, exists bool, expected interface{}) bool {
+		a, ok := toFloat(value)
+		b, expectedOk := toFloat(expected)
+		return exists && ok && expectedOk && compare(a, b)
+	}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func eventValuesEqual(value, expected interface{}) bool {
+	a, ok := toFloat(value)
+	b, expectedOk := toFloat(expected)
+	if ok && expectedOk {
+		return a == b
+	}
+	return reflect.DeepEqual(value, expected)
+}
+
+func eventValueIn(value, expected interface{}) bool {
+	values, ok := expected.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, v := range values {
+		if eventValuesEqual(value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+//parseEventRules returns the rule sets of the eventRules config of the destination, parsed once per backend config update
+func parseEventRules(destination *backendconfig.DestinationT) ([]EventRuleSetT, error) {
+	rulesConfig, ok := destination.Config["eventRules"]
+	if !ok || rulesConfig == nil {
+		return nil, nil
+	}
+
+	var ruleSets []EventRuleSetT
+	rulesJSON, err := json.Marshal(rulesConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(rulesJSON, &ruleSets); err != nil {
+		return nil, fmt.Errorf("invalid event rules %s: %w", string(rulesJSON), err)
+	}
+	for i := range ruleSets {
+		if err := ruleSets[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+	return ruleSets, nil
+}
+
+//getEventRules returns the rule sets of the destination which apply to its connection with the source
+func getEventRules(sourceID, destinationID string) []EventRuleSetT {
+	configSubscriberLock.RLock()
+	ruleSets := destinationEventRulesMap[destinationID]
+	configSubscriberLock.RUnlock()
+
+	var connectionRuleSets []EventRuleSetT
+	for _, ruleSet := range ruleSets {
+		if len(ruleSet.SourceIDs) == 0 || misc.ContainsString(ruleSet.SourceIDs, sourceID) {
+			connectionRuleSets = append(connectionRuleSets, ruleSet)
+		}
+	}
+	return connectionRuleSets
+}
+
+func (ruleSet *EventRuleSetT) validate() error {
+	for _, filter := range ruleSet.Filters {
+		if filter.Action != AllowEventRule && filter.Action != DenyEventRule {
+			return fmt.Errorf("invalid event filter action %q", filter.Action)
+		}
+		for _, condition := range filter.Conditions {
+			if condition.Field == "" {
+				return fmt.Errorf("event filter condition without field")
+			}
+			if _, ok := eventConditionOperators[condition.Operator]; !ok {
+				return fmt.Errorf("invalid event filter operator %q", condition.Operator)
+			}
+		}
+	}
+	if ruleSet.Sampling != nil && (ruleSet.Sampling.Rate < 0 || ruleSet.Sampling.Rate > 1) {
+		return fmt.Errorf("invalid event sampling rate %v", ruleSet.Sampling.Rate)
+	}
+	for _, mapping := range ruleSet.Mappings {
+		if mapping.Field == "" {
+			return fmt.Errorf("event mapping without field")
+		}
+		switch mapping.Action {
+		case RenameEventMapping:
+			if mapping.To == "" {
+				return fmt.Errorf("event mapping renaming %s without to", mapping.Field)
+			}
+		case DropEventMapping, SetEventMapping:
+		default:
+			return fmt.Errorf("invalid event mapping action %q", mapping.Action)
+		}
+	}
+	return nil
+}
+
+//allows returns whether the filters & sampling of the rule set let the event through
+func (ruleSet *EventRuleSetT) allows(event map[string]interface{}) bool {
+	allowed := true
+	for _, filter := range ruleSet.Filters {
+		if filter.Action == AllowEventRule {
+			allowed = false
+			break
+		}
+	}
+	for _, filter := range ruleSet.Filters {
+		if filter.matches(event) {
+			allowed = filter.Action == AllowEventRule
+			break
+		}
+	}
+	if !allowed || ruleSet.Sampling == nil {
+		return allowed
+	}
+
+	key := ruleSet.Sampling.Key
+	if key == "" {
+		key = "messageId"
+	}
+	value, _ := getEventField(event, key)
+	bucket := int(math.Abs(float64(misc.GetHash(fmt.Sprint(value)) % samplingBuckets)))
+	return float64(bucket) < ruleSet.Sampling.Rate*samplingBuckets
+}
+
+func (filter *EventFilterRuleT) matches(event map[string]interface{}) bool {
+	for _, condition := range filter.Conditions {
+		value, exists := getEventField(event, condition.Field)
+		if !eventConditionOperators[condition.Operator](value, exists, condition.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+//mapEvent returns the event with the mappings of the rule set applied, leaving the given event unmodified
+func (ruleSet *EventRuleSetT) mapEvent(event map[string]interface{}) map[string]interface{} {
+	if len(ruleSet.Mappings) == 0 {
+		return event
+	}
+	mapped := copyEventValue(event).(map[string]interface{})
+	for _, mapping := range ruleSet.Mappings {
+		switch mapping.Action {
+		case RenameEventMapping:
+			if value, ok := getEventField(mapped, mapping.Field); ok {
+				deleteEventField(mapped, mapping.Field)
+				setEventField(mapped, mapping.To, value)
+			}
+		case DropEventMapping:
+			deleteEventField(mapped, mapping.Field)
+		case SetEventMapping:
+			setEventField(mapped, mapping.Field, mapping.Value)
+		}
+	}
+	return mapped
+}
+
+//applyEventRules drops the events which the event rules of the connection don't let through & maps the rest of them.
+//It returns the events let through & the number of dropped events.
+func applyEventRules(ruleSets []EventRuleSetT, events []transformer.TransformerResponseT) ([]transformer.TransformerResponseT, int) {
+	if len(ruleSets) == 0 {
+		return events, 0
+	}
+	var allowed []transformer.TransformerResponseT
+	for _, event := range events {
+		message := event.Output
+		dropped := false
+		for i := range ruleSets {
+			if !ruleSets[i].allows(message) {
+				dropped = true
+				break
+			}
+			message = ruleSets[i].mapEvent(message)
+		}
+		if dropped {
+			continue
+		}
+		event.Output = message
+		allowed = append(allowed, event)
+	}
+	return allowed, len(events) - len(allowed)
+}
+
+func getEventField(event map[string]interface{}, path string) (interface{}, bool) {
+	keys := strings.Split(path, ".")
+	var current interface{} = event
+	for _, key := range keys {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func setEventField(event map[string]interface{}, path string, value interface{}) {
+	keys := strings.Split(path, ".")
+	object := event
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := object[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			object[key] = next
+		}
+		object = next
+	}
+	object[keys[len(keys)-1]] = value
+}
+
+func deleteEventField(event map[string]interface{}, path string) {
+	keys := strings.Split(path, ".")
+	object := event
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := object[key].(map[string]interface{})
+		if !ok {
+			return
+		}
+		object = next
+	}
+	delete(object, keys[len(keys)-1])
+}
+
+//copyEventValue deep copies the maps & slices of an event, which are shared by the events of all the destinations of the source
+func copyEventValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			copied[key] = copyEventValue(value)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, value := range v {
+			copied[i] = copyEventValue(value)
+		}
+		return copied
+	}
+	return value
+}
@@ -0,0 +1,143 @@
+package processor
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+	"github.com/rudderlabs/rudder-server/processor/transformer"
+)
+
+var _ = Describe("Event rules", func() {
+	newEvents := func(messages ...map[string]interface{}) []transformer.TransformerResponseT {
+		var events []transformer.TransformerResponseT
+		for i, message := range messages {
+			events = append(events, transformer.TransformerResponseT{Output: message, StatusCode: 200, Metadata: transformer.MetadataT{MessageID: fmt.Sprint(i)}})
+		}
+		return events
+	}
+	outputs := func(events []transformer.TransformerResponseT) []map[string]interface{} {
+		var messages []map[string]interface{}
+		for _, event := range events {
+			messages = append(messages, event.Output)
+		}
+		return messages
+	}
+
+	Context("rules config", func() {
+		It("returns the rule sets of the connection", func() {
+			destination := backendconfig.DestinationT{Config: map[string]interface{}{"eventRules": []interface{}{
+				map[string]interface{}{"filters": []interface{}{map[string]interface{}{"action": "deny", "conditions": []interface{}{map[string]interface{}{"field": "type", "operator": "eq", "value": "page"}}}}},
+				map[string]interface{}{"sourceIds": []interface{}{"source-2"}, "sampling": map[string]interface{}{"rate": 0.5}},
+			}}}
+			ruleSets, err := parseEventRules(&destination)
+			Expect(err).To(BeNil())
+			configSubscriberLock.Lock()
+			destinationEventRulesMap = map[string][]EventRuleSetT{"dest-1": ruleSets}
+			configSubscriberLock.Unlock()
+
+			Expect(getEventRules("source-1", "dest-1")).To(HaveLen(1))
+			connectionRuleSets := getEventRules("source-2", "dest-1")
+			Expect(connectionRuleSets).To(HaveLen(2))
+			Expect(connectionRuleSets[1].Sampling.Rate).To(Equal(0.5))
+			Expect(getEventRules("source-1", "dest-2")).To(BeEmpty())
+
+			ruleSets, err = parseEventRules(&backendconfig.DestinationT{})
+			Expect(err).To(BeNil())
+			Expect(ruleSets).To(BeEmpty())
+		})
+
+		It("rejects invalid rules", func() {
+			for _, ruleSet := range []map[string]interface{}{
+				{"filters": []interface{}{map[string]interface{}{"action": "drop"}}},
+				{"filters": []interface{}{map[string]interface{}{"action": "deny", "conditions": []interface{}{map[string]interface{}{"field": "event", "operator": "like"}}}}},
+				{"sampling": map[string]interface{}{"rate": 2}},
+				{"mappings": []interface{}{map[string]interface{}{"action": "rename", "field": "event"}}},
+				{"mappings": []interface{}{map[string]interface{}{"action": "upper", "field": "event"}}},
+			} {
+				_, err := parseEventRules(&backendconfig.DestinationT{Config: map[string]interface{}{"eventRules": []interface{}{ruleSet}}})
+				Expect(err).NotTo(BeNil())
+			}
+		})
+	})
+
+	Context("filters", func() {
+		It("denies the events matching deny filters", func() {
+			ruleSets := []EventRuleSetT{{Filters: []EventFilterRuleT{{Action: DenyEventRule, Conditions: []EventConditionT{
+				{Field: "event", Operator: "in", Value: []interface{}{"Ping", "Heartbeat"}},
+				{Field: "properties.internal", Operator: "eq", Value: true},
+			}}}}}
+			events := newEvents(
+				map[string]interface{}{"event": "Ping", "properties": map[string]interface{}{"internal": true}},
+				map[string]interface{}{"event": "Ping", "properties": map[string]interface{}{"internal": false}},
+				map[string]interface{}{"event": "Order Completed"},
+			)
+			allowed, dropped := applyEventRules(ruleSets, events)
+			Expect(dropped).To(Equal(1))
+			Expect(allowed).To(HaveLen(2))
+			Expect(allowed[0].Metadata.MessageID).To(Equal("1"))
+		})
+
+		It("denies the events matching no allow filter", func() {
+			ruleSets := []EventRuleSetT{{Filters: []EventFilterRuleT{
+				{Action: DenyEventRule, Conditions: []EventConditionT{{Field: "properties.price", Operator: "lt", Value: float64(0)}}},
+				{Action: AllowEventRule, Conditions: []EventConditionT{{Field: "type", Operator: "eq", Value: "track"}, {Field: "properties.price", Operator: "gte", Value: 10}}},
+				{Action: AllowEventRule, Conditions: []EventConditionT{{Field: "userId", Operator: "exists"}}},
+			}}}
+			events := newEvents(
+				map[string]interface{}{"type": "track", "properties": map[string]interface{}{"price": 10.5}},
+				map[string]interface{}{"type": "track", "userId": "u1", "properties": map[string]interface{}{"price": float64(-1)}},
+				map[string]interface{}{"type": "track", "properties": map[string]interface{}{"price": float64(5)}},
+				map[string]interface{}{"type": "identify", "userId": "u1"},
+			)
+			allowed, dropped := applyEventRules(ruleSets, events)
+			Expect(dropped).To(Equal(2))
+			Expect(allowed[0].Metadata.MessageID).To(Equal("0"))
+			Expect(allowed[1].Metadata.MessageID).To(Equal("3"))
+		})
+	})
+
+	Context("sampling", func() {
+		It("lets through the sampled rate of events, consistently by its key", func() {
+			ruleSets := []EventRuleSetT{{Sampling: &EventSamplingT{Rate: 0.5, Key: "userId"}}}
+			var messages []map[string]interface{}
+			for i := 0; i < 1000; i++ {
+				messages = append(messages, map[string]interface{}{"userId": fmt.Sprint("user-", i)})
+			}
+			allowed, dropped := applyEventRules(ruleSets, newEvents(messages...))
+			Expect(len(allowed) + dropped).To(Equal(1000))
+			Expect(len(allowed)).To(BeNumerically("~", 500, 100))
+
+			again, _ := applyEventRules(ruleSets, newEvents(messages...))
+			Expect(outputs(again)).To(Equal(outputs(allowed)))
+
+			none, dropped := applyEventRules([]EventRuleSetT{{Sampling: &EventSamplingT{Rate: 0}}}, newEvents(messages...))
+			Expect(none).To(BeEmpty())
+			Expect(dropped).To(Equal(1000))
+		})
+	})
+
+	Context("mappings", func() {
+		It("renames, drops & sets fields without modifying the events of other destinations", func() {
+			ruleSets := []EventRuleSetT{{Mappings: []EventMappingRuleT{
+				{Action: RenameEventMapping, Field: "properties.revenue", To: "properties.value"},
+				{Action: DropEventMapping, Field: "context.ip"},
+				{Action: SetEventMapping, Field: "context.destination.name", Value: "analytics"},
+			}}}
+			message := map[string]interface{}{
+				"properties": map[string]interface{}{"revenue": 10},
+				"context":    map[string]interface{}{"ip": "1.2.3.4", "locale": "en"},
+			}
+			allowed, dropped := applyEventRules(ruleSets, newEvents(message))
+			Expect(dropped).To(BeZero())
+			Expect(allowed[0].Output).To(Equal(map[string]interface{}{
+				"properties": map[string]interface{}{"value": 10},
+				"context":    map[string]interface{}{"locale": "en", "destination": map[string]interface{}{"name": "analytics"}},
+			}))
+			Expect(message["properties"]).To(Equal(map[string]interface{}{"revenue": 10}))
+			Expect(message["context"]).To(Equal(map[string]interface{}{"ip": "1.2.3.4", "locale": "en"}))
+		})
+	})
+})
@@ -20,6 +20,7 @@ var (
 	jsonfast = jsoniter.ConfigCompatibleWithStandardLibrary
 
 	destTransformURL      string
+	destTransformURLs     []string
 	postParametersTFields []string
 )
 
@@ -34,6 +35,15 @@ func Init() {
 
 func loadConfig() {
 	destTransformURL = config.GetEnv("DEST_TRANSFORM_URL", "http://localhost:9090")
+	destTransformURLs = nil
+	for _, url := range strings.Split(config.GetEnv("DEST_TRANSFORM_URLS", ""), ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			destTransformURLs = append(destTransformURLs, url)
+		}
+	}
+	if len(destTransformURLs) == 0 {
+		destTransformURLs = []string{destTransformURL}
+	}
 }
 
 const (
@@ -97,15 +107,20 @@ func CollectIntgTransformErrorStats(input []byte) {
 	var integrationStats []TransStatsT
 	err := jsonfast.Unmarshal(input, &integrationStats)
 	if err == nil {
-		for _, integrationStat := range integrationStats {
-			if len(integrationStat.StatTags) > 0 {
-				stats.NewTaggedStat("integration.failure_detailed", stats.CountType, integrationStat.StatTags).Increment()
-			}
-		}
+		ReportIntgTransformErrorStats(integrationStats)
 	}
 
 }
 
+//ReportIntgTransformErrorStats reports the stats of the integrations in the decoded transformer response
+func ReportIntgTransformErrorStats(integrationStats []TransStatsT) {
+	for _, integrationStat := range integrationStats {
+		if len(integrationStat.StatTags) > 0 {
+			stats.NewTaggedStat("integration.failure_detailed", stats.CountType, integrationStat.StatTags).Increment()
+		}
+	}
+}
+
 // GetPostInfo parses the transformer response
 func ValidatePostInfo(transformRawParams PostParametersT) error {
 	transformRaw, err := jsonfast.Marshal(transformRawParams)
@@ -179,6 +194,11 @@ func GetTransformerURL() string {
 	return destTransformURL
 }
 
+//GetTransformerURLs gets the base urls of the transformers which requests to the transfomer url are balanced across
+func GetTransformerURLs() []string {
+	return destTransformURLs
+}
+
 //GetDestinationURL returns node URL
 func GetDestinationURL(destType string) string {
 	destinationEndPoint := fmt.Sprintf("%s/v0/%s", destTransformURL, strings.ToLower(destType))
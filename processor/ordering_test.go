@@ -0,0 +1,37 @@
+package processor
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+	"github.com/rudderlabs/rudder-server/utils/types"
+)
+
+var _ = Describe("Ordering key", func() {
+	destination := &backendconfig.DestinationT{
+		ID:                    "orderdest",
+		DestinationDefinition: backendconfig.DestinationDefinitionT{Name: "WEBHOOK"},
+	}
+	event := types.SingularEventT{"userId": "u1", "context": map[string]interface{}{"groupId": "g1"}}
+
+	AfterEach(func() {
+		os.Unsetenv("RSERVER_ROUTER_ORDERDEST_ORDERING_POLICY")
+		os.Unsetenv("RSERVER_ROUTER_ORDERDEST_ORDERING_KEY")
+	})
+
+	It("picks the value of the ordering key of destinations ordering by key from the event", func() {
+		os.Setenv("RSERVER_ROUTER_ORDERDEST_ORDERING_POLICY", "key")
+		os.Setenv("RSERVER_ROUTER_ORDERDEST_ORDERING_KEY", "context.groupId")
+		orderingKeyPaths := map[string]string{}
+		Expect(orderingKeyValue(orderingKeyPaths, event, destination)).To(Equal("g1"))
+		Expect(orderingKeyPaths).To(HaveKeyWithValue("orderdest", "context.groupId"))
+	})
+
+	It("doesn't pick ordering keys for destinations not ordering by key", func() {
+		os.Setenv("RSERVER_ROUTER_ORDERDEST_ORDERING_KEY", "context.groupId")
+		Expect(orderingKeyValue(map[string]string{}, event, destination)).To(BeEmpty())
+	})
+})
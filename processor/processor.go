@@ -20,6 +20,7 @@ import (
 	"github.com/rudderlabs/rudder-server/router"
 
 	"github.com/rudderlabs/rudder-server/router/batchrouter"
+	router_utils "github.com/rudderlabs/rudder-server/router/utils"
 	"github.com/rudderlabs/rudder-server/services/dedup"
 	"golang.org/x/sync/errgroup"
 
@@ -31,8 +32,10 @@ import (
 	"github.com/rudderlabs/rudder-server/processor/integrations"
 	"github.com/rudderlabs/rudder-server/processor/stash"
 	"github.com/rudderlabs/rudder-server/processor/transformer"
+	"github.com/rudderlabs/rudder-server/processor/transformer/native"
 	"github.com/rudderlabs/rudder-server/rruntime"
 	destinationdebugger "github.com/rudderlabs/rudder-server/services/debugger/destination"
+	taildebugger "github.com/rudderlabs/rudder-server/services/debugger/tail"
 	transformationdebugger "github.com/rudderlabs/rudder-server/services/debugger/transformation"
 	"github.com/rudderlabs/rudder-server/services/stats"
 	"github.com/rudderlabs/rudder-server/utils"
@@ -65,6 +68,7 @@ type HandleT struct {
 	batchRouterDB                  jobsdb.JobsDB
 	errorDB                        jobsdb.JobsDB
 	transformer                    transformer.Transformer
+	enricher                       *enricherT
 	pStatsJobs                     *misc.PerfStats
 	pStatsDBR                      *misc.PerfStats
 	statGatewayDBR                 stats.RudderStats
@@ -132,6 +136,7 @@ type DestStatT struct {
 	numEvents              stats.RudderStats
 	numOutputSuccessEvents stats.RudderStats
 	numOutputFailedEvents  stats.RudderStats
+	numOutputDroppedEvents stats.RudderStats
 	transformTime          stats.RudderStats
 }
 
@@ -154,6 +159,7 @@ type ParametersT struct {
 	SourceCategory          string      `json:"source_category"`
 	RecordID                interface{} `json:"record_id"`
 	WorkspaceId             string      `json:"workspaceId"`
+	OrderingKey             string      `json:"ordering_key,omitempty"`
 }
 
 type MetricMetadata struct {
@@ -239,12 +245,14 @@ func (proc *HandleT) newEventFilterStat(sourceID, workspaceID string, destinatio
 	numEvents := proc.stats.NewTaggedStat("proc_event_filter_input_events", stats.CountType, tags)
 	numOutputSuccessEvents := proc.stats.NewTaggedStat("proc_event_filter_output_success_events", stats.CountType, tags)
 	numOutputFailedEvents := proc.stats.NewTaggedStat("proc_event_filter_output_failed_events", stats.CountType, tags)
+	numOutputDroppedEvents := proc.stats.NewTaggedStat("proc_event_filter_output_dropped_events", stats.CountType, tags)
 	eventFilterTime := proc.stats.NewTaggedStat("proc_event_filter_time", stats.TimerType, tags)
 
 	return &DestStatT{
 		numEvents:              numEvents,
 		numOutputSuccessEvents: numOutputSuccessEvents,
 		numOutputFailedEvents:  numOutputFailedEvents,
+		numOutputDroppedEvents: numOutputDroppedEvents,
 		transformTime:          eventFilterTime,
 	}
 }
@@ -263,8 +271,12 @@ func Init() {
 
 // NewProcessor creates a new Processor intanstace
 func NewProcessor() *HandleT {
+	var trans transformer.Transformer = transformer.NewTransformer()
+	if transformer.IsEmbeddedTransformerEnabled() {
+		trans = transformer.NewEmbeddedTransformer(trans)
+	}
 	return &HandleT{
-		transformer: transformer.NewTransformer(),
+		transformer: trans,
 	}
 }
 
@@ -299,6 +311,8 @@ func (proc *HandleT) Setup(backendConfig backendconfig.BackendConfig, gatewayDB
 	proc.readLoopSleep = readLoopSleep
 	proc.maxLoopSleep = maxLoopSleep
 
+	proc.enricher = newEnricher()
+
 	proc.gatewayDB = gatewayDB
 	proc.routerDB = routerDB
 	proc.batchRouterDB = batchRouterDB
@@ -439,6 +453,7 @@ var (
 	writeKeyDestinationMap    map[string][]backendconfig.DestinationT
 	writeKeySourceMap         map[string]backendconfig.SourceT
 	destinationIDtoTypeMap    map[string]string
+	destinationEventRulesMap  map[string][]EventRuleSetT
 	batchDestinations         []string
 	configSubscriberLock      sync.RWMutex
 	customDestinations        []string
@@ -453,6 +468,8 @@ var (
 	pollInterval              time.Duration
 	isUnLocked                bool
 	GWCustomVal               string
+	geoDatabasePath           string
+	lookupTablesDir           string
 )
 
 func loadConfig() {
@@ -482,6 +499,8 @@ func loadConfig() {
 	config.RegisterDurationConfigVariable(time.Duration(5), &pollInterval, false, time.Second, []string{"Processor.pollInterval", "Processor.pollIntervalInS"}...)
 	// GWCustomVal is used as a key in the jobsDB customval column
 	config.RegisterStringConfigVariable("GW", &GWCustomVal, false, "Gateway.CustomVal")
+	config.RegisterStringConfigVariable("", &geoDatabasePath, false, "Processor.Enrichment.geoDatabasePath")
+	config.RegisterStringConfigVariable("", &lookupTablesDir, false, "Processor.Enrichment.lookupTablesDir")
 }
 
 // syncTransformerFeatureJson polls the transformer feature json endpoint,
@@ -539,6 +558,7 @@ func (proc *HandleT) makeFeaturesFetchCall() bool {
 	} else if res.StatusCode == 404 {
 		proc.transformerFeatures = json.RawMessage(defaultTransformerFeatures)
 	}
+	transformer.UpdateFeatures(proc.transformerFeatures)
 
 	return false
 }
@@ -571,6 +591,7 @@ func (proc *HandleT) backendConfigSubscriber() {
 		writeKeyDestinationMap = make(map[string][]backendconfig.DestinationT)
 		writeKeySourceMap = map[string]backendconfig.SourceT{}
 		destinationIDtoTypeMap = make(map[string]string)
+		destinationEventRulesMap = make(map[string][]EventRuleSetT)
 		sources := config.Data.(backendconfig.ConfigT)
 		for _, source := range sources.Sources {
 			writeKeySourceMap[source.WriteKey] = source
@@ -578,6 +599,14 @@ func (proc *HandleT) backendConfigSubscriber() {
 				writeKeyDestinationMap[source.WriteKey] = source.Destinations
 				for _, destination := range source.Destinations {
 					destinationIDtoTypeMap[destination.ID] = destination.DestinationDefinition.Name
+					if _, ok := destinationEventRulesMap[destination.ID]; ok {
+						continue
+					}
+					ruleSets, err := parseEventRules(&destination)
+					if err != nil {
+						pkgLogger.Errorf("Ignoring event rules of destination %s: %v", destination.ID, err)
+					}
+					destinationEventRulesMap[destination.ID] = ruleSets
 				}
 			}
 		}
@@ -712,6 +741,23 @@ func enhanceWithMetadata(commonMetadata *transformer.MetadataT, event *transform
 	event.Metadata = metadata
 }
 
+//orderingKeyValue returns the value of the ordering key of the destination in the event, if the destination orders events by key
+func orderingKeyValue(orderingKeyPaths map[string]string, singularEvent types.SingularEventT, destination *backendconfig.DestinationT) string {
+	path, ok := orderingKeyPaths[destination.ID]
+	if !ok {
+		path = router_utils.OrderingKeyPath(destination.DestinationDefinition.Name, destination.ID)
+		orderingKeyPaths[destination.ID] = path
+	}
+	if path == "" {
+		return ""
+	}
+	eventBytes, err := jsonfast.Marshal(singularEvent)
+	if err != nil {
+		return ""
+	}
+	return gjson.GetBytes(eventBytes, path).String()
+}
+
 func getKeyFromSourceAndDest(srcID string, destID string) string {
 	return srcID + "::" + destID
 }
@@ -721,6 +767,49 @@ func getSourceAndDestIDsFromKey(key string) (sourceID string, destID string) {
 	return fields[0], fields[1]
 }
 
+//recordUserTransformedTailEvents streams the events returned by user transformation of a destination to live tail subscribers, along with the failed ones
+func recordUserTransformedTailEvents(sourceID, destinationID string, transformedEvents []transformer.TransformerEventT, failedEvents []transformer.TransformerResponseT) {
+	if !taildebugger.HasSubscribers() {
+		return
+	}
+	var events []*taildebugger.EventT
+	for i := range transformedEvents {
+		payload, err := json.Marshal(transformedEvents[i].Message)
+		if err != nil {
+			continue
+		}
+		events = append(events, &taildebugger.EventT{
+			Stage:         taildebugger.UserTransformationStage,
+			SourceID:      sourceID,
+			DestinationID: destinationID,
+			EventName:     misc.GetStringifiedData(transformedEvents[i].Message["event"]),
+			EventType:     misc.GetStringifiedData(transformedEvents[i].Message["type"]),
+			UserID:        taildebugger.GetUserID(payload),
+			Payload:       payload,
+			StatusCode:    "200",
+		})
+	}
+	for i := range failedEvents {
+		payload, err := json.Marshal(failedEvents[i].Output)
+		if err != nil {
+			continue
+		}
+		response, _ := json.Marshal(map[string]string{"error": failedEvents[i].Error})
+		events = append(events, &taildebugger.EventT{
+			Stage:         taildebugger.UserTransformationStage,
+			SourceID:      sourceID,
+			DestinationID: destinationID,
+			EventName:     misc.GetStringifiedData(failedEvents[i].Output["event"]),
+			EventType:     misc.GetStringifiedData(failedEvents[i].Output["type"]),
+			UserID:        taildebugger.GetUserID(payload),
+			Payload:       payload,
+			StatusCode:    strconv.Itoa(failedEvents[i].StatusCode),
+			Response:      response,
+		})
+	}
+	taildebugger.Record(events...)
+}
+
 func recordEventDeliveryStatus(jobsByDestID map[string][]*jobsdb.JobT) {
 	for destID, jobs := range jobsByDestID {
 		if !destinationdebugger.HasUploadEnabled(destID) {
@@ -1174,6 +1263,7 @@ func (proc *HandleT) processJobsForDest(jobList []*jobsdb.JobT, parsedEventList
 				shallowEventCopy := transformer.TransformerEventT{}
 				shallowEventCopy.Message = singularEvent
 				shallowEventCopy.Message["request_ip"] = requestIP
+				proc.enricher.enrich(&sourceForSingularEvent, singularEvent, requestIP)
 				enhanceWithTimeFields(&shallowEventCopy, singularEvent, receivedAt)
 				enhanceWithMetadata(commonMetadataFromSingularEvent, &shallowEventCopy, backendconfig.DestinationT{})
 
@@ -1270,6 +1360,9 @@ func (proc *HandleT) processJobsForDest(jobList []*jobsdb.JobT, parsedEventList
 	//TRACKING PLAN - END
 
 	// The below part further segregates events by sourceID and DestinationID.
+	consentDrops := newConsentDrops()
+	//paths of ordering keys of destinations ordering events by key, looked up once per destination
+	orderingKeyPaths := map[string]string{}
 	for writeKeyT, eventList := range validatedEventsByWriteKey {
 		for _, event := range eventList {
 			writeKey := string(writeKeyT)
@@ -1295,6 +1388,19 @@ func (proc *HandleT) processJobsForDest(jobList []*jobsdb.JobT, parsedEventList
 					// At the TP flow we are not having destination information, so adding it here.
 					shallowEventCopy.Metadata.DestinationID = destination.ID
 					shallowEventCopy.Metadata.DestinationType = destination.DestinationDefinition.Name
+					shallowEventCopy.Metadata.DestinationDefinitionID = destination.DestinationDefinition.ID
+					//ordering key is picked from the event before transformation, for router to order the transformed events by it
+					shallowEventCopy.Metadata.OrderingKey = orderingKeyValue(orderingKeyPaths, singularEvent, &destination)
+
+					//Sending events only to the destinations the user consented to
+					if reason := consentDropReason(singularEvent, &destination); reason != "" {
+						inPU := types.GATEWAY
+						if trackingPlanEnabledMap[SourceIDT(shallowEventCopy.Metadata.SourceID)] {
+							inPU = types.TRACKINGPLAN_VALIDATOR
+						}
+						proc.recordConsentDrop(consentDrops, &shallowEventCopy.Metadata, reason, inPU)
+						continue
+					}
 
 					//TODO: Test for multiple workspaces ex: hosted data plane
 					/* Stream destinations does not need config in transformer. As the Kafka destination config
@@ -1324,6 +1430,8 @@ func (proc *HandleT) processJobsForDest(jobList []*jobsdb.JobT, parsedEventList
 		}
 	}
 
+	reportMetrics = append(reportMetrics, consentDrops.reportMetrics()...)
+
 	if len(statusList) != len(jobList) {
 		panic(fmt.Errorf("len(statusList):%d != len(jobList):%d", len(statusList), len(jobList)))
 	}
@@ -1543,6 +1651,22 @@ type transformSrcDestOutput struct {
 	errorsPerDestID map[string][]*jobsdb.JobT
 }
 
+//destinationTransform transforms the events natively if the destination type has a native transformation,
+//and with the remote transformer otherwise, or for events the native transformation doesn't support
+func (proc *HandleT) destinationTransform(ctx context.Context, destType string, events []transformer.TransformerEventT, url string, batchSize int) transformer.ResponseT {
+	transform, ok := native.Get(destType)
+	if !ok {
+		return proc.transformer.Transform(ctx, events, url, batchSize)
+	}
+	response, unsupported := native.Transform(transform, events)
+	if len(unsupported) > 0 {
+		remoteResponse := proc.transformer.Transform(ctx, unsupported, url, batchSize)
+		response.Events = append(response.Events, remoteResponse.Events...)
+		response.FailedEvents = append(response.FailedEvents, remoteResponse.FailedEvents...)
+	}
+	return response
+}
+
 func (proc *HandleT) transformSrcDest(
 	ctx context.Context,
 	// main inputs
@@ -1640,6 +1764,7 @@ func (proc *HandleT) transformSrcDest(
 			trace.Logf(ctx, "UserTransform", "User Transform output size: %d", len(eventsToTransform))
 
 			transformationdebugger.UploadTransformationStatus(&transformationdebugger.TransformationStatusT{SourceID: sourceID, DestID: destID, Destination: &destination, UserTransformedEvents: eventsToTransform, EventsByMessageID: eventsByMessageID, FailedEvents: response.FailedEvents, UniqueMessageIds: uniqueMessageIdsBySrcDestKey[srcAndDestKey]})
+			recordUserTransformedTailEvents(sourceID, destID, eventsToTransform, response.FailedEvents)
 
 			//REPORTING - START
 			if proc.isReportingEnabled() {
@@ -1679,10 +1804,13 @@ func (proc *HandleT) transformSrcDest(
 	}
 	transformAtFromFeaturesFile := gjson.Get(string(proc.transformerFeatures), fmt.Sprintf("routerTransform.%s", destination.DestinationDefinition.Name)).String()
 
-	//Filtering events based on the supported message types - START
+	//Filtering events based on the supported message types & the event rules of the connection - START
 	s := time.Now()
 	proc.logger.Debug("Supported messages filtering input size", len(eventsToTransform))
+	numFilterInputEvents := len(eventsToTransform)
 	response = ConvertToFilteredTransformerResponse(eventsToTransform, transformAt != "none")
+	var numDroppedEvents int
+	response.Events, numDroppedEvents = applyEventRules(getEventRules(sourceID, destID), response.Events)
 	var successMetrics []*types.PUReportedMetric
 	var successCountMap map[string]int64
 	var successCountMetadataMap map[string]MetricMetadata
@@ -1715,9 +1843,10 @@ func (proc *HandleT) transformSrcDest(
 	}
 	//REPORTING - END
 	eventFilterStat := proc.newEventFilterStat(sourceID, workspaceID, destination)
-	eventFilterStat.numEvents.Count(len(eventsToTransform))
+	eventFilterStat.numEvents.Count(numFilterInputEvents)
 	eventFilterStat.numOutputSuccessEvents.Count(len(response.Events))
 	eventFilterStat.numOutputFailedEvents.Count(len(failedJobs))
+	eventFilterStat.numOutputDroppedEvents.Count(numDroppedEvents)
 	eventFilterStat.transformTime.Since(s)
 
 	//Filtering events based on the supported message types - END
@@ -1741,7 +1870,7 @@ func (proc *HandleT) transformSrcDest(
 			trace.Logf(ctx, "Dest Transform", "input size %d", len(eventsToTransform))
 			proc.logger.Debug("Dest Transform input size", len(eventsToTransform))
 			s := time.Now()
-			response = proc.transformer.Transform(ctx, eventsToTransform, url, transformBatchSize)
+			response = proc.destinationTransform(ctx, destType, eventsToTransform, url, transformBatchSize)
 
 			destTransformationStat := proc.newDestinationTransformationStat(sourceID, workspaceID, transformAt, destination)
 			destTransformationStat.transformTime.Since(s)
@@ -1831,6 +1960,7 @@ func (proc *HandleT) transformSrcDest(
 			destDefID := metadata.DestinationDefinitionID
 			sourceCategory := metadata.SourceCategory
 			workspaceId := metadata.WorkspaceID
+			orderingKey := metadata.OrderingKey
 			//If the response from the transformer does not have userID in metadata, setting userID to random-uuid.
 			//This is done to respect findWorker logic in router.
 			if rudderID == "" {
@@ -1856,6 +1986,7 @@ func (proc *HandleT) transformSrcDest(
 				DestinationDefinitionID: destDefID,
 				RecordID:                recordId,
 				WorkspaceId:             workspaceId,
+				OrderingKey:             orderingKey,
 			}
 			marshalledParams, err := jsonfast.Marshal(params)
 			if err != nil {
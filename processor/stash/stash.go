@@ -14,6 +14,7 @@ import (
 	uuid "github.com/gofrs/uuid"
 	"github.com/rudderlabs/rudder-server/config"
 	"github.com/rudderlabs/rudder-server/jobsdb"
+	"github.com/rudderlabs/rudder-server/services/dlq"
 	"github.com/rudderlabs/rudder-server/services/filemanager"
 	"github.com/rudderlabs/rudder-server/services/stats"
 	"github.com/rudderlabs/rudder-server/utils/logger"
@@ -243,6 +244,20 @@ func (st *HandleT) readErrJobsLoop(ctx context.Context) {
 				continue
 			}
 
+			//aborted router & batch router jobs are held in dead letter queue in waiting state, till they are redriven or discarded
+			var heldList []*jobsdb.JobT
+			if dlq.IsEnabled() {
+				var stashList []*jobsdb.JobT
+				for _, job := range combinedList {
+					if dlq.IsHeld(job) {
+						heldList = append(heldList, job)
+					} else {
+						stashList = append(stashList, job)
+					}
+				}
+				combinedList = stashList
+			}
+
 			hasFileUploader := st.errFileUploader != nil
 
 			jobState := jobsdb.Executing.State
@@ -265,6 +280,19 @@ func (st *HandleT) readErrJobsLoop(ctx context.Context) {
 				}
 				statusList = append(statusList, &status)
 			}
+			for _, job := range heldList {
+				status := jobsdb.JobStatusT{
+					JobID:         job.JobID,
+					AttemptNum:    job.LastJobStatus.AttemptNum + 1,
+					JobState:      jobsdb.Waiting.State,
+					ExecTime:      time.Now(),
+					RetryTime:     time.Now(),
+					ErrorCode:     "",
+					ErrorResponse: []byte(`{}`),
+					Parameters:    []byte(`{}`),
+				}
+				statusList = append(statusList, &status)
+			}
 
 			err := st.errorDB.UpdateJobStatus(statusList, nil, nil)
 			if err != nil {
@@ -272,7 +300,7 @@ func (st *HandleT) readErrJobsLoop(ctx context.Context) {
 				panic(err)
 			}
 
-			if hasFileUploader {
+			if hasFileUploader && len(combinedList) > 0 {
 				st.errProcessQ <- combinedList
 			}
 		}
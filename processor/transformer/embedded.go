@@ -0,0 +1,383 @@
+package transformer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"runtime/metrics"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+
+	"github.com/rudderlabs/rudder-server/config"
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+	"github.com/rudderlabs/rudder-server/processor/integrations"
+	"github.com/rudderlabs/rudder-server/services/stats"
+	"github.com/rudderlabs/rudder-server/utils/logger"
+)
+
+var (
+	embeddedTransformerEnabled                          bool
+	embeddedTimeout                                     time.Duration
+	embeddedMaxHeapGrowthInMB, embeddedMaxCallStackSize int
+	embeddedMaxConcurrency                              int
+	errEmbeddedTimeout                                  = errors.New("embedded transformation timed out")
+	errEmbeddedMemoryLimit                              = errors.New("embedded transformation exceeded memory limit")
+	heapObjectsMetric                                   = "/memory/classes/heap/objects:bytes"
+	exportRegex                                         = regexp.MustCompile(`(?m)^(\s*)export\s+(default\s+)?`)
+	unsupportedCodeRegex                                = regexp.MustCompile(`(?m)(^\s*import\s)|\bfetch(V2)?\s*\(|\bgeolocation\s*\(|\basync\s|\bawait\s`)
+	embeddedMemoryWatchInterval                         = 10 * time.Millisecond
+)
+
+func loadEmbeddedConfig() {
+	config.RegisterBoolConfigVariable(false, &embeddedTransformerEnabled, false, "Processor.EmbeddedTransformer.enabled")
+	config.RegisterDurationConfigVariable(4, &embeddedTimeout, true, time.Second, "Processor.EmbeddedTransformer.timeout")
+	config.RegisterIntConfigVariable(64, &embeddedMaxHeapGrowthInMB, true, 1, "Processor.EmbeddedTransformer.maxHeapGrowthInMB")
+	config.RegisterIntConfigVariable(1000, &embeddedMaxCallStackSize, true, 1, "Processor.EmbeddedTransformer.maxCallStackSize")
+	config.RegisterIntConfigVariable(8, &embeddedMaxConcurrency, false, 1, "Processor.EmbeddedTransformer.maxConcurrency")
+}
+
+//IsEmbeddedTransformerEnabled returns true if user transformations should run in-process
+func IsEmbeddedTransformerEnabled() bool {
+	return embeddedTransformerEnabled
+}
+
+//UserTransformationCodeT is the code of a user transformation version, as served by the config backend
+type UserTransformationCodeT struct {
+	Code        string `json:"code"`
+	CodeVersion string `json:"codeVersion"`
+	Name        string `json:"name"`
+}
+
+//embeddedProgramT is a compiled user transformation, or the reason it can't run in-process
+type embeddedProgramT struct {
+	program     *goja.Program
+	unsupported string
+}
+
+/*
+EmbeddedHandleT runs user transformations in-process, in a sandboxed javascript runtime.
+Transformations of code version 1 defining transformEvent(event, metadata) run in-process,
+with a timeout per event, a call stack limit & a limit on heap growth while they run.
+Runtimes share the heap of the process, so the heap growth limit is a safeguard against runaway transformations
+rather than a memory cap per transformation: growth is measured for the whole process & isn't isolated per runtime.
+Transformations using libraries, network calls or async code, transformations exceeding the limits,
+& other stages of transformation are delegated to the remote transformer.
+*/
+type EmbeddedHandleT struct {
+	remote Transformer
+	logger logger.LoggerI
+
+	//Client & ConfigBackendURL fetch the code of user transformations
+	Client           *http.Client
+	ConfigBackendURL string
+
+	programsMu       sync.RWMutex
+	programs         map[string]*embeddedProgramT // transformation versionID -> program
+	guardConcurrency chan struct{}
+
+	embeddedStat stats.RudderStats
+	fallbackStat stats.RudderStats
+}
+
+//NewEmbeddedTransformer creates a transformer running user transformations in-process, falling back to remote
+func NewEmbeddedTransformer(remote Transformer) *EmbeddedHandleT {
+	return &EmbeddedHandleT{remote: remote}
+}
+
+//Setup initializes this class
+func (trans *EmbeddedHandleT) Setup() {
+	trans.remote.Setup()
+	trans.logger = pkgLogger.Child("embedded")
+	trans.programs = make(map[string]*embeddedProgramT)
+	trans.guardConcurrency = make(chan struct{}, embeddedMaxConcurrency)
+	trans.embeddedStat = stats.NewTaggedStat("processor.embedded_transformer_events", stats.CountType, stats.Tags{"runtime": "embedded"})
+	trans.fallbackStat = stats.NewTaggedStat("processor.embedded_transformer_events", stats.CountType, stats.Tags{"runtime": "remote"})
+	if trans.Client == nil {
+		trans.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if trans.ConfigBackendURL == "" {
+		trans.ConfigBackendURL = backendconfig.GetConfigBackendURL()
+	}
+}
+
+func (trans *EmbeddedHandleT) Validate(clientEvents []TransformerEventT, url string, batchSize int) ResponseT {
+	return trans.remote.Validate(clientEvents, url, batchSize)
+}
+
+//Transform runs user transformations in-process where possible & the rest of the events through the remote transformer
+func (trans *EmbeddedHandleT) Transform(ctx context.Context, clientEvents []TransformerEventT, url string, batchSize int) ResponseT {
+	if url != integrations.GetUserTransformURL() || len(clientEvents) == 0 {
+		return trans.remote.Transform(ctx, clientEvents, url, batchSize)
+	}
+
+	var remoteEvents []TransformerEventT
+	var versionIDs []string
+	eventsByVersionID := make(map[string][]TransformerEventT)
+	for _, event := range clientEvents {
+		if len(event.Destination.Transformations) == 0 || len(event.Libraries) > 0 {
+			remoteEvents = append(remoteEvents, event)
+			continue
+		}
+		versionID := event.Destination.Transformations[0].VersionID
+		if _, ok := eventsByVersionID[versionID]; !ok {
+			versionIDs = append(versionIDs, versionID)
+		}
+		eventsByVersionID[versionID] = append(eventsByVersionID[versionID], event)
+	}
+
+	var response ResponseT
+	for _, versionID := range versionIDs {
+		events := eventsByVersionID[versionID]
+		program := trans.getProgram(versionID)
+		if program.unsupported != "" {
+			trans.logger.Debugf("[Embedded Transformer] Running transformation %s remotely: %s", versionID, program.unsupported)
+			remoteEvents = append(remoteEvents, events...)
+			continue
+		}
+		transformed, err := trans.run(program.program, events)
+		if err != nil {
+			trans.logger.Errorf("[Embedded Transformer] Running transformation %s remotely: %v", versionID, err)
+			remoteEvents = append(remoteEvents, events...)
+			continue
+		}
+		trans.embeddedStat.Count(len(events))
+		response.Events = append(response.Events, transformed.Events...)
+		response.FailedEvents = append(response.FailedEvents, transformed.FailedEvents...)
+	}
+
+	if len(remoteEvents) > 0 {
+		trans.fallbackStat.Count(len(remoteEvents))
+		remoteResponse := trans.remote.Transform(ctx, remoteEvents, url, batchSize)
+		response.Events = append(response.Events, remoteResponse.Events...)
+		response.FailedEvents = append(response.FailedEvents, remoteResponse.FailedEvents...)
+	}
+	return response
+}
+
+//getProgram compiles the code of the transformation version once, versions are immutable
+func (trans *EmbeddedHandleT) getProgram(versionID string) *embeddedProgramT {
+	trans.programsMu.RLock()
+	program, ok := trans.programs[versionID]
+	trans.programsMu.RUnlock()
+	if ok {
+		return program
+	}
+
+	code, err := trans.fetchCode(versionID)
+	if err != nil {
+		//not cached, so that the code is fetched again for the next batch
+		return &embeddedProgramT{unsupported: fmt.Sprintf("fetching code failed: %v", err)}
+	}
+	program = compile(code)
+
+	trans.programsMu.Lock()
+	trans.programs[versionID] = program
+	trans.programsMu.Unlock()
+	return program
+}
+
+func (trans *EmbeddedHandleT) fetchCode(versionID string) (*UserTransformationCodeT, error) {
+	codeURL := fmt.Sprintf("%s/transformation/getByVersionId?versionId=%s", trans.ConfigBackendURL, url.QueryEscape(versionID))
+	resp, err := trans.Client.Get(codeURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("config backend returned %d: %s", resp.StatusCode, string(body))
+	}
+	var code UserTransformationCodeT
+	if err = jsonfast.Unmarshal(body, &code); err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func compile(code *UserTransformationCodeT) *embeddedProgramT {
+	if code.CodeVersion != "1" {
+		return &embeddedProgramT{unsupported: fmt.Sprintf("code version %q", code.CodeVersion)}
+	}
+	if unsupportedCodeRegex.MatchString(code.Code) {
+		return &embeddedProgramT{unsupported: "code uses imports, network calls or async functions"}
+	}
+	program, err := goja.Compile(code.Name, exportRegex.ReplaceAllString(code.Code, "$1"), true)
+	if err != nil {
+		return &embeddedProgramT{unsupported: fmt.Sprintf("compiling code failed: %v", err)}
+	}
+	return &embeddedProgramT{program: program}
+}
+
+//run transforms the events with the program in a new runtime.
+//It returns an error if the transformation can't run in-process, user code errors fail the events like the remote transformer.
+func (trans *EmbeddedHandleT) run(program *goja.Program, events []TransformerEventT) (response ResponseT, err error) {
+	trans.guardConcurrency <- struct{}{}
+	defer func() { <-trans.guardConcurrency }()
+
+	vm := goja.New()
+	vm.SetMaxCallStackSize(embeddedMaxCallStackSize)
+	withTimeout, stopLimits := limit(vm)
+	defer stopLimits()
+
+	console := vm.NewObject()
+	consoleLog := func(call goja.FunctionCall) goja.Value {
+		trans.logger.Debug(call.Arguments)
+		return goja.Undefined()
+	}
+	for _, level := range []string{"log", "info", "warn", "error", "debug"} {
+		_ = console.Set(level, consoleLog)
+	}
+	_ = vm.Set("console", console)
+
+	if err = withTimeout(func() error {
+		_, err := vm.RunProgram(program)
+		return err
+	}); err != nil {
+		return ResponseT{}, limitOrCodeError(err)
+	}
+	transformEvent, ok := goja.AssertFunction(vm.Get("transformEvent"))
+	if !ok {
+		return ResponseT{}, fmt.Errorf("code doesn't define transformEvent(event, metadata)")
+	}
+	parseJSON, _ := goja.AssertFunction(vm.Get("JSON").ToObject(vm).Get("parse"))
+	stringifyJSON, _ := goja.AssertFunction(vm.Get("JSON").ToObject(vm).Get("stringify"))
+
+	for i := range events {
+		event := &events[i]
+		var outputs []map[string]interface{}
+		eventErr := withTimeout(func() (err error) {
+			outputs, err = transformEmbeddedEvent(vm, transformEvent, parseJSON, stringifyJSON, event)
+			return err
+		})
+		if eventErr != nil {
+			var interrupted *goja.InterruptedError
+			if errors.As(eventErr, &interrupted) {
+				return ResponseT{}, limitOrCodeError(eventErr)
+			}
+			response.FailedEvents = append(response.FailedEvents, TransformerResponseT{StatusCode: http.StatusBadRequest, Error: eventErr.Error(), Metadata: event.Metadata})
+			continue
+		}
+		for _, output := range outputs {
+			response.Events = append(response.Events, TransformerResponseT{Output: output, Metadata: event.Metadata, StatusCode: http.StatusOK})
+		}
+	}
+	return response, nil
+}
+
+//transformEmbeddedEvent passes a copy of the event to the transformation, so that it can't modify the event of the caller
+func transformEmbeddedEvent(vm *goja.Runtime, transformEvent, parseJSON, stringifyJSON goja.Callable, event *TransformerEventT) ([]map[string]interface{}, error) {
+	messageJSON, err := jsonfast.Marshal(event.Message)
+	if err != nil {
+		return nil, err
+	}
+	message, err := parseJSON(goja.Undefined(), vm.ToValue(string(messageJSON)))
+	if err != nil {
+		return nil, err
+	}
+	metadata := event.Metadata
+	metadataFn := func(goja.FunctionCall) goja.Value {
+		metadataJSON, _ := jsonfast.Marshal(metadata)
+		value, _ := parseJSON(goja.Undefined(), vm.ToValue(string(metadataJSON)))
+		return value
+	}
+
+	result, err := transformEvent(goja.Undefined(), message, vm.ToValue(metadataFn))
+	if err != nil {
+		return nil, err
+	}
+	if goja.IsUndefined(result) || goja.IsNull(result) {
+		//event is filtered out
+		return nil, nil
+	}
+	resultJSON, err := stringifyJSON(goja.Undefined(), result)
+	if err != nil {
+		return nil, err
+	}
+
+	var outputs []map[string]interface{}
+	var output map[string]interface{}
+	if err = jsonfast.Unmarshal([]byte(resultJSON.String()), &output); err == nil {
+		return append(outputs, output), nil
+	}
+	var outputArray []interface{}
+	if err = jsonfast.Unmarshal([]byte(resultJSON.String()), &outputArray); err != nil {
+		return nil, fmt.Errorf("returned event from transformEvent(event) is not an object")
+	}
+	for _, item := range outputArray {
+		output, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("returned event in events array from transformEvent(event) is not an object")
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+//limit interrupts the runtime once the heap of the process grows past the limit while it runs.
+//Heap growth is that of the whole process, so concurrent work can interrupt the transformation too,
+//in which case it falls back to the remote transformer.
+//It returns a func running a call of the runtime with the timeout, so that each event gets the whole timeout,
+//& a func to stop watching the heap.
+func limit(vm *goja.Runtime) (withTimeout func(call func() error) error, stop func()) {
+	done := make(chan struct{})
+	maxHeapGrowth := uint64(embeddedMaxHeapGrowthInMB) * 1024 * 1024
+	startHeap := heapObjectsBytes()
+	go func() {
+		ticker := time.NewTicker(embeddedMemoryWatchInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if heap := heapObjectsBytes(); heap > startHeap && heap-startHeap > maxHeapGrowth {
+					vm.Interrupt(errEmbeddedMemoryLimit)
+				}
+			}
+		}
+	}()
+	withTimeout = func(call func() error) error {
+		timer := time.AfterFunc(embeddedTimeout, func() {
+			vm.Interrupt(errEmbeddedTimeout)
+		})
+		err := call()
+		var interrupted *goja.InterruptedError
+		if !timer.Stop() && !errors.As(err, &interrupted) {
+			//timeout fired once the call was over, it mustn't interrupt the next one
+			vm.ClearInterrupt()
+		}
+		return err
+	}
+	return withTimeout, func() {
+		close(done)
+	}
+}
+
+func heapObjectsBytes() uint64 {
+	sample := []metrics.Sample{{Name: heapObjectsMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+//limitOrCodeError returns the limit the runtime was interrupted for, or the error of the code
+func limitOrCodeError(err error) error {
+	var interrupted *goja.InterruptedError
+	if errors.As(err, &interrupted) {
+		if limitErr, ok := interrupted.Value().(error); ok {
+			return limitErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,165 @@
+package transformer_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-server/config"
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+	"github.com/rudderlabs/rudder-server/processor/integrations"
+	"github.com/rudderlabs/rudder-server/processor/transformer"
+	"github.com/rudderlabs/rudder-server/services/stats"
+	"github.com/rudderlabs/rudder-server/utils/logger"
+)
+
+//remoteTransformer records the events sent to the remote transformer & echoes them back
+type remoteTransformer struct {
+	mu     sync.Mutex
+	events []transformer.TransformerEventT
+}
+
+func (t *remoteTransformer) Setup() {}
+
+func (t *remoteTransformer) Transform(_ context.Context, clientEvents []transformer.TransformerEventT, _ string, _ int) transformer.ResponseT {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var response transformer.ResponseT
+	for _, event := range clientEvents {
+		t.events = append(t.events, event)
+		response.Events = append(response.Events, transformer.TransformerResponseT{Output: event.Message, Metadata: event.Metadata, StatusCode: 200})
+	}
+	return response
+}
+
+func (t *remoteTransformer) Validate(clientEvents []transformer.TransformerEventT, url string, batchSize int) transformer.ResponseT {
+	return t.Transform(context.TODO(), clientEvents, url, batchSize)
+}
+
+var transformationCodes = map[string]transformer.UserTransformationCodeT{
+	"embedded": {CodeVersion: "1", Code: `
+export function transformEvent(event, metadata) {
+	if (event.event === "drop") {
+		return null;
+	}
+	if (event.event === "fail") {
+		throw new Error("failing " + metadata(event).messageId);
+	}
+	if (event.event === "split") {
+		return [event, {type: "identify", userId: event.userId}];
+	}
+	event.properties.transformed = true;
+	return event;
+}`},
+	"timeout": {CodeVersion: "1", Code: `export function transformEvent(event) { while (true) {} }`},
+	"slow":    {CodeVersion: "1", Code: `export function transformEvent(event) { const start = Date.now(); while (Date.now() - start < 120) {} return event; }`},
+	"fetch":   {CodeVersion: "1", Code: `export async function transformEvent(event) { event.res = await fetch("https://example.com"); return event; }`},
+	"v0":      {CodeVersion: "0", Code: `function transform(events) { return events; }`},
+}
+
+func Test_EmbeddedTransformer(t *testing.T) {
+	os.Setenv("RSERVER_PROCESSOR_EMBEDDED_TRANSFORMER_TIMEOUT", "200ms")
+	defer os.Unsetenv("RSERVER_PROCESSOR_EMBEDDED_TRANSFORMER_TIMEOUT")
+	config.Load()
+	logger.Init()
+	stats.Setup()
+	transformer.Init()
+
+	codeRequests := make(map[string]int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		versionID := r.URL.Query().Get("versionId")
+		codeRequests[versionID]++
+		code, ok := transformationCodes[versionID]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(code))
+	}))
+	defer srv.Close()
+
+	newEvent := func(versionID, messageID, eventName string) transformer.TransformerEventT {
+		return transformer.TransformerEventT{
+			Message:     map[string]interface{}{"event": eventName, "userId": "u1", "properties": map[string]interface{}{}},
+			Metadata:    transformer.MetadataT{MessageID: messageID},
+			Destination: backendconfig.DestinationT{Transformations: []backendconfig.TransformationT{{VersionID: versionID}}},
+		}
+	}
+	newTransformer := func() (*transformer.EmbeddedHandleT, *remoteTransformer) {
+		remote := &remoteTransformer{}
+		tr := transformer.NewEmbeddedTransformer(remote)
+		tr.Client = srv.Client()
+		tr.ConfigBackendURL = srv.URL
+		tr.Setup()
+		return tr, remote
+	}
+
+	t.Run("runs transformations in-process", func(t *testing.T) {
+		tr, remote := newTransformer()
+		events := []transformer.TransformerEventT{
+			newEvent("embedded", "m1", "track"),
+			newEvent("embedded", "m2", "drop"),
+			newEvent("embedded", "m3", "fail"),
+			newEvent("embedded", "m4", "split"),
+		}
+		response := tr.Transform(context.TODO(), events, integrations.GetUserTransformURL(), 200)
+
+		require.Empty(t, remote.events)
+		require.Len(t, response.Events, 3)
+		require.Equal(t, "m1", response.Events[0].Metadata.MessageID)
+		require.Equal(t, map[string]interface{}{"transformed": true}, response.Events[0].Output["properties"])
+		require.Equal(t, "m4", response.Events[1].Metadata.MessageID)
+		require.Equal(t, "identify", response.Events[2].Output["type"])
+		require.Len(t, response.FailedEvents, 1)
+		require.Equal(t, 400, response.FailedEvents[0].StatusCode)
+		require.Contains(t, response.FailedEvents[0].Error, "failing m3")
+		require.Equal(t, map[string]interface{}{}, events[0].Message["properties"], "events of the caller shouldn't be modified")
+
+		tr.Transform(context.TODO(), events[:1], integrations.GetUserTransformURL(), 200)
+		require.Equal(t, 1, codeRequests["embedded"], "code should be fetched once per version")
+	})
+
+	t.Run("times out each event rather than the whole batch", func(t *testing.T) {
+		tr, remote := newTransformer()
+		events := []transformer.TransformerEventT{
+			newEvent("slow", "m1", "track"),
+			newEvent("slow", "m2", "track"),
+			newEvent("slow", "m3", "track"),
+		}
+		response := tr.Transform(context.TODO(), events, integrations.GetUserTransformURL(), 200)
+
+		require.Empty(t, remote.events)
+		require.Len(t, response.Events, len(events))
+	})
+
+	t.Run("falls back to the remote transformer for what it can't run", func(t *testing.T) {
+		tr, remote := newTransformer()
+		withLibraries := newEvent("embedded", "m5", "track")
+		withLibraries.Libraries = []backendconfig.LibraryT{{VersionID: "library"}}
+		events := []transformer.TransformerEventT{
+			newEvent("timeout", "m1", "track"),
+			newEvent("fetch", "m2", "track"),
+			newEvent("v0", "m3", "track"),
+			newEvent("missing", "m4", "track"),
+			withLibraries,
+		}
+		response := tr.Transform(context.TODO(), events, integrations.GetUserTransformURL(), 200)
+
+		require.Len(t, remote.events, len(events))
+		require.Len(t, response.Events, len(events))
+		require.Empty(t, response.FailedEvents)
+	})
+
+	t.Run("delegates other stages to the remote transformer", func(t *testing.T) {
+		tr, remote := newTransformer()
+		events := []transformer.TransformerEventT{newEvent("embedded", "m1", "track")}
+		tr.Transform(context.TODO(), events, integrations.GetDestinationURL("GA"), 200)
+		require.Len(t, remote.events, 1)
+	})
+}
@@ -0,0 +1,284 @@
+package transformer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rudderlabs/rudder-server/config"
+	"github.com/rudderlabs/rudder-server/processor/integrations"
+	"github.com/rudderlabs/rudder-server/rruntime"
+	"github.com/rudderlabs/rudder-server/services/stats"
+)
+
+var (
+	hedgeAfter                     time.Duration
+	healthCheckInterval            time.Duration
+	circuitBreakerEnabled          bool
+	circuitBreakerFailureThreshold int
+	circuitBreakerOpenDuration     time.Duration
+
+	//errNoTransformerAvailable is returned when the circuit breakers of all the transformers are open
+	errNoTransformerAvailable = errors.New("circuit breakers of all transformers are open")
+)
+
+func loadEndpointsConfig() {
+	config.RegisterDurationConfigVariable(time.Duration(0), &hedgeAfter, true, time.Millisecond, "Processor.Transformer.hedgeAfter")
+	config.RegisterDurationConfigVariable(time.Duration(10), &healthCheckInterval, false, time.Second, "Processor.Transformer.healthCheckInterval")
+	config.RegisterBoolConfigVariable(false, &circuitBreakerEnabled, true, "Processor.Transformer.CircuitBreaker.enabled")
+	config.RegisterIntConfigVariable(5, &circuitBreakerFailureThreshold, true, 1, "Processor.Transformer.CircuitBreaker.failureThreshold")
+	config.RegisterDurationConfigVariable(time.Duration(30), &circuitBreakerOpenDuration, true, time.Second, "Processor.Transformer.CircuitBreaker.openDuration")
+}
+
+//endpointT is a transformer which requests are balanced to, along with its health & circuit breaker
+type endpointT struct {
+	url string
+
+	mu       sync.Mutex
+	inFlight int
+	healthy  bool
+	//failures counts the consecutive failed requests, which open the circuit breaker once they reach the threshold
+	failures  int
+	openUntil time.Time
+	//probing is set while the request probing a transformer with an open circuit breaker is in flight
+	probing bool
+}
+
+//endpointsT balances the requests to the transformer across transformers
+type endpointsT struct {
+	baseURL   string
+	endpoints []*endpointT
+	next      int
+	mu        sync.Mutex
+}
+
+func newEndpoints(baseURL string, urls []string) *endpointsT {
+	pool := &endpointsT{baseURL: baseURL}
+	for _, url := range urls {
+		pool.endpoints = append(pool.endpoints, &endpointT{url: strings.TrimSuffix(url, "/"), healthy: true})
+	}
+	return pool
+}
+
+//pathOf returns the path of the url on the transformer, if it is a url of the transformer
+func (pool *endpointsT) pathOf(url string) (string, bool) {
+	if pool.baseURL == "" || !strings.HasPrefix(url, pool.baseURL) {
+		return "", false
+	}
+	return strings.TrimPrefix(url, pool.baseURL), true
+}
+
+/*
+acquire returns the transformer with the least requests in flight, except for the excluded one.
+Unhealthy transformers are used only if all of them are unhealthy, since health checks can be wrong.
+Transformers with open circuit breakers are never used, hence nil is returned if the breakers of all of them are open.
+*/
+func (pool *endpointsT) acquire(exclude *endpointT) *endpointT {
+	pool.mu.Lock()
+	start := pool.next
+	pool.next++
+	pool.mu.Unlock()
+
+	now := time.Now()
+	for _, healthyOnly := range []bool{true, false} {
+		var best *endpointT
+		bestInFlight := 0
+		for i := range pool.endpoints {
+			endpoint := pool.endpoints[(start+i)%len(pool.endpoints)]
+			if endpoint == exclude {
+				continue
+			}
+			endpoint.mu.Lock()
+			if (endpoint.healthy || !healthyOnly) && endpoint.closed(now) && (best == nil || endpoint.inFlight < bestInFlight) {
+				best, bestInFlight = endpoint, endpoint.inFlight
+			}
+			endpoint.mu.Unlock()
+		}
+		if best != nil && best.tryAcquire(now) {
+			return best
+		}
+	}
+	return nil
+}
+
+//closed returns whether the circuit breaker lets requests through, which it does for a single probing request once open long enough
+func (endpoint *endpointT) closed(now time.Time) bool {
+	if !circuitBreakerEnabled || endpoint.failures < circuitBreakerFailureThreshold {
+		return true
+	}
+	return !now.Before(endpoint.openUntil) && !endpoint.probing
+}
+
+func (endpoint *endpointT) tryAcquire(now time.Time) bool {
+	endpoint.mu.Lock()
+	defer endpoint.mu.Unlock()
+	if !endpoint.closed(now) {
+		return false
+	}
+	if circuitBreakerEnabled && endpoint.failures >= circuitBreakerFailureThreshold {
+		endpoint.probing = true
+	}
+	endpoint.inFlight++
+	return true
+}
+
+//release records the outcome of a request to the transformer, unless it was cancelled
+func (endpoint *endpointT) release(ctx context.Context, failed bool) {
+	endpoint.mu.Lock()
+	defer endpoint.mu.Unlock()
+	endpoint.inFlight--
+	endpoint.probing = false
+	if ctx.Err() != nil {
+		return
+	}
+	//failures are counted only while the circuit breaker is enabled, so that enabling it doesn't open it for earlier failures
+	if !failed || !circuitBreakerEnabled {
+		endpoint.failures = 0
+		return
+	}
+	endpoint.failures++
+	if endpoint.failures >= circuitBreakerFailureThreshold {
+		endpoint.openUntil = time.Now().Add(circuitBreakerOpenDuration)
+		if endpoint.failures == circuitBreakerFailureThreshold {
+			pkgLogger.Errorf("Opening circuit breaker of transformer %s for %v after %d failed requests", endpoint.url, circuitBreakerOpenDuration, endpoint.failures)
+			stats.NewTaggedStat("processor.transformer_circuit_breaker_opened", stats.CountType, stats.Tags{"transformer": endpoint.url}).Increment()
+		}
+	}
+}
+
+//checkHealth marks the transformers which don't respond to health checks as unhealthy, until they do again
+func (pool *endpointsT) checkHealth(client *http.Client) {
+	for {
+		time.Sleep(healthCheckInterval)
+		for _, endpoint := range pool.endpoints {
+			healthy := false
+			ctx, cancel := context.WithTimeout(context.Background(), healthCheckInterval)
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.url+"/health", nil)
+			if err == nil {
+				var resp *http.Response
+				if resp, err = client.Do(req); err == nil {
+					healthy = resp.StatusCode == http.StatusOK
+					_, _ = io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+				}
+			}
+			cancel()
+
+			endpoint.mu.Lock()
+			if endpoint.healthy != healthy {
+				pkgLogger.Infof("Transformer %s is healthy: %v", endpoint.url, healthy)
+			}
+			endpoint.healthy = healthy
+			endpoint.mu.Unlock()
+		}
+	}
+}
+
+func (trans *HandleT) setupEndpoints() {
+	if len(trans.Endpoints) == 0 {
+		trans.Endpoints = integrations.GetTransformerURLs()
+	}
+	trans.endpoints = newEndpoints(integrations.GetTransformerURL(), trans.Endpoints)
+	if len(trans.endpoints.endpoints) > 1 && healthCheckInterval > 0 {
+		rruntime.Go(func() {
+			trans.endpoints.checkHealth(trans.Client)
+		})
+	}
+}
+
+//transformerResponseT is the raw response of a transformer
+type transformerResponseT struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+func (trans *HandleT) postTo(ctx context.Context, url string, payload *requestPayloadT) (*transformerResponseT, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payload.body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", payload.contentType)
+	req.Header.Set("Accept", payload.contentType)
+	if payload.contentEncoding != "" {
+		req.Header.Set("Content-Encoding", payload.contentEncoding)
+	}
+	if payload.acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", payload.acceptEncoding)
+	}
+	resp, err := trans.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	//Transport decompresses gzip responses only if it asked for them itself
+	if !resp.Uncompressed {
+		if body, err = decompress(resp.Header.Get("Content-Encoding"), body); err != nil {
+			return nil, err
+		}
+	}
+	return &transformerResponseT{statusCode: resp.StatusCode, header: resp.Header, body: body}, nil
+}
+
+/*
+post sends the request to a transformer, balancing requests to the transformer url across transformers.
+If the transformer doesn't respond within hedgeAfter, the request is also sent to another transformer and the first response wins.
+*/
+func (trans *HandleT) post(ctx context.Context, url string, payload *requestPayloadT) (*transformerResponseT, error) {
+	path, ok := trans.endpoints.pathOf(url)
+	if !ok {
+		return trans.postTo(ctx, url, payload)
+	}
+	primary := trans.endpoints.acquire(nil)
+	if primary == nil {
+		return nil, errNoTransformerAvailable
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type result struct {
+		resp *transformerResponseT
+		err  error
+	}
+	results := make(chan result, 2)
+	send := func(endpoint *endpointT) {
+		go func() {
+			resp, err := trans.postTo(ctx, endpoint.url+path, payload)
+			endpoint.release(ctx, err != nil || resp.statusCode >= http.StatusInternalServerError)
+			results <- result{resp: resp, err: err}
+		}()
+	}
+	send(primary)
+	inFlight := 1
+
+	var hedge <-chan time.Time
+	if hedgeAfter > 0 && len(trans.endpoints.endpoints) > 1 {
+		timer := time.NewTimer(hedgeAfter)
+		defer timer.Stop()
+		hedge = timer.C
+	}
+	for {
+		select {
+		case r := <-results:
+			inFlight--
+			if r.err == nil || inFlight == 0 {
+				return r.resp, r.err
+			}
+		case <-hedge:
+			if endpoint := trans.endpoints.acquire(primary); endpoint != nil {
+				trans.hedgedStat.Increment()
+				send(endpoint)
+				inFlight++
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package transformer_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-server/config"
+	"github.com/rudderlabs/rudder-server/processor/integrations"
+	"github.com/rudderlabs/rudder-server/processor/transformer"
+	"github.com/rudderlabs/rudder-server/services/stats"
+	"github.com/rudderlabs/rudder-server/utils/logger"
+)
+
+//echoTransformer echoes the events back after the delay, counting its requests. It fails requests while failing is set.
+type echoTransformer struct {
+	delay    time.Duration
+	requests int64
+	failing  int32
+}
+
+func (t *echoTransformer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	atomic.AddInt64(&t.requests, 1)
+	if atomic.LoadInt32(&t.failing) == 1 {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	var events []transformer.TransformerEventT
+	if err := json.NewDecoder(r.Body).Decode(&events); err != nil {
+		panic(err)
+	}
+	select {
+	case <-time.After(t.delay):
+	case <-r.Context().Done():
+		return
+	}
+	responses := make([]transformer.TransformerResponseT, len(events))
+	for i := range events {
+		responses[i] = transformer.TransformerResponseT{Output: events[i].Message, Metadata: events[i].Metadata, StatusCode: http.StatusOK}
+	}
+	w.Header().Set("apiVersion", "2")
+	if err := json.NewEncoder(w).Encode(responses); err != nil {
+		panic(err)
+	}
+}
+
+func setupEndpointsTest(t *testing.T, env map[string]string) {
+	for key, value := range env {
+		os.Setenv(key, value)
+	}
+	t.Cleanup(func() {
+		for key := range env {
+			os.Unsetenv(key)
+		}
+	})
+	config.Load()
+	logger.Init()
+	stats.Setup()
+	integrations.Init()
+	transformer.Init()
+}
+
+func newEvents(count int) []transformer.TransformerEventT {
+	events := make([]transformer.TransformerEventT, count)
+	for i := range events {
+		events[i] = transformer.TransformerEventT{Message: map[string]interface{}{"index": i}}
+	}
+	return events
+}
+
+func Test_TransformerEndpoints(t *testing.T) {
+	t.Run("balances requests across transformers", func(t *testing.T) {
+		setupEndpointsTest(t, nil)
+		first, second := &echoTransformer{}, &echoTransformer{}
+		firstSrv, secondSrv := httptest.NewServer(first), httptest.NewServer(second)
+		defer firstSrv.Close()
+		defer secondSrv.Close()
+
+		tr := transformer.NewTransformer()
+		tr.Endpoints = []string{firstSrv.URL, secondSrv.URL}
+		tr.Setup()
+
+		response := tr.Transform(context.TODO(), newEvents(100), integrations.GetDestinationURL("WEBHOOK"), 10)
+		require.Len(t, response.Events, 100)
+		require.NotZero(t, atomic.LoadInt64(&first.requests))
+		require.NotZero(t, atomic.LoadInt64(&second.requests))
+		require.Equal(t, int64(10), atomic.LoadInt64(&first.requests)+atomic.LoadInt64(&second.requests))
+	})
+
+	t.Run("hedges requests to slow transformers", func(t *testing.T) {
+		setupEndpointsTest(t, map[string]string{"RSERVER_PROCESSOR_TRANSFORMER_HEDGE_AFTER": "50ms"})
+		slow, fast := &echoTransformer{delay: 5 * time.Second}, &echoTransformer{}
+		slowSrv, fastSrv := httptest.NewServer(slow), httptest.NewServer(fast)
+		defer slowSrv.Close()
+		defer fastSrv.Close()
+
+		tr := transformer.NewTransformer()
+		tr.Endpoints = []string{slowSrv.URL, fastSrv.URL}
+		tr.Setup()
+
+		start := time.Now()
+		response := tr.Transform(context.TODO(), newEvents(40), integrations.GetDestinationURL("WEBHOOK"), 10)
+		require.Len(t, response.Events, 40)
+		require.Less(t, int64(time.Since(start)), int64(2*time.Second))
+		require.NotZero(t, atomic.LoadInt64(&slow.requests))
+	})
+
+	t.Run("fails events once the circuit breakers of all transformers are open", func(t *testing.T) {
+		setupEndpointsTest(t, map[string]string{
+			"RSERVER_PROCESSOR_TRANSFORMER_CIRCUIT_BREAKER_ENABLED":           "true",
+			"RSERVER_PROCESSOR_TRANSFORMER_CIRCUIT_BREAKER_FAILURE_THRESHOLD": "2",
+		})
+		srv := httptest.NewServer(&echoTransformer{})
+		url := srv.URL
+		srv.Close()
+
+		tr := transformer.NewTransformer()
+		tr.Endpoints = []string{url}
+		tr.Setup()
+
+		response := tr.Transform(context.TODO(), newEvents(10), integrations.GetDestinationURL("WEBHOOK"), 10)
+		require.Empty(t, response.Events)
+		require.Len(t, response.FailedEvents, 10)
+		require.Equal(t, http.StatusServiceUnavailable, response.FailedEvents[0].StatusCode)
+	})
+
+	t.Run("doesn't count failures while the circuit breaker is disabled", func(t *testing.T) {
+		setupEndpointsTest(t, map[string]string{"RSERVER_PROCESSOR_TRANSFORMER_CIRCUIT_BREAKER_FAILURE_THRESHOLD": "2"})
+		echo := &echoTransformer{failing: 1}
+		srv := httptest.NewServer(echo)
+		defer srv.Close()
+
+		tr := transformer.NewTransformer()
+		tr.Endpoints = []string{srv.URL}
+		tr.Setup()
+
+		for i := 0; i < 3; i++ {
+			response := tr.Transform(context.TODO(), newEvents(1), integrations.GetDestinationURL("WEBHOOK"), 10)
+			require.Len(t, response.FailedEvents, 1)
+			require.Equal(t, http.StatusInternalServerError, response.FailedEvents[0].StatusCode)
+		}
+
+		setupEndpointsTest(t, map[string]string{"RSERVER_PROCESSOR_TRANSFORMER_CIRCUIT_BREAKER_ENABLED": "true"})
+		atomic.StoreInt32(&echo.failing, 0)
+		response := tr.Transform(context.TODO(), newEvents(1), integrations.GetDestinationURL("WEBHOOK"), 10)
+		require.Len(t, response.Events, 1)
+	})
+}
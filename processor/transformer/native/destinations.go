@@ -0,0 +1,97 @@
+package native
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/rudderlabs/rudder-server/processor/transformer"
+)
+
+//userIDOf returns userId of the message, or its anonymousId for anonymous users
+func userIDOf(message map[string]interface{}) interface{} {
+	if userID, ok := message["userId"]; ok && userID != nil && userID != "" {
+		return userID
+	}
+	return message["anonymousId"]
+}
+
+//webhook sends the event as json to the webhookUrl of the destination, with the headers of the destination
+func webhook(event *transformer.TransformerEventT) (map[string]interface{}, error) {
+	destConfig := event.Destination.Config
+	url, _ := destConfig["webhookUrl"].(string)
+	if url == "" {
+		return nil, errors.New("Invalid URL in destination config")
+	}
+	method, _ := destConfig["webhookMethod"].(string)
+	if method != "" && strings.ToUpper(method) != "POST" {
+		//GET flattens the event into query params, which is left to the remote transformer
+		return nil, ErrUnsupported
+	}
+
+	headers := map[string]interface{}{"content-type": "application/json"}
+	if destHeaders, ok := destConfig["headers"].([]interface{}); ok {
+		for _, destHeader := range destHeaders {
+			header, _ := destHeader.(map[string]interface{})
+			from, _ := header["from"].(string)
+			to, _ := header["to"].(string)
+			if from != "" && to != "" {
+				headers[from] = to
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"version":  "1",
+		"type":     "REST",
+		"method":   "POST",
+		"endpoint": url,
+		"headers":  headers,
+		"params":   map[string]interface{}{},
+		"body": map[string]interface{}{
+			"JSON":       map[string]interface{}(event.Message),
+			"JSON_ARRAY": map[string]interface{}{},
+			"XML":        map[string]interface{}{},
+			"FORM":       map[string]interface{}{},
+		},
+		"files":  map[string]interface{}{},
+		"userId": event.Message["anonymousId"],
+	}, nil
+}
+
+//kafka sends the event to the topic of the destination, keyed by the user
+func kafka(event *transformer.TransformerEventT) (map[string]interface{}, error) {
+	if multiTopic, _ := event.Destination.Config["enableMultiTopic"].(bool); multiTopic {
+		//topics per event type & name are left to the remote transformer
+		return nil, ErrUnsupported
+	}
+	output := map[string]interface{}{
+		"message": map[string]interface{}(event.Message),
+		"userId":  userIDOf(event.Message),
+		"topic":   event.Destination.Config["topic"],
+	}
+	if integrations, ok := event.Message["integrations"].(map[string]interface{}); ok {
+		if kafkaIntegration, ok := integrations["kafka"].(map[string]interface{}); ok && kafkaIntegration["schemaId"] != nil {
+			output["schemaId"] = kafkaIntegration["schemaId"]
+		}
+	}
+	return output, nil
+}
+
+//kinesis sends the event to the stream of the destination, partitioned by the user or by the message id
+func kinesis(event *transformer.TransformerEventT) (map[string]interface{}, error) {
+	partitionKey := userIDOf(event.Message)
+	if useMessageID, _ := event.Destination.Config["useMessageId"].(bool); useMessageID {
+		if messageID, ok := event.Message["messageId"]; ok && messageID != nil && messageID != "" {
+			partitionKey = messageID
+		}
+	}
+	return map[string]interface{}{
+		"message": map[string]interface{}(event.Message),
+		"userId":  partitionKey,
+	}, nil
+}
+
+//objectStorage uploads the events as they are
+func objectStorage(event *transformer.TransformerEventT) (map[string]interface{}, error) {
+	return map[string]interface{}(event.Message), nil
+}
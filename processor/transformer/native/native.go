@@ -0,0 +1,95 @@
+//Package native transforms events for common destinations in Go, instead of the destination endpoint of the remote transformer.
+//Native transformations must produce the same output as the remote transformer, which fixtures in testdata verify
+//once recorded from it, see testdata/README.md.
+//Warehouse destinations always go through the remote transformer, as their transformation flattens events
+//& maps them to warehouse schemas in ways which aren't replicated here.
+package native
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/rudderlabs/rudder-server/config"
+	"github.com/rudderlabs/rudder-server/processor/transformer"
+)
+
+//TransformFunc transforms an event for a destination.
+//It returns ErrUnsupported for events it can't transform like the remote transformer, which are sent to the remote transformer.
+type TransformFunc func(event *transformer.TransformerEventT) (map[string]interface{}, error)
+
+//ErrUnsupported is returned by TransformFuncs for events which have to be transformed by the remote transformer
+var ErrUnsupported = errors.New("event is not supported by native transformation")
+
+var (
+	enabled          bool
+	disabledDestType []string
+	registryMu       sync.RWMutex
+	registry         = map[string]TransformFunc{}
+)
+
+func Init() {
+	loadConfig()
+}
+
+func loadConfig() {
+	config.RegisterBoolConfigVariable(false, &enabled, true, "Processor.NativeTransformer.enabled")
+	config.RegisterStringSliceConfigVariable(nil, &disabledDestType, true, "Processor.NativeTransformer.disabledDestinations")
+}
+
+//init registers native transformations of stream & object storage destinations only, events of other destinations,
+//including warehouse destinations, are transformed by the remote transformer.
+func init() {
+	Register("WEBHOOK", webhook)
+	Register("KAFKA", kafka)
+	Register("KINESIS", kinesis)
+	for _, destType := range []string{"S3", "GCS", "AZURE_BLOB", "MINIO", "DIGITAL_OCEAN_SPACES"} {
+		Register(destType, objectStorage)
+	}
+}
+
+//Register adds the native transformation of a destination type
+func Register(destType string, transform TransformFunc) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[destType] = transform
+}
+
+//Get returns the native transformation of the destination type, if it is enabled
+func Get(destType string) (TransformFunc, bool) {
+	if !enabled {
+		return nil, false
+	}
+	for _, disabled := range disabledDestType {
+		if strings.EqualFold(disabled, destType) {
+			return nil, false
+		}
+	}
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	transform, ok := registry[destType]
+	return transform, ok
+}
+
+/*
+Transform transforms the events with the native transformation.
+Events which the transformation doesn't support are returned, to be transformed by the remote transformer.
+Failed events have status code 400, like failures of the remote transformer.
+*/
+func Transform(transform TransformFunc, events []transformer.TransformerEventT) (response transformer.ResponseT, unsupported []transformer.TransformerEventT) {
+	for i := range events {
+		event := &events[i]
+		output, err := transform(event)
+		if errors.Is(err, ErrUnsupported) {
+			unsupported = append(unsupported, *event)
+			continue
+		}
+		if err != nil {
+			response.FailedEvents = append(response.FailedEvents, transformer.TransformerResponseT{StatusCode: http.StatusBadRequest, Error: err.Error(), Metadata: event.Metadata})
+			continue
+		}
+		response.Events = append(response.Events, transformer.TransformerResponseT{Output: output, Metadata: event.Metadata, StatusCode: http.StatusOK})
+	}
+	return response, unsupported
+}
@@ -0,0 +1,155 @@
+package native_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/rudder-server/config"
+	"github.com/rudderlabs/rudder-server/processor/transformer"
+	"github.com/rudderlabs/rudder-server/processor/transformer/native"
+)
+
+var (
+	record         = flag.Bool("record", false, "record the responses of the remote transformer to the fixtures in testdata")
+	transformerURL = flag.String("transformer-url", "http://localhost:9090", "url of the remote transformer to record responses from")
+)
+
+//fixture is an event for a destination & the response of the remote transformer for it.
+//Recorded is set by -record only, fixtures without it have responses written by hand which don't prove parity.
+type fixture struct {
+	Event    transformer.TransformerEventT    `json:"event"`
+	Response transformer.TransformerResponseT `json:"response"`
+	Recorded bool                             `json:"recorded,omitempty"`
+}
+
+func setup(t *testing.T) {
+	os.Setenv("RSERVER_PROCESSOR_NATIVE_TRANSFORMER_ENABLED", "true")
+	t.Cleanup(func() { os.Unsetenv("RSERVER_PROCESSOR_NATIVE_TRANSFORMER_ENABLED") })
+	config.Load()
+	native.Init()
+}
+
+//recordResponse replaces the response of the fixture with the one of the remote transformer
+func recordResponse(t *testing.T, destType string, f *fixture) {
+	body, err := json.Marshal([]transformer.TransformerEventT{f.Event})
+	require.NoError(t, err)
+	resp, err := http.Post(*transformerURL+"/v0/"+strings.ToLower(destType), "application/json; charset=utf-8", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var responses []transformer.TransformerResponseT
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&responses))
+	require.Len(t, responses, 1)
+	f.Response = responses[0]
+	f.Recorded = true
+}
+
+//Test_Parity replays the events of the fixtures & diffs native transformations against the recorded responses of the remote transformer.
+//Run with -record to refresh the fixtures from a remote transformer running at -transformer-url.
+//Fixtures which weren't recorded are still replayed, but reported as skipped, as their responses can't show parity.
+func Test_Parity(t *testing.T) {
+	setup(t)
+
+	paths, err := filepath.Glob(filepath.Join("testdata", "*", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		path := path
+		destType := filepath.Base(filepath.Dir(path))
+		t.Run(destType+"/"+strings.TrimSuffix(filepath.Base(path), ".json"), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			var f fixture
+			require.NoError(t, json.Unmarshal(data, &f))
+
+			if *record {
+				recordResponse(t, destType, &f)
+				data, err = json.MarshalIndent(f, "", "  ")
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(path, append(data, '\n'), 0o644))
+			}
+
+			transform, ok := native.Get(destType)
+			require.True(t, ok, "no native transformation for %s", destType)
+			response, unsupported := native.Transform(transform, []transformer.TransformerEventT{f.Event})
+			require.Empty(t, unsupported)
+			requireResponse(t, f.Response, response)
+			if !f.Recorded {
+				t.Skipf("%s hasn't been recorded from the remote transformer, run with -record", path)
+			}
+		})
+	}
+}
+
+//requireResponse requires the native transformation of an event to match the response of the fixture
+func requireResponse(t *testing.T, expected transformer.TransformerResponseT, response transformer.ResponseT) {
+	if expected.StatusCode != http.StatusOK {
+		require.Len(t, response.FailedEvents, 1)
+		require.Equal(t, expected.StatusCode, response.FailedEvents[0].StatusCode)
+		require.Equal(t, expected.Error, response.FailedEvents[0].Error)
+		require.Equal(t, expected.Metadata, response.FailedEvents[0].Metadata)
+		return
+	}
+	require.Len(t, response.Events, 1)
+	require.Equal(t, expected.Metadata, response.Events[0].Metadata)
+	expectedOutput, err := json.Marshal(expected.Output)
+	require.NoError(t, err)
+	actualOutput, err := json.Marshal(response.Events[0].Output)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expectedOutput), string(actualOutput))
+}
+
+func Test_Transform(t *testing.T) {
+	setup(t)
+
+	t.Run("leaves unsupported events to the remote transformer", func(t *testing.T) {
+		transform, ok := native.Get("WEBHOOK")
+		require.True(t, ok)
+		events := []transformer.TransformerEventT{
+			{Message: map[string]interface{}{"anonymousId": "anon-1"}, Metadata: transformer.MetadataT{MessageID: "m1"}},
+			{Message: map[string]interface{}{"anonymousId": "anon-1"}, Metadata: transformer.MetadataT{MessageID: "m2"}},
+		}
+		events[0].Destination.Config = map[string]interface{}{"webhookUrl": "https://example.com", "webhookMethod": "GET"}
+		events[1].Destination.Config = map[string]interface{}{"webhookUrl": "https://example.com"}
+
+		response, unsupported := native.Transform(transform, events)
+		require.Len(t, unsupported, 1)
+		require.Equal(t, "m1", unsupported[0].Metadata.MessageID)
+		require.Len(t, response.Events, 1)
+		require.Equal(t, "m2", response.Events[0].Metadata.MessageID)
+	})
+
+	t.Run("leaves destinations without native transformations to the remote transformer", func(t *testing.T) {
+		_, ok := native.Get("AM")
+		require.False(t, ok)
+	})
+
+	t.Run("leaves warehouse destinations to the remote transformer", func(t *testing.T) {
+		warehouseDestinations := []string{"RS", "BQ", "SNOWFLAKE", "POSTGRES", "CLICKHOUSE", "MSSQL", "AZURE_SYNAPSE", "S3_DATALAKE", "GCS_DATALAKE", "AZURE_DATALAKE", "DELTALAKE"}
+		for _, destType := range warehouseDestinations {
+			_, ok := native.Get(destType)
+			require.False(t, ok, destType)
+		}
+	})
+
+	t.Run("can be disabled per destination type", func(t *testing.T) {
+		os.Setenv("RSERVER_PROCESSOR_NATIVE_TRANSFORMER_DISABLED_DESTINATIONS", "KAFKA")
+		defer os.Unsetenv("RSERVER_PROCESSOR_NATIVE_TRANSFORMER_DISABLED_DESTINATIONS")
+		config.Load()
+		native.Init()
+		_, ok := native.Get("KAFKA")
+		require.False(t, ok)
+		_, ok = native.Get("KINESIS")
+		require.True(t, ok)
+	})
+}
@@ -0,0 +1,219 @@
+package transformer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/tidwall/gjson"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/rudderlabs/rudder-server/config"
+	"github.com/rudderlabs/rudder-server/processor/integrations"
+	"github.com/rudderlabs/rudder-server/utils/misc"
+)
+
+/*
+Requests to the transformer are sent as json by default.
+Processor can compress them & encode them as msgpack, if the transformer supports it as per its features:
+
+	{"protocol": {"compressions": ["gzip", "zstd"], "encodings": ["json", "msgpack"]}}
+
+The compression & encoding used are the first ones of the preferences in Processor.Transformer.compression & Processor.Transformer.encoding
+which the transformer supports. Responses are decoded as per their Content-Encoding & Content-Type.
+*/
+
+const (
+	GzipCompression = "gzip"
+	ZstdCompression = "zstd"
+	JSONEncoding    = "json"
+	MsgpackEncoding = "msgpack"
+
+	jsonContentType    = "application/json; charset=utf-8"
+	msgpackContentType = "application/msgpack"
+)
+
+var (
+	compressionPreferences []string
+	encodingPreferences    []string
+	compressionMinSize     int
+	//protocolFeatures are the compressions & encodings supported by the transformer
+	protocolFeatures atomic.Value
+
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+func loadProtocolConfig() {
+	config.RegisterStringSliceConfigVariable(nil, &compressionPreferences, true, "Processor.Transformer.compression")
+	config.RegisterStringSliceConfigVariable(nil, &encodingPreferences, true, "Processor.Transformer.encoding")
+	config.RegisterIntConfigVariable(1, &compressionMinSize, true, 1024, "Processor.Transformer.compressionMinSizeInKB")
+}
+
+//protocolFeaturesT are the compressions & encodings the transformer advertises in its features
+type protocolFeaturesT struct {
+	Compressions []string `json:"compressions"`
+	Encodings    []string `json:"encodings"`
+}
+
+//UpdateFeatures updates the compressions & encodings supported by the transformer, as per its features
+func UpdateFeatures(features json.RawMessage) {
+	var supported protocolFeaturesT
+	if protocol := gjson.GetBytes(features, "protocol"); protocol.Exists() {
+		if err := json.Unmarshal([]byte(protocol.Raw), &supported); err != nil {
+			pkgLogger.Errorf("Invalid protocol in transformer features %s: %v", protocol.Raw, err)
+		}
+	}
+	protocolFeatures.Store(supported)
+}
+
+//negotiate returns the first preference which the transformer supports, or empty if none
+func negotiate(preferences, supported []string) string {
+	for _, preference := range preferences {
+		if misc.ContainsString(supported, preference) {
+			return preference
+		}
+	}
+	return ""
+}
+
+//requestPayloadT is the encoded & compressed body of a request to the transformer
+type requestPayloadT struct {
+	body            []byte
+	contentType     string
+	contentEncoding string
+	acceptEncoding  string
+}
+
+func encodeRequest(data []TransformerEventT) (*requestPayloadT, error) {
+	supported, _ := protocolFeatures.Load().(protocolFeaturesT)
+	payload := &requestPayloadT{contentType: jsonContentType}
+
+	var err error
+	if negotiate(encodingPreferences, supported.Encodings) == MsgpackEncoding {
+		payload.contentType = msgpackContentType
+		payload.body, err = marshalMsgpack(data)
+	} else {
+		payload.body, err = jsonfast.Marshal(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	compression := negotiate(compressionPreferences, supported.Compressions)
+	payload.acceptEncoding = compression
+	if compression == "" || len(payload.body) < compressionMinSize {
+		return payload, nil
+	}
+	switch compression {
+	case GzipCompression:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(payload.body); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		payload.body = buf.Bytes()
+	case ZstdCompression:
+		payload.body = zstdEncoder.EncodeAll(payload.body, nil)
+	}
+	payload.contentEncoding = compression
+	return payload, nil
+}
+
+//decompress decompresses the body of a response of the transformer as per its Content-Encoding
+func decompress(contentEncoding string, body []byte) ([]byte, error) {
+	switch contentEncoding {
+	case "", "identity":
+		return body, nil
+	case GzipCompression:
+		r, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	case ZstdCompression:
+		return zstdDecoder.DecodeAll(body, nil)
+	}
+	return nil, fmt.Errorf("unsupported content encoding %q", contentEncoding)
+}
+
+//decodeResponse decodes the body of a response of the transformer as per its Content-Type & reports its integration stats
+func decodeResponse(resp *transformerResponseT, transformerResponses *[]TransformerResponseT) error {
+	if resp.header.Get("Content-Type") != msgpackContentType {
+		integrations.CollectIntgTransformErrorStats(resp.body)
+		return jsonfast.Unmarshal(resp.body, transformerResponses)
+	}
+
+	var integrationStats []integrations.TransStatsT
+	if err := unmarshalMsgpack(resp.body, &integrationStats); err == nil {
+		integrations.ReportIntgTransformErrorStats(integrationStats)
+	}
+	if err := unmarshalMsgpack(resp.body, transformerResponses); err != nil {
+		return err
+	}
+	for i := range *transformerResponses {
+		if output := (*transformerResponses)[i].Output; output != nil {
+			(*transformerResponses)[i].Output = normalizeMsgpackValue(output).(map[string]interface{})
+		}
+	}
+	return nil
+}
+
+func marshalMsgpack(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func unmarshalMsgpack(data []byte, v interface{}) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
+
+//normalizeMsgpackValue converts the numbers of a msgpack decoded value to float64, as they are when decoded from json
+func normalizeMsgpackValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = normalizeMsgpackValue(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = normalizeMsgpackValue(value)
+		}
+		return v
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return value
+}
@@ -0,0 +1,138 @@
+package transformer_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/rudderlabs/rudder-server/processor/integrations"
+	"github.com/rudderlabs/rudder-server/processor/transformer"
+)
+
+//protocolTransformer echoes the events back with the compression & encoding of the request, recording them
+type protocolTransformer struct {
+	t               *testing.T
+	contentEncoding string
+	contentType     string
+}
+
+func (p *protocolTransformer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.contentEncoding = r.Header.Get("Content-Encoding")
+	p.contentType = r.Header.Get("Content-Type")
+
+	var body io.Reader = r.Body
+	switch p.contentEncoding {
+	case "gzip":
+		gr, err := gzip.NewReader(r.Body)
+		require.NoError(p.t, err)
+		body = gr
+	case "zstd":
+		zr, err := zstd.NewReader(r.Body)
+		require.NoError(p.t, err)
+		defer zr.Close()
+		body = zr
+	}
+
+	var events []transformer.TransformerEventT
+	if p.contentType == "application/msgpack" {
+		dec := msgpack.NewDecoder(body)
+		dec.SetCustomStructTag("json")
+		require.NoError(p.t, dec.Decode(&events))
+	} else {
+		require.NoError(p.t, json.NewDecoder(body).Decode(&events))
+	}
+	responses := make([]transformer.TransformerResponseT, len(events))
+	for i := range events {
+		responses[i] = transformer.TransformerResponseT{Output: events[i].Message, Metadata: events[i].Metadata, StatusCode: http.StatusOK}
+	}
+
+	var encoded bytes.Buffer
+	if p.contentType == "application/msgpack" {
+		enc := msgpack.NewEncoder(&encoded)
+		enc.SetCustomStructTag("json")
+		require.NoError(p.t, enc.Encode(responses))
+	} else {
+		require.NoError(p.t, json.NewEncoder(&encoded).Encode(responses))
+	}
+	w.Header().Set("apiVersion", "2")
+	w.Header().Set("Content-Type", p.contentType)
+	if r.Header.Get("Accept-Encoding") == "zstd" {
+		zw, err := zstd.NewWriter(nil)
+		require.NoError(p.t, err)
+		w.Header().Set("Content-Encoding", "zstd")
+		_, _ = w.Write(zw.EncodeAll(encoded.Bytes(), nil))
+		return
+	}
+	_, _ = w.Write(encoded.Bytes())
+}
+
+func Test_TransformerProtocol(t *testing.T) {
+	newLargeEvents := func() []transformer.TransformerEventT {
+		events := make([]transformer.TransformerEventT, 10)
+		for i := range events {
+			events[i] = transformer.TransformerEventT{
+				Message:  map[string]interface{}{"index": float64(i), "properties": map[string]interface{}{"text": strings.Repeat("a", 200), "price": 9.5}},
+				Metadata: transformer.MetadataT{MessageID: "message"},
+			}
+		}
+		return events
+	}
+	transform := func(t *testing.T, env map[string]string, features string) *protocolTransformer {
+		setupEndpointsTest(t, env)
+		transformer.UpdateFeatures(json.RawMessage(features))
+		t.Cleanup(func() { transformer.UpdateFeatures(nil) })
+
+		pt := &protocolTransformer{t: t}
+		srv := httptest.NewServer(pt)
+		t.Cleanup(srv.Close)
+		tr := transformer.NewTransformer()
+		tr.Endpoints = []string{srv.URL}
+		tr.Setup()
+
+		events := newLargeEvents()
+		response := tr.Transform(context.TODO(), events, integrations.GetDestinationURL("WEBHOOK"), 10)
+		require.Empty(t, response.FailedEvents)
+		require.Len(t, response.Events, len(events))
+		for i := range events {
+			require.Equal(t, map[string]interface{}(events[i].Message), response.Events[i].Output)
+			require.Equal(t, "message", response.Events[i].Metadata.MessageID)
+		}
+		return pt
+	}
+
+	t.Run("sends json uncompressed unless the transformer supports more", func(t *testing.T) {
+		pt := transform(t, map[string]string{
+			"RSERVER_PROCESSOR_TRANSFORMER_COMPRESSION": "zstd,gzip",
+			"RSERVER_PROCESSOR_TRANSFORMER_ENCODING":    "msgpack",
+		}, `{"routerTransform": {}}`)
+		require.Empty(t, pt.contentEncoding)
+		require.Equal(t, "application/json; charset=utf-8", pt.contentType)
+	})
+
+	t.Run("compresses with the preferred compression the transformer supports", func(t *testing.T) {
+		pt := transform(t, map[string]string{
+			"RSERVER_PROCESSOR_TRANSFORMER_COMPRESSION": "zstd,gzip",
+		}, `{"protocol": {"compressions": ["gzip"]}}`)
+		require.Equal(t, "gzip", pt.contentEncoding)
+		require.Equal(t, "application/json; charset=utf-8", pt.contentType)
+	})
+
+	t.Run("encodes as msgpack with zstd compressed responses", func(t *testing.T) {
+		pt := transform(t, map[string]string{
+			"RSERVER_PROCESSOR_TRANSFORMER_COMPRESSION": "zstd",
+			"RSERVER_PROCESSOR_TRANSFORMER_ENCODING":    "msgpack,json",
+		}, `{"protocol": {"compressions": ["gzip", "zstd"], "encodings": ["json", "msgpack"]}}`)
+		require.Equal(t, "zstd", pt.contentEncoding)
+		require.Equal(t, "application/msgpack", pt.contentType)
+	})
+}
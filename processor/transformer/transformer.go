@@ -3,8 +3,8 @@ package transformer
 //go:generate mockgen -destination=../../mocks/processor/transformer/mock_transformer.go -package=mocks_transformer github.com/rudderlabs/rudder-server/processor/transformer Transformer
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -65,6 +65,8 @@ type MetadataT struct {
 	EventType               string   `json:"eventType"`
 	SourceDefinitionID      string   `json:"sourceDefinitionId"`
 	DestinationDefinitionID string   `json:"destinationDefinitionId"`
+	// value of the ordering key of the destination in the event, if the destination orders events by key
+	OrderingKey string `json:"orderingKey,omitempty"`
 }
 
 type TransformerEventT struct {
@@ -86,6 +88,10 @@ type HandleT struct {
 	logger logger.LoggerI
 
 	Client *http.Client
+	//Endpoints are the base urls of the transformers which requests to the transformer url are balanced across
+	Endpoints  []string
+	endpoints  *endpointsT
+	hedgedStat stats.RudderStats
 
 	guardConcurrency chan struct{}
 }
@@ -120,6 +126,9 @@ func loadConfig() {
 
 	config.RegisterIntConfigVariable(30, &maxRetry, true, 1, "Processor.maxRetry")
 	config.RegisterDurationConfigVariable(time.Duration(100), &retrySleep, true, time.Millisecond, []string{"Processor.retrySleep", "Processor.retrySleepInMS"}...)
+	loadEmbeddedConfig()
+	loadEndpointsConfig()
+	loadProtocolConfig()
 }
 
 type TransformerResponseT struct {
@@ -144,6 +153,7 @@ func (trans *HandleT) Setup() {
 	trans.receivedStat = stats.NewStat("processor.transformer_received", stats.CountType)
 	trans.failedStat = stats.NewStat("processor.transformer_failed", stats.CountType)
 	trans.transformTimerStat = stats.NewStat("processor.transformation_time", stats.TimerType)
+	trans.hedgedStat = stats.NewStat("processor.transformer_hedged_requests", stats.CountType)
 
 	trans.guardConcurrency = make(chan struct{}, maxConcurrency)
 	trans.perfStats = &misc.PerfStats{}
@@ -158,6 +168,7 @@ func (trans *HandleT) Setup() {
 			},
 		}
 	}
+	trans.setupEndpoints()
 }
 
 //ResponseT represents a Transformer response
@@ -293,20 +304,18 @@ func statsTags(event TransformerEventT) stats.Tags {
 func (trans *HandleT) request(ctx context.Context, url string, data []TransformerEventT) []TransformerResponseT {
 	//Call remote transformation
 	var (
-		rawJSON []byte
+		payload *requestPayloadT
 		err     error
 	)
 
 	trace.WithRegion(ctx, "marshal", func() {
-		rawJSON, err = jsonfast.Marshal(data)
+		payload, err = encodeRequest(data)
 	})
-	trace.Logf(ctx, "marshal", "request raw body size: %d", len(rawJSON))
 	if err != nil {
 		panic(err)
 	}
 	retryCount := 0
-	var resp *http.Response
-	var respData []byte
+	var resp *transformerResponseT
 	//We should rarely have error communicating with our JS
 	reqFailed := false
 
@@ -319,13 +328,17 @@ func (trans *HandleT) request(ctx context.Context, url string, data []Transforme
 	for {
 		s := time.Now()
 		trace.WithRegion(ctx, "request/post", func() {
-			resp, err = trans.Client.Post(url, "application/json; charset=utf-8", bytes.NewBuffer(rawJSON))
+			resp, err = trans.post(ctx, url, payload)
 		})
-		if err == nil {
-			//If no err returned by client.Post, reading body.
-			//If reading body fails, retrying.
-			respData, err = io.ReadAll(resp.Body)
-			resp.Body.Close()
+		if errors.Is(err, errNoTransformerAvailable) {
+			//Failing the events instead of retrying, so that they are stored in the error db rather than blocking the pipeline
+			trans.logger.Errorf("Failing %d events as %v, URL: %v", len(data), err, url)
+			stats.NewTaggedStat("processor.transformer_circuit_open_events", stats.CountType, statsTags(data[0])).Count(len(data))
+			transformerResponses := make([]TransformerResponseT, len(data))
+			for i := range data {
+				transformerResponses[i] = TransformerResponseT{StatusCode: http.StatusServiceUnavailable, Error: err.Error(), Metadata: data[i].Metadata}
+			}
+			return transformerResponses
 		}
 
 		if err != nil {
@@ -345,8 +358,8 @@ func (trans *HandleT) request(ctx context.Context, url string, data []Transforme
 		}
 
 		// perform version compatability check only on success
-		if resp.StatusCode == http.StatusOK {
-			transformerAPIVersion, convErr := strconv.Atoi(resp.Header.Get("apiVersion"))
+		if resp.statusCode == http.StatusOK {
+			transformerAPIVersion, convErr := strconv.Atoi(resp.header.Get("apiVersion"))
 			if convErr != nil {
 				transformerAPIVersion = 0
 			}
@@ -361,37 +374,36 @@ func (trans *HandleT) request(ctx context.Context, url string, data []Transforme
 	}
 
 	// Remove Assertion?
-	if !(resp.StatusCode == http.StatusOK ||
-		resp.StatusCode == http.StatusBadRequest ||
-		resp.StatusCode == http.StatusNotFound ||
-		resp.StatusCode == http.StatusRequestEntityTooLarge) {
-		trans.logger.Errorf("Transformer returned status code: %v", resp.StatusCode)
+	if !(resp.statusCode == http.StatusOK ||
+		resp.statusCode == http.StatusBadRequest ||
+		resp.statusCode == http.StatusNotFound ||
+		resp.statusCode == http.StatusRequestEntityTooLarge) {
+		trans.logger.Errorf("Transformer returned status code: %v", resp.statusCode)
 	}
 
 	var transformerResponses []TransformerResponseT
-	if resp.StatusCode == http.StatusOK {
-		integrations.CollectIntgTransformErrorStats(respData)
-
-		trace.Logf(ctx, "Unmarshal", "response raw size: %d", len(respData))
+	if resp.statusCode == http.StatusOK {
+		trace.Logf(ctx, "Unmarshal", "response raw size: %d", len(resp.body))
 		trace.WithRegion(ctx, "Unmarshal", func() {
-			err = jsonfast.Unmarshal(respData, &transformerResponses)
+			err = decodeResponse(resp, &transformerResponses)
 		})
 		//This is returned by our JS engine so should  be parsable
 		//but still handling it
 		if err != nil {
+			rawJSON, _ := jsonfast.Marshal(data)
 			trans.logger.Errorf("Data sent to transformer : %v", string(rawJSON))
-			trans.logger.Errorf("Transformer returned : %v", string(respData))
-			respData = []byte(fmt.Sprintf("Failed to unmarshal transformer response: %s", string(respData)))
+			trans.logger.Errorf("Transformer returned : %v", string(resp.body))
+			resp.body = []byte(fmt.Sprintf("Failed to unmarshal transformer response: %s", string(resp.body)))
 			transformerResponses = nil
-			resp.StatusCode = 400
+			resp.statusCode = 400
 		}
 	}
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.statusCode != http.StatusOK {
 		for i := range data {
 			transformEvent := &data[i]
-			resp := TransformerResponseT{StatusCode: resp.StatusCode, Error: string(respData), Metadata: transformEvent.Metadata}
-			transformerResponses = append(transformerResponses, resp)
+			failedResponse := TransformerResponseT{StatusCode: resp.statusCode, Error: string(resp.body), Metadata: transformEvent.Metadata}
+			transformerResponses = append(transformerResponses, failedResponse)
 		}
 	}
 	return transformerResponses
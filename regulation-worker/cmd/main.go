@@ -6,16 +6,21 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/rudderlabs/rudder-server/config"
 	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+	"github.com/rudderlabs/rudder-server/jobsdb"
 	"github.com/rudderlabs/rudder-server/regulation-worker/internal/client"
 	"github.com/rudderlabs/rudder-server/regulation-worker/internal/delete"
 	"github.com/rudderlabs/rudder-server/regulation-worker/internal/delete/api"
 	"github.com/rudderlabs/rudder-server/regulation-worker/internal/delete/batch"
 	"github.com/rudderlabs/rudder-server/regulation-worker/internal/delete/kvstore"
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/delete/warehouse"
 	"github.com/rudderlabs/rudder-server/regulation-worker/internal/destination"
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/export"
 	"github.com/rudderlabs/rudder-server/regulation-worker/internal/initialize"
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/propagator"
 	"github.com/rudderlabs/rudder-server/regulation-worker/internal/service"
 	"github.com/rudderlabs/rudder-server/services/filemanager"
 	"github.com/rudderlabs/rudder-server/utils/logger"
@@ -53,6 +58,24 @@ func Run(ctx context.Context) {
 		panic("error while getting workspaceId")
 	}
 
+	warehouseManager := &warehouse.WarehouseManager{}
+	exporter := &export.ExportManager{
+		FMFactory:    &filemanager.FileManagerFactoryT{},
+		Warehouse:    warehouseManager,
+		Destinations: dest,
+		Sources:      dest,
+	}
+	//events are collected from jobsdb only if regulation-worker has access to it
+	if config.IsEnvSet("JOBS_DB_HOST") {
+		jobsdb.Init2()
+		for _, prefix := range []string{"gw", "rt", "batch_rt"} {
+			readonlyJobsDB := &jobsdb.ReadonlyHandleT{}
+			readonlyJobsDB.Setup(prefix)
+			defer readonlyJobsDB.TearDown()
+			exporter.AddJobsDB(prefix, readonlyJobsDB)
+		}
+	}
+
 	svc := service.JobSvc{
 		API: &client.JobAPI{
 			Client:         &http.Client{},
@@ -69,7 +92,20 @@ func Run(ctx context.Context) {
 			&api.APIManager{
 				Client:           &http.Client{},
 				DestTransformURL: config.MustGetEnv("DEST_TRANSFORM_URL"),
-			}),
+			},
+			warehouseManager),
+		Exporter:          exporter,
+		Limiter:           service.NewDestLimiter(config.GetInt("RegulationWorker.maxConcurrentJobsPerDestination", 1)),
+		HeartbeatInterval: config.GetDuration("RegulationWorker.jobHeartbeatIntervalInS", 60, time.Second),
+	}
+
+	//users of completed jobs are propagated to rudder-server only if its url is configured
+	if propagationURL := config.GetEnv("REGULATION_PROPAGATION_URL", ""); propagationURL != "" {
+		svc.Propagator = &propagator.PipelinePropagator{
+			Client:         &http.Client{},
+			URLPrefix:      propagationURL,
+			WorkspaceToken: config.MustGetEnv("CONFIG_BACKEND_TOKEN"),
+		}
 	}
 
 	pkgLogger.Infof("calling looper with service: %v", svc)
@@ -84,6 +120,7 @@ func Run(ctx context.Context) {
 
 func withLoop(svc service.JobSvc) *service.Looper {
 	return &service.Looper{
-		Svc: svc,
+		Svc:     svc,
+		Workers: config.GetInt("RegulationWorker.workers", 4),
 	}
 }
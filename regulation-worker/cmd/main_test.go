@@ -307,7 +307,7 @@ func TestFlow(t *testing.T) {
 				mu.Lock()
 				status := test.status
 				mu.Unlock()
-				if status == "pending" && test.getJobRespCode == 200 {
+				if status != "complete" && test.getJobRespCode == 200 {
 					return false
 				}
 			}
@@ -336,6 +336,8 @@ func TestFlow(t *testing.T) {
 
 func getJob(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
+	mu.Lock()
+	defer mu.Unlock()
 	for i, test := range testData {
 		status := test.status
 		if status == "pending" {
@@ -356,9 +358,12 @@ func updateJobStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if status.Status == "complete" {
+	//a running job is leased to the worker running it & isn't handed out again,
+	//unless it is released by the worker, marking it pending
+	switch status.Status {
+	case "running", "pending", "complete":
 		mu.Lock()
-		testData[jobID-1].status = "complete"
+		testData[jobID-1].status = model.JobStatus(status.Status)
 		mu.Unlock()
 	}
 	w.WriteHeader(testData[jobID-1].updateJobRespCode)
@@ -103,7 +103,25 @@ func (j *JobAPI) Get(ctx context.Context) (model.Job, error) {
 //marshals status into appropriate status schema, and sent as payload
 //checked for returned status code.
 func (j *JobAPI) UpdateStatus(ctx context.Context, status model.JobStatus, jobID int) error {
-	pkgLogger.Debugf("sending PATCH request to update job status for jobId: ", jobID, "with status: %v", status)
+	return j.patchStatus(ctx, jobID, statusJobSchema{
+		Status: string(status),
+	})
+}
+
+//UpdateExportStatus updates status of an export job along with location of the exported archive.
+func (j *JobAPI) UpdateExportStatus(ctx context.Context, status model.JobStatus, jobID int, export model.ExportResult) error {
+	return j.patchStatus(ctx, jobID, statusJobSchema{
+		Status: string(status),
+		Export: &exportSchema{
+			Location:    export.Location,
+			EventCount:  export.EventCount,
+			GeneratedAt: export.GeneratedAt.Format(time.RFC3339),
+		},
+	})
+}
+
+func (j *JobAPI) patchStatus(ctx context.Context, jobID int, statusSchema statusJobSchema) error {
+	pkgLogger.Debugf("sending PATCH request to update job status for jobId: ", jobID, "with status: %v", statusSchema.Status)
 	ctx, cancel := context.WithTimeout(ctx, time.Duration(time.Minute))
 	defer cancel()
 
@@ -113,9 +131,6 @@ func (j *JobAPI) UpdateStatus(ctx context.Context, status model.JobStatus, jobID
 	url := fmt.Sprint(j.URLPrefix, prepURL(genEndPoint, j.WorkspaceID, fmt.Sprint(jobID)))
 	pkgLogger.Debugf("sending request to URL: %v", url)
 
-	statusSchema := statusJobSchema{
-		Status: string(status),
-	}
 	body, err := json.Marshal(statusSchema)
 	if err != nil {
 		pkgLogger.Errorf("error while marshalling status schema: %v", err)
@@ -174,10 +189,16 @@ func mapPayloadToJob(wjs jobSchema, workspaceID string) (model.Job, error) {
 		return model.Job{}, fmt.Errorf("error while get JobID:%w", err)
 	}
 
+	jobType := model.JobType(wjs.JobType)
+	if jobType == "" {
+		jobType = model.JobTypeDeletion
+	}
+
 	return model.Job{
 		ID:             jobID,
 		WorkspaceID:    workspaceID,
 		DestinationID:  wjs.DestinationID,
+		Type:           jobType,
 		Status:         model.JobStatusRunning,
 		UserAttributes: usrAttribute,
 	}, nil
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/rudderlabs/rudder-server/regulation-worker/internal/client"
 	"github.com/rudderlabs/rudder-server/regulation-worker/internal/initialize"
@@ -119,3 +120,64 @@ func TestUpdateStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestGetJobType(t *testing.T) {
+	initialize.Init()
+	var tests = []struct {
+		name            string
+		respBody        string
+		expectedJobType model.JobType
+	}{
+		{
+			name:            "job without type is a deletion job",
+			respBody:        `{"jobId":"1","destinationId":"23","userAttributes":[{"userId":"1"}]}`,
+			expectedJobType: model.JobTypeDeletion,
+		},
+		{
+			name:            "export job",
+			respBody:        `{"jobId":"1","jobType":"export","userAttributes":[{"userId":"1"}]}`,
+			expectedJobType: model.JobTypeExport,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.respBody)
+			}))
+			defer svr.Close()
+
+			c := client.JobAPI{
+				Client:      &http.Client{},
+				WorkspaceID: "1001",
+				URLPrefix:   svr.URL,
+			}
+			job, err := c.Get(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedJobType, job.Type)
+		})
+	}
+}
+
+func TestUpdateExportStatus(t *testing.T) {
+	initialize.Init()
+	var body []byte
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/dataplane/workspaces/1001/regulations/workerJobs/1", r.URL.Path)
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(200)
+	}))
+	defer svr.Close()
+
+	c := client.JobAPI{
+		Client:      &http.Client{},
+		URLPrefix:   svr.URL,
+		WorkspaceID: "1001",
+	}
+	err := c.UpdateExportStatus(context.Background(), model.JobStatusComplete, 1, model.ExportResult{
+		Location:    "s3://bucket/rudder-data-exports/1001/1001_1.zip",
+		EventCount:  10,
+		GeneratedAt: time.Date(2021, 12, 8, 10, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"status":"complete","export":{"location":"s3://bucket/rudder-data-exports/1001/1001_1.zip","eventCount":10,"generatedAt":"2021-12-08T10:00:00Z"}}`, string(body))
+}
@@ -3,11 +3,19 @@ package client
 type jobSchema struct {
 	JobID          string                 `json:"jobId"`
 	DestinationID  string                 `json:"destinationId"`
+	JobType        string                 `json:"jobType"`
 	UserAttributes []userAttributesSchema `json:"userAttributes"`
 }
 
 type statusJobSchema struct {
-	Status string `json:"status"`
+	Status string        `json:"status"`
+	Export *exportSchema `json:"export,omitempty"`
+}
+
+type exportSchema struct {
+	Location    string `json:"location"`
+	EventCount  int    `json:"eventCount"`
+	GeneratedAt string `json:"generatedAt"`
 }
 
 type userAttributesSchema struct {
@@ -31,55 +31,127 @@ import (
 
 var (
 	pkgLogger             = logger.NewLogger().Child("batch")
-	regexRequiredSuffix   = regexp.MustCompile(".json.gz$")
+	regexRequiredSuffix   = regexp.MustCompile(`(\.json\.gz|\.csv|\.csv\.gz|\.parquet)$`)
 	StatusTrackerFileName = "rudderDeleteTracker.txt"
-	supportedDestinations = []string{"S3"}
+	supportedDestinations = []string{"S3", "GCS", "AZURE_BLOB", "MINIO"}
 )
 
 const listMaxItem int64 = 1000
 
+//formats of the files which can be cleaned, identified by file suffix.
+const (
+	jsonGzFormat  = ".json.gz"
+	csvFormat     = ".csv"
+	csvGzFormat   = ".csv.gz"
+	parquetFormat = ".parquet"
+)
+
+//lower cased names of the columns holding user identifiers in csv & parquet files.
+var (
+	userIDColumns = []string{"userid", "user_id"}
+	emailColumns  = []string{"email", "context_traits_email"}
+	phoneColumns  = []string{"phone", "context_traits_phone"}
+)
+
 type deleteManager interface {
-	delete(ctx context.Context, patternFilePtr, targetFilePtr string) ([]byte, error)
+	delete(ctx context.Context, decompressedFile string) ([]byte, error)
 }
 
 type Batch struct {
-	mu         sync.Mutex
-	FM         filemanager.FileManager
-	DM         deleteManager
-	TmpDirPath string
+	mu          sync.Mutex
+	FM          filemanager.FileManager
+	TmpDirPath  string
+	patternFile string
+	identifiers userIdentifiers
+}
+
+//userIdentifiers maps a lower cased column name to the values of users to be deleted.
+type userIdentifiers map[string]map[string]struct{}
+
+//empty values are skipped, so that rows with empty identifier columns aren't deleted.
+func newUserIdentifiers(userAttributes []model.UserAttribute) userIdentifiers {
+	identifiers := make(userIdentifiers)
+	add := func(columns []string, value string) {
+		if value == "" {
+			return
+		}
+		for _, column := range columns {
+			if _, ok := identifiers[column]; !ok {
+				identifiers[column] = make(map[string]struct{})
+			}
+			identifiers[column][value] = struct{}{}
+		}
+	}
+	for _, users := range userAttributes {
+		add(userIDColumns, users.UserID)
+		if users.Email != nil {
+			add(emailColumns, *users.Email)
+		}
+		if users.Phone != nil {
+			add(phoneColumns, *users.Phone)
+		}
+	}
+	return identifiers
+}
+
+//has returns true if `column` holds user identifiers.
+func (identifiers userIdentifiers) has(column string) bool {
+	_, ok := identifiers[strings.ToLower(column)]
+	return ok
+}
+
+//matches returns true if `value` of `column` belongs to one of the users to be deleted.
+func (identifiers userIdentifiers) matches(column, value string) bool {
+	_, ok := identifiers[strings.ToLower(column)][value]
+	return ok
+}
+
+//returns the format of the file based on its suffix & empty string if the format isn't supported.
+func getFileFormat(fileName string) string {
+	for _, format := range []string{jsonGzFormat, csvGzFormat, csvFormat, parquetFormat} {
+		if strings.HasSuffix(fileName, format) {
+			return format
+		}
+	}
+	return ""
 }
 
-//return appropriate deleteManger based on destination Name
-func getDeleteManager(destName string) (*S3DeleteManager, error) {
-	switch destName {
-	case "S3":
-		return &S3DeleteManager{}, nil
+//return appropriate deleteManager based on the format of the file to be cleaned.
+//files of all the supported destinations are cleaned locally, after being downloaded using filemanager.
+func (b *Batch) getDeleteManager(fileName string) (deleteManager, error) {
+	switch getFileFormat(fileName) {
+	case jsonGzFormat:
+		return &JSONDeleteManager{patternFile: b.patternFile}, nil
+	case csvFormat, csvGzFormat:
+		return &CSVDeleteManager{identifiers: b.identifiers}, nil
+	case parquetFormat:
+		return &ParquetDeleteManager{identifiers: b.identifiers}, nil
 	default:
-		return nil, model.ErrDestNotImplemented
+		return nil, fmt.Errorf("file format of %s not supported", fileName)
 	}
 }
 
-//returns list of all .json.gz files.
+//returns list of all .json.gz, .csv, .csv.gz & .parquet files.
 //NOTE: assuming that all of batch destination have same file system as S3, i.e. flat.
 func (b *Batch) listFiles(ctx context.Context) ([]*filemanager.FileObject, error) {
 	pkgLogger.Debugf("getting a list of files from destination")
 	fileObjects, err := b.FM.ListFilesWithPrefix("", listMaxItem)
 	if err != nil {
 		pkgLogger.Errorf("error while getting list of files: %v", err)
-		return []*filemanager.FileObject{}, fmt.Errorf("failed to fetch object list from S3: %v", err)
+		return []*filemanager.FileObject{}, fmt.Errorf("failed to fetch object list from destination: %v", err)
 	}
 	if len(fileObjects) == 0 {
 		return nil, nil
 	}
 
-	//since everything is stored as a file in S3, above fileObjects list also has directory & not just *.json.gz files. So, need to remove those.
+	//since everything is stored as a file in S3, above fileObjects list also has directory & not just supported files. So, need to remove those.
 	count := 0
 	for i := 0; i < len(fileObjects); i++ {
 		if regexRequiredSuffix.Match([]byte(fileObjects[i].Key)) {
 			count++
 		}
 	}
-	//list of only .gz files
+	//list of only supported files
 	gzFileObjects := make([]*filemanager.FileObject, count)
 	index := 0
 	for i := 0; i < len(fileObjects); i++ {
@@ -105,7 +177,7 @@ func removeCleanedFiles(files []*filemanager.FileObject, cleanedFiles []string)
 	j := 0
 	presentCount := 0
 	present := make([]bool, len(files))
-	for j < len(cleanedFiles) {
+	for i < len(files) && j < len(cleanedFiles) {
 		if files[i].Key < cleanedFiles[j] {
 			i++
 		} else if files[i].Key > cleanedFiles[j] {
@@ -239,18 +311,33 @@ func (b *Batch) compress(fileName string, cleanedBytes []byte) error {
 }
 
 // delete users corresponding to `userAttributes` from `fileName` available locally
-func (b *Batch) delete(ctx context.Context, PatternFile, targetFile string) error {
-
-	decompressedFile, err := b.decompress(targetFile)
+func (b *Batch) delete(ctx context.Context, targetFile string) error {
+	dm, err := b.getDeleteManager(targetFile)
 	if err != nil {
-		return fmt.Errorf("error while decompressing file: %w", err)
+		return err
+	}
+
+	compressed := strings.HasSuffix(targetFile, ".gz")
+	decompressedFile := targetFile
+	if compressed {
+		decompressedFile, err = b.decompress(targetFile)
+		if err != nil {
+			return fmt.Errorf("error while decompressing file: %w", err)
+		}
 	}
 
-	out, err := b.DM.delete(ctx, PatternFile, decompressedFile)
+	out, err := dm.delete(ctx, decompressedFile)
 	if err != nil {
 		return fmt.Errorf("error while cleaning object, %w", err)
 	}
 
+	if !compressed {
+		err = os.WriteFile(targetFile, out, 0644)
+		if err != nil {
+			return fmt.Errorf("error while writing cleaned file: %w", err)
+		}
+		return nil
+	}
 	err = b.compress(targetFile, out)
 	if err != nil {
 		return fmt.Errorf("error while compressing file: %w", err)
@@ -307,7 +394,7 @@ func uploadWithExpBackoff(ctx context.Context, fu func(ctx context.Context, uplo
 	return nil
 }
 
-//replace old json.gz & statusTrackerFile with the new during upload.
+//replace old file & statusTrackerFile with the new during upload.
 //Note: upload happens concurrently in 5 go routine by default
 func (b *Batch) upload(ctx context.Context, uploadFileAbsPath, actualFileName, absStatusTrackerFileName string) error {
 	pkgLogger.Debugf("uploading file")
@@ -350,19 +437,21 @@ func (b *Batch) createPatternFile(userAttributes []model.UserAttribute) (string,
 	searchObject := make([]byte, 0)
 
 	for _, users := range userAttributes {
-		searchObject = append(searchObject, "/"...)
-		searchObject = append(searchObject, "\"userId\": *\""...)
-		searchObject = append(searchObject, users.UserID...)
-		searchObject = append(searchObject, "\"/d;"...)
+		if users.UserID != "" {
+			searchObject = append(searchObject, "/"...)
+			searchObject = append(searchObject, "\"userId\": *\""...)
+			searchObject = append(searchObject, users.UserID...)
+			searchObject = append(searchObject, "\"/d;"...)
+		}
 
-		if users.Email != nil {
+		if users.Email != nil && *users.Email != "" {
 			searchObject = append(searchObject, "/"...)
 			searchObject = append(searchObject, "\"email\": *\""...)
 			searchObject = append(searchObject, []byte(*users.Email)...)
 			searchObject = append(searchObject, "\"/d;"...)
 		}
 
-		if users.Phone != nil {
+		if users.Phone != nil && *users.Phone != "" {
 			searchObject = append(searchObject, "/"...)
 			searchObject = append(searchObject, "\"phone\": *\""...)
 			searchObject = append(searchObject, []byte(*users.Phone)...)
@@ -409,12 +498,6 @@ func (bm *BatchManager) Delete(ctx context.Context, job model.Job, destConfig ma
 		return model.JobStatusFailed
 	}
 
-	dm, err := getDeleteManager(destName)
-	if err != nil {
-		pkgLogger.Errorf("error while getting appropriate delete manager: %v", err)
-		return model.JobStatusFailed
-	}
-
 	//parent directory of all the temporary files created/downloaded in the process of deletion.
 	tmpDirPath, err := os.MkdirTemp("", "")
 	if err != nil {
@@ -423,14 +506,18 @@ func (bm *BatchManager) Delete(ctx context.Context, job model.Job, destConfig ma
 	}
 
 	batch := Batch{
-		FM:         fm,
-		DM:         dm,
-		TmpDirPath: tmpDirPath,
+		FM:          fm,
+		TmpDirPath:  tmpDirPath,
+		identifiers: newUserIdentifiers(job.UserAttributes),
 	}
-	defer batch.cleanup(destConfig["prefix"].(string))
+	defer batch.cleanup()
+
+	//statusTrackerFile is kept in the destination until the job completes,
+	//so that a retry of the same job after a failure or crash resumes from the files not yet cleaned.
+	prefix, _ := destConfig["prefix"].(string)
 
 	//file with pattern to be searched & deleted from all downloaded files.
-	absPatternFile, err := batch.createPatternFile(job.UserAttributes)
+	batch.patternFile, err = batch.createPatternFile(job.UserAttributes)
 	if err != nil {
 		pkgLogger.Errorf("error while creating pattern file: %v", err)
 		return model.JobStatusFailed
@@ -451,7 +538,7 @@ func (bm *BatchManager) Delete(ctx context.Context, job model.Job, destConfig ma
 		//since those files are already cleaned.
 		var cleanedFiles []string
 		absStatusTrackerFileName, err := func() (string, error) {
-			absStatusTrackerFileName, err := batch.download(ctx, filepath.Join(destConfig["prefix"].(string), StatusTrackerFileName))
+			absStatusTrackerFileName, err := batch.download(ctx, filepath.Join(prefix, StatusTrackerFileName))
 			if err != nil {
 				pkgLogger.Errorf("error while downloading statusTrackerFile: %v", err)
 				return "", fmt.Errorf("error while downloading statusTrackerFile: %w", err)
@@ -553,8 +640,7 @@ func (bm *BatchManager) Delete(ctx context.Context, job model.Job, destConfig ma
 				fileSizeStats := stats.NewTaggedStat("file_size_mb", stats.CountType, stats.Tags{"jobId": fmt.Sprintf("%d", job.ID)})
 				fileSizeStats.Count(getFileSize(FileAbsPath))
 
-				getFileSize(FileAbsPath)
-				err = batch.delete(gCtx, absPatternFile, FileAbsPath)
+				err = batch.delete(gCtx, FileAbsPath)
 				if err != nil {
 					pkgLogger.Errorf("error: %v, while deleting file:%v", err, files[_i].Key)
 					return fmt.Errorf("error: %w, while deleting file:%s", err, files[_i].Key)
@@ -575,6 +661,7 @@ func (bm *BatchManager) Delete(ctx context.Context, job model.Job, destConfig ma
 			return model.JobStatusFailed
 		}
 	}
+	batch.removeStatusTrackerFile(prefix)
 	return model.JobStatusComplete
 }
 
@@ -586,13 +673,18 @@ func getFileSize(fileAbsPath string) int {
 	return int(fileSize)
 }
 
-func (b *Batch) cleanup(prefix string) {
-	pkgLogger.Debugf("removing all temporary files & directory locally & from destination.")
+//removes statusTrackerFile from destination, once all the files are cleaned.
+func (b *Batch) removeStatusTrackerFile(prefix string) {
+	pkgLogger.Debugf("removing status tracker file from destination.")
 	err := b.FM.DeleteObjects([]string{filepath.Join(prefix, StatusTrackerFileName)})
 	if err != nil {
 		pkgLogger.Errorf("error while deleting delete status tracker file from destination: %v", err)
 	}
-	err = os.RemoveAll(b.TmpDirPath)
+}
+
+func (b *Batch) cleanup() {
+	pkgLogger.Debugf("removing all temporary files & directory locally.")
+	err := os.RemoveAll(b.TmpDirPath)
 	if err != nil {
 		pkgLogger.Errorf("error while deleting temporary directory locally: %v", err)
 	}
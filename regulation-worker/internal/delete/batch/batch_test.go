@@ -1,6 +1,7 @@
 package batch_test
 
 import (
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -139,6 +140,82 @@ func TestBatchDelete(t *testing.T) {
 	}
 }
 
+func TestBatchDeleteResume(t *testing.T) {
+	initialize.Init()
+
+	job := model.Job{
+		ID:            2,
+		WorkspaceID:   "1001",
+		DestinationID: "1234",
+		Status:        model.JobStatusPending,
+		UserAttributes: []model.UserAttribute{
+			{
+				UserID: "Jermaine1473336609491897794707338",
+				Phone:  strPtr("6463633841"),
+				Email:  strPtr("dorowane8n285680461479465450293436@gmail.com"),
+			},
+			{
+				UserID: "Mercie8221821544021583104106123",
+				Email:  strPtr("dshirilad8536019424659691213279980@gmail.com"),
+			},
+			{
+				UserID: "Claiborn443446989226249191822329",
+				Phone:  strPtr("8782905113"),
+			},
+		},
+	}
+	tests := []struct {
+		name          string
+		statusTracker string
+		skippedFiles  map[string]bool
+	}{
+		{
+			name:          "files already cleaned by the same job are skipped",
+			statusTracker: "2\n/original100.json.gz\n",
+			skippedFiles:  map[string]bool{"original100.json.gz": true},
+		},
+		{
+			name:          "files cleaned by some other job are cleaned again",
+			statusTracker: "1\n/original100.json.gz\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bm := batch.BatchManager{
+				FMFactory: statusTrackerFileManagerFactory{statusTracker: tt.statusTracker},
+			}
+			//destination without prefix, status tracker file is stored at the root of the bucket.
+			status := bm.Delete(context.Background(), job, map[string]interface{}{"bucketName": "regulation-test-data"}, "GCS")
+			require.Equal(t, model.JobStatusComplete, status)
+
+			for _, fileName := range []string{"original100.json.gz", filepath.Join("test", "original101.json.gz")} {
+				content := readGzipFile(t, filepath.Join(mockBucketLocation, fileName))
+				if tt.skippedFiles[fileName] {
+					require.Equal(t, readGzipFile(t, filepath.Join(mockBucket, fileName)), content, "already cleaned file shouldn't be cleaned again")
+					continue
+				}
+				for _, user := range job.UserAttributes {
+					require.NotContains(t, content, fmt.Sprintf(`"userId": "%s"`, user.UserID))
+				}
+			}
+			require.NoFileExists(t, filepath.Join(mockBucketLocation, batch.StatusTrackerFileName), "status tracker file should be removed once job completes")
+
+			require.NoError(t, os.RemoveAll(mockBucketLocation))
+		})
+	}
+}
+
+func readGzipFile(t *testing.T, fileName string) string {
+	filePtr, err := os.Open(fileName)
+	require.NoError(t, err)
+	defer filePtr.Close()
+	gzipReader, err := gzip.NewReader(filePtr)
+	require.NoError(t, err)
+	content, err := io.ReadAll(gzipReader)
+	require.NoError(t, err)
+	return string(content)
+}
+
 func strPtr(str string) *string {
 	return &(str)
 }
@@ -167,6 +244,23 @@ func (ff mockFileManagerFactory) New(settings *filemanager.SettingsT) (filemanag
 	}, nil
 }
 
+//creates a mockBucket with a status tracker file, as left behind by a previous run of a job.
+type statusTrackerFileManagerFactory struct {
+	statusTracker string
+}
+
+func (ff statusTrackerFileManagerFactory) New(settings *filemanager.SettingsT) (filemanager.FileManager, error) {
+	fm, err := mockFileManagerFactory{}.New(settings)
+	if err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(filepath.Join(mockBucketLocation, batch.StatusTrackerFileName), []byte(ff.statusTracker), 0644)
+	if err != nil {
+		return nil, err
+	}
+	return fm, nil
+}
+
 type mockFileManager struct {
 	mockBucketLocation string
 	listCalled         bool
@@ -0,0 +1,84 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+)
+
+// CSVDeleteManager cleans csv files having a header row, by dropping the rows with any of the user identifiers.
+type CSVDeleteManager struct {
+	identifiers userIdentifiers
+}
+
+// Delete rows of users corresponding to `identifiers` from `decompressedFile` & return the cleaned content
+func (dm *CSVDeleteManager) delete(ctx context.Context, decompressedFile string) ([]byte, error) {
+	pkgLogger.Debugf("deleting users from csv file: %v", decompressedFile)
+
+	filePtr, err := os.Open(decompressedFile)
+	if err != nil {
+		return nil, fmt.Errorf("error while opening file, %w", err)
+	}
+	defer filePtr.Close()
+
+	csvReader := csv.NewReader(filePtr)
+	csvReader.FieldsPerRecord = -1
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return []byte{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error while reading csv header: %w", err)
+	}
+
+	//indices of the columns holding user identifiers.
+	identifierIdx := make([]int, 0)
+	for idx, column := range header {
+		if dm.identifiers.has(column) {
+			identifierIdx = append(identifierIdx, idx)
+		}
+	}
+
+	var buffer bytes.Buffer
+	csvWriter := csv.NewWriter(&buffer)
+	if err = csvWriter.Write(header); err != nil {
+		return nil, fmt.Errorf("error while writing csv header: %w", err)
+	}
+	for {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error while reading csv record: %w", err)
+		}
+		if dm.matches(header, identifierIdx, record) {
+			continue
+		}
+		if err = csvWriter.Write(record); err != nil {
+			return nil, fmt.Errorf("error while writing csv record: %w", err)
+		}
+	}
+	csvWriter.Flush()
+	if err = csvWriter.Error(); err != nil {
+		return nil, fmt.Errorf("error while writing cleaned csv file: %w", err)
+	}
+
+	pkgLogger.Debugf("deletion successful from file: %v", decompressedFile)
+	return buffer.Bytes(), nil
+}
+
+func (dm *CSVDeleteManager) matches(header []string, identifierIdx []int, record []string) bool {
+	for _, idx := range identifierIdx {
+		if idx < len(record) && dm.identifiers.matches(header[idx], record[idx]) {
+			return true
+		}
+	}
+	return false
+}
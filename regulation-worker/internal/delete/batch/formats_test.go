@@ -0,0 +1,145 @@
+package batch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/initialize"
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/model"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+type parquetRow struct {
+	UserID *string `parquet:"name=user_id, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Email  *string `parquet:"name=context_traits_email, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Event  string  `parquet:"name=event, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Count  int64   `parquet:"name=count, type=INT64"`
+}
+
+func strPtr(str string) *string {
+	return &str
+}
+
+var testIdentifiers = newUserIdentifiers([]model.UserAttribute{
+	{UserID: "alice", Email: strPtr("alice@example.com")},
+	{UserID: "bob", Phone: strPtr("6463633841")},
+})
+
+func TestCSVDelete(t *testing.T) {
+	initialize.Init()
+
+	fileName := filepath.Join(t.TempDir(), "events.csv")
+	content := "id,userId,Email,phone\n" +
+		"1,alice,,\n" +
+		"2,carol,alice@example.com,\n" +
+		"3,carol,carol@example.com,6463633841\n" +
+		"4,\"dave, jr\",dave@example.com,\n"
+	require.NoError(t, os.WriteFile(fileName, []byte(content), 0644))
+
+	dm := CSVDeleteManager{identifiers: testIdentifiers}
+	out, err := dm.delete(context.Background(), fileName)
+	require.NoError(t, err)
+	require.Equal(t, "id,userId,Email,phone\n4,\"dave, jr\",dave@example.com,\n", string(out))
+}
+
+func TestCSVDeleteSkipsEmptyIdentifiers(t *testing.T) {
+	initialize.Init()
+
+	fileName := filepath.Join(t.TempDir(), "events.csv")
+	content := "id,user_id,email,phone\n" +
+		"1,,,\n" +
+		"2,carol,,\n" +
+		"3,,alice@example.com,\n"
+	require.NoError(t, os.WriteFile(fileName, []byte(content), 0644))
+
+	identifiers := newUserIdentifiers([]model.UserAttribute{
+		{UserID: "", Email: strPtr("alice@example.com"), Phone: strPtr("")},
+		{UserID: "bob", Email: strPtr("")},
+	})
+	dm := CSVDeleteManager{identifiers: identifiers}
+	out, err := dm.delete(context.Background(), fileName)
+	require.NoError(t, err)
+	require.Equal(t, "id,user_id,email,phone\n1,,,\n2,carol,,\n", string(out))
+}
+
+func TestPatternFileSkipsEmptyIdentifiers(t *testing.T) {
+	b := Batch{TmpDirPath: t.TempDir()}
+	patternFile, err := b.createPatternFile([]model.UserAttribute{
+		{UserID: "", Email: strPtr("alice@example.com"), Phone: strPtr("")},
+	})
+	require.NoError(t, err)
+	pattern, err := os.ReadFile(patternFile)
+	require.NoError(t, err)
+	require.Equal(t, `/"email": *"alice@example.com"/d;`, string(pattern))
+}
+
+func TestCSVDeleteEmptyFile(t *testing.T) {
+	initialize.Init()
+
+	fileName := filepath.Join(t.TempDir(), "events.csv")
+	require.NoError(t, os.WriteFile(fileName, []byte{}, 0644))
+
+	dm := CSVDeleteManager{identifiers: testIdentifiers}
+	out, err := dm.delete(context.Background(), fileName)
+	require.NoError(t, err)
+	require.Empty(t, out)
+}
+
+func TestParquetDelete(t *testing.T) {
+	initialize.Init()
+
+	tmpDir := t.TempDir()
+	fileName := filepath.Join(tmpDir, "events.parquet")
+	filePtr, err := os.Create(fileName)
+	require.NoError(t, err)
+	pWriter, err := writer.NewParquetWriterFromWriter(filePtr, new(parquetRow), 1)
+	require.NoError(t, err)
+	pWriter.CompressionType = parquet.CompressionCodec_GZIP
+	rows := []parquetRow{
+		{UserID: strPtr("alice"), Event: "signup", Count: 1},
+		{UserID: strPtr("carol"), Email: strPtr("alice@example.com"), Event: "login", Count: 2},
+		{UserID: strPtr("carol"), Email: strPtr("carol@example.com"), Event: "login", Count: 3},
+		{Event: "anonymous", Count: 4},
+		{UserID: strPtr("bob"), Event: "logout", Count: 5},
+	}
+	for _, row := range rows {
+		require.NoError(t, pWriter.Write(row))
+	}
+	require.NoError(t, pWriter.WriteStop())
+	require.NoError(t, filePtr.Close())
+
+	dm := ParquetDeleteManager{identifiers: testIdentifiers}
+	out, err := dm.delete(context.Background(), fileName)
+	require.NoError(t, err)
+
+	cleanedFileName := filepath.Join(tmpDir, "cleaned.parquet")
+	require.NoError(t, os.WriteFile(cleanedFileName, out, 0644))
+	pFile, err := (&localParquetFile{}).Open(cleanedFileName)
+	require.NoError(t, err)
+	defer pFile.Close()
+	pReader, err := reader.NewParquetReader(pFile, new(parquetRow), 1)
+	require.NoError(t, err)
+	defer pReader.ReadStop()
+
+	require.Equal(t, parquet.CompressionCodec_GZIP, pReader.Footer.GetRowGroups()[0].GetColumns()[0].GetMetaData().GetCodec())
+	cleanedRows := make([]parquetRow, pReader.GetNumRows())
+	require.NoError(t, pReader.Read(&cleanedRows))
+	require.Equal(t, []parquetRow{
+		{UserID: strPtr("carol"), Email: strPtr("carol@example.com"), Event: "login", Count: 3},
+		{Event: "anonymous", Count: 4},
+	}, cleanedRows)
+}
+
+func TestGetFileFormat(t *testing.T) {
+	require.Equal(t, jsonGzFormat, getFileFormat("a/b/file.json.gz"))
+	require.Equal(t, csvFormat, getFileFormat("a/b/file.csv"))
+	require.Equal(t, csvGzFormat, getFileFormat("a/b/file.csv.gz"))
+	require.Equal(t, parquetFormat, getFileFormat("a/b/file.parquet"))
+	require.Equal(t, "", getFileFormat("a/b/file.json"))
+	require.Equal(t, "", getFileFormat(StatusTrackerFileName))
+}
@@ -0,0 +1,27 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+// JSONDeleteManager cleans newline delimited json files, which is the default format for batch destinations.
+type JSONDeleteManager struct {
+	patternFile string
+}
+
+// reason behind using sed: https://www.rtuin.nl/2012/01/fast-search-and-replace-in-large-files-with-sed/
+// Delete user details matching the patterns in `patternFile` from `decompressedFile` & return the cleaned content
+func (dm *JSONDeleteManager) delete(ctx context.Context, decompressedFile string) ([]byte, error) {
+	pkgLogger.Debugf("deleting pattern in file: %v from decompressed file: %v using sed command", dm.patternFile, decompressedFile)
+	//actual delete
+	out, err := exec.CommandContext(ctx, "sed", "-f", dm.patternFile, decompressedFile).Output()
+	if err != nil {
+		pkgLogger.Errorf("error while executing sed command: %v", err)
+		return nil, fmt.Errorf("error while running sed command: %s", err)
+	}
+
+	pkgLogger.Debugf("deletion successful from file: %v", decompressedFile)
+	return out, nil
+}
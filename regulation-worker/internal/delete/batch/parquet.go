@@ -0,0 +1,163 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+const (
+	parquetParallelism   int64 = 4
+	parquetReadBatchSize       = 1000
+)
+
+// ParquetDeleteManager cleans parquet files, by dropping the rows with any of the user identifiers in top level columns.
+type ParquetDeleteManager struct {
+	identifiers userIdentifiers
+}
+
+// Delete rows of users corresponding to `identifiers` from `fileName` & return the cleaned content.
+// The cleaned file keeps the schema & compression codec of the original one.
+func (dm *ParquetDeleteManager) delete(ctx context.Context, fileName string) ([]byte, error) {
+	pkgLogger.Debugf("deleting users from parquet file: %v", fileName)
+
+	pFile, err := (&localParquetFile{}).Open(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("error while opening file, %w", err)
+	}
+	defer pFile.Close()
+
+	pReader, err := reader.NewParquetReader(pFile, nil, parquetParallelism)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading parquet file: %w", err)
+	}
+	defer pReader.ReadStop()
+
+	//reader renames the columns to go field names, so restore the original column names for the cleaned file.
+	schemaHandler := pReader.SchemaHandler
+	schemaElements := make([]*parquet.SchemaElement, len(schemaHandler.SchemaElements))
+	//go field name of top level columns holding user identifiers -> column name
+	identifierFields := make(map[string]string)
+	for idx, element := range schemaHandler.SchemaElements {
+		elementCopy := *element
+		elementCopy.Name = schemaHandler.Infos[idx].ExName
+		schemaElements[idx] = &elementCopy
+
+		path := common.StrToPath(schemaHandler.IndexMap[int32(idx)])
+		if len(path) == 2 && element.GetNumChildren() == 0 && dm.identifiers.has(elementCopy.Name) {
+			identifierFields[schemaHandler.Infos[idx].InName] = elementCopy.Name
+		}
+	}
+
+	var buffer bytes.Buffer
+	pWriter, err := writer.NewParquetWriterFromWriter(&buffer, schemaElements, parquetParallelism)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating parquet writer: %w", err)
+	}
+	if rowGroups := pReader.Footer.GetRowGroups(); len(rowGroups) > 0 && len(rowGroups[0].GetColumns()) > 0 {
+		pWriter.CompressionType = rowGroups[0].GetColumns()[0].GetMetaData().GetCodec()
+	}
+
+	for remaining := int(pReader.GetNumRows()); remaining > 0; remaining -= parquetReadBatchSize {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		batchSize := parquetReadBatchSize
+		if remaining < batchSize {
+			batchSize = remaining
+		}
+		rows, err := pReader.ReadByNumber(batchSize)
+		if err != nil {
+			return nil, fmt.Errorf("error while reading parquet rows: %w", err)
+		}
+		for _, row := range rows {
+			if dm.matches(identifierFields, row) {
+				continue
+			}
+			if err = pWriter.Write(row); err != nil {
+				return nil, fmt.Errorf("error while writing parquet row: %w", err)
+			}
+		}
+	}
+	if err = pWriter.WriteStop(); err != nil {
+		return nil, fmt.Errorf("error while writing cleaned parquet file: %w", err)
+	}
+
+	pkgLogger.Debugf("deletion successful from file: %v", fileName)
+	return buffer.Bytes(), nil
+}
+
+func (dm *ParquetDeleteManager) matches(identifierFields map[string]string, row interface{}) bool {
+	rowValue := reflect.ValueOf(row)
+	for fieldName, column := range identifierFields {
+		field := rowValue.FieldByName(fieldName)
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		}
+		if field.IsValid() && dm.identifiers.matches(column, fmt.Sprint(field.Interface())) {
+			return true
+		}
+	}
+	return false
+}
+
+// localParquetFile implements source.ParquetFile over a local file, as parquet reader opens a handle per column.
+type localParquetFile struct {
+	filePath string
+	file     *os.File
+}
+
+func (f *localParquetFile) Open(name string) (source.ParquetFile, error) {
+	if name == "" {
+		name = f.filePath
+	}
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &localParquetFile{filePath: name, file: file}, nil
+}
+
+func (f *localParquetFile) Create(name string) (source.ParquetFile, error) {
+	file, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	return &localParquetFile{filePath: name, file: file}, nil
+}
+
+func (f *localParquetFile) Seek(offset int64, whence int) (int64, error) {
+	return f.file.Seek(offset, whence)
+}
+
+// Read fills `b` completely unless the end of file is reached, as expected by the thrift reader.
+func (f *localParquetFile) Read(b []byte) (int, error) {
+	count := 0
+	for count < len(b) {
+		n, err := f.file.Read(b[count:])
+		count += n
+		if err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+func (f *localParquetFile) Write(b []byte) (int, error) {
+	return f.file.Write(b)
+}
+
+func (f *localParquetFile) Close() error {
+	return f.file.Close()
+}
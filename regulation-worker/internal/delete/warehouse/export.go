@@ -0,0 +1,87 @@
+package warehouse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/model"
+	"github.com/rudderlabs/rudder-server/warehouse/manager"
+	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+// IsSupported returns true if users can be deleted from & exported out of the destination
+func (wm *WarehouseManager) IsSupported(destName string) bool {
+	for _, d := range supportedDestinations {
+		if d == destName {
+			return true
+		}
+	}
+	return false
+}
+
+// Export finds rows of the users of job in event tables of the warehouse
+// and calls write with the table identifier & row, as a map of column to value, for each of them.
+func (wm *WarehouseManager) Export(ctx context.Context, job model.Job, destDetail model.Destination, write func(table string, row map[string]string) error) error {
+	destName := destDetail.Name
+	userIDs, emails := getUserIdentifiers(job.UserAttributes)
+	if len(userIDs) == 0 && len(emails) == 0 {
+		return nil
+	}
+
+	whManager, err := manager.New(destName)
+	if err != nil {
+		return fmt.Errorf("failed to get warehouse manager for destination: %v with error: %w", destName, err)
+	}
+	warehouse := warehouseutils.WarehouseT{
+		Destination: backendconfig.DestinationT{
+			ID:     destDetail.DestinationID,
+			Config: destDetail.Config,
+		},
+		Namespace: getConfiguredNamespace(destName, destDetail.Config),
+		Type:      destName,
+	}
+	dbClient, err := whManager.Connect(warehouse)
+	if err != nil {
+		return fmt.Errorf("failed to connect to warehouse: %v with error: %w", destName, err)
+	}
+	defer dbClient.Close()
+
+	tables, err := getTables(dbClient, destName, destDetail.Config, warehouse.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get tables in warehouse: %v with error: %w", destName, err)
+	}
+
+	for _, table := range tables {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		result, err := dbClient.Query(selectStatement(destName, table, userIDs, emails))
+		if err != nil {
+			return fmt.Errorf("failed to export users from table: %v with error: %w", table.identifier(), err)
+		}
+		for _, values := range result.Values {
+			row := make(map[string]string, len(values))
+			for idx, value := range values {
+				column := fmt.Sprintf("%d", idx)
+				if idx < len(result.Columns) {
+					column = result.Columns[idx]
+				}
+				row[column] = value
+			}
+			if err = write(fmt.Sprintf(`%s/%s`, destDetail.DestinationID, table.identifier()), row); err != nil {
+				return err
+			}
+		}
+		pkgLogger.Infof("exported %d rows of users of job: %d from table: %s", len(result.Values), job.ID, table.identifier())
+	}
+	return nil
+}
+
+// selectStatement returns the statement selecting rows of all the users from table
+func selectStatement(destName string, table tableT, userIDs, emails []string) string {
+	tableName := fmt.Sprintf(`%s.%s`, quoteIdentifier(destName, table.namespace), quoteIdentifier(destName, table.name))
+	conditions := userConditions(destName, table, userIDs, emails, 0)
+	return fmt.Sprintf(`SELECT * FROM %s WHERE %s`, tableName, strings.Join(conditions, " OR "))
+}
@@ -0,0 +1,331 @@
+package warehouse
+
+//This is going to delete user rows from event tables in sql warehouses.
+//Tables are discovered using the warehouse's information schema and rows are deleted in batches of users,
+//matching user_id/anonymous_id against user ids and email/context_traits_email against emails.
+//Deletes are idempotent, deleting again from a table only finds no rows of the users,
+//so a job restarted on another worker or after a restart safely deletes from all the tables again.
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/rudderlabs/rudder-server/config"
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/model"
+	"github.com/rudderlabs/rudder-server/services/stats"
+	"github.com/rudderlabs/rudder-server/utils/logger"
+	"github.com/rudderlabs/rudder-server/utils/misc"
+	"github.com/rudderlabs/rudder-server/warehouse/client"
+	"github.com/rudderlabs/rudder-server/warehouse/manager"
+	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+var (
+	pkgLogger             = logger.NewLogger().Child("warehouse")
+	supportedDestinations = []string{"POSTGRES", "RS", "SNOWFLAKE", "BQ", "CLICKHOUSE"}
+	// user ids are matched against user_id and anonymous_id, emails against email and context_traits_email
+	userIDColumns = []string{"user_id", "anonymous_id"}
+	emailColumns  = []string{"email", "context_traits_email"}
+	// namespaces are considered to be synced by rudder if they have any of these tables
+	// so that tables with similar columns in other schemas of the warehouse are left untouched
+	rudderTables = []string{"tracks", "identifies"}
+)
+
+type WarehouseManager struct {
+	mu sync.Mutex
+	// progress holds tables from which users of a job have been deleted
+	// so that a job retried by this worker does not delete again from the same tables
+	// it is kept in memory only, as deleting again from a table is harmless
+	progress map[int]map[string]bool
+}
+
+// tableT is an event table along with the columns using which users are deleted from it
+type tableT struct {
+	namespace     string
+	name          string
+	userIDColumns []string
+	emailColumns  []string
+}
+
+func (t tableT) identifier() string {
+	return fmt.Sprintf(`%s.%s`, t.namespace, t.name)
+}
+
+func (wm *WarehouseManager) GetSupportedDestinations() []string {
+	return supportedDestinations
+}
+
+func (wm *WarehouseManager) Delete(ctx context.Context, job model.Job, destConfig map[string]interface{}, destName string) model.JobStatus {
+	pkgLogger.Debugf("deleting job: %v from warehouse: %v", job, destName)
+	deletionTime := stats.NewTaggedStat("warehouse_deletion_time", stats.TimerType, stats.Tags{"jobId": fmt.Sprintf("%d", job.ID), "workspaceId": job.WorkspaceID, "destType": "warehouse", "destName": destName})
+	deletionTime.Start()
+	defer deletionTime.End()
+
+	userIDs, emails := getUserIdentifiers(job.UserAttributes)
+	if len(userIDs) == 0 && len(emails) == 0 {
+		return model.JobStatusComplete
+	}
+
+	whManager, err := manager.New(destName)
+	if err != nil {
+		pkgLogger.Errorf("failed to get warehouse manager for destination: %v with error: %v", destName, err)
+		return model.JobStatusFailed
+	}
+	warehouse := warehouseutils.WarehouseT{
+		Destination: backendconfig.DestinationT{
+			ID:     job.DestinationID,
+			Config: destConfig,
+		},
+		Namespace: getConfiguredNamespace(destName, destConfig),
+		Type:      destName,
+	}
+	dbClient, err := whManager.Connect(warehouse)
+	if err != nil {
+		pkgLogger.Errorf("failed to connect to warehouse: %v with error: %v", destName, err)
+		return model.JobStatusFailed
+	}
+	defer dbClient.Close()
+
+	tables, err := getTables(dbClient, destName, destConfig, warehouse.Namespace)
+	if err != nil {
+		pkgLogger.Errorf("failed to get tables in warehouse: %v with error: %v", destName, err)
+		return model.JobStatusFailed
+	}
+
+	batchSize := config.GetInt("RegulationWorker.warehouse.deleteBatchSize", 1000)
+	for idx, table := range tables {
+		if ctx.Err() != nil {
+			return model.JobStatusFailed
+		}
+		if wm.isTableDone(job.ID, table.identifier()) {
+			continue
+		}
+		for _, stmt := range deleteStatements(destName, destConfig, table, userIDs, emails, batchSize) {
+			err = execute(ctx, dbClient, stmt)
+			if err != nil {
+				pkgLogger.Errorf("failed to delete users from table: %v with error: %v", table.identifier(), err)
+				return model.JobStatusFailed
+			}
+		}
+		wm.markTableDone(job.ID, table.identifier())
+		pkgLogger.Infof("deleted users of job: %d from table: %s (%d/%d tables)", job.ID, table.identifier(), idx+1, len(tables))
+	}
+
+	wm.clearProgress(job.ID)
+	pkgLogger.Debugf("deletion successful")
+	return model.JobStatusComplete
+}
+
+func (wm *WarehouseManager) isTableDone(jobID int, table string) bool {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+	return wm.progress[jobID][table]
+}
+
+func (wm *WarehouseManager) markTableDone(jobID int, table string) {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+	if wm.progress == nil {
+		wm.progress = map[int]map[string]bool{}
+	}
+	if wm.progress[jobID] == nil {
+		wm.progress[jobID] = map[string]bool{}
+	}
+	wm.progress[jobID][table] = true
+}
+
+func (wm *WarehouseManager) clearProgress(jobID int) {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+	delete(wm.progress, jobID)
+}
+
+func execute(ctx context.Context, dbClient client.Client, stmt string) error {
+	if dbClient.Type == client.SQLClient {
+		_, err := dbClient.SQL.ExecContext(ctx, stmt)
+		return err
+	}
+	_, err := dbClient.Query(stmt)
+	return err
+}
+
+func getUserIdentifiers(userAttributes []model.UserAttribute) (userIDs, emails []string) {
+	for _, user := range userAttributes {
+		if user.UserID != "" {
+			userIDs = append(userIDs, user.UserID)
+		}
+		if user.Email != nil && *user.Email != "" {
+			emails = append(emails, *user.Email)
+		}
+	}
+	return
+}
+
+// getConfiguredNamespace returns the namespace set in destination config
+// if empty, all namespaces synced by rudder in the warehouse are considered
+func getConfiguredNamespace(destName string, destConfig map[string]interface{}) string {
+	// clickhouse loads into the configured database
+	if destName == "CLICKHOUSE" {
+		database, _ := destConfig["database"].(string)
+		return database
+	}
+	namespace, _ := destConfig["namespace"].(string)
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" {
+		return ""
+	}
+	return warehouseutils.ToProviderCase(destName, warehouseutils.ToSafeNamespace(destName, namespace))
+}
+
+// columnsQuery returns the query listing namespace, table and column for columns used to delete users
+func columnsQuery(destName string, destConfig map[string]interface{}, namespace string) string {
+	var columns []string
+	for _, column := range append(append([]string{"id"}, userIDColumns...), emailColumns...) {
+		columns = append(columns, fmt.Sprintf(`'%s'`, column))
+	}
+	columnsFilter := strings.Join(columns, ", ")
+
+	switch destName {
+	case "CLICKHOUSE":
+		return fmt.Sprintf(`SELECT c.database, c.table, c.name FROM system.columns c JOIN system.tables t ON c.database = t.database AND c.table = t.name WHERE c.database = '%s' AND t.engine NOT LIKE '%%View' AND t.engine != 'Distributed' AND lower(c.name) IN (%s)`, namespace, columnsFilter)
+	case "BQ":
+		schemaPrefix := fmt.Sprintf("`%s`", namespace)
+		if namespace == "" {
+			location, _ := destConfig["location"].(string)
+			if location == "" {
+				location = "US"
+			}
+			schemaPrefix = fmt.Sprintf("`region-%s`", strings.ToLower(location))
+		}
+		return fmt.Sprintf(`SELECT c.table_schema, c.table_name, c.column_name FROM %[1]s.INFORMATION_SCHEMA.COLUMNS c JOIN %[1]s.INFORMATION_SCHEMA.TABLES t ON c.table_schema = t.table_schema AND c.table_name = t.table_name WHERE t.table_type = 'BASE TABLE' AND lower(c.column_name) IN (%[2]s)`, schemaPrefix, columnsFilter)
+	default:
+		var namespaceFilter string
+		if namespace != "" {
+			namespaceFilter = fmt.Sprintf(`AND c.table_schema = '%s'`, namespace)
+		}
+		return fmt.Sprintf(`SELECT c.table_schema, c.table_name, c.column_name FROM information_schema.columns c JOIN information_schema.tables t ON c.table_schema = t.table_schema AND c.table_name = t.table_name WHERE t.table_type = 'BASE TABLE' AND c.table_schema NOT IN ('information_schema', 'pg_catalog') AND lower(c.column_name) IN (%s) %s`, columnsFilter, namespaceFilter)
+	}
+}
+
+// getTables returns tables in namespaces synced by rudder that have columns to delete users by
+func getTables(dbClient client.Client, destName string, destConfig map[string]interface{}, namespace string) ([]tableT, error) {
+	result, err := dbClient.Query(columnsQuery(destName, destConfig, namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	tablesMap := map[string]*tableT{}
+	var tableIdentifiers []string
+	rudderNamespaces := map[string]bool{}
+	for _, row := range result.Values {
+		if len(row) != 3 {
+			continue
+		}
+		table := tableT{namespace: row[0], name: row[1]}
+		column := strings.ToLower(row[2])
+		if misc.ContainsString(rudderTables, strings.ToLower(table.name)) {
+			rudderNamespaces[table.namespace] = true
+		}
+		if _, ok := tablesMap[table.identifier()]; !ok {
+			tablesMap[table.identifier()] = &table
+			tableIdentifiers = append(tableIdentifiers, table.identifier())
+		}
+		t := tablesMap[table.identifier()]
+		switch {
+		// users table has user id in id column
+		case column == "id" && strings.EqualFold(t.name, warehouseutils.UsersTable):
+			t.userIDColumns = append(t.userIDColumns, row[2])
+		case misc.ContainsString(userIDColumns, column):
+			t.userIDColumns = append(t.userIDColumns, row[2])
+		case misc.ContainsString(emailColumns, column):
+			t.emailColumns = append(t.emailColumns, row[2])
+		}
+	}
+
+	var tables []tableT
+	for _, identifier := range tableIdentifiers {
+		table := tablesMap[identifier]
+		if (namespace == "" && !rudderNamespaces[table.namespace]) || (len(table.userIDColumns) == 0 && len(table.emailColumns) == 0) {
+			continue
+		}
+		tables = append(tables, *table)
+	}
+	return tables, nil
+}
+
+// deleteStatements returns statements deleting the users from table in batches of batchSize users
+func deleteStatements(destName string, destConfig map[string]interface{}, table tableT, userIDs, emails []string, batchSize int) []string {
+	tableName := fmt.Sprintf(`%s.%s`, quoteIdentifier(destName, table.namespace), quoteIdentifier(destName, table.name))
+	var stmts []string
+	for _, condition := range userConditions(destName, table, userIDs, emails, batchSize) {
+		switch destName {
+		case "CLICKHOUSE":
+			var clusterClause string
+			if cluster, _ := destConfig["cluster"].(string); strings.TrimSpace(cluster) != "" {
+				clusterClause = fmt.Sprintf(`ON CLUSTER "%s"`, cluster)
+			}
+			stmts = append(stmts, fmt.Sprintf(`ALTER TABLE %s %s DELETE WHERE %s`, tableName, clusterClause, condition))
+		default:
+			stmts = append(stmts, fmt.Sprintf(`DELETE FROM %s WHERE %s`, tableName, condition))
+		}
+	}
+	return stmts
+}
+
+// userConditions returns conditions matching rows of the users in table, each for a batch of batchSize users
+func userConditions(destName string, table tableT, userIDs, emails []string, batchSize int) []string {
+	if batchSize <= 0 {
+		batchSize = len(userIDs) + len(emails)
+	}
+	var conditions []string
+	for _, batch := range chunk(userIDs, batchSize) {
+		values := quoteLiterals(destName, batch)
+		for _, column := range table.userIDColumns {
+			conditions = append(conditions, fmt.Sprintf(`%s IN (%s)`, quoteIdentifier(destName, column), values))
+		}
+	}
+	for _, batch := range chunk(emails, batchSize) {
+		values := quoteLiterals(destName, batch)
+		for _, column := range table.emailColumns {
+			conditions = append(conditions, fmt.Sprintf(`%s IN (%s)`, quoteIdentifier(destName, column), values))
+		}
+	}
+	return conditions
+}
+
+func quoteIdentifier(destName, identifier string) string {
+	if destName == "BQ" {
+		return fmt.Sprintf("`%s`", identifier)
+	}
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(identifier, `"`, `""`))
+}
+
+func quoteLiterals(destName string, values []string) string {
+	quoted := make([]string, len(values))
+	for idx, val := range values {
+		switch destName {
+		case "POSTGRES":
+			quoted[idx] = misc.QuoteLiteral(val)
+		case "BQ", "CLICKHOUSE":
+			val = strings.ReplaceAll(val, `\`, `\\`)
+			quoted[idx] = fmt.Sprintf(`'%s'`, strings.ReplaceAll(val, `'`, `\'`))
+		default:
+			// redshift and snowflake treat backslash as escape character in string literals
+			val = strings.ReplaceAll(val, `\`, `\\`)
+			quoted[idx] = fmt.Sprintf(`'%s'`, strings.ReplaceAll(val, `'`, `''`))
+		}
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func chunk(values []string, size int) (chunks [][]string) {
+	for size < len(values) {
+		values, chunks = values[size:], append(chunks, values[:size])
+	}
+	if len(values) > 0 {
+		chunks = append(chunks, values)
+	}
+	return
+}
@@ -66,6 +66,49 @@ func (d *DestMiddleware) GetDestDetails(ctx context.Context, destID string) (mod
 	return destDetail, nil
 }
 
+//GetDestinations returns details of all the destinations of the workspace, each destination listed once
+//even if it is connected to multiple sources.
+func (d *DestMiddleware) GetDestinations(ctx context.Context) ([]model.Destination, error) {
+	config, err := d.getDestDetails(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var destinations []model.Destination
+	seen := make(map[string]bool)
+	for _, source := range config.Sources {
+		for _, dest := range source.Destinations {
+			if seen[dest.ID] {
+				continue
+			}
+			seen[dest.ID] = true
+			destinations = append(destinations, model.Destination{
+				Config:        dest.Config,
+				DestinationID: dest.ID,
+				Name:          dest.DestinationDefinition.Name,
+			})
+		}
+	}
+	return destinations, nil
+}
+
+//GetSourceIDs returns ids of all the sources of the workspace, failing if backend config is of another workspace.
+func (d *DestMiddleware) GetSourceIDs(ctx context.Context, workspaceID string) ([]string, error) {
+	config, err := d.getDestDetails(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if config.WorkspaceID != workspaceID {
+		return nil, fmt.Errorf("backend config is of workspace: %s, not of workspace: %s", config.WorkspaceID, workspaceID)
+	}
+
+	sourceIDs := make([]string, 0, len(config.Sources))
+	for _, source := range config.Sources {
+		sourceIDs = append(sourceIDs, source.ID)
+	}
+	return sourceIDs, nil
+}
+
 func (d *DestMiddleware) getDestDetails(ctx context.Context) (backendconfig.ConfigT, error) {
 	pkgLogger.Debugf("getting destination details with exponential backoff")
 
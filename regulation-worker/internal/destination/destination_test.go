@@ -84,3 +84,32 @@ func TestGetDestDetails(t *testing.T) {
 	require.NoError(t, err, "expected no err")
 	require.Equal(t, expDest, destDetail, "actual dest detail different than expected")
 }
+
+func TestGetSourceIDs(t *testing.T) {
+	initialize.Init()
+	ctx := context.Background()
+	testConfig := backendconfig.ConfigT{
+		WorkspaceID: "1234",
+		Sources: []backendconfig.SourceT{
+			{ID: "src-1"},
+			{ID: "src-2"},
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDestMiddleware := destination.NewMockdestinationMiddleware(mockCtrl)
+	mockDestMiddleware.EXPECT().Get().Return(testConfig, true).Times(2)
+
+	dest := destination.DestMiddleware{
+		Dest: mockDestMiddleware,
+	}
+
+	sourceIDs, err := dest.GetSourceIDs(ctx, "1234")
+	require.NoError(t, err)
+	require.Equal(t, []string{"src-1", "src-2"}, sourceIDs)
+
+	_, err = dest.GetSourceIDs(ctx, "5678")
+	require.Error(t, err, "expected err for sources of another workspace")
+}
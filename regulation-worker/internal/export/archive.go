@@ -0,0 +1,70 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"os"
+)
+
+// archiveT is a zip archive of json lines files, one for each place events are collected from.
+// Records are kept in memory till the archive is closed, as a zip entry can't be written to once the next one is created,
+// which is fine for events of the few users of a job.
+type archiveT struct {
+	file    *os.File
+	writer  *zip.Writer
+	entries map[string][][]byte
+	order   []string
+	count   int
+	closed  bool
+}
+
+func newArchive(path string) (*archiveT, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &archiveT{
+		file:    file,
+		writer:  zip.NewWriter(file),
+		entries: make(map[string][][]byte),
+	}, nil
+}
+
+// write adds record as a line of the file `name` in the archive.
+func (a *archiveT) write(name string, record interface{}) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if _, ok := a.entries[name]; !ok {
+		a.order = append(a.order, name)
+	}
+	a.entries[name] = append(a.entries[name], line)
+	a.count++
+	return nil
+}
+
+// close writes the files to the archive. It is safe to call it more than once.
+func (a *archiveT) close() error {
+	if a.closed {
+		return nil
+	}
+	a.closed = true
+	defer a.file.Close()
+
+	for _, name := range a.order {
+		w, err := a.writer.Create(name)
+		if err != nil {
+			return err
+		}
+		for _, line := range a.entries[name] {
+			if _, err = w.Write(append(line, '\n')); err != nil {
+				return err
+			}
+		}
+	}
+	if err := a.writer.Close(); err != nil {
+		return err
+	}
+	return a.file.Close()
+}
@@ -0,0 +1,98 @@
+package export
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/rudderlabs/rudder-server/services/filemanager"
+	"github.com/tidwall/gjson"
+)
+
+const listMaxItem int64 = 1000
+
+// jobs tables backed up by jobsdb, job status tables are skipped as they don't have events
+var regexBackupFile = regexp.MustCompile(`^(pre_drop_)?(gw|rt|batch_rt)_jobs_\d+.*\.gz$`)
+
+// exportFromBackups writes events of the users in jobsdb backups uploaded to the configured bucket to the archive.
+// Backups are listed page by page till a page has no backups which aren't exported yet,
+// as file managers of some providers, e.g. DigitalOcean Spaces, return the same page on every call.
+func exportFromBackups(ctx context.Context, fm filemanager.FileManager, tmpDirPath string, filter *jobFilterT, archive *archiveT) error {
+	exported := make(map[string]struct{})
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		files, err := fm.ListFilesWithPrefix(fm.GetConfiguredPrefix(), listMaxItem)
+		if err != nil {
+			return fmt.Errorf("error while listing backups: %w", err)
+		}
+		var listed bool
+		for _, file := range files {
+			if _, ok := exported[file.Key]; ok {
+				continue
+			}
+			exported[file.Key] = struct{}{}
+			listed = true
+			if !regexBackupFile.MatchString(filepath.Base(file.Key)) {
+				continue
+			}
+			if err = exportFromBackupFile(fm, file.Key, tmpDirPath, filter, archive); err != nil {
+				return fmt.Errorf("error while exporting from backup %s: %w", file.Key, err)
+			}
+		}
+		if !listed {
+			return nil
+		}
+	}
+}
+
+// exportFromBackupFile downloads a backup, with a json row of the jobs table in each line, & writes events of the users to the archive.
+func exportFromBackupFile(fm filemanager.FileManager, key, tmpDirPath string, filter *jobFilterT, archive *archiveT) error {
+	localPath := filepath.Join(tmpDirPath, "backups", key)
+	if err := os.MkdirAll(filepath.Dir(localPath), os.ModePerm); err != nil {
+		return err
+	}
+	file, err := os.Create(localPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(localPath)
+	defer file.Close()
+
+	if err = fm.Download(file, key); err != nil {
+		return err
+	}
+	if _, err = file.Seek(0, 0); err != nil {
+		return err
+	}
+	gzReader, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gzReader.Close()
+
+	source := filepath.Base(key)
+	scanner := bufio.NewScanner(gzReader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		row := gjson.ParseBytes(scanner.Bytes())
+		for _, event := range filter.userEvents([]byte(row.Get("parameters").Raw), []byte(row.Get("event_payload").Raw)) {
+			err = archive.write("backups/"+source+".jsonl", eventRecord{
+				Source:    source,
+				JobID:     row.Get("job_id").Int(),
+				CreatedAt: row.Get("created_at").Time(),
+				Event:     json.RawMessage(event),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,183 @@
+package export
+
+//This is going to collect events of the users of an export (data subject access request) job
+//from jobsdb datasets, jobsdb backups in object storage & warehouse destinations of the workspace,
+//package them into a zip archive & upload it to the configured bucket.
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/rudderlabs/rudder-server/config"
+	"github.com/rudderlabs/rudder-server/jobsdb"
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/model"
+	"github.com/rudderlabs/rudder-server/services/filemanager"
+	"github.com/rudderlabs/rudder-server/services/regulation"
+	"github.com/rudderlabs/rudder-server/services/stats"
+	"github.com/rudderlabs/rudder-server/utils/logger"
+)
+
+var pkgLogger = logger.NewLogger().Child("export")
+
+const exportPathPrefix = "rudder-data-exports"
+
+type jobsDBReader interface {
+	GetJobsOfUsers(userIDs, emails []string, afterJobID int64, count int) ([]*jobsdb.JobT, error)
+}
+
+type warehouseExporter interface {
+	IsSupported(destName string) bool
+	Export(ctx context.Context, job model.Job, destDetail model.Destination, write func(table string, row map[string]string) error) error
+}
+
+type destinationLister interface {
+	GetDestinations(ctx context.Context) ([]model.Destination, error)
+}
+
+type sourceLister interface {
+	GetSourceIDs(ctx context.Context, workspaceID string) ([]string, error)
+}
+
+type ExportManager struct {
+	FMFactory    filemanager.FileManagerFactory
+	Warehouse    warehouseExporter
+	Destinations destinationLister
+	//Sources of the workspace of the job, events of other sources in jobsdb & its backups are left out of the export
+	Sources sourceLister
+	//jobsDBs are keyed by table prefix, events are not collected from jobsdb if none is added
+	jobsDBs map[string]jobsDBReader
+}
+
+// AddJobsDB adds jobsdb with table prefix to the places events are collected from.
+func (em *ExportManager) AddJobsDB(prefix string, db jobsDBReader) {
+	if em.jobsDBs == nil {
+		em.jobsDBs = make(map[string]jobsDBReader)
+	}
+	em.jobsDBs[prefix] = db
+}
+
+// Export collects events of the users of job into an archive, uploads it to JOBS_BACKUP_BUCKET
+// & returns its location along with the status of job.
+func (em *ExportManager) Export(ctx context.Context, job model.Job) (model.ExportResult, model.JobStatus) {
+	pkgLogger.Debugf("exporting job: %v", job)
+	exportTime := stats.NewTaggedStat("export_time", stats.TimerType, stats.Tags{"jobId": fmt.Sprintf("%d", job.ID), "workspaceId": job.WorkspaceID})
+	exportTime.Start()
+	defer exportTime.End()
+
+	if config.GetEnv("JOBS_BACKUP_BUCKET", "") == "" {
+		pkgLogger.Errorf("JOBS_BACKUP_BUCKET not configured, unable to upload export of job: %d", job.ID)
+		return model.ExportResult{}, model.JobStatusFailed
+	}
+	fm, err := em.FMFactory.New(&filemanager.SettingsT{
+		Provider: config.GetEnv("JOBS_BACKUP_STORAGE_PROVIDER", "S3"),
+		Config:   filemanager.GetProviderConfigFromEnv(),
+	})
+	if err != nil {
+		pkgLogger.Errorf("error while getting file manager for configured bucket: %v", err)
+		return model.ExportResult{}, model.JobStatusFailed
+	}
+
+	//parent directory of the archive & backups downloaded in the process of export.
+	tmpDirPath, err := os.MkdirTemp("", "")
+	if err != nil {
+		pkgLogger.Errorf("error while creating temporary directory to store all temporary files during export: %v", err)
+		return model.ExportResult{}, model.JobStatusFailed
+	}
+	defer os.RemoveAll(tmpDirPath)
+	archivePath := filepath.Join(tmpDirPath, fmt.Sprintf("%s_%d.zip", job.WorkspaceID, job.ID))
+
+	eventCount, err := em.writeArchive(ctx, fm, job, tmpDirPath, archivePath)
+	if err != nil {
+		pkgLogger.Errorf("error while collecting events of job: %d: %v", job.ID, err)
+		return model.ExportResult{}, model.JobStatusFailed
+	}
+
+	archiveFile, err := os.Open(archivePath)
+	if err != nil {
+		pkgLogger.Errorf("error while opening archive: %v", err)
+		return model.ExportResult{}, model.JobStatusFailed
+	}
+	defer archiveFile.Close()
+	output, err := fm.Upload(archiveFile, exportPathPrefix, job.WorkspaceID)
+	if err != nil {
+		pkgLogger.Errorf("error while uploading archive of job: %d: %v", job.ID, err)
+		return model.ExportResult{}, model.JobStatusFailed
+	}
+
+	pkgLogger.Infof("exported %d events of job: %d to: %s", eventCount, job.ID, output.Location)
+	return model.ExportResult{
+		Location:    output.Location,
+		EventCount:  eventCount,
+		GeneratedAt: time.Now(),
+	}, model.JobStatusComplete
+}
+
+// writeArchive writes events of the users from all the sources into archive at archivePath & returns the number of events written.
+func (em *ExportManager) writeArchive(ctx context.Context, fm filemanager.FileManager, job model.Job, tmpDirPath, archivePath string) (int, error) {
+	archive, err := newArchive(archivePath)
+	if err != nil {
+		return 0, err
+	}
+	defer archive.close()
+
+	users := make([]regulation.UserT, len(job.UserAttributes))
+	for i, userAttribute := range job.UserAttributes {
+		users[i] = regulation.UserT{
+			UserID: userAttribute.UserID,
+			Email:  userAttribute.Email,
+			Phone:  userAttribute.Phone,
+		}
+	}
+	matcher := regulation.NewUserMatcher(users)
+
+	//jobsdb & its backups are shared by workspaces, so only events of the sources of the workspace are exported
+	if em.Sources == nil {
+		return 0, fmt.Errorf("sources of workspace: %s unknown", job.WorkspaceID)
+	}
+	sourceIDs, err := em.Sources.GetSourceIDs(ctx, job.WorkspaceID)
+	if err != nil {
+		return 0, fmt.Errorf("error while getting sources of workspace: %s: %w", job.WorkspaceID, err)
+	}
+	filter := newJobFilter(sourceIDs, matcher)
+
+	prefixes := make([]string, 0, len(em.jobsDBs))
+	for prefix := range em.jobsDBs {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		if err = exportFromJobsDB(ctx, em.jobsDBs[prefix], prefix, job, filter, archive); err != nil {
+			return 0, fmt.Errorf("error while exporting from %s jobsdb: %w", prefix, err)
+		}
+	}
+
+	if err = exportFromBackups(ctx, fm, tmpDirPath, filter, archive); err != nil {
+		return 0, fmt.Errorf("error while exporting from backups: %w", err)
+	}
+
+	if em.Warehouse != nil && em.Destinations != nil {
+		destinations, err := em.Destinations.GetDestinations(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("error while getting destinations: %w", err)
+		}
+		for _, dest := range destinations {
+			if !em.Warehouse.IsSupported(dest.Name) {
+				continue
+			}
+			err = em.Warehouse.Export(ctx, job, dest, func(table string, row map[string]string) error {
+				return archive.write(fmt.Sprintf("warehouse/%s.jsonl", table), row)
+			})
+			if err != nil {
+				return 0, fmt.Errorf("error while exporting from warehouse %s: %w", dest.DestinationID, err)
+			}
+		}
+	}
+
+	if err = archive.close(); err != nil {
+		return 0, err
+	}
+	return archive.count, nil
+}
@@ -0,0 +1,177 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rudderlabs/rudder-server/jobsdb"
+	mock_filemanager "github.com/rudderlabs/rudder-server/mocks/services/filemanager"
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/export"
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/initialize"
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/model"
+	"github.com/rudderlabs/rudder-server/services/filemanager"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+type jobsDBReader struct {
+	jobs []*jobsdb.JobT
+}
+
+func (r *jobsDBReader) GetJobsOfUsers(userIDs, emails []string, afterJobID int64, count int) ([]*jobsdb.JobT, error) {
+	var jobs []*jobsdb.JobT
+	for _, job := range r.jobs {
+		if job.JobID > afterJobID && len(jobs) < count {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+type warehouseExporter struct{}
+
+func (w *warehouseExporter) IsSupported(destName string) bool {
+	return destName == "POSTGRES"
+}
+
+func (w *warehouseExporter) Export(ctx context.Context, job model.Job, destDetail model.Destination, write func(table string, row map[string]string) error) error {
+	return write(destDetail.DestinationID+"/rudder.tracks", map[string]string{"id": "1", "user_id": "alice"})
+}
+
+type destinationLister struct{}
+
+func (d *destinationLister) GetDestinations(ctx context.Context) ([]model.Destination, error) {
+	return []model.Destination{
+		{DestinationID: "pg-1", Name: "POSTGRES"},
+		{DestinationID: "s3-1", Name: "S3"},
+	}, nil
+}
+
+type sourceLister struct{}
+
+func (s *sourceLister) GetSourceIDs(ctx context.Context, workspaceID string) ([]string, error) {
+	if workspaceID != "1001" {
+		return nil, errors.New("unknown workspace")
+	}
+	return []string{"src-1"}, nil
+}
+
+func TestExport(t *testing.T) {
+	initialize.Init()
+	t.Setenv("JOBS_BACKUP_BUCKET", "backups")
+	t.Setenv("JOBS_BACKUP_STORAGE_PROVIDER", "S3")
+	t.Setenv("RSERVER_REGULATION_WORKER_EXPORT_JOBS_DBBATCH_SIZE", "1")
+
+	email := "bob@example.com"
+	job := model.Job{
+		ID:          1,
+		WorkspaceID: "1001",
+		Type:        model.JobTypeExport,
+		UserAttributes: []model.UserAttribute{
+			{UserID: "alice"},
+			{UserID: "bob", Email: &email},
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockFM := mock_filemanager.NewMockFileManager(mockCtrl)
+	mockFMFactory := mock_filemanager.NewMockFileManagerFactory(mockCtrl)
+	mockFMFactory.EXPECT().New(gomock.Any()).Return(mockFM, nil).Times(1)
+
+	mockFM.EXPECT().GetConfiguredPrefix().Return("").AnyTimes()
+	//file managers like that of DigitalOcean Spaces list the same page again
+	mockFM.EXPECT().ListFilesWithPrefix("", gomock.Any()).Return([]*filemanager.FileObject{
+		{Key: "1/gw_jobs_1.1.2.1638921600.1638925200.gz"},
+		{Key: "1/gw_job_status_1.1.2.gz"},
+	}, nil).Times(2)
+	mockFM.EXPECT().Download(gomock.Any(), "1/gw_jobs_1.1.2.1638921600.1638925200.gz").DoAndReturn(func(file *os.File, key string) error {
+		gzWriter := gzip.NewWriter(file)
+		_, err := gzWriter.Write([]byte(`{"job_id": 1, "created_at": "2021-12-08T00:00:00Z", "parameters": {"source_id": "src-1"}, "event_payload": {"batch": [{"userId": "alice", "messageId": "m1"}]}}` + "\n" +
+			`{"job_id": 2, "created_at": "2021-12-08T00:00:00Z", "parameters": {"source_id": "src-1"}, "event_payload": {"batch": [{"userId": "carol", "messageId": "m2"}]}}` + "\n" +
+			`{"job_id": 3, "created_at": "2021-12-08T00:00:00Z", "parameters": {"source_id": "src-2"}, "event_payload": {"batch": [{"userId": "alice", "messageId": "m7"}]}}` + "\n"))
+		require.NoError(t, err)
+		return gzWriter.Close()
+	}).Times(1)
+
+	archive := map[string][]string{}
+	mockFM.EXPECT().Upload(gomock.Any(), "rudder-data-exports", "1001").DoAndReturn(func(file *os.File, prefixes ...string) (filemanager.UploadOutput, error) {
+		stat, err := file.Stat()
+		require.NoError(t, err)
+		reader, err := zip.NewReader(file, stat.Size())
+		require.NoError(t, err)
+		for _, f := range reader.File {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			scanner := bufio.NewScanner(rc)
+			for scanner.Scan() {
+				archive[f.Name] = append(archive[f.Name], scanner.Text())
+			}
+			rc.Close()
+		}
+		return filemanager.UploadOutput{Location: "s3://backups/rudder-data-exports/1001/1001_1.zip"}, nil
+	}).Times(1)
+
+	em := &export.ExportManager{
+		FMFactory:    mockFMFactory,
+		Warehouse:    &warehouseExporter{},
+		Destinations: &destinationLister{},
+		Sources:      &sourceLister{},
+	}
+	em.AddJobsDB("gw", &jobsDBReader{jobs: []*jobsdb.JobT{
+		{JobID: 1, CreatedAt: time.Now(), Parameters: []byte(`{"source_id": "src-1"}`), EventPayload: []byte(`{"batch": [{"userId": "alice", "messageId": "m3"}, {"userId": "carol", "messageId": "m4"}]}`)},
+		{JobID: 2, CreatedAt: time.Now(), Parameters: []byte(`{"source_id": "src-1"}`), EventPayload: []byte(`{"batch": [{"userId": "dave", "context": {"traits": {"email": "bob@example.com"}}, "messageId": "m5"}]}`)},
+		{JobID: 3, CreatedAt: time.Now(), Parameters: []byte(`{"source_id": "src-2"}`), EventPayload: []byte(`{"batch": [{"userId": "alice", "messageId": "m8"}]}`)},
+	}})
+	em.AddJobsDB("rt", &jobsDBReader{jobs: []*jobsdb.JobT{
+		{JobID: 1, CreatedAt: time.Now(), Parameters: []byte(`{"source_id": "src-1", "workspaceId": "1001"}`), EventPayload: []byte(`{"userId": "bob", "messageId": "m6"}`)},
+		{JobID: 2, CreatedAt: time.Now(), Parameters: []byte(`{"source_id": "src-2", "workspaceId": "1002"}`), EventPayload: []byte(`{"userId": "bob", "messageId": "m9"}`)},
+	}})
+
+	result, status := em.Export(context.Background(), job)
+	require.Equal(t, model.JobStatusComplete, status)
+	require.Equal(t, "s3://backups/rudder-data-exports/1001/1001_1.zip", result.Location)
+	require.Equal(t, 5, result.EventCount)
+
+	messageIDs := func(name string) []string {
+		var ids []string
+		for _, line := range archive[name] {
+			ids = append(ids, gjson.Get(line, "event.messageId").String())
+		}
+		return ids
+	}
+	require.Equal(t, []string{"m3", "m5"}, messageIDs("jobsdb/gw.jsonl"))
+	require.Equal(t, []string{"m6"}, messageIDs("jobsdb/rt.jsonl"))
+	require.Equal(t, []string{"m1"}, messageIDs("backups/gw_jobs_1.1.2.1638921600.1638925200.gz.jsonl"))
+	require.Equal(t, []string{`{"id":"1","user_id":"alice"}`}, archive["warehouse/pg-1/rudder.tracks.jsonl"])
+}
+
+func TestExportWithoutBucket(t *testing.T) {
+	initialize.Init()
+	t.Setenv("JOBS_BACKUP_BUCKET", "")
+
+	em := &export.ExportManager{}
+	_, status := em.Export(context.Background(), model.Job{ID: 1, WorkspaceID: "1001", Type: model.JobTypeExport})
+	require.Equal(t, model.JobStatusFailed, status)
+}
+
+func TestExportWithoutSourcesOfWorkspace(t *testing.T) {
+	initialize.Init()
+	t.Setenv("JOBS_BACKUP_BUCKET", "backups")
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockFMFactory := mock_filemanager.NewMockFileManagerFactory(mockCtrl)
+	mockFMFactory.EXPECT().New(gomock.Any()).Return(mock_filemanager.NewMockFileManager(mockCtrl), nil).Times(1)
+
+	em := &export.ExportManager{FMFactory: mockFMFactory, Sources: &sourceLister{}}
+	_, status := em.Export(context.Background(), model.Job{ID: 1, WorkspaceID: "1002", Type: model.JobTypeExport})
+	require.Equal(t, model.JobStatusFailed, status)
+}
@@ -0,0 +1,107 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rudderlabs/rudder-server/config"
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/model"
+	"github.com/rudderlabs/rudder-server/services/regulation"
+	"github.com/tidwall/gjson"
+)
+
+// eventRecord is an event of a user along with where it was found
+type eventRecord struct {
+	Source    string          `json:"source"`
+	JobID     int64           `json:"job_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Event     json.RawMessage `json:"event"`
+}
+
+// exportFromJobsDB writes events of the users in jobs of all datasets of db to the archive.
+func exportFromJobsDB(ctx context.Context, db jobsDBReader, prefix string, job model.Job, filter *jobFilterT, archive *archiveT) error {
+	var userIDs, emails []string
+	for _, user := range job.UserAttributes {
+		if user.UserID != "" {
+			userIDs = append(userIDs, user.UserID)
+		}
+		if user.Email != nil && *user.Email != "" {
+			emails = append(emails, *user.Email)
+		}
+	}
+	if len(userIDs) == 0 && len(emails) == 0 {
+		return nil
+	}
+
+	batchSize := config.GetInt("RegulationWorker.export.jobsDBBatchSize", 1000)
+	var afterJobID int64
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		jobs, err := db.GetJobsOfUsers(userIDs, emails, afterJobID, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+		afterJobID = jobs[len(jobs)-1].JobID
+
+		for _, userJob := range jobs {
+			for _, event := range filter.userEvents(userJob.Parameters, userJob.EventPayload) {
+				err = archive.write(fmt.Sprintf("jobsdb/%s.jsonl", prefix), eventRecord{
+					Source:    prefix,
+					JobID:     userJob.JobID,
+					CreatedAt: userJob.CreatedAt,
+					Event:     json.RawMessage(event),
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// jobFilterT picks events of the users from jobs of the sources of the workspace
+type jobFilterT struct {
+	sourceIDs map[string]struct{}
+	matcher   *regulation.UserMatcherT
+}
+
+func newJobFilter(sourceIDs []string, matcher *regulation.UserMatcherT) *jobFilterT {
+	filter := &jobFilterT{
+		sourceIDs: make(map[string]struct{}, len(sourceIDs)),
+		matcher:   matcher,
+	}
+	for _, sourceID := range sourceIDs {
+		filter.sourceIDs[sourceID] = struct{}{}
+	}
+	return filter
+}
+
+// userEvents returns events of the users in a job's payload, if the job is of a source of the workspace.
+// Gateway, router & batch router jobs have source_id in parameters.
+// Gateway jobs have a batch of events, possibly of other users too, while router & batch router jobs have a single event.
+func (filter *jobFilterT) userEvents(parameters, payload []byte) []string {
+	if _, ok := filter.sourceIDs[gjson.GetBytes(parameters, "source_id").String()]; !ok {
+		return nil
+	}
+	parsed := gjson.ParseBytes(payload)
+	if batch := parsed.Get("batch"); batch.IsArray() {
+		var events []string
+		for _, event := range batch.Array() {
+			if filter.matcher.Matches(event) {
+				events = append(events, event.Raw)
+			}
+		}
+		return events
+	}
+	if filter.matcher.Matches(parsed) {
+		return []string{parsed.Raw}
+	}
+	return nil
+}
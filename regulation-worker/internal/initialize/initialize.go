@@ -6,6 +6,11 @@ import (
 	"github.com/rudderlabs/rudder-server/config"
 	"github.com/rudderlabs/rudder-server/services/stats"
 	"github.com/rudderlabs/rudder-server/utils/logger"
+	"github.com/rudderlabs/rudder-server/warehouse/bigquery"
+	"github.com/rudderlabs/rudder-server/warehouse/clickhouse"
+	"github.com/rudderlabs/rudder-server/warehouse/postgres"
+	"github.com/rudderlabs/rudder-server/warehouse/redshift"
+	"github.com/rudderlabs/rudder-server/warehouse/snowflake"
 )
 
 var once sync.Once
@@ -16,5 +21,11 @@ func Init() {
 		logger.Init()
 		stats.Init()
 		stats.Setup()
+		// warehouse integrations used to connect while deleting from warehouses
+		postgres.Init()
+		redshift.Init()
+		snowflake.Init()
+		bigquery.Init()
+		clickhouse.Init()
 	})
 }
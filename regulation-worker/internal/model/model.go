@@ -25,10 +25,19 @@ const (
 	JobStatusAborted      JobStatus = "aborted"
 )
 
+//JobType is the kind of regulation a job is for, jobs without a type are deletion jobs
+type JobType string
+
+const (
+	JobTypeDeletion JobType = "deletion"
+	JobTypeExport   JobType = "export"
+)
+
 type Job struct {
 	ID             int
 	WorkspaceID    string
 	DestinationID  string
+	Type           JobType
 	Status         JobStatus
 	UserAttributes []UserAttribute
 	UpdatedAt      time.Time
@@ -40,6 +49,13 @@ type UserAttribute struct {
 	Email  *string
 }
 
+//ExportResult is the archive of user data collected for an export job
+type ExportResult struct {
+	Location    string
+	EventCount  int
+	GeneratedAt time.Time
+}
+
 type Destination struct {
 	Config        map[string]interface{}
 	DestinationID string
@@ -0,0 +1,71 @@
+package propagator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/model"
+	"github.com/rudderlabs/rudder-server/services/regulation"
+	"github.com/rudderlabs/rudder-server/utils/logger"
+)
+
+var pkgLogger = logger.NewLogger().Child("propagator")
+
+// PipelinePropagator sends users of completed deletion jobs to rudder-server,
+// so that their new events are suppressed & pending events are dropped from the pipeline.
+type PipelinePropagator struct {
+	Client         *http.Client
+	URLPrefix      string
+	WorkspaceToken string
+}
+
+// Propagate makes a POST request to /v1/regulations of rudder-server with users of the job.
+func (p *PipelinePropagator) Propagate(ctx context.Context, job model.Job) error {
+	pkgLogger.Debugf("propagating job %d to the pipeline", job.ID)
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	users := make([]regulation.UserT, len(job.UserAttributes))
+	for i, userAttribute := range job.UserAttributes {
+		users[i] = regulation.UserT{
+			UserID: userAttribute.UserID,
+			Email:  userAttribute.Email,
+			Phone:  userAttribute.Phone,
+		}
+	}
+	body, err := json.Marshal(regulation.PropagationRequestT{
+		JobID:         int64(job.ID),
+		WorkspaceID:   job.WorkspaceID,
+		DestinationID: job.DestinationID,
+		Users:         users,
+	})
+	if err != nil {
+		return fmt.Errorf("error while marshalling propagation request: %w", err)
+	}
+
+	url := fmt.Sprint(p.URLPrefix, "/v1/regulations")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error while creating propagation request: %w", err)
+	}
+	req.SetBasicAuth(p.WorkspaceToken, "")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error while making propagation request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("propagation request failed with status code: %d, body: %s", resp.StatusCode, string(respBody))
+	}
+	pkgLogger.Debugf("propagated job %d to the pipeline, response: %s", job.ID, string(respBody))
+	return nil
+}
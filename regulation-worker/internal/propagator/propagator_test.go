@@ -0,0 +1,85 @@
+package propagator_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/initialize"
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/model"
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/propagator"
+	"github.com/rudderlabs/rudder-server/services/regulation"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropagate(t *testing.T) {
+	initialize.Init()
+	email := "user1@example.com"
+	job := model.Job{
+		ID:            1,
+		WorkspaceID:   "1234",
+		DestinationID: "1111",
+		UserAttributes: []model.UserAttribute{
+			{UserID: "user1", Email: &email},
+			{UserID: "user2"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		respCode     int
+		expectedErr  bool
+		workspaceKey string
+	}{
+		{
+			name:         "propagation succeeds",
+			respCode:     200,
+			workspaceKey: "token",
+		},
+		{
+			name:         "propagation fails with non 2xx response",
+			respCode:     400,
+			expectedErr:  true,
+			workspaceKey: "token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received regulation.PropagationRequestT
+			svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "/v1/regulations", r.URL.Path)
+				token, _, ok := r.BasicAuth()
+				require.True(t, ok)
+				require.Equal(t, tt.workspaceKey, token)
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+				w.WriteHeader(tt.respCode)
+				w.Write([]byte(`{"op_id": 1}`))
+			}))
+			defer svr.Close()
+
+			p := &propagator.PipelinePropagator{
+				Client:         &http.Client{},
+				URLPrefix:      svr.URL,
+				WorkspaceToken: tt.workspaceKey,
+			}
+			err := p.Propagate(context.Background(), job)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, regulation.PropagationRequestT{
+				JobID:         1,
+				WorkspaceID:   "1234",
+				DestinationID: "1111",
+				Users: []regulation.UserT{
+					{UserID: "user1", Email: &email},
+					{UserID: "user2"},
+				},
+			}, received)
+		})
+	}
+}
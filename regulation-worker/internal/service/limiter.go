@@ -0,0 +1,75 @@
+package service
+
+import (
+	"sync"
+)
+
+// DestLimiter caps the number of jobs run concurrently for a destination,
+// so that jobs of a destination with long running deletions don't occupy all the workers.
+type DestLimiter struct {
+	mu    sync.Mutex
+	max   int
+	slots map[string]chan struct{}
+}
+
+func NewDestLimiter(maxJobsPerDestination int) *DestLimiter {
+	if maxJobsPerDestination < 1 {
+		maxJobsPerDestination = 1
+	}
+	return &DestLimiter{
+		max:   maxJobsPerDestination,
+		slots: make(map[string]chan struct{}),
+	}
+}
+
+func (l *DestLimiter) destSlots(destID string) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.slots[destID]; !ok {
+		l.slots[destID] = make(chan struct{}, l.max)
+	}
+	return l.slots[destID]
+}
+
+// TryAcquire takes up a slot to run a job of the destination, returns false if all its slots are taken.
+func (l *DestLimiter) TryAcquire(destID string) bool {
+	select {
+	case l.destSlots(destID) <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Release frees up the slot acquired for the destination.
+func (l *DestLimiter) Release(destID string) {
+	<-l.destSlots(destID)
+}
+
+// inFlightJobs tracks jobs being run by the workers of a process, as regulation manager may hand out
+// the same pending job to more than one worker before any of them marks it running.
+type inFlightJobs struct {
+	mu   sync.Mutex
+	jobs map[int]struct{}
+}
+
+func newInFlightJobs() *inFlightJobs {
+	return &inFlightJobs{jobs: make(map[int]struct{})}
+}
+
+// tryAdd marks job in flight, returns false if it is already being run by another worker.
+func (f *inFlightJobs) tryAdd(jobID int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.jobs[jobID]; ok {
+		return false
+	}
+	f.jobs[jobID] = struct{}{}
+	return true
+}
+
+func (f *inFlightJobs) remove(jobID int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.jobs, jobID)
+}
@@ -4,30 +4,77 @@ import (
 	"context"
 	"time"
 
-	backoff "github.com/cenkalti/backoff/v4"
 	"github.com/rudderlabs/rudder-server/regulation-worker/internal/model"
 	"github.com/rudderlabs/rudder-server/utils/logger"
 	"github.com/rudderlabs/rudder-server/utils/misc"
+	"golang.org/x/sync/errgroup"
 )
 
 var pkgLogger = logger.NewLogger().Child("service")
 
 type Looper struct {
-	Backoff backoff.BackOffContext
-	Svc     JobSvc
+	Svc JobSvc
+	//Workers is the number of jobs run concurrently, a single job is run at a time if it isn't set
+	Workers int
+	//NoJobSleep is the time a worker waits for before getting a job when no runnable job is found, defaults to 10 minutes
+	NoJobSleep time.Duration
+	//BusySleep is the time a worker waits for before getting a job after releasing one of a busy destination
+	//or skipping one already run by another worker, defaults to 10 seconds
+	BusySleep time.Duration
 }
 
+// Loop runs workers getting & running jobs until ctx is cancelled or one of them fails.
+// Jobs running when ctx is cancelled are released for other instances to resume them.
 func (l *Looper) Loop(ctx context.Context) error {
-	pkgLogger.Infof("running regulation worker in infinite loop")
+	workers := l.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	pkgLogger.Infof("running regulation worker in infinite loop with %d workers", workers)
+	if workers > 1 && l.Svc.inFlight == nil {
+		l.Svc.inFlight = newInFlightJobs()
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	for i := 0; i < workers; i++ {
+		workerID := i
+		g.Go(func() error {
+			return l.work(ctx, workerID)
+		})
+	}
+	return g.Wait()
+}
+
+func (l *Looper) work(ctx context.Context, workerID int) error {
+	noJobSleep := l.NoJobSleep
+	if noJobSleep <= 0 {
+		noJobSleep = 10 * time.Minute
+	}
+	busySleep := l.BusySleep
+	if busySleep <= 0 {
+		busySleep = 10 * time.Second
+	}
 	for {
+		if ctx.Err() != nil {
+			pkgLogger.Debugf("context cancelled... exiting worker: %d", workerID)
+			return nil
+		}
 		err := l.Svc.JobSvc(ctx)
-		if err == model.ErrNoRunnableJob {
-			pkgLogger.Debugf("no runnable job found... sleeping")
-			if ctxCanceled := misc.SleepCtx(ctx, 10*time.Minute); ctxCanceled {
-				pkgLogger.Debugf("context cancelled... exiting infinite loop")
+		if err == model.ErrNoRunnableJob || err == errDestinationBusy || err == errJobInFlight {
+			sleep := noJobSleep
+			if err == errDestinationBusy || err == errJobInFlight {
+				sleep = busySleep
+			}
+			pkgLogger.Debugf("no runnable job found... sleeping worker: %d", workerID)
+			if ctxCanceled := misc.SleepCtx(ctx, sleep); ctxCanceled {
+				pkgLogger.Debugf("context cancelled... exiting worker: %d", workerID)
 				return nil
 			}
 		} else if err != nil {
+			if ctx.Err() != nil {
+				pkgLogger.Debugf("context cancelled... exiting worker: %d", workerID)
+				return nil
+			}
 			return err
 		}
 	}
@@ -0,0 +1,268 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/initialize"
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/model"
+	"github.com/rudderlabs/rudder-server/regulation-worker/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPI hands out pending jobs once each, like regulation manager leasing a job to a worker.
+// Released jobs are handed out after the other pending jobs.
+type fakeAPI struct {
+	mu       sync.Mutex
+	jobs     []model.Job
+	statuses map[int][]model.JobStatus
+}
+
+func newFakeAPI(jobs ...model.Job) *fakeAPI {
+	return &fakeAPI{jobs: jobs, statuses: map[int][]model.JobStatus{}}
+}
+
+func (a *fakeAPI) Get(ctx context.Context) (model.Job, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, job := range a.jobs {
+		statuses := a.statuses[job.ID]
+		if len(statuses) == 0 || statuses[len(statuses)-1] == model.JobStatusPending {
+			a.statuses[job.ID] = append(a.statuses[job.ID], model.JobStatusRunning)
+			return job, nil
+		}
+	}
+	return model.Job{}, model.ErrNoRunnableJob
+}
+
+func (a *fakeAPI) UpdateStatus(ctx context.Context, status model.JobStatus, jobID int) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.statuses[jobID] = append(a.statuses[jobID], status)
+	if status == model.JobStatusPending {
+		for i, job := range a.jobs {
+			if job.ID == jobID {
+				a.jobs = append(append(a.jobs[:i:i], a.jobs[i+1:]...), job)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (a *fakeAPI) UpdateExportStatus(ctx context.Context, status model.JobStatus, jobID int, export model.ExportResult) error {
+	return a.UpdateStatus(ctx, status, jobID)
+}
+
+func (a *fakeAPI) lastStatus(jobID int) model.JobStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	statuses := a.statuses[jobID]
+	if len(statuses) == 0 {
+		return model.JobStatusUndefined
+	}
+	return statuses[len(statuses)-1]
+}
+
+func (a *fakeAPI) count(jobID int, status model.JobStatus) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var c int
+	for _, s := range a.statuses[jobID] {
+		if s == status {
+			c++
+		}
+	}
+	return c
+}
+
+type fakeDestDetail struct{}
+
+func (d *fakeDestDetail) GetWorkspaceId(ctx context.Context) (string, error) {
+	return "1001", nil
+}
+
+func (d *fakeDestDetail) GetDestDetails(ctx context.Context, destID string) (model.Destination, error) {
+	return model.Destination{DestinationID: destID, Name: destID}, nil
+}
+
+// fakeDeleter blocks deletion of jobs of a destination until it is unblocked or ctx is cancelled
+type fakeDeleter struct {
+	mu      sync.Mutex
+	blocked map[string]chan struct{}
+	running map[string]int
+	maxRun  map[string]int
+}
+
+func newFakeDeleter(blockedDestinations ...string) *fakeDeleter {
+	d := &fakeDeleter{blocked: map[string]chan struct{}{}, running: map[string]int{}, maxRun: map[string]int{}}
+	for _, dest := range blockedDestinations {
+		d.blocked[dest] = make(chan struct{})
+	}
+	return d
+}
+
+func (d *fakeDeleter) Delete(ctx context.Context, job model.Job, destDetail model.Destination) model.JobStatus {
+	d.mu.Lock()
+	d.running[job.DestinationID]++
+	if d.running[job.DestinationID] > d.maxRun[job.DestinationID] {
+		d.maxRun[job.DestinationID] = d.running[job.DestinationID]
+	}
+	blocked := d.blocked[job.DestinationID]
+	d.mu.Unlock()
+	defer func() {
+		d.mu.Lock()
+		d.running[job.DestinationID]--
+		d.mu.Unlock()
+	}()
+
+	if blocked != nil {
+		select {
+		case <-blocked:
+		case <-ctx.Done():
+			return model.JobStatusFailed
+		}
+	}
+	return model.JobStatusComplete
+}
+
+func (d *fakeDeleter) unblock(destID string) {
+	close(d.blocked[destID])
+}
+
+func (d *fakeDeleter) maxRunning(destID string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.maxRun[destID]
+}
+
+func TestLooperRunsJobsConcurrently(t *testing.T) {
+	initialize.Init()
+	api := newFakeAPI(
+		model.Job{ID: 1, DestinationID: "S3"},
+		model.Job{ID: 2, DestinationID: "S3"},
+		model.Job{ID: 3, DestinationID: "API"},
+	)
+	deleter := newFakeDeleter("S3")
+	l := &service.Looper{
+		Svc: service.JobSvc{
+			API:        api,
+			Deleter:    deleter,
+			DestDetail: &fakeDestDetail{},
+			Limiter:    service.NewDestLimiter(1),
+		},
+		Workers:    2,
+		NoJobSleep: 10 * time.Millisecond,
+		BusySleep:  10 * time.Millisecond,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- l.Loop(ctx) }()
+
+	// job of another destination isn't blocked behind a long running deletion,
+	// as the job waiting for the destination is released instead of holding a worker
+	require.Eventually(t, func() bool { return api.lastStatus(3) == model.JobStatusComplete }, time.Second, 10*time.Millisecond)
+	require.Equal(t, model.JobStatusRunning, api.lastStatus(1))
+	require.GreaterOrEqual(t, api.count(2, model.JobStatusPending), 1, "job of a busy destination is released")
+
+	deleter.unblock("S3")
+	require.Eventually(t, func() bool {
+		return api.lastStatus(1) == model.JobStatusComplete && api.lastStatus(2) == model.JobStatusComplete
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, 1, deleter.maxRunning("S3"), "jobs of a destination are capped by limiter")
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestLooperReleasesJobsOnShutdown(t *testing.T) {
+	initialize.Init()
+	api := newFakeAPI(model.Job{ID: 1, DestinationID: "S3"})
+	deleter := newFakeDeleter("S3")
+	l := &service.Looper{
+		Svc: service.JobSvc{
+			API:               api,
+			Deleter:           deleter,
+			DestDetail:        &fakeDestDetail{},
+			HeartbeatInterval: 10 * time.Millisecond,
+		},
+		Workers:    2,
+		NoJobSleep: 10 * time.Millisecond,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- l.Loop(ctx) }()
+
+	// lease of the running job is renewed through heartbeats
+	require.Eventually(t, func() bool { return api.count(1, model.JobStatusRunning) >= 3 }, time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	require.Equal(t, model.JobStatusPending, api.lastStatus(1), "interrupted job is released for other workers to resume")
+}
+
+// racyAPI hands out a job until it is complete, like regulation manager handing out
+// the same pending job to workers asking for one before any of them marks it running.
+type racyAPI struct {
+	*fakeAPI
+}
+
+func (a *racyAPI) Get(ctx context.Context) (model.Job, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, job := range a.jobs {
+		statuses := a.statuses[job.ID]
+		if len(statuses) == 0 || statuses[len(statuses)-1] != model.JobStatusComplete {
+			return job, nil
+		}
+	}
+	return model.Job{}, model.ErrNoRunnableJob
+}
+
+func TestLooperSkipsJobsAlreadyRunning(t *testing.T) {
+	initialize.Init()
+	api := &racyAPI{newFakeAPI(model.Job{ID: 1, DestinationID: "S3"})}
+	deleter := newFakeDeleter("S3")
+	l := &service.Looper{
+		Svc: service.JobSvc{
+			API:        api,
+			Deleter:    deleter,
+			DestDetail: &fakeDestDetail{},
+		},
+		Workers:    4,
+		NoJobSleep: 10 * time.Millisecond,
+		BusySleep:  10 * time.Millisecond,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- l.Loop(ctx) }()
+
+	require.Eventually(t, func() bool { return api.lastStatus(1) == model.JobStatusRunning }, time.Second, 10*time.Millisecond)
+	// other workers keep getting the running job in the meantime
+	time.Sleep(50 * time.Millisecond)
+	deleter.unblock("S3")
+	require.Eventually(t, func() bool { return api.lastStatus(1) == model.JobStatusComplete }, time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	require.Equal(t, 1, deleter.maxRunning("S3"), "a job is run by a single worker")
+	require.Equal(t, 0, api.count(1, model.JobStatusPending), "status of the running job isn't overwritten by duplicates")
+}
+
+func TestDestLimiter(t *testing.T) {
+	limiter := service.NewDestLimiter(2)
+	require.True(t, limiter.TryAcquire("dest-1"))
+	require.True(t, limiter.TryAcquire("dest-1"))
+	require.True(t, limiter.TryAcquire("dest-2"))
+	require.False(t, limiter.TryAcquire("dest-1"), "no more than 2 jobs of a destination")
+
+	limiter.Release("dest-1")
+	require.True(t, limiter.TryAcquire("dest-1"))
+}
@@ -50,6 +50,20 @@ func (mr *MockAPIClientMockRecorder) Get(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAPIClient)(nil).Get), ctx)
 }
 
+// UpdateExportStatus mocks base method.
+func (m *MockAPIClient) UpdateExportStatus(ctx context.Context, status model.JobStatus, jobID int, export model.ExportResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExportStatus", ctx, status, jobID, export)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateExportStatus indicates an expected call of UpdateExportStatus.
+func (mr *MockAPIClientMockRecorder) UpdateExportStatus(ctx, status, jobID, export interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExportStatus", reflect.TypeOf((*MockAPIClient)(nil).UpdateExportStatus), ctx, status, jobID, export)
+}
+
 // UpdateStatus mocks base method.
 func (m *MockAPIClient) UpdateStatus(ctx context.Context, status model.JobStatus, jobID int) error {
 	m.ctrl.T.Helper()
@@ -153,3 +167,78 @@ func (mr *MockdeleterMockRecorder) Delete(ctx, job, destDetail interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*Mockdeleter)(nil).Delete), ctx, job, destDetail)
 }
+
+// Mockpropagator is a mock of propagator interface.
+type Mockpropagator struct {
+	ctrl     *gomock.Controller
+	recorder *MockpropagatorMockRecorder
+}
+
+// MockpropagatorMockRecorder is the mock recorder for Mockpropagator.
+type MockpropagatorMockRecorder struct {
+	mock *Mockpropagator
+}
+
+// NewMockpropagator creates a new mock instance.
+func NewMockpropagator(ctrl *gomock.Controller) *Mockpropagator {
+	mock := &Mockpropagator{ctrl: ctrl}
+	mock.recorder = &MockpropagatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpropagator) EXPECT() *MockpropagatorMockRecorder {
+	return m.recorder
+}
+
+// Propagate mocks base method.
+func (m *Mockpropagator) Propagate(ctx context.Context, job model.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Propagate", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Propagate indicates an expected call of Propagate.
+func (mr *MockpropagatorMockRecorder) Propagate(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Propagate", reflect.TypeOf((*Mockpropagator)(nil).Propagate), ctx, job)
+}
+
+// Mockexporter is a mock of exporter interface.
+type Mockexporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockexporterMockRecorder
+}
+
+// MockexporterMockRecorder is the mock recorder for Mockexporter.
+type MockexporterMockRecorder struct {
+	mock *Mockexporter
+}
+
+// NewMockexporter creates a new mock instance.
+func NewMockexporter(ctrl *gomock.Controller) *Mockexporter {
+	mock := &Mockexporter{ctrl: ctrl}
+	mock.recorder = &MockexporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockexporter) EXPECT() *MockexporterMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *Mockexporter) Export(ctx context.Context, job model.Job) (model.ExportResult, model.JobStatus) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, job)
+	ret0, _ := ret[0].(model.ExportResult)
+	ret1, _ := ret[1].(model.JobStatus)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockexporterMockRecorder) Export(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*Mockexporter)(nil).Export), ctx, job)
+}
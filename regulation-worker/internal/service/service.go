@@ -4,18 +4,22 @@ package service
 //TODO: appropriate status var update and handling via model.status
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff"
 	"github.com/rudderlabs/rudder-server/regulation-worker/internal/model"
 	"github.com/rudderlabs/rudder-server/services/stats"
+	"github.com/rudderlabs/rudder-server/utils/misc"
 )
 
 //go:generate mockgen -source=service.go -destination=mock_service_test.go -package=service github.com/rudderlabs/rudder-server/regulation-worker/internal/service
 type APIClient interface {
 	Get(ctx context.Context) (model.Job, error)
 	UpdateStatus(ctx context.Context, status model.JobStatus, jobID int) error
+	UpdateExportStatus(ctx context.Context, status model.JobStatus, jobID int, export model.ExportResult) error
 }
 
 type destDetail interface {
@@ -26,10 +30,38 @@ type deleter interface {
 	Delete(ctx context.Context, job model.Job, destDetail model.Destination) model.JobStatus
 }
 
+//time given to mark an interrupted job pending on shutdown
+var releaseTimeout = 30 * time.Second
+
+//errDestinationBusy is returned when a job is released as its destination is already running as many jobs as allowed by Limiter
+var errDestinationBusy = errors.New("destination is running maximum number of jobs")
+
+//errJobInFlight is returned when a job handed out by the API is already being run by another worker of the process
+var errJobInFlight = errors.New("job is already running")
+
+type propagator interface {
+	Propagate(ctx context.Context, job model.Job) error
+}
+
+type exporter interface {
+	Export(ctx context.Context, job model.Job) (model.ExportResult, model.JobStatus)
+}
+
 type JobSvc struct {
 	API        APIClient
 	Deleter    deleter
 	DestDetail destDetail
+	//Propagator is optional, if set users of completed jobs are suppressed in the pipeline as well
+	Propagator propagator
+	//Exporter is optional, if not set export jobs are marked unsupported
+	Exporter exporter
+	//Limiter is optional, if set it caps the number of jobs run concurrently for a destination
+	Limiter *DestLimiter
+	//HeartbeatInterval is the interval at which the lease of a running job is renewed, no heartbeats are sent if it is zero
+	HeartbeatInterval time.Duration
+
+	//inFlight is set by Looper when jobs are run by more than one worker, duplicate jobs aren't tracked if it is nil
+	inFlight *inFlightJobs
 }
 
 //called by looper
@@ -49,16 +81,50 @@ func (js *JobSvc) JobSvc(ctx context.Context) error {
 	defer totalJobTime.End()
 
 	pkgLogger.Debugf("job: %v", job)
+	//a job handed out to more than one worker is run only by the first one, the duplicate is dropped
+	//without updating its status, as that would overwrite the status set by the worker running it.
+	if js.inFlight != nil {
+		if !js.inFlight.tryAdd(job.ID) {
+			pkgLogger.Debugf("job: %d is already running, skipping it", job.ID)
+			return errJobInFlight
+		}
+		defer js.inFlight.remove(job.ID)
+	}
+
+	//a slot of the destination is taken before job is marked running, so that a job waiting for its destination
+	//neither holds a lease nor a worker. It is released instead for it to be picked up once the destination is free.
+	if js.Limiter != nil && job.Type != model.JobTypeExport {
+		if !js.Limiter.TryAcquire(job.DestinationID) {
+			pkgLogger.Debugf("destination: %s is busy, releasing job: %d", job.DestinationID, job.ID)
+			if err := js.updateStatus(ctx, model.JobStatusPending, job.ID); err != nil {
+				return err
+			}
+			return errDestinationBusy
+		}
+		defer js.Limiter.Release(job.DestinationID)
+	}
+
 	//once job is successfully received, calling updatestatus API to update the status of job to running.
 	status := model.JobStatusRunning
 	err = js.updateStatus(ctx, status, job.ID)
 	if err != nil {
 		return err
 	}
+
+	//job is leased to this worker as long as it is running, lease is renewed by heartbeats
+	//& has to be stopped before the final status is updated, so that it isn't overwritten.
+	stopHeartbeat := js.startHeartbeat(ctx, job.ID)
+	defer stopHeartbeat()
+
+	if job.Type == model.JobTypeExport {
+		return js.export(ctx, job, stopHeartbeat)
+	}
+
 	//executing deletion
 	destDetail, err := js.DestDetail.GetDestDetails(ctx, job.DestinationID)
 	if err != nil {
 		pkgLogger.Errorf("error while getting destination details: %v", err)
+		stopHeartbeat()
 		if err == model.ErrInvalidDestination {
 			return js.updateStatus(ctx, model.JobStatusAborted, job.ID)
 		}
@@ -66,12 +132,90 @@ func (js *JobSvc) JobSvc(ctx context.Context) error {
 	}
 
 	status = js.Deleter.Delete(ctx, job, destDetail)
+	stopHeartbeat()
+	if ctx.Err() != nil {
+		return js.releaseJob(job.ID)
+	}
+	if status == model.JobStatusComplete && js.Propagator != nil {
+		if err := js.Propagator.Propagate(ctx, job); err != nil {
+			pkgLogger.Errorf("error while propagating job %d to the pipeline: %v", job.ID, err)
+			status = model.JobStatusFailed
+		}
+	}
 
 	return js.updateStatus(ctx, status, job.ID)
 }
 
+//export collects data of the users of job & reports location of the archive along with the status of job.
+func (js *JobSvc) export(ctx context.Context, job model.Job, stopHeartbeat func()) error {
+	if js.Exporter == nil {
+		pkgLogger.Warnf("exporter not configured, unable to run export job: %d", job.ID)
+		stopHeartbeat()
+		return js.updateStatus(ctx, model.JobStatusNotSupported, job.ID)
+	}
+
+	result, status := js.Exporter.Export(ctx, job)
+	stopHeartbeat()
+	if ctx.Err() != nil {
+		return js.releaseJob(job.ID)
+	}
+	if status != model.JobStatusComplete {
+		return js.updateStatus(ctx, status, job.ID)
+	}
+	return js.withRetry(ctx, func() error {
+		return js.API.UpdateExportStatus(ctx, status, job.ID, result)
+	})
+}
+
+//startHeartbeat renews the lease of job every HeartbeatInterval by updating its status to running,
+//so that the job isn't handed over to another worker while it is running. It returns a func to stop heartbeats,
+//which waits for an in-flight heartbeat & is safe to call more than once.
+func (js *JobSvc) startHeartbeat(ctx context.Context, jobID int) (stop func()) {
+	if js.HeartbeatInterval <= 0 {
+		return func() {}
+	}
+	heartbeatCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if ctxCanceled := misc.SleepCtx(heartbeatCtx, js.HeartbeatInterval); ctxCanceled {
+				return
+			}
+			pkgLogger.Debugf("renewing lease of job: %d", jobID)
+			if err := js.API.UpdateStatus(heartbeatCtx, model.JobStatusRunning, jobID); err != nil {
+				pkgLogger.Warnf("error while renewing lease of job: %d: %v", jobID, err)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			cancel()
+			<-done
+		})
+	}
+}
+
+//releaseJob marks an interrupted job pending on shutdown, so that it is picked up & resumed by another worker.
+//A new context is used as the one job was running with is already cancelled.
+func (js *JobSvc) releaseJob(jobID int) error {
+	pkgLogger.Infof("releasing job: %d on shutdown", jobID)
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+	return js.API.UpdateStatus(ctx, model.JobStatusPending, jobID)
+}
+
 func (js *JobSvc) updateStatus(ctx context.Context, status model.JobStatus, jobID int) error {
 	pkgLogger.Debugf("updating job status to: %v", status)
+	return js.withRetry(ctx, func() error {
+		return js.API.UpdateStatus(ctx, status, jobID)
+	})
+}
+
+//withRetry retries updating status with exponential backoff for upto 10 minutes
+func (js *JobSvc) withRetry(ctx context.Context, update func() error) error {
 	maxWait := time.Minute * 10
 	var err error
 	bo := backoff.NewExponentialBackOff()
@@ -80,7 +224,7 @@ func (js *JobSvc) updateStatus(ctx context.Context, status model.JobStatus, jobI
 	bo.MaxElapsedTime = maxWait
 
 	if err = backoff.Retry(func() error {
-		err := js.API.UpdateStatus(ctx, status, jobID)
+		err := update()
 		pkgLogger.Debugf("trying to update status...")
 		return err
 	}, boCtx); err != nil {
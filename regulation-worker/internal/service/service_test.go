@@ -2,6 +2,7 @@ package service_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	gomock "github.com/golang/mock/gomock"
@@ -97,3 +98,140 @@ func TestJobSvc(t *testing.T) {
 		})
 	}
 }
+
+func TestJobSvcPropagation(t *testing.T) {
+	initialize.Init()
+	job := model.Job{
+		ID:            1,
+		WorkspaceID:   "1234",
+		DestinationID: "1111",
+	}
+	dest := model.Destination{
+		DestinationID: "1111",
+		Name:          "S3",
+	}
+	var tests = []struct {
+		name                string
+		deleteJobStatus     model.JobStatus
+		propagateErr        error
+		propagateCallCount  int
+		expectedFinalStatus model.JobStatus
+	}{
+		{
+			name:                "completed job is propagated",
+			deleteJobStatus:     model.JobStatusComplete,
+			propagateCallCount:  1,
+			expectedFinalStatus: model.JobStatusComplete,
+		},
+		{
+			name:                "completed job is marked failed if propagation fails",
+			deleteJobStatus:     model.JobStatusComplete,
+			propagateErr:        errors.New("propagation failed"),
+			propagateCallCount:  1,
+			expectedFinalStatus: model.JobStatusFailed,
+		},
+		{
+			name:                "failed job is not propagated",
+			deleteJobStatus:     model.JobStatusFailed,
+			propagateCallCount:  0,
+			expectedFinalStatus: model.JobStatusFailed,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockAPIClient := service.NewMockAPIClient(mockCtrl)
+			mockAPIClient.EXPECT().Get(ctx).Return(job, nil).Times(1)
+			mockAPIClient.EXPECT().UpdateStatus(ctx, model.JobStatusRunning, job.ID).Return(nil).Times(1)
+			mockAPIClient.EXPECT().UpdateStatus(ctx, tt.expectedFinalStatus, job.ID).Return(nil).Times(1)
+
+			mockDeleter := service.NewMockdeleter(mockCtrl)
+			mockDeleter.EXPECT().Delete(ctx, job, dest).Return(tt.deleteJobStatus).Times(1)
+
+			mockDestDetail := service.NewMockdestDetail(mockCtrl)
+			mockDestDetail.EXPECT().GetDestDetails(ctx, job.DestinationID).Return(dest, nil).Times(1)
+
+			mockPropagator := service.NewMockpropagator(mockCtrl)
+			mockPropagator.EXPECT().Propagate(ctx, job).Return(tt.propagateErr).Times(tt.propagateCallCount)
+			svc := service.JobSvc{
+				API:        mockAPIClient,
+				Deleter:    mockDeleter,
+				DestDetail: mockDestDetail,
+				Propagator: mockPropagator,
+			}
+			err := svc.JobSvc(ctx)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestJobSvcExport(t *testing.T) {
+	initialize.Init()
+	job := model.Job{
+		ID:          1,
+		WorkspaceID: "1234",
+		Type:        model.JobTypeExport,
+	}
+	result := model.ExportResult{Location: "s3://bucket/rudder-data-exports/1234/1234_1.zip", EventCount: 1}
+	var tests = []struct {
+		name                    string
+		withExporter            bool
+		exportStatus            model.JobStatus
+		updateStatusCount       int
+		expectedStatus          model.JobStatus
+		updateExportStatusCount int
+	}{
+		{
+			name:                    "completed export reports archive location",
+			withExporter:            true,
+			exportStatus:            model.JobStatusComplete,
+			updateExportStatusCount: 1,
+		},
+		{
+			name:              "failed export updates status",
+			withExporter:      true,
+			exportStatus:      model.JobStatusFailed,
+			updateStatusCount: 1,
+			expectedStatus:    model.JobStatusFailed,
+		},
+		{
+			name:              "export job is unsupported without exporter",
+			updateStatusCount: 1,
+			expectedStatus:    model.JobStatusNotSupported,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockAPIClient := service.NewMockAPIClient(mockCtrl)
+			mockAPIClient.EXPECT().Get(ctx).Return(job, nil).Times(1)
+			mockAPIClient.EXPECT().UpdateStatus(ctx, model.JobStatusRunning, job.ID).Return(nil).Times(1)
+			mockAPIClient.EXPECT().UpdateStatus(ctx, tt.expectedStatus, job.ID).Return(nil).Times(tt.updateStatusCount)
+			mockAPIClient.EXPECT().UpdateExportStatus(ctx, model.JobStatusComplete, job.ID, result).Return(nil).Times(tt.updateExportStatusCount)
+
+			// export jobs aren't for a destination
+			mockDeleter := service.NewMockdeleter(mockCtrl)
+			mockDestDetail := service.NewMockdestDetail(mockCtrl)
+			svc := service.JobSvc{
+				API:        mockAPIClient,
+				Deleter:    mockDeleter,
+				DestDetail: mockDestDetail,
+			}
+			if tt.withExporter {
+				mockExporter := service.NewMockexporter(mockCtrl)
+				mockExporter.EXPECT().Export(ctx, job).Return(result, tt.exportStatus).Times(1)
+				svc.Exporter = mockExporter
+			}
+			err := svc.JobSvc(ctx)
+			require.NoError(t, err)
+		})
+	}
+}
@@ -237,6 +237,31 @@ func (r *RouterRpcHandler) GetDSList(dsName string, result *string) (err error)
 	return nil
 }
 
+/*
+GetRecordedRequests returns the requests of a destination recorded in dry run & shadow delivery modes, oldest first
+*/
+func (r *RouterRpcHandler) GetRecordedRequests(destinationID string, result *string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			pkgLogger.Error(r)
+			err = fmt.Errorf("Internal Rudder Server Error. Error: %v", r)
+		}
+	}()
+	response, err := json.MarshalIndent(requestRecorder.get(destinationID), "", "  ")
+	if err != nil {
+		return err
+	}
+	*result = string(response)
+	return nil
+}
+
+//ClearRecordedRequests drops the requests of a destination recorded in dry run & shadow delivery modes
+func (r *RouterRpcHandler) ClearRecordedRequests(destinationID string, result *string) (err error) {
+	requestRecorder.clear(destinationID)
+	*result = "cleared recorded requests of destination " + destinationID
+	return nil
+}
+
 /*
 JobCountsByStateAndDestination
 ================================================================================
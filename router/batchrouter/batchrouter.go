@@ -227,6 +227,7 @@ type StorageUploadOutput struct {
 	FirstEventAt     string
 	LastEventAt      string
 	TotalEvents      int
+	TotalBytes       int64
 	UseRudderStorage bool
 }
 
@@ -641,6 +642,10 @@ func (brt *HandleT) copyJobsToStorage(provider string, batchJobs *BatchJobsT, ma
 	if err != nil {
 		panic(err)
 	}
+	var totalBytes int64
+	if fileInfo, err := outputFile.Stat(); err == nil {
+		totalBytes = fileInfo.Size()
+	}
 
 	brt.logger.Debugf("BRT: Starting upload to %s", provider)
 	folderName := ""
@@ -702,6 +707,7 @@ func (brt *HandleT) copyJobsToStorage(provider string, batchJobs *BatchJobsT, ma
 		FirstEventAt:     firstEventAt,
 		LastEventAt:      lastEventAt,
 		TotalEvents:      len(batchJobs.Jobs) - dedupedIDMergeRuleJobs,
+		TotalBytes:       totalBytes,
 		UseRudderStorage: useRudderStorage,
 	}
 }
@@ -962,6 +968,7 @@ func (brt *HandleT) postToWarehouse(batchJobs *BatchJobsT, output StorageUploadO
 		FirstEventAt:     output.FirstEventAt,
 		LastEventAt:      output.LastEventAt,
 		TotalEvents:      output.TotalEvents,
+		TotalBytes:       output.TotalBytes,
 		UseRudderStorage: output.UseRudderStorage,
 		SourceBatchID:    sampleParameters.SourceBatchID,
 		SourceTaskID:     sampleParameters.SourceTaskID,
@@ -1076,6 +1083,7 @@ func (brt *HandleT) setJobStatus(batchJobs *BatchJobsT, isWarehouse bool, errOcc
 				maxFailedCountForJob {
 				job.Parameters = misc.UpdateJSONWithNewKeyVal(job.Parameters, "stage", "batch_router")
 				job.Parameters = misc.UpdateJSONWithNewKeyVal(job.Parameters, "reason", errOccurred.Error())
+				job.Parameters = misc.UpdateJSONWithNewKeyVal(job.Parameters, "error_code", strconv.Itoa(getBRTErrorCode(jobsdb.Aborted.State)))
 				abortedEvents = append(abortedEvents, job)
 				router.PrepareJobRunIdAbortedEventsMap(job.Parameters, jobRunIDAbortedEventsMap)
 				jobState = jobsdb.Aborted.State
@@ -1086,6 +1094,7 @@ func (brt *HandleT) setJobStatus(batchJobs *BatchJobsT, isWarehouse bool, errOcc
 				if time.Since(warehouseServiceFailedTime) > warehouseServiceMaxRetryTime {
 					job.Parameters = misc.UpdateJSONWithNewKeyVal(job.Parameters, "stage", "batch_router")
 					job.Parameters = misc.UpdateJSONWithNewKeyVal(job.Parameters, "reason", errOccurred.Error())
+					job.Parameters = misc.UpdateJSONWithNewKeyVal(job.Parameters, "error_code", strconv.Itoa(getBRTErrorCode(jobsdb.Aborted.State)))
 					abortedEvents = append(abortedEvents, job)
 					router.PrepareJobRunIdAbortedEventsMap(job.Parameters, jobRunIDAbortedEventsMap)
 					jobState = jobsdb.Aborted.State
@@ -1095,6 +1104,7 @@ func (brt *HandleT) setJobStatus(batchJobs *BatchJobsT, isWarehouse bool, errOcc
 		case jobsdb.Aborted.State:
 			job.Parameters = misc.UpdateJSONWithNewKeyVal(job.Parameters, "stage", "batch_router")
 			job.Parameters = misc.UpdateJSONWithNewKeyVal(job.Parameters, "reason", errOccurred.Error())
+			job.Parameters = misc.UpdateJSONWithNewKeyVal(job.Parameters, "error_code", strconv.Itoa(getBRTErrorCode(jobsdb.Aborted.State)))
 			abortedEvents = append(abortedEvents, job)
 			router.PrepareJobRunIdAbortedEventsMap(job.Parameters, jobRunIDAbortedEventsMap)
 		}
@@ -0,0 +1,189 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rudderlabs/rudder-server/processor/integrations"
+	"github.com/rudderlabs/rudder-server/router/types"
+	"github.com/rudderlabs/rudder-server/router/utils"
+	"github.com/rudderlabs/rudder-server/rruntime"
+)
+
+//Delivery modes of a destination, set with Router.<destinationID>.deliveryMode
+const (
+	//DryRunDeliveryMode records the requests to the destination instead of sending them & marks the jobs as succeeded
+	//with dryRunStatusCode. Events of jobs delivered in dry run mode are dropped for good, they aren't sent later.
+	DryRunDeliveryMode = "dryRun"
+	//ShadowDeliveryMode sends the requests to Router.<destinationID>.shadowURL as well & records the comparison of both responses.
+	//It isn't supported for destinations delivered through the transformer proxy or a custom destination manager.
+	ShadowDeliveryMode = "shadow"
+)
+
+const dryRunResponse = `{"dryRun": true, "message": "request recorded instead of being sent to destination"}`
+
+//dryRunStatusCode is the status code of jobs delivered in dry run mode. It is a success status so that jobs aren't retried,
+//distinct from the ones of destinations so that reporting doesn't count the jobs as delivered.
+//Delivery stats & request metrics skip these jobs.
+const dryRunStatusCode = 299
+
+//RecordedRequestT is a request to a destination, recorded in dry run & shadow delivery modes
+type RecordedRequestT struct {
+	DestinationID string                 `json:"destinationId"`
+	Mode          string                 `json:"mode"`
+	JobIDs        []int64                `json:"jobIds"`
+	URL           string                 `json:"url"`
+	Method        string                 `json:"method"`
+	Headers       map[string]interface{} `json:"headers"`
+	QueryParams   map[string]interface{} `json:"params"`
+	Body          interface{}            `json:"body"`
+	RecordedAt    time.Time              `json:"recordedAt"`
+	//Comparison of responses of the destination & the shadow url, set in shadow delivery mode
+	Shadow *ShadowComparisonT `json:"shadow,omitempty"`
+}
+
+//ShadowComparisonT compares the response of the destination with the response of the shadow url for the same request
+type ShadowComparisonT struct {
+	URL                string `json:"url"`
+	StatusCode         int    `json:"statusCode"`
+	ResponseBody       string `json:"responseBody"`
+	ShadowStatusCode   int    `json:"shadowStatusCode"`
+	ShadowResponseBody string `json:"shadowResponseBody"`
+	StatusCodeMatched  bool   `json:"statusCodeMatched"`
+	ResponseMatched    bool   `json:"responseMatched"`
+}
+
+//requestRecorderT holds the latest recorded requests of each destination, upto deliveryModeRecordsLimit
+type requestRecorderT struct {
+	mu       sync.RWMutex
+	requests map[string][]*RecordedRequestT
+}
+
+var requestRecorder = &requestRecorderT{requests: make(map[string][]*RecordedRequestT)}
+
+func (recorder *requestRecorderT) record(request *RecordedRequestT) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	requests := append(recorder.requests[request.DestinationID], request)
+	if len(requests) > deliveryModeRecordsLimit {
+		requests = requests[len(requests)-deliveryModeRecordsLimit:]
+	}
+	recorder.requests[request.DestinationID] = requests
+}
+
+func (recorder *requestRecorderT) get(destinationID string) []*RecordedRequestT {
+	recorder.mu.RLock()
+	defer recorder.mu.RUnlock()
+	return append([]*RecordedRequestT{}, recorder.requests[destinationID]...)
+}
+
+func (recorder *requestRecorderT) clear(destinationID string) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	delete(recorder.requests, destinationID)
+}
+
+//getDeliveryMode returns the delivery mode of the destination & the shadow url in shadow delivery mode.
+//It returns an empty mode for normal delivery, also when shadow delivery mode doesn't have a shadow url
+//or can't apply to the destination.
+func (rt *HandleT) getDeliveryMode(destinationID string) (mode, shadowURL string) {
+	mode = getRouterConfigString("deliveryMode", destinationID, "")
+	switch mode {
+	case DryRunDeliveryMode:
+		return mode, ""
+	case ShadowDeliveryMode:
+		if rt.customDestinationManager != nil || rt.transformerProxy {
+			pkgLogger.Errorf("[Router] :: Shadow delivery mode isn't supported for destination %s of type %s delivered through the transformer proxy or a custom destination manager, delivering normally", destinationID, rt.destName)
+			return "", ""
+		}
+		shadowURL = getRouterConfigString("shadowURL", destinationID, "")
+		if shadowURL == "" {
+			pkgLogger.Errorf("[Router] :: Shadow delivery mode of destination %s has no shadowURL, delivering normally", destinationID)
+			return "", ""
+		}
+		return mode, shadowURL
+	default:
+		return "", ""
+	}
+}
+
+func newRecordedRequest(mode string, destinationJob *types.DestinationJobT, postInfo *integrations.PostParametersT) *RecordedRequestT {
+	jobIDs := make([]int64, 0, len(destinationJob.JobMetadataArray))
+	for _, metadata := range destinationJob.JobMetadataArray {
+		jobIDs = append(jobIDs, metadata.JobID)
+	}
+	request := &RecordedRequestT{
+		DestinationID: destinationJob.Destination.ID,
+		Mode:          mode,
+		JobIDs:        jobIDs,
+		RecordedAt:    time.Now(),
+	}
+	if postInfo == nil {
+		//custom destination managers send the message as is
+		request.Body = destinationJob.Message
+		return request
+	}
+	request.URL = postInfo.URL
+	request.Method = postInfo.RequestMethod
+	request.Headers = redactHeaders(postInfo.Headers)
+	request.QueryParams = postInfo.QueryParams
+	request.Body = postInfo.Body
+	return request
+}
+
+//redactHeaders masks credentials in request headers, since recorded requests are visible through the admin interface
+func redactHeaders(headers map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(headers))
+	for key, val := range headers {
+		switch strings.ToLower(key) {
+		case "authorization", "proxy-authorization", "x-api-key", "api-key", "cookie":
+			redacted[key] = "<redacted>"
+		default:
+			redacted[key] = val
+		}
+	}
+	return redacted
+}
+
+//shadowPost sends the request to the shadow url in parallel to its delivery to the destination.
+//The returned func records the comparison once the destination responds, without waiting for the shadow url.
+func (worker *workerT) shadowPost(destinationJob *types.DestinationJobT, postInfo integrations.PostParametersT, shadowURL string) func(statusCode int, responseBody string) {
+	request := newRecordedRequest(ShadowDeliveryMode, destinationJob, &postInfo)
+	shadowInfo := postInfo
+	shadowInfo.URL = shadowURL
+	shadowRespCh := make(chan *utils.SendPostResponse, 1)
+	rruntime.Go(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), worker.rt.netClientTimeout)
+		defer cancel()
+		shadowRespCh <- worker.rt.netHandle.SendPost(ctx, shadowInfo)
+	})
+
+	return func(statusCode int, responseBody string) {
+		rruntime.Go(func() {
+			shadowResp := <-shadowRespCh
+			request.Shadow = &ShadowComparisonT{
+				URL:                shadowURL,
+				StatusCode:         statusCode,
+				ResponseBody:       responseBody,
+				ShadowStatusCode:   shadowResp.StatusCode,
+				ShadowResponseBody: string(shadowResp.ResponseBody),
+				StatusCodeMatched:  statusCode == shadowResp.StatusCode,
+				ResponseMatched:    statusCode == shadowResp.StatusCode && jsonEqual(responseBody, string(shadowResp.ResponseBody)),
+			}
+			requestRecorder.record(request)
+		})
+	}
+}
+
+//jsonEqual compares responses as json if both are valid json, as strings otherwise
+func jsonEqual(a, b string) bool {
+	var aJSON, bJSON interface{}
+	if json.Unmarshal([]byte(a), &aJSON) != nil || json.Unmarshal([]byte(b), &bJSON) != nil {
+		return a == b
+	}
+	return reflect.DeepEqual(aJSON, bJSON)
+}
@@ -0,0 +1,136 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mocksRouter "github.com/rudderlabs/rudder-server/mocks/router"
+	"github.com/rudderlabs/rudder-server/processor/integrations"
+	"github.com/rudderlabs/rudder-server/router/types"
+	router_utils "github.com/rudderlabs/rudder-server/router/utils"
+)
+
+type customDestinationManagerStub struct{}
+
+func (*customDestinationManagerStub) SendData(json.RawMessage, string, string) (int, string) {
+	return 200, ""
+}
+
+var _ = Describe("Delivery modes", func() {
+	initRouter()
+
+	destinationJob := &types.DestinationJobT{
+		JobMetadataArray: []types.JobMetadataT{{JobID: 1}, {JobID: 2}},
+	}
+	destinationJob.Destination.ID = "dryrundest"
+	postInfo := integrations.PostParametersT{
+		Type:          "REST",
+		URL:           "https://destination.example.com/track",
+		RequestMethod: "POST",
+		Headers:       map[string]interface{}{"Authorization": "Bearer secret", "Content-Type": "application/json"},
+		Body:          map[string]interface{}{"JSON": map[string]interface{}{"event": "Order Completed"}},
+	}
+
+	AfterEach(func() {
+		requestRecorder.clear(destinationJob.Destination.ID)
+	})
+
+	Context("delivery mode config", func() {
+		var rt *HandleT
+
+		BeforeEach(func() {
+			rt = &HandleT{destName: "WEBHOOK"}
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("RSERVER_ROUTER_DRYRUNDEST_DELIVERY_MODE")
+			os.Unsetenv("RSERVER_ROUTER_DRYRUNDEST_SHADOW_URL")
+		})
+
+		It("delivers normally by default", func() {
+			mode, _ := rt.getDeliveryMode(destinationJob.Destination.ID)
+			Expect(mode).To(BeEmpty())
+		})
+
+		It("reads the delivery mode of the destination", func() {
+			os.Setenv("RSERVER_ROUTER_DRYRUNDEST_DELIVERY_MODE", DryRunDeliveryMode)
+			mode, _ := rt.getDeliveryMode(destinationJob.Destination.ID)
+			Expect(mode).To(Equal(DryRunDeliveryMode))
+		})
+
+		It("delivers normally in shadow mode without a shadow url", func() {
+			os.Setenv("RSERVER_ROUTER_DRYRUNDEST_DELIVERY_MODE", ShadowDeliveryMode)
+			mode, _ := rt.getDeliveryMode(destinationJob.Destination.ID)
+			Expect(mode).To(BeEmpty())
+
+			os.Setenv("RSERVER_ROUTER_DRYRUNDEST_SHADOW_URL", "https://shadow.example.com")
+			mode, shadowURL := rt.getDeliveryMode(destinationJob.Destination.ID)
+			Expect(mode).To(Equal(ShadowDeliveryMode))
+			Expect(shadowURL).To(Equal("https://shadow.example.com"))
+		})
+
+		It("delivers normally in shadow mode through the transformer proxy or a custom destination manager", func() {
+			os.Setenv("RSERVER_ROUTER_DRYRUNDEST_DELIVERY_MODE", ShadowDeliveryMode)
+			os.Setenv("RSERVER_ROUTER_DRYRUNDEST_SHADOW_URL", "https://shadow.example.com")
+
+			rt.transformerProxy = true
+			mode, shadowURL := rt.getDeliveryMode(destinationJob.Destination.ID)
+			Expect(mode).To(BeEmpty())
+			Expect(shadowURL).To(BeEmpty())
+
+			rt.transformerProxy = false
+			rt.customDestinationManager = &customDestinationManagerStub{}
+			mode, shadowURL = rt.getDeliveryMode(destinationJob.Destination.ID)
+			Expect(mode).To(BeEmpty())
+			Expect(shadowURL).To(BeEmpty())
+
+			os.Setenv("RSERVER_ROUTER_DRYRUNDEST_DELIVERY_MODE", DryRunDeliveryMode)
+			mode, _ = rt.getDeliveryMode(destinationJob.Destination.ID)
+			Expect(mode).To(Equal(DryRunDeliveryMode))
+		})
+	})
+
+	Context("recorded requests", func() {
+		It("records requests with redacted credentials, upto the limit", func() {
+			for i := 0; i < deliveryModeRecordsLimit+1; i++ {
+				requestRecorder.record(newRecordedRequest(DryRunDeliveryMode, destinationJob, &postInfo))
+			}
+			requests := requestRecorder.get(destinationJob.Destination.ID)
+			Expect(requests).To(HaveLen(deliveryModeRecordsLimit))
+			Expect(requests[0].JobIDs).To(Equal([]int64{1, 2}))
+			Expect(requests[0].URL).To(Equal(postInfo.URL))
+			Expect(requests[0].Headers["Authorization"]).To(Equal("<redacted>"))
+			Expect(requests[0].Headers["Content-Type"]).To(Equal("application/json"))
+			Expect(postInfo.Headers["Authorization"]).To(Equal("Bearer secret"))
+		})
+
+		It("records the comparison of destination & shadow responses", func() {
+			mockCtrl := gomock.NewController(GinkgoT())
+			defer mockCtrl.Finish()
+			mockNetHandle := mocksRouter.NewMockNetHandleI(mockCtrl)
+			worker := &workerT{rt: &HandleT{netHandle: mockNetHandle, netClientTimeout: time.Second}}
+
+			mockNetHandle.EXPECT().SendPost(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, shadowInfo integrations.PostParametersT) *router_utils.SendPostResponse {
+				Expect(shadowInfo.URL).To(Equal("https://shadow.example.com"))
+				return &router_utils.SendPostResponse{StatusCode: 200, ResponseBody: []byte(`{"b": 2, "a": 1}`)}
+			})
+
+			recordComparison := worker.shadowPost(destinationJob, postInfo, "https://shadow.example.com")
+			recordComparison(200, `{"a": 1, "b": 2}`)
+
+			Eventually(func() []*RecordedRequestT {
+				return requestRecorder.get(destinationJob.Destination.ID)
+			}, time.Second).Should(HaveLen(1))
+			shadow := requestRecorder.get(destinationJob.Destination.ID)[0].Shadow
+			Expect(shadow.ShadowStatusCode).To(Equal(200))
+			Expect(shadow.StatusCodeMatched).To(BeTrue())
+			Expect(shadow.ResponseMatched).To(BeTrue())
+		})
+	})
+})
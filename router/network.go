@@ -203,6 +203,7 @@ func (network *NetHandleT) SendPost(ctx context.Context, structData integrations
 			StatusCode:          resp.StatusCode,
 			ResponseBody:        respBody,
 			ResponseContentType: contentTypeHeader,
+			RetryAfter:          utils.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
 		}
 	}
 
@@ -0,0 +1,108 @@
+package router
+
+import (
+	"math"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/rudderlabs/rudder-server/jobsdb"
+	"github.com/rudderlabs/rudder-server/router/utils"
+	"github.com/rudderlabs/rudder-server/utils/misc"
+)
+
+//orderingPolicyT is the ordering policy of a destination, with the path of the ordering key in the payload for utils.KeyOrderingPolicy
+type orderingPolicyT struct {
+	policy string
+	key    string
+}
+
+//defaultOrderingPolicy is the policy of destinations which don't set one, it follows guaranteeUserEventOrder of the destination type
+func (rt *HandleT) defaultOrderingPolicy() orderingPolicyT {
+	if rt.guaranteeUserEventOrder {
+		return orderingPolicyT{policy: utils.UserOrderingPolicy}
+	}
+	return orderingPolicyT{policy: utils.NoOrderingPolicy}
+}
+
+//getOrderingConfigString looks for the key in destination, destination type & global router config, in that order
+func (rt *HandleT) getOrderingConfigString(key, destinationID, defaultValue string) string {
+	return utils.OrderingConfigString(rt.destName, destinationID, key, defaultValue)
+}
+
+func (rt *HandleT) loadOrderingPolicy(destinationID string) orderingPolicyT {
+	defaultPolicy := rt.defaultOrderingPolicy()
+	policy := orderingPolicyT{
+		policy: rt.getOrderingConfigString("orderingPolicy", destinationID, defaultPolicy.policy),
+		key:    rt.getOrderingConfigString("orderingKey", destinationID, ""),
+	}
+	switch policy.policy {
+	case utils.UserOrderingPolicy, utils.NoOrderingPolicy:
+		return orderingPolicyT{policy: policy.policy}
+	case utils.KeyOrderingPolicy:
+		if policy.key == "" {
+			rt.logger.Errorf("[%v Router] :: Ordering policy %s of destination %s has no orderingKey, ordering by user", rt.destName, utils.KeyOrderingPolicy, destinationID)
+			return orderingPolicyT{policy: utils.UserOrderingPolicy}
+		}
+		return policy
+	default:
+		rt.logger.Errorf("[%v Router] :: Invalid ordering policy %q of destination %s, using %s", rt.destName, policy.policy, destinationID, defaultPolicy.policy)
+		return defaultPolicy
+	}
+}
+
+//orderingKeyOf returns the key whose jobs are delivered in order, one after the other.
+//ordered is false if the destination of the job doesn't order its events.
+//The value of the ordering key of utils.KeyOrderingPolicy is set in job parameters by processor, from the event before transformation.
+//Jobs without a value, e.g. stored before processor set it, are ordered by their user.
+func (rt *HandleT) orderingKeyOf(job *jobsdb.JobT) (key string, ordered bool) {
+	rt.configSubscriberLock.RLock()
+	policy, ok := rt.orderingPolicies[destinationID(job)]
+	rt.configSubscriberLock.RUnlock()
+	if !ok {
+		policy = rt.defaultOrderingPolicy()
+	}
+
+	switch policy.policy {
+	case utils.NoOrderingPolicy:
+		return "", false
+	case utils.KeyOrderingPolicy:
+		if value := gjson.GetBytes(job.Parameters, "ordering_key").String(); value != "" {
+			return policy.key + ":" + value, true
+		}
+	}
+	return job.UserID, true
+}
+
+//workerIndexFor returns the worker which delivers the jobs of an ordering key
+func (rt *HandleT) workerIndexFor(orderingKey string) int {
+	return int(math.Abs(float64(misc.GetHash(orderingKey) % rt.noOfWorkers)))
+}
+
+//restoreOrderingBlocks rebuilds the failed jobs of ordering keys from jobsdb, so that ordering survives restarts.
+//Failed jobs are the oldest pending jobs of their keys, as the later jobs of a key wait for its failed job,
+//hence the oldest failed job of each key blocks the rest of its jobs, like before the restart.
+func (rt *HandleT) restoreOrderingBlocks() {
+	var afterJobID int64
+	restored := 0
+	for {
+		failedJobs := rt.jobsDB.GetToRetry(jobsdb.GetQueryParamsT{CustomValFilters: []string{rt.destName}, JobCount: jobQueryBatchSize, AfterJobID: afterJobID})
+		for _, job := range failedJobs {
+			afterJobID = job.JobID
+			orderingKey, ordered := rt.orderingKeyOf(job)
+			if !ordered || orderingKey == "" {
+				continue
+			}
+			worker := rt.workers[rt.workerIndexFor(orderingKey)]
+			worker.failedJobIDMutex.Lock()
+			if _, ok := worker.failedJobIDMap[orderingKey]; !ok {
+				worker.failedJobIDMap[orderingKey] = job.JobID
+				restored++
+			}
+			worker.failedJobIDMutex.Unlock()
+		}
+		if len(failedJobs) < jobQueryBatchSize {
+			break
+		}
+	}
+	rt.logger.Infof("[%v Router] :: Restored %d ordering keys blocked by failed jobs", rt.destName, restored)
+}
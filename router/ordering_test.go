@@ -0,0 +1,196 @@
+package router
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/rudderlabs/rudder-server/jobsdb"
+	mocksJobsDB "github.com/rudderlabs/rudder-server/mocks/jobsdb"
+	"github.com/rudderlabs/rudder-server/router/throttler"
+	"github.com/rudderlabs/rudder-server/router/utils"
+)
+
+var _ = Describe("Ordering policies", func() {
+	initRouter()
+
+	var rt *HandleT
+
+	newJob := func(jobID int64, destinationID, userID, payload string) *jobsdb.JobT {
+		return &jobsdb.JobT{
+			JobID:        jobID,
+			UserID:       userID,
+			Parameters:   []byte(`{"destination_id": "` + destinationID + `"}`),
+			EventPayload: []byte(payload),
+		}
+	}
+
+	//keyJob is a job of keydest with the value of its ordering key set in parameters by processor
+	keyJob := func(jobID int64, userID, orderingKey string) *jobsdb.JobT {
+		job := newJob(jobID, "keydest", userID, `{"body": {}}`)
+		job.Parameters = []byte(`{"destination_id": "keydest", "ordering_key": "` + orderingKey + `"}`)
+		return job
+	}
+
+	BeforeEach(func() {
+		var throttler throttler.HandleT
+		throttler.SetUp("GA")
+		rt = &HandleT{
+			backgroundCtx:           context.Background(),
+			throttler:               &throttler,
+			destName:                "GA",
+			logger:                  pkgLogger,
+			guaranteeUserEventOrder: true,
+			noOfWorkers:             4,
+			orderingPolicies:        map[string]orderingPolicyT{},
+		}
+		for i := 0; i < rt.noOfWorkers; i++ {
+			rt.workers = append(rt.workers, &workerT{
+				workerID:         i,
+				rt:               rt,
+				failedJobIDMap:   make(map[string]int64),
+				abortedUserIDMap: make(map[string]int),
+				retryForJobMap:   make(map[int64]time.Time),
+			})
+		}
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("RSERVER_ROUTER_ORDERDEST_ORDERING_POLICY")
+		os.Unsetenv("RSERVER_ROUTER_ORDERDEST_ORDERING_KEY")
+		os.Unsetenv("RSERVER_ROUTER_GA_ORDERING_POLICY")
+	})
+
+	Context("ordering policy config", func() {
+		It("orders by user by default & follows guaranteeUserEventOrder", func() {
+			Expect(rt.loadOrderingPolicy("orderdest")).To(Equal(orderingPolicyT{policy: utils.UserOrderingPolicy}))
+
+			rt.guaranteeUserEventOrder = false
+			Expect(rt.loadOrderingPolicy("orderdest")).To(Equal(orderingPolicyT{policy: utils.NoOrderingPolicy}))
+		})
+
+		It("prefers the policy of the destination over the one of the destination type", func() {
+			os.Setenv("RSERVER_ROUTER_GA_ORDERING_POLICY", utils.NoOrderingPolicy)
+			Expect(rt.loadOrderingPolicy("orderdest")).To(Equal(orderingPolicyT{policy: utils.NoOrderingPolicy}))
+
+			os.Setenv("RSERVER_ROUTER_ORDERDEST_ORDERING_POLICY", utils.KeyOrderingPolicy)
+			os.Setenv("RSERVER_ROUTER_ORDERDEST_ORDERING_KEY", "groupId")
+			Expect(rt.loadOrderingPolicy("orderdest")).To(Equal(orderingPolicyT{policy: utils.KeyOrderingPolicy, key: "groupId"}))
+		})
+
+		It("falls back to ordering by user if key ordering has no key & to the default on invalid policies", func() {
+			os.Setenv("RSERVER_ROUTER_ORDERDEST_ORDERING_POLICY", utils.KeyOrderingPolicy)
+			Expect(rt.loadOrderingPolicy("orderdest")).To(Equal(orderingPolicyT{policy: utils.UserOrderingPolicy}))
+
+			os.Setenv("RSERVER_ROUTER_ORDERDEST_ORDERING_POLICY", "random")
+			Expect(rt.loadOrderingPolicy("orderdest")).To(Equal(orderingPolicyT{policy: utils.UserOrderingPolicy}))
+		})
+	})
+
+	Context("ordering keys", func() {
+		It("orders jobs by user, by key or not at all as per the policy of their destination", func() {
+			rt.orderingPolicies["keydest"] = orderingPolicyT{policy: utils.KeyOrderingPolicy, key: "groupId"}
+			rt.orderingPolicies["nonedest"] = orderingPolicyT{policy: utils.NoOrderingPolicy}
+
+			key, ordered := rt.orderingKeyOf(newJob(1, "userdest", "u1", `{"groupId": "g1"}`))
+			Expect(ordered).To(BeTrue())
+			Expect(key).To(Equal("u1"))
+
+			key, ordered = rt.orderingKeyOf(keyJob(2, "u1", "g1"))
+			Expect(ordered).To(BeTrue())
+			Expect(key).To(Equal("groupId:g1"))
+
+			key, ordered = rt.orderingKeyOf(newJob(3, "keydest", "u1", `{"groupId": "g1"}`))
+			Expect(ordered).To(BeTrue())
+			Expect(key).To(Equal("u1"))
+
+			_, ordered = rt.orderingKeyOf(newJob(4, "nonedest", "u1", `{}`))
+			Expect(ordered).To(BeFalse())
+		})
+
+		It("orders jobs of processor transformed destinations by the ordering key in job parameters", func() {
+			rt.orderingPolicies["keydest"] = orderingPolicyT{policy: utils.KeyOrderingPolicy, key: "groupId"}
+			job := newJob(1, "keydest", "u1", `{"version": "1", "endpoint": "https://api.example.com", "body": {"JSON": {"group": "g2"}}}`)
+			job.Parameters = []byte(`{"destination_id": "keydest", "ordering_key": "g1"}`)
+
+			key, ordered := rt.orderingKeyOf(job)
+			Expect(ordered).To(BeTrue())
+			Expect(key).To(Equal("groupId:g1"))
+		})
+
+		It("looks up the ordering key path of destinations ordering by key only", func() {
+			Expect(utils.OrderingKeyPath("GA", "orderdest")).To(BeEmpty())
+
+			os.Setenv("RSERVER_ROUTER_ORDERDEST_ORDERING_KEY", "groupId")
+			Expect(utils.OrderingKeyPath("GA", "orderdest")).To(BeEmpty())
+
+			os.Setenv("RSERVER_ROUTER_ORDERDEST_ORDERING_POLICY", utils.KeyOrderingPolicy)
+			Expect(utils.OrderingKeyPath("GA", "orderdest")).To(Equal("groupId"))
+		})
+
+		It("holds back later jobs of a failed key only", func() {
+			rt.orderingPolicies["keydest"] = orderingPolicyT{policy: utils.KeyOrderingPolicy, key: "groupId"}
+			worker := rt.workers[rt.workerIndexFor("groupId:g1")]
+			worker.failedJobIDMap["groupId:g1"] = 2
+
+			Expect(rt.findWorker(keyJob(2, "u1", "g1"), time.Now())).To(Equal(worker))
+			Expect(rt.findWorker(keyJob(3, "u2", "g1"), time.Now())).To(BeNil())
+			Expect(rt.findWorker(keyJob(4, "u1", "g2"), time.Now())).NotTo(BeNil())
+		})
+	})
+
+	Context("restarts", func() {
+		It("restores the oldest failed job of each ordering key from jobsdb", func() {
+			mockCtrl := gomock.NewController(GinkgoT())
+			defer mockCtrl.Finish()
+			mockJobsDB := mocksJobsDB.NewMockJobsDB(mockCtrl)
+			rt.jobsDB = mockJobsDB
+			rt.orderingPolicies["nonedest"] = orderingPolicyT{policy: utils.NoOrderingPolicy}
+
+			failedJobs := []*jobsdb.JobT{
+				newJob(10, "userdest", "u1", `{}`),
+				newJob(12, "userdest", "u1", `{}`),
+				newJob(13, "nonedest", "u2", `{}`),
+			}
+			mockJobsDB.EXPECT().GetToRetry(jobsdb.GetQueryParamsT{CustomValFilters: []string{"GA"}, JobCount: jobQueryBatchSize}).Return(failedJobs).Times(1)
+
+			rt.restoreOrderingBlocks()
+
+			Expect(rt.workers[rt.workerIndexFor("u1")].failedJobIDMap).To(HaveKeyWithValue("u1", int64(10)))
+			for _, worker := range rt.workers {
+				Expect(worker.failedJobIDMap).NotTo(HaveKey("u2"))
+			}
+		})
+
+		It("restores failed jobs of ordering keys across pages of jobs", func() {
+			mockCtrl := gomock.NewController(GinkgoT())
+			defer mockCtrl.Finish()
+			mockJobsDB := mocksJobsDB.NewMockJobsDB(mockCtrl)
+			rt.jobsDB = mockJobsDB
+			rt.orderingPolicies["keydest"] = orderingPolicyT{policy: utils.KeyOrderingPolicy, key: "groupId"}
+			firstPage := make([]*jobsdb.JobT, 0, jobQueryBatchSize)
+			firstPage = append(firstPage, keyJob(1, "u1", "g1"))
+			for jobID := int64(2); len(firstPage) < jobQueryBatchSize; jobID++ {
+				firstPage = append(firstPage, keyJob(jobID, "u2", "g1"))
+			}
+			lastJobID := firstPage[len(firstPage)-1].JobID
+			gomock.InOrder(
+				mockJobsDB.EXPECT().GetToRetry(jobsdb.GetQueryParamsT{CustomValFilters: []string{"GA"}, JobCount: jobQueryBatchSize}).Return(firstPage),
+				mockJobsDB.EXPECT().GetToRetry(jobsdb.GetQueryParamsT{CustomValFilters: []string{"GA"}, JobCount: jobQueryBatchSize, AfterJobID: lastJobID}).Return([]*jobsdb.JobT{
+					keyJob(lastJobID+1, "u1", "g2"),
+					keyJob(lastJobID+2, "u3", ""),
+				}),
+			)
+
+			rt.restoreOrderingBlocks()
+
+			Expect(rt.workers[rt.workerIndexFor("groupId:g1")].failedJobIDMap).To(HaveKeyWithValue("groupId:g1", int64(1)))
+			Expect(rt.workers[rt.workerIndexFor("groupId:g2")].failedJobIDMap).To(HaveKeyWithValue("groupId:g2", lastJobID+1))
+			Expect(rt.workers[rt.workerIndexFor("u3")].failedJobIDMap).To(HaveKeyWithValue("u3", lastJobID+2))
+		})
+	})
+})
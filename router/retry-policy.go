@@ -0,0 +1,180 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+)
+
+//Backoff shapes of a retry policy
+const (
+	ExponentialBackoff = "exponential"
+	LinearBackoff      = "linear"
+	//JitteredBackoff is exponential backoff randomized by +/- 50%, so that failed jobs don't retry in lockstep
+	JitteredBackoff = "jittered"
+)
+
+const jitteredBackoffRandomizationFactor = 0.5
+
+//RetryPolicyT is the retry policy of a destination, set as "retryPolicy" in the backend config of the destination or of its definition, eg.
+//{"retryableStatusCodes": [500, 503, 429], "maxAttempts": 5, "maxAge": "1h", "backoff": "jittered", "minBackoff": "5s", "maxBackoff": "10m", "honorRetryAfter": true}
+//Fields which aren't set fall back to the router config.
+type RetryPolicyT struct {
+	//RetryableStatusCodes are the response codes for which jobs are retried, 5xx & 429 by default. Jobs failing with other codes are aborted.
+	RetryableStatusCodes []int `json:"retryableStatusCodes"`
+	//MaxAttempts & MaxAge abort jobs once both of them are reached. Jobs throttled with 429 are retried till they succeed.
+	MaxAttempts int    `json:"maxAttempts"`
+	MaxAge      string `json:"maxAge"`
+	Backoff     string `json:"backoff"`
+	MinBackoff  string `json:"minBackoff"`
+	MaxBackoff  string `json:"maxBackoff"`
+	//HonorRetryAfter waits for the Retry-After header of the destination response, if it is longer than the backoff
+	HonorRetryAfter bool `json:"honorRetryAfter"`
+}
+
+//retryPolicyT is the parsed RetryPolicyT of a destination, zero values fall back to the router config
+type retryPolicyT struct {
+	retryableStatusCodes map[int]struct{}
+	maxAttempts          int
+	maxAge               time.Duration
+	backoff              string
+	minBackoff           time.Duration
+	maxBackoff           time.Duration
+	honorRetryAfter      bool
+}
+
+//getRetryPolicy returns the retry policy of the destination, the one of the destination overrides the one of its definition
+func getRetryPolicy(destination *backendconfig.DestinationT) (retryPolicyT, error) {
+	policyConfig, ok := destination.Config["retryPolicy"]
+	if !ok {
+		policyConfig, ok = destination.DestinationDefinition.Config["retryPolicy"]
+	}
+	if !ok || policyConfig == nil {
+		return retryPolicyT{}, nil
+	}
+
+	var policy RetryPolicyT
+	policyJSON, err := json.Marshal(policyConfig)
+	if err != nil {
+		return retryPolicyT{}, err
+	}
+	if err = json.Unmarshal(policyJSON, &policy); err != nil {
+		return retryPolicyT{}, fmt.Errorf("invalid retry policy %s: %w", string(policyJSON), err)
+	}
+	return policy.parse()
+}
+
+func (policy *RetryPolicyT) parse() (retryPolicyT, error) {
+	parsed := retryPolicyT{
+		maxAttempts:     policy.MaxAttempts,
+		backoff:         policy.Backoff,
+		honorRetryAfter: policy.HonorRetryAfter,
+	}
+	if len(policy.RetryableStatusCodes) > 0 {
+		parsed.retryableStatusCodes = make(map[int]struct{}, len(policy.RetryableStatusCodes))
+		for _, statusCode := range policy.RetryableStatusCodes {
+			if statusCode < 100 || statusCode > 599 {
+				return retryPolicyT{}, fmt.Errorf("invalid retryable status code %d", statusCode)
+			}
+			parsed.retryableStatusCodes[statusCode] = struct{}{}
+		}
+	}
+	switch policy.Backoff {
+	case "", ExponentialBackoff, LinearBackoff, JitteredBackoff:
+	default:
+		return retryPolicyT{}, fmt.Errorf("invalid backoff %q, should be one of %s, %s & %s", policy.Backoff, ExponentialBackoff, LinearBackoff, JitteredBackoff)
+	}
+	for _, duration := range []struct {
+		value  string
+		parsed *time.Duration
+	}{{policy.MaxAge, &parsed.maxAge}, {policy.MinBackoff, &parsed.minBackoff}, {policy.MaxBackoff, &parsed.maxBackoff}} {
+		if duration.value == "" {
+			continue
+		}
+		var err error
+		if *duration.parsed, err = time.ParseDuration(duration.value); err != nil {
+			return retryPolicyT{}, err
+		}
+	}
+	return parsed, nil
+}
+
+func (rt *HandleT) retryPolicyOf(destinationID string) retryPolicyT {
+	rt.configSubscriberLock.RLock()
+	defer rt.configSubscriberLock.RUnlock()
+	return rt.retryPolicies[destinationID]
+}
+
+//isJobTerminated returns whether a failed job of the destination won't be retried, as the retry policy of the destination aborts it
+func (rt *HandleT) isJobTerminated(destinationID string, statusCode int) bool {
+	retryPolicy := rt.retryPolicyOf(destinationID)
+	return !retryPolicy.isRetryable(statusCode)
+}
+
+func (policy *retryPolicyT) isRetryable(statusCode int) bool {
+	if policy.retryableStatusCodes == nil {
+		return statusCode >= 500 || statusCode == http.StatusTooManyRequests
+	}
+	_, ok := policy.retryableStatusCodes[statusCode]
+	return ok
+}
+
+//retryLimitsReached returns true once the job is past both the max attempts & the max age of the policy
+func (rt *HandleT) retryLimitsReached(policy *retryPolicyT, attemptNum int, firstAttemptedAt time.Time) bool {
+	maxAttempts, maxAge := rt.maxFailedCountForJob, rt.retryTimeWindow
+	if policy.maxAttempts > 0 {
+		maxAttempts = policy.maxAttempts
+	}
+	if policy.maxAge > 0 {
+		maxAge = policy.maxAge
+	}
+	return time.Since(firstAttemptedAt) > maxAge && attemptNum >= maxAttempts
+}
+
+//durationBeforeNextAttempt returns the backoff of the policy after the attempt, or the Retry-After of the destination if it is longer
+func (policy *retryPolicyT) durationBeforeNextAttempt(attempt int, retryAfter time.Duration) time.Duration {
+	minBackoff, maxBackoff := minRetryBackoff, maxRetryBackoff
+	if policy.minBackoff > 0 {
+		minBackoff = policy.minBackoff
+	}
+	if policy.maxBackoff > 0 {
+		maxBackoff = policy.maxBackoff
+	}
+
+	var d time.Duration
+	switch policy.backoff {
+	case LinearBackoff:
+		d = minBackoff * time.Duration(attempt)
+		if d > maxBackoff {
+			d = maxBackoff
+		}
+	case JitteredBackoff:
+		d = durationBeforeNextAttempt(attempt, minBackoff, maxBackoff, jitteredBackoffRandomizationFactor)
+	default:
+		d = durationBeforeNextAttempt(attempt, minBackoff, maxBackoff, 0)
+	}
+
+	if policy.honorRetryAfter && retryAfter > d {
+		return retryAfter
+	}
+	return d
+}
+
+func durationBeforeNextAttempt(attempt int, minBackoff, maxBackoff time.Duration, randomizationFactor float64) (d time.Duration) {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = minBackoff
+	b.MaxInterval = maxBackoff
+	b.RandomizationFactor = randomizationFactor
+	b.MaxElapsedTime = 0
+	b.Multiplier = 2
+	b.Reset()
+	for index := 0; index < attempt; index++ {
+		d = b.NextBackOff()
+	}
+	return
+}
@@ -0,0 +1,131 @@
+package router
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+	router_utils "github.com/rudderlabs/rudder-server/router/utils"
+)
+
+var _ = Describe("Retry policies", func() {
+	initRouter()
+
+	rt := &HandleT{maxFailedCountForJob: 3, retryTimeWindow: time.Hour}
+
+	Context("retry policy config", func() {
+		It("uses the policy of the destination over the one of its definition", func() {
+			destination := backendconfig.DestinationT{
+				Config: map[string]interface{}{"retryPolicy": map[string]interface{}{
+					"retryableStatusCodes": []interface{}{500, 429},
+					"maxAttempts":          5,
+					"maxAge":               "10m",
+					"backoff":              LinearBackoff,
+					"minBackoff":           "1s",
+					"maxBackoff":           "5s",
+					"honorRetryAfter":      true,
+				}},
+				DestinationDefinition: backendconfig.DestinationDefinitionT{
+					Config: map[string]interface{}{"retryPolicy": map[string]interface{}{"maxAttempts": 10}},
+				},
+			}
+			policy, err := getRetryPolicy(&destination)
+			Expect(err).To(BeNil())
+			Expect(policy.maxAttempts).To(Equal(5))
+			Expect(policy.maxAge).To(Equal(10 * time.Minute))
+			Expect(policy.backoff).To(Equal(LinearBackoff))
+			Expect(policy.honorRetryAfter).To(BeTrue())
+
+			delete(destination.Config, "retryPolicy")
+			policy, err = getRetryPolicy(&destination)
+			Expect(err).To(BeNil())
+			Expect(policy.maxAttempts).To(Equal(10))
+		})
+
+		It("rejects invalid policies", func() {
+			for _, retryPolicy := range []map[string]interface{}{
+				{"backoff": "fibonacci"},
+				{"maxAge": "forever"},
+				{"retryableStatusCodes": []interface{}{1000}},
+				{"maxAttempts": "many"},
+			} {
+				_, err := getRetryPolicy(&backendconfig.DestinationT{Config: map[string]interface{}{"retryPolicy": retryPolicy}})
+				Expect(err).NotTo(BeNil())
+			}
+		})
+	})
+
+	Context("retries", func() {
+		It("retries 5xx & 429 by default & the configured status codes otherwise", func() {
+			var policy retryPolicyT
+			Expect(policy.isRetryable(500)).To(BeTrue())
+			Expect(policy.isRetryable(http.StatusTooManyRequests)).To(BeTrue())
+			Expect(policy.isRetryable(400)).To(BeFalse())
+
+			policy, err := (&RetryPolicyT{RetryableStatusCodes: []int{400, 503}}).parse()
+			Expect(err).To(BeNil())
+			Expect(policy.isRetryable(400)).To(BeTrue())
+			Expect(policy.isRetryable(503)).To(BeTrue())
+			Expect(policy.isRetryable(500)).To(BeFalse())
+		})
+
+		It("terminates failed jobs as per the retry policy of their destination", func() {
+			policy, err := (&RetryPolicyT{RetryableStatusCodes: []int{409}}).parse()
+			Expect(err).To(BeNil())
+			rt := &HandleT{retryPolicies: map[string]retryPolicyT{"dest-1": policy}}
+
+			Expect(rt.isJobTerminated("dest-1", 409)).To(BeFalse())
+			Expect(rt.isJobTerminated("dest-1", 500)).To(BeTrue())
+			Expect(rt.isJobTerminated("dest-2", http.StatusTooManyRequests)).To(BeFalse())
+			Expect(rt.isJobTerminated("dest-2", 409)).To(BeTrue())
+		})
+
+		It("reaches the retry limits once both max attempts & max age are reached", func() {
+			policy := retryPolicyT{maxAttempts: 5, maxAge: time.Minute}
+			Expect(rt.retryLimitsReached(&policy, 5, time.Now())).To(BeFalse())
+			Expect(rt.retryLimitsReached(&policy, 4, time.Now().Add(-2*time.Minute))).To(BeFalse())
+			Expect(rt.retryLimitsReached(&policy, 5, time.Now().Add(-2*time.Minute))).To(BeTrue())
+
+			var defaultPolicy retryPolicyT
+			Expect(rt.retryLimitsReached(&defaultPolicy, 3, time.Now().Add(-2*time.Minute))).To(BeFalse())
+			Expect(rt.retryLimitsReached(&defaultPolicy, 3, time.Now().Add(-2*time.Hour))).To(BeTrue())
+		})
+
+		It("backs off as per the shape of the policy", func() {
+			exponential := retryPolicyT{minBackoff: time.Second, maxBackoff: 10 * time.Second}
+			Expect(exponential.durationBeforeNextAttempt(1, 0)).To(Equal(time.Second))
+			Expect(exponential.durationBeforeNextAttempt(3, 0)).To(Equal(4 * time.Second))
+			Expect(exponential.durationBeforeNextAttempt(10, 0)).To(Equal(10 * time.Second))
+
+			linear := retryPolicyT{backoff: LinearBackoff, minBackoff: time.Second, maxBackoff: 10 * time.Second}
+			Expect(linear.durationBeforeNextAttempt(3, 0)).To(Equal(3 * time.Second))
+			Expect(linear.durationBeforeNextAttempt(20, 0)).To(Equal(10 * time.Second))
+
+			jittered := retryPolicyT{backoff: JitteredBackoff, minBackoff: time.Second, maxBackoff: 10 * time.Second}
+			d := jittered.durationBeforeNextAttempt(3, 0)
+			Expect(d).To(BeNumerically(">=", 2*time.Second))
+			Expect(d).To(BeNumerically("<=", 6*time.Second))
+		})
+
+		It("waits for Retry-After of the destination if the policy honors it", func() {
+			policy := retryPolicyT{minBackoff: time.Second, maxBackoff: 10 * time.Second}
+			Expect(policy.durationBeforeNextAttempt(1, time.Minute)).To(Equal(time.Second))
+
+			policy.honorRetryAfter = true
+			Expect(policy.durationBeforeNextAttempt(1, time.Minute)).To(Equal(time.Minute))
+			Expect(policy.durationBeforeNextAttempt(3, time.Second)).To(Equal(4 * time.Second))
+		})
+
+		It("parses Retry-After headers", func() {
+			now := time.Date(2021, 10, 1, 10, 0, 0, 0, time.UTC)
+			Expect(router_utils.ParseRetryAfter("120", now)).To(Equal(2 * time.Minute))
+			Expect(router_utils.ParseRetryAfter("Fri, 01 Oct 2021 10:01:00 GMT", now)).To(Equal(time.Minute))
+			Expect(router_utils.ParseRetryAfter("Fri, 01 Oct 2021 09:59:00 GMT", now)).To(BeZero())
+			Expect(router_utils.ParseRetryAfter("soon", now)).To(BeZero())
+			Expect(router_utils.ParseRetryAfter("", now)).To(BeZero())
+		})
+	})
+})
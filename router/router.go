@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"math"
 	"math/rand"
 	"net/http"
 	"sort"
@@ -15,7 +14,6 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/cenkalti/backoff/v4"
 	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
 	"github.com/rudderlabs/rudder-server/processor/integrations"
 	"github.com/rudderlabs/rudder-server/router/customdestinationmanager"
@@ -96,6 +94,8 @@ type HandleT struct {
 	transformer                            transformer.Transformer
 	configSubscriberLock                   sync.RWMutex
 	destinationsMap                        map[string]*router_utils.BatchDestinationT // destinationID -> destination
+	orderingPolicies                       map[string]orderingPolicyT                 // destinationID -> ordering policy
+	retryPolicies                          map[string]retryPolicyT                    // destinationID -> retry policy
 	logger                                 logger.LoggerI
 	batchInputCountStat                    stats.RudderStats
 	batchOutputCountStat                   stats.RudderStats
@@ -167,7 +167,7 @@ type workerT struct {
 	workerID                   int                     // identifies the worker
 	failedJobs                 int                     // counts the failed jobs of a worker till it gets reset by external channel
 	sleepTime                  time.Duration           // the sleep duration for every job of the worker
-	failedJobIDMap             map[string]int64        // user to failed jobId
+	failedJobIDMap             map[string]int64        // ordering key to failed jobId
 	failedJobIDMutex           sync.RWMutex            // lock to protect structure above
 	retryForJobMap             map[int64]time.Time     // jobID to next retry time map
 	retryForJobMapMutex        sync.RWMutex            // lock to protect structure above
@@ -202,6 +202,7 @@ var (
 	toAbortDestinationIDs                                         string
 	QueryFilters                                                  jobsdb.QueryFiltersT
 	disableEgress                                                 bool
+	deliveryModeRecordsLimit                                      int
 )
 
 type requestMetric struct {
@@ -223,13 +224,6 @@ func isSuccessStatus(status int) bool {
 	return status >= 200 && status < 300
 }
 
-func isJobTerminated(status int) bool {
-	if status == 429 {
-		return false
-	}
-
-	return status >= 200 && status < 500
-}
 
 func loadConfig() {
 	config.RegisterIntConfigVariable(10000, &jobQueryBatchSize, true, 1, "Router.jobQueryBatchSize")
@@ -241,6 +235,7 @@ func loadConfig() {
 	config.RegisterDurationConfigVariable(time.Duration(0), &minSleep, false, time.Second, []string{"Router.minSleep", "Router.minSleepInS"}...)
 	config.RegisterDurationConfigVariable(time.Duration(5), &maxStatusUpdateWait, true, time.Second, []string{"Router.maxStatusUpdateWait", "Router.maxStatusUpdateWaitInS"}...)
 	config.RegisterBoolConfigVariable(false, &disableEgress, false, "disableEgress")
+	config.RegisterIntConfigVariable(100, &deliveryModeRecordsLimit, true, 1, "Router.deliveryModeRecordsLimit")
 	// Time period for diagnosis ticker
 	config.RegisterDurationConfigVariable(time.Duration(60), &diagnosisTickerTime, false, time.Second, []string{"Diagnostics.routerTimePeriod", "Diagnostics.routerTimePeriodInS"}...)
 	config.RegisterDurationConfigVariable(time.Duration(10), &minRetryBackoff, true, time.Second, []string{"Router.minRetryBackoff", "Router.minRetryBackoffInS"}...)
@@ -376,15 +371,15 @@ func (worker *workerT) workerProcess() {
 
 			var isPrevFailedUser bool
 			var previousFailedJobID int64
-			if worker.rt.guaranteeUserEventOrder {
-				//If there is a failed jobID from this user, we cannot pass future jobs
+			if orderingKey, ordered := worker.rt.orderingKeyOf(job); ordered {
+				//If there is a failed jobID for this ordering key, we cannot pass future jobs
 				worker.failedJobIDMutex.RLock()
-				previousFailedJobID, isPrevFailedUser = worker.failedJobIDMap[userID]
+				previousFailedJobID, isPrevFailedUser = worker.failedJobIDMap[orderingKey]
 				worker.failedJobIDMutex.RUnlock()
 
-				// mark job as waiting if prev job from same user has not succeeded yet
+				// mark job as waiting if prev job of same ordering key has not succeeded yet
 				if isPrevFailedUser {
-					markedAsWaiting := worker.handleJobForPrevFailedUser(job, parameters, userID, previousFailedJobID)
+					markedAsWaiting := worker.handleJobForPrevFailedUser(job, parameters, orderingKey, previousFailedJobID)
 					if markedAsWaiting {
 						worker.rt.logger.Debugf(`Decrementing in throttle map for destination:%s since job:%d is marked as waiting for user:%s`, parameters.DestinationID, job.JobID, userID)
 						worker.rt.throttler.Dec(parameters.DestinationID, userID, 1, worker.throttledAtTime, throttler.ALL_LEVELS)
@@ -497,13 +492,19 @@ func (worker *workerT) processDestinationJobs() {
 	worker.jobCountsByDestAndUser = make(map[string]*destJobCountsT)
 }
 
-func (worker *workerT) canSendJobToDestination(prevRespStatusCode int, failedUserIDsMap map[string]struct{}, destinationJob types.DestinationJobT) bool {
+func (worker *workerT) canSendJobToDestination(prevRespStatusCode int, failedOrderingKeysMap map[string]struct{}, destinationJob types.DestinationJobT) bool {
 	if prevRespStatusCode == 0 {
 		return true
 	}
 
-	if !worker.rt.guaranteeUserEventOrder {
-		//if guaranteeUserEventOrder is false, letting the next jobs pass
+	var orderingKeys []string
+	for _, metadata := range destinationJob.JobMetadataArray {
+		if orderingKey, ordered := worker.rt.orderingKeyOf(metadata.JobT); ordered {
+			orderingKeys = append(orderingKeys, orderingKey)
+		}
+	}
+	if len(orderingKeys) == 0 {
+		//if the destination doesn't order its events, letting the next jobs pass
 		return true
 	}
 
@@ -513,9 +514,9 @@ func (worker *workerT) canSendJobToDestination(prevRespStatusCode int, failedUse
 	}
 
 	//If the destinationJob has come through router transform,
-	//drop the request if it is of a failed user, else send
-	for _, metadata := range destinationJob.JobMetadataArray {
-		if _, ok := failedUserIDsMap[metadata.UserID]; ok {
+	//drop the request if it is of a failed ordering key, else send
+	for _, orderingKey := range orderingKeys {
+		if _, ok := failedOrderingKeysMap[orderingKey]; ok {
 			return false
 		}
 	}
@@ -551,6 +552,7 @@ func (worker *workerT) handleWorkerDestinationJobs(ctx context.Context) {
 	worker.batchTimeStat.Start()
 
 	var respContentType string
+	var respRetryAfter time.Duration
 	var respStatusCode, prevRespStatusCode int
 	var respBody string
 	var respBodyTemp string
@@ -598,20 +600,22 @@ func (worker *workerT) handleWorkerDestinationJobs(ctx context.Context) {
 		u2e3 will send
 	*/
 
-	failedUserIDsMap := make(map[string]struct{})
+	failedOrderingKeysMap := make(map[string]struct{})
 	apiCallsCount := make(map[string]*destJobCountsT)
 	routerJobResponses := make([]*RouterJobResponse, 0)
 	for _, destinationJob := range worker.destinationJobs {
 		var attemptedToSendTheJob bool
+		respRetryAfter = 0
 		respBodyArr := make([]string, 0)
 		if destinationJob.StatusCode == 200 || destinationJob.StatusCode == 0 {
-			if worker.canSendJobToDestination(prevRespStatusCode, failedUserIDsMap, destinationJob) {
+			if worker.canSendJobToDestination(prevRespStatusCode, failedOrderingKeysMap, destinationJob) {
 				diagnosisStartTime := time.Now()
 				sourceID := destinationJob.JobMetadataArray[0].SourceID
 				destinationID := destinationJob.JobMetadataArray[0].DestinationID
 
 				worker.recordAPICallCount(apiCallsCount, destinationID, destinationJob.JobMetadataArray)
 				transformAt := destinationJob.JobMetadataArray[0].TransformAt
+				deliveryMode, shadowURL := worker.rt.getDeliveryMode(destinationID)
 
 				// START: request to destination endpoint
 				worker.deliveryTimeStat.Start()
@@ -634,7 +638,12 @@ func (worker *workerT) handleWorkerDestinationJobs(ctx context.Context) {
 							panic(fmt.Errorf("different destinations are grouped together"))
 						}
 					}
-					respStatusCode, respBody = worker.rt.customDestinationManager.SendData(destinationJob.Message, sourceID, destinationID)
+					if deliveryMode == DryRunDeliveryMode {
+						requestRecorder.record(newRecordedRequest(deliveryMode, &destinationJob, nil))
+						respStatusCode, respBody = dryRunStatusCode, dryRunResponse
+					} else {
+						respStatusCode, respBody = worker.rt.customDestinationManager.SendData(destinationJob.Message, sourceID, destinationID)
+					}
 				} else {
 					result := getIterableStruct(destinationJob.Message, transformAt)
 					for _, val := range result {
@@ -648,7 +657,10 @@ func (worker *workerT) handleWorkerDestinationJobs(ctx context.Context) {
 							sendCtx, cancel := context.WithTimeout(ctx, worker.rt.netClientTimeout)
 							defer cancel()
 							//transformer proxy start
-							if worker.rt.transformerProxy {
+							if deliveryMode == DryRunDeliveryMode {
+								requestRecorder.record(newRecordedRequest(deliveryMode, &destinationJob, &val))
+								respStatusCode, respBodyTemp = dryRunStatusCode, dryRunResponse
+							} else if worker.rt.transformerProxy {
 								rtl_time := time.Now()
 								respStatusCode, respBodyTemp = worker.rt.transformer.ProxyRequest(ctx, val, worker.rt.destName)
 								worker.routerProxyStat.SendTiming(time.Since(rtl_time))
@@ -667,9 +679,17 @@ func (worker *workerT) handleWorkerDestinationJobs(ctx context.Context) {
 									})
 								}
 							} else {
+								var recordShadowComparison func(statusCode int, responseBody string)
+								if deliveryMode == ShadowDeliveryMode {
+									recordShadowComparison = worker.shadowPost(&destinationJob, val, shadowURL)
+								}
 								rdl_time := time.Now()
 								resp := worker.rt.netHandle.SendPost(sendCtx, val)
 								respStatusCode, respBodyTemp, respContentType = resp.StatusCode, string(resp.ResponseBody), resp.ResponseContentType
+								respRetryAfter = resp.RetryAfter
+								if recordShadowComparison != nil {
+									recordShadowComparison(respStatusCode, respBodyTemp)
+								}
 								// stat end
 								worker.routerDeliveryLatencyStat.SendTiming(time.Since(rdl_time))
 							}
@@ -688,7 +708,7 @@ func (worker *workerT) handleWorkerDestinationJobs(ctx context.Context) {
 
 				//Using reponse status code and body to get response code rudder router logic is based on.
 				// Works when transformer proxy in disabled
-				if !worker.rt.transformerProxy && destinationResponseHandler != nil {
+				if !worker.rt.transformerProxy && destinationResponseHandler != nil && deliveryMode != DryRunDeliveryMode {
 					respStatusCode = destinationResponseHandler.IsSuccessStatus(respStatusCode, respBody)
 				}
 
@@ -698,7 +718,7 @@ func (worker *workerT) handleWorkerDestinationJobs(ctx context.Context) {
 				deliveryLatencyStat.End()
 				// END: request to destination endpoint
 
-				if isSuccessStatus(respStatusCode) && !worker.rt.saveDestinationResponseOverride {
+				if isSuccessStatus(respStatusCode) && respStatusCode != dryRunStatusCode && !worker.rt.saveDestinationResponseOverride {
 					if saveDestinationResponse {
 						if !getRouterConfigBool("saveDestinationResponse", worker.rt.destName, true) {
 							respBody = ""
@@ -708,7 +728,9 @@ func (worker *workerT) handleWorkerDestinationJobs(ctx context.Context) {
 					}
 				}
 
-				worker.updateReqMetrics(respStatusCode, &diagnosisStartTime)
+				if deliveryMode != DryRunDeliveryMode {
+					worker.updateReqMetrics(respStatusCode, &diagnosisStartTime)
+				}
 			} else {
 				respStatusCode = 500
 				if !worker.rt.enableBatching {
@@ -722,9 +744,14 @@ func (worker *workerT) handleWorkerDestinationJobs(ctx context.Context) {
 
 		prevRespStatusCode = respStatusCode
 
-		if !isJobTerminated(respStatusCode) {
+		if !isSuccessStatus(respStatusCode) {
 			for _, metadata := range destinationJob.JobMetadataArray {
-				failedUserIDsMap[metadata.UserID] = struct{}{}
+				if worker.rt.isJobTerminated(metadata.DestinationID, respStatusCode) {
+					continue
+				}
+				if orderingKey, ordered := worker.rt.orderingKeyOf(metadata.JobT); ordered {
+					failedOrderingKeysMap[orderingKey] = struct{}{}
+				}
 			}
 		}
 
@@ -745,6 +772,7 @@ func (worker *workerT) handleWorkerDestinationJobs(ctx context.Context) {
 				destinationJobMetadata: &_destinationJobMetadata,
 				respStatusCode:         respStatusCode,
 				respBody:               respBody,
+				respRetryAfter:         respRetryAfter,
 				attemptedToSendTheJob:  attemptedToSendTheJob,
 			})
 		}
@@ -794,7 +822,7 @@ func (worker *workerT) handleWorkerDestinationJobs(ctx context.Context) {
 		status.ErrorResponse = []byte(`{}`)
 		status.ErrorCode = strconv.Itoa(respStatusCode)
 
-		worker.postStatusOnResponseQ(respStatusCode, routerJobResponse.respBody, destinationJob.Message, respContentType, destinationJobMetadata, &status)
+		worker.postStatusOnResponseQ(respStatusCode, routerJobResponse.respBody, destinationJob.Message, respContentType, routerJobResponse.respRetryAfter, destinationJobMetadata, &status)
 
 		worker.sendEventDeliveryStat(destinationJobMetadata, &status, &destinationJob.Destination)
 
@@ -839,7 +867,7 @@ func (worker *workerT) handleWorkerDestinationJobs(ctx context.Context) {
 				Parameters:    []byte(`{}`),
 			}
 
-			worker.postStatusOnResponseQ(500, "transformer failed to handle this job", nil, "", &routerJob.JobMetadata, &status)
+			worker.postStatusOnResponseQ(500, "transformer failed to handle this job", nil, "", 0, &routerJob.JobMetadata, &status)
 		}
 	}
 
@@ -852,6 +880,7 @@ type RouterJobResponse struct {
 	destinationJobMetadata *types.JobMetadataT
 	respStatusCode         int
 	respBody               string
+	respRetryAfter         time.Duration
 	attemptedToSendTheJob  bool
 	status                 *jobsdb.JobStatusT
 }
@@ -950,7 +979,7 @@ func (worker *workerT) updateAbortedMetrics(destinationID, statusCode string) {
 }
 
 func (worker *workerT) postStatusOnResponseQ(respStatusCode int, respBody string, payload json.RawMessage,
-	respContentType string, destinationJobMetadata *types.JobMetadataT, status *jobsdb.JobStatusT) {
+	respContentType string, respRetryAfter time.Duration, destinationJobMetadata *types.JobMetadataT, status *jobsdb.JobStatusT) {
 	//Enhancing status.ErrorResponse with firstAttemptedAt
 	firstAttemptedAtTime := time.Now()
 	if destinationJobMetadata.FirstAttemptedAt != "" {
@@ -965,16 +994,19 @@ func (worker *workerT) postStatusOnResponseQ(respStatusCode int, respBody string
 	status.ErrorResponse = router_utils.EnhanceJSON(status.ErrorResponse, "response", respBody)
 	status.ErrorResponse = router_utils.EnhanceJSON(status.ErrorResponse, "content-type", respContentType)
 
+	orderingKey, ordered := worker.rt.orderingKeyOf(destinationJobMetadata.JobT)
 	if isSuccessStatus(respStatusCode) {
-		atomic.AddUint64(&worker.rt.successCount, 1)
+		if respStatusCode != dryRunStatusCode {
+			atomic.AddUint64(&worker.rt.successCount, 1)
+		}
 		status.JobState = jobsdb.Succeeded.State
 		worker.rt.logger.Debugf("[%v Router] :: sending success status to response", worker.rt.destName)
 		worker.rt.responseQ <- jobResponseT{status: status, worker: worker, userID: destinationJobMetadata.UserID, JobT: destinationJobMetadata.JobT}
 
-		if worker.rt.guaranteeUserEventOrder {
-			//Removing the user from aborted user map
+		if ordered {
+			//Removing the ordering key from aborted user map
 			worker.abortedUserMutex.Lock()
-			delete(worker.abortedUserIDMap, destinationJobMetadata.UserID)
+			delete(worker.abortedUserIDMap, orderingKey)
 			worker.abortedUserMutex.Unlock()
 		}
 
@@ -1005,24 +1037,22 @@ func (worker *workerT) postStatusOnResponseQ(respStatusCode int, respBody string
 
 		worker.rt.failedEventsChan <- *status
 
-		if respStatusCode >= 500 {
-			timeElapsed := time.Since(firstAttemptedAtTime)
-			if timeElapsed > worker.rt.retryTimeWindow && status.AttemptNum >= worker.rt.maxFailedCountForJob {
-				status.JobState = jobsdb.Aborted.State
-				worker.retryForJobMapMutex.Lock()
-				delete(worker.retryForJobMap, destinationJobMetadata.JobID)
-				worker.retryForJobMapMutex.Unlock()
-			} else {
-				worker.retryForJobMapMutex.Lock()
-				worker.retryForJobMap[destinationJobMetadata.JobID] = time.Now().Add(durationBeforeNextAttempt(status.AttemptNum))
-				worker.retryForJobMapMutex.Unlock()
-			}
-		} else if respStatusCode == 429 {
+		retryPolicy := worker.rt.retryPolicyOf(destinationJobMetadata.DestinationID)
+		if !retryPolicy.isRetryable(respStatusCode) {
+			status.JobState = jobsdb.Aborted.State
+			status.ErrorResponse = router_utils.EnhanceJSON(status.ErrorResponse, "abortReason", "response status code is not retryable")
+		} else if respStatusCode != http.StatusTooManyRequests && worker.rt.retryLimitsReached(&retryPolicy, status.AttemptNum, firstAttemptedAtTime) {
+			status.JobState = jobsdb.Aborted.State
+			status.ErrorResponse = router_utils.EnhanceJSON(status.ErrorResponse, "abortReason", "retry limits reached")
 			worker.retryForJobMapMutex.Lock()
-			worker.retryForJobMap[destinationJobMetadata.JobID] = time.Now().Add(durationBeforeNextAttempt(status.AttemptNum))
+			delete(worker.retryForJobMap, destinationJobMetadata.JobID)
 			worker.retryForJobMapMutex.Unlock()
 		} else {
-			status.JobState = jobsdb.Aborted.State
+			nextAttemptAt := time.Now().Add(retryPolicy.durationBeforeNextAttempt(status.AttemptNum, respRetryAfter))
+			status.ErrorResponse = router_utils.EnhanceJSON(status.ErrorResponse, "nextAttemptAt", nextAttemptAt.Format(misc.RFC3339Milli))
+			worker.retryForJobMapMutex.Lock()
+			worker.retryForJobMap[destinationJobMetadata.JobID] = nextAttemptAt
+			worker.retryForJobMapMutex.Unlock()
 		}
 
 		if status.JobState == jobsdb.Aborted.State {
@@ -1030,35 +1060,36 @@ func (worker *workerT) postStatusOnResponseQ(respStatusCode int, respBody string
 			worker.updateAbortedMetrics(destinationJobMetadata.DestinationID, status.ErrorCode)
 			destinationJobMetadata.JobT.Parameters = misc.UpdateJSONWithNewKeyVal(destinationJobMetadata.JobT.Parameters, "stage", "router")
 			destinationJobMetadata.JobT.Parameters = misc.UpdateJSONWithNewKeyVal(destinationJobMetadata.JobT.Parameters, "reason", status.ErrorResponse) //NOTE: Old key used was "error_response"
+			destinationJobMetadata.JobT.Parameters = misc.UpdateJSONWithNewKeyVal(destinationJobMetadata.JobT.Parameters, "error_code", status.ErrorCode)
 		}
 
-		if worker.rt.guaranteeUserEventOrder {
+		if ordered {
 			if addToFailedMap {
 				//#JobOrder (see other #JobOrder comment)
 				worker.failedJobIDMutex.RLock()
-				_, isPrevFailedUser := worker.failedJobIDMap[destinationJobMetadata.UserID]
+				_, isPrevFailedUser := worker.failedJobIDMap[orderingKey]
 				worker.failedJobIDMutex.RUnlock()
-				if !isPrevFailedUser && destinationJobMetadata.UserID != "" {
-					worker.rt.logger.Errorf("[%v Router] :: userId %v failed for the first time adding to map", worker.rt.destName, destinationJobMetadata.UserID)
+				if !isPrevFailedUser && orderingKey != "" {
+					worker.rt.logger.Errorf("[%v Router] :: ordering key %v failed for the first time adding to map", worker.rt.destName, orderingKey)
 					worker.failedJobIDMutex.Lock()
-					worker.failedJobIDMap[destinationJobMetadata.UserID] = destinationJobMetadata.JobID
+					worker.failedJobIDMap[orderingKey] = destinationJobMetadata.JobID
 					worker.failedJobIDMutex.Unlock()
 				}
 			} else {
 				//Job is aborted.
-				//So, adding the user to aborted map, if not already present.
-				//If user is present in the aborted map, decrementing the count.
-				//This map is used to limit the pick up of aborted users's job.
+				//So, adding the ordering key to aborted map, if not already present.
+				//If ordering key is present in the aborted map, decrementing the count.
+				//This map is used to limit the pick up of aborted ordering keys's job.
 				worker.abortedUserMutex.Lock()
-				worker.rt.logger.Debugf("[%v Router] :: adding userID to abortedUserMap : %s", worker.rt.destName, destinationJobMetadata.UserID)
-				count, ok := worker.abortedUserIDMap[destinationJobMetadata.UserID]
+				worker.rt.logger.Debugf("[%v Router] :: adding ordering key to abortedUserMap : %s", worker.rt.destName, orderingKey)
+				count, ok := worker.abortedUserIDMap[orderingKey]
 				if !ok {
-					worker.abortedUserIDMap[destinationJobMetadata.UserID] = 0
+					worker.abortedUserIDMap[orderingKey] = 0
 				} else {
 					//Decrementing the count.
-					//This is necessary to let other jobs of the same user to get a worker.
+					//This is necessary to let other jobs of the same ordering key to get a worker.
 					count--
-					worker.abortedUserIDMap[destinationJobMetadata.UserID] = count
+					worker.abortedUserIDMap[orderingKey] = count
 				}
 
 				worker.abortedUserMutex.Unlock()
@@ -1082,7 +1113,8 @@ func (worker *workerT) sendRouterResponseCountStat(destinationJobMetadata *types
 
 func (worker *workerT) sendEventDeliveryStat(destinationJobMetadata *types.JobMetadataT, status *jobsdb.JobStatusT, destination *backendconfig.DestinationT) {
 	destinationTag := misc.GetTagName(destination.ID, destination.Name)
-	if status.JobState == jobsdb.Succeeded.State {
+	//jobs delivered in dry run mode aren't sent to the destination
+	if status.JobState == jobsdb.Succeeded.State && status.ErrorCode != strconv.Itoa(dryRunStatusCode) {
 		eventsDeliveredStat := stats.NewTaggedStat("event_delivery", stats.CountType, stats.Tags{
 			"module":         "router",
 			"destType":       worker.rt.destName,
@@ -1124,43 +1156,28 @@ func (worker *workerT) sendDestinationResponseToConfigBackend(payload json.RawMe
 	destinationdebugger.RecordEventDeliveryStatus(destinationJobMetadata.DestinationID, &deliveryStatus)
 }
 
-func (worker *workerT) handleJobForPrevFailedUser(job *jobsdb.JobT, parameters JobParametersT, userID string, previousFailedJobID int64) (markedAsWaiting bool) {
-	// job is behind in queue of failed job from same user
+func (worker *workerT) handleJobForPrevFailedUser(job *jobsdb.JobT, parameters JobParametersT, orderingKey string, previousFailedJobID int64) (markedAsWaiting bool) {
+	// job is behind in queue of failed job of same ordering key
 	if previousFailedJobID < job.JobID {
-		worker.rt.logger.Debugf("[%v Router] :: skipping processing job for userID: %v since prev failed job exists, prev id %v, current id %v", worker.rt.destName, userID, previousFailedJobID, job.JobID)
-		resp := fmt.Sprintf(`{"blocking_id":"%v", "user_id":"%s"}`, previousFailedJobID, userID)
+		worker.rt.logger.Debugf("[%v Router] :: skipping processing job for ordering key: %v since prev failed job exists, prev id %v, current id %v", worker.rt.destName, orderingKey, previousFailedJobID, job.JobID)
+		resp, _ := json.Marshal(map[string]string{"blocking_id": strconv.FormatInt(previousFailedJobID, 10), "user_id": job.UserID, "ordering_key": orderingKey})
 		status := jobsdb.JobStatusT{
 			JobID:         job.JobID,
 			AttemptNum:    job.LastJobStatus.AttemptNum,
 			ExecTime:      time.Now(),
 			RetryTime:     time.Now(),
 			JobState:      jobsdb.Waiting.State,
-			ErrorResponse: []byte(resp), // check
+			ErrorResponse: resp, // check
 			Parameters:    []byte(`{}`),
 		}
-		worker.rt.responseQ <- jobResponseT{status: &status, worker: worker, userID: userID, JobT: job}
+		worker.rt.responseQ <- jobResponseT{status: &status, worker: worker, userID: job.UserID, JobT: job}
 		return true
 	}
-	if previousFailedJobID != job.JobID {
-		panic(fmt.Errorf("previousFailedJobID:%d != job.JobID:%d", previousFailedJobID, job.JobID))
-	}
+	//jobs before the failed job can only be seen after the ordering policy of the destination changes,
+	//they are delivered as they precede the failed job
 	return false
 }
 
-func durationBeforeNextAttempt(attempt int) (d time.Duration) {
-	b := backoff.NewExponentialBackOff()
-	b.InitialInterval = minRetryBackoff
-	b.MaxInterval = maxRetryBackoff
-	b.RandomizationFactor = 0
-	b.MaxElapsedTime = 0
-	b.Multiplier = 2
-	b.Reset()
-	for index := 0; index < attempt; index++ {
-		d = b.NextBackOff()
-	}
-	return
-}
-
 func (rt *HandleT) addToFailedList(jobStatus jobsdb.JobStatusT) {
 	rt.failedEventsListMutex.Lock()
 	defer rt.failedEventsListMutex.Unlock()
@@ -1269,49 +1286,45 @@ func (rt *HandleT) findWorker(job *jobsdb.JobT, throttledAtTime time.Time) (toSe
 		return nil
 	}
 
-	if !rt.guaranteeUserEventOrder {
-		//if guaranteeUserEventOrder is false, assigning worker randomly and returning here.
+	orderingKey, ordered := rt.orderingKeyOf(job)
+	if !ordered {
+		//if the destination doesn't order its events, assigning worker randomly and returning here.
 		return rt.workers[rand.Intn(rt.noOfWorkers)]
 	}
 
-	index := int(math.Abs(float64(misc.GetHash(userID) % rt.noOfWorkers)))
-
-	worker := rt.workers[index]
+	worker := rt.workers[rt.workerIndexFor(orderingKey)]
 
 	//#JobOrder (see other #JobOrder comment)
 	worker.failedJobIDMutex.RLock()
 	defer worker.failedJobIDMutex.RUnlock()
-	blockJobID, found := worker.failedJobIDMap[userID]
+	blockJobID, found := worker.failedJobIDMap[orderingKey]
 	if !found {
-		//not a failed user
-		//checking if he is an aborted user,
+		//not a failed ordering key
+		//checking if it is an aborted ordering key,
 		//if yes returning worker only for 1 job
 		worker.abortedUserMutex.Lock()
 		defer worker.abortedUserMutex.Unlock()
-		if count, ok := worker.abortedUserIDMap[userID]; ok {
+		if count, ok := worker.abortedUserIDMap[orderingKey]; ok {
 			if count >= rt.allowAbortedUserJobsCountForProcessing {
-				rt.logger.Debugf("[%v Router] :: allowed jobs count(%d) >= allowAbortedUserJobsCountForProcessing(%d) for userID %s. returing nil worker", rt.destName, count, rt.allowAbortedUserJobsCountForProcessing, userID)
+				rt.logger.Debugf("[%v Router] :: allowed jobs count(%d) >= allowAbortedUserJobsCountForProcessing(%d) for ordering key %s. returing nil worker", rt.destName, count, rt.allowAbortedUserJobsCountForProcessing, orderingKey)
 				return nil
 			}
 
-			rt.logger.Debugf("[%v Router] :: userID found in abortedUserIDtoJobMap: %s. Allowing jobID: %d. returing worker", rt.destName, userID, job.JobID)
+			rt.logger.Debugf("[%v Router] :: ordering key found in abortedUserIDtoJobMap: %s. Allowing jobID: %d. returing worker", rt.destName, orderingKey, job.JobID)
 			// incrementing abortedUserIDMap after all checks of backoff, throttle etc are made
 			// We don't need lock inside this defer func, because we already hold the lock above and this
 			// defer is called before defer Unlock
 			defer func() {
 				if toSendWorker != nil {
-					toSendWorker.abortedUserIDMap[userID] = toSendWorker.abortedUserIDMap[userID] + 1
+					toSendWorker.abortedUserIDMap[orderingKey] = toSendWorker.abortedUserIDMap[orderingKey] + 1
 				}
 			}()
 		}
 		toSendWorker = worker
 	} else {
-		//This job can only be higher than blocking
-		//We only let the blocking job pass
-		if job.JobID < blockJobID {
-			panic(fmt.Errorf("job.JobID:%d < blockJobID:%d", job.JobID, blockJobID))
-		}
-		if job.JobID == blockJobID {
+		//This job can only be higher than blocking, unless the ordering policy of the destination changed
+		//We only let the blocking job & the jobs preceding it pass
+		if job.JobID <= blockJobID {
 			toSendWorker = worker
 		}
 	}
@@ -1489,30 +1502,31 @@ func (rt *HandleT) commitStatusList(responseList *[]jobResponseT) {
 		rt.jobsDB.ReleaseUpdateJobStatusLocks()
 	}
 
-	if rt.guaranteeUserEventOrder {
-		//#JobOrder (see other #JobOrder comment)
-		for _, resp := range *responseList {
-			status := resp.status.JobState
-			userID := resp.userID
-			worker := resp.worker
-			if status == jobsdb.Succeeded.State || status == jobsdb.Aborted.State {
-				worker.failedJobIDMutex.RLock()
-				lastJobID, ok := worker.failedJobIDMap[userID]
-				worker.failedJobIDMutex.RUnlock()
-				if ok && lastJobID == resp.status.JobID {
-					rt.toClearFailJobIDMutex.Lock()
-					rt.logger.Debugf("[%v Router] :: clearing failedJobIDMap for userID: %v", rt.destName, userID)
-					_, ok := rt.toClearFailJobIDMap[worker.workerID]
-					if !ok {
-						rt.toClearFailJobIDMap[worker.workerID] = make([]string, 0)
-					}
-					rt.toClearFailJobIDMap[worker.workerID] = append(rt.toClearFailJobIDMap[worker.workerID], userID)
-					rt.toClearFailJobIDMutex.Unlock()
+	//#JobOrder (see other #JobOrder comment)
+	for _, resp := range *responseList {
+		status := resp.status.JobState
+		worker := resp.worker
+		if status == jobsdb.Succeeded.State || status == jobsdb.Aborted.State {
+			orderingKey, ordered := rt.orderingKeyOf(resp.JobT)
+			if !ordered {
+				continue
+			}
+			worker.failedJobIDMutex.RLock()
+			lastJobID, ok := worker.failedJobIDMap[orderingKey]
+			worker.failedJobIDMutex.RUnlock()
+			if ok && lastJobID == resp.status.JobID {
+				rt.toClearFailJobIDMutex.Lock()
+				rt.logger.Debugf("[%v Router] :: clearing failedJobIDMap for ordering key: %v", rt.destName, orderingKey)
+				_, ok := rt.toClearFailJobIDMap[worker.workerID]
+				if !ok {
+					rt.toClearFailJobIDMap[worker.workerID] = make([]string, 0)
 				}
+				rt.toClearFailJobIDMap[worker.workerID] = append(rt.toClearFailJobIDMap[worker.workerID], orderingKey)
+				rt.toClearFailJobIDMutex.Unlock()
 			}
 		}
-		//End #JobOrder
 	}
+	//End #JobOrder
 }
 
 // statusInsertLoop will run in a separate goroutine
@@ -1717,21 +1731,19 @@ func (rt *HandleT) generatorLoop(ctx context.Context) {
 }
 
 func (rt *HandleT) readAndProcess() int {
-	if rt.guaranteeUserEventOrder {
-		//#JobOrder (See comment marked #JobOrder
-		rt.toClearFailJobIDMutex.Lock()
-		for idx := range rt.toClearFailJobIDMap {
-			wrk := rt.workers[idx]
-			wrk.failedJobIDMutex.Lock()
-			for _, userID := range rt.toClearFailJobIDMap[idx] {
-				delete(wrk.failedJobIDMap, userID)
-			}
-			wrk.failedJobIDMutex.Unlock()
+	//#JobOrder (See comment marked #JobOrder
+	rt.toClearFailJobIDMutex.Lock()
+	for idx := range rt.toClearFailJobIDMap {
+		wrk := rt.workers[idx]
+		wrk.failedJobIDMutex.Lock()
+		for _, orderingKey := range rt.toClearFailJobIDMap[idx] {
+			delete(wrk.failedJobIDMap, orderingKey)
 		}
-		rt.toClearFailJobIDMap = make(map[int][]string)
-		rt.toClearFailJobIDMutex.Unlock()
-		//End of #JobOrder
+		wrk.failedJobIDMutex.Unlock()
 	}
+	rt.toClearFailJobIDMap = make(map[int][]string)
+	rt.toClearFailJobIDMutex.Unlock()
+	//End of #JobOrder
 
 	toQuery := jobQueryBatchSize
 	retryList := rt.jobsDB.GetToRetry(jobsdb.GetQueryParamsT{CustomValFilters: []string{rt.destName}, JobCount: toQuery})
@@ -2013,6 +2025,7 @@ func (rt *HandleT) Start() {
 	ctx := rt.backgroundCtx
 	rt.backgroundGroup.Go(func() error {
 		<-rt.backendConfigInitialized
+		rt.restoreOrderingBlocks()
 		rt.generatorLoop(ctx)
 		return nil
 	})
@@ -2038,6 +2051,8 @@ func (rt *HandleT) backendConfigSubscriber() {
 		config := <-ch
 		rt.configSubscriberLock.Lock()
 		rt.destinationsMap = map[string]*router_utils.BatchDestinationT{}
+		rt.orderingPolicies = map[string]orderingPolicyT{}
+		rt.retryPolicies = map[string]retryPolicyT{}
 		allSources := config.Data.(backendconfig.ConfigT)
 		for _, source := range allSources.Sources {
 			if len(source.Destinations) > 0 {
@@ -2047,6 +2062,12 @@ func (rt *HandleT) backendConfigSubscriber() {
 							rt.destinationsMap[destination.ID] = &router_utils.BatchDestinationT{Destination: destination, Sources: []backendconfig.SourceT{}}
 						}
 						rt.destinationsMap[destination.ID].Sources = append(rt.destinationsMap[destination.ID].Sources, source)
+						rt.orderingPolicies[destination.ID] = rt.loadOrderingPolicy(destination.ID)
+						retryPolicy, err := getRetryPolicy(&destination)
+						if err != nil {
+							rt.logger.Errorf("[%v Router] :: Ignoring retry policy of destination %s: %v", rt.destName, destination.ID, err)
+						}
+						rt.retryPolicies[destination.ID] = retryPolicy
 
 						rt.destinationResponseHandler = New(destination.DestinationDefinition.ResponseRules)
 						if value, ok := destination.DestinationDefinition.Config["saveDestinationResponse"].(bool); ok {
@@ -2128,6 +2149,7 @@ func (rt *HandleT) Pause() {
 		worker.retryForJobMap = make(map[int64]time.Time)
 		worker.abortedUserIDMap = make(map[string]int)
 	}
+	rt.restoreOrderingBlocks()
 
 	rt.paused = true
 }
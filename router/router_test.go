@@ -3,12 +3,14 @@ package router
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"time"
 
 	uuid "github.com/gofrs/uuid"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/tidwall/gjson"
 
 	"github.com/rudderlabs/rudder-server/admin"
 	"github.com/rudderlabs/rudder-server/config"
@@ -208,6 +210,63 @@ var _ = Describe("Router", func() {
 			time.Sleep(3 * time.Second)
 		})
 
+		It("should record jobs of destinations in dry run mode instead of sending them", func() {
+			os.Setenv("RSERVER_ROUTER_DID1_DELIVERY_MODE", DryRunDeliveryMode)
+			defer os.Unsetenv("RSERVER_ROUTER_DID1_DELIVERY_MODE")
+			defer requestRecorder.clear(GADestinationID)
+			router := &HandleT{}
+
+			router.Setup(c.mockBackendConfig, c.mockRouterJobsDB, c.mockProcErrorsDB, gaDestinationDefinition, nil)
+			mockNetHandle := mocksRouter.NewMockNetHandleI(c.mockCtrl)
+			router.netHandle = mockNetHandle
+
+			gaPayload := `{"body": {"XML": {}, "FORM": {}, "JSON": {}}, "type": "REST", "files": {}, "method": "POST", "params": {"t": "event", "v": "1", "ea": "Demo Track", "cid": "anon_id", "tid": "UA-185645846-1"}, "userId": "anon_id", "headers": {}, "version": "1", "endpoint": "https://www.google-analytics.com/collect"}`
+			parameters := fmt.Sprintf(`{"source_id": "1fMCVYZboDlYlauh4GFsEo2JU77", "destination_id": "%s", "message_id": "2f548e6d-60f6-44af-a1f4-62b3272445c3", "received_at": "2021-06-28T10:04:48.527+05:30", "transform_at": "processor"}`, GADestinationID)
+
+			var unprocessedJobsList []*jobsdb.JobT = []*jobsdb.JobT{
+				{
+					UUID:         uuid.Must(uuid.NewV4()),
+					UserID:       "u1",
+					JobID:        2010,
+					CreatedAt:    time.Date(2020, 04, 28, 13, 26, 00, 00, time.UTC),
+					ExpireAt:     time.Date(2020, 04, 28, 13, 26, 00, 00, time.UTC),
+					CustomVal:    CustomVal["GA"],
+					EventPayload: []byte(gaPayload),
+					LastJobStatus: jobsdb.JobStatusT{
+						AttemptNum: 0,
+					},
+					Parameters: []byte(parameters),
+				},
+			}
+
+			callRetry := c.mockRouterJobsDB.EXPECT().GetToRetry(jobsdb.GetQueryParamsT{CustomValFilters: []string{CustomVal["GA"]}, JobCount: c.dbReadBatchSize}).Return(emptyJobsList).Times(1)
+			callWaiting := c.mockRouterJobsDB.EXPECT().GetWaiting(jobsdb.GetQueryParamsT{CustomValFilters: []string{CustomVal["GA"]}, JobCount: c.dbReadBatchSize}).Return(emptyJobsList).Times(1).After(callRetry)
+			c.mockRouterJobsDB.EXPECT().GetUnprocessed(jobsdb.GetQueryParamsT{CustomValFilters: []string{CustomVal["GA"]}, JobCount: c.dbReadBatchSize}).Return(unprocessedJobsList).Times(1).After(callWaiting)
+
+			c.mockRouterJobsDB.EXPECT().UpdateJobStatus(gomock.Any(), []string{CustomVal["GA"]}, nil).Times(1).Return(nil)
+
+			mockNetHandle.EXPECT().SendPost(gomock.Any(), gomock.Any()).Times(0)
+
+			callBeginTransaction := c.mockRouterJobsDB.EXPECT().BeginGlobalTransaction().Times(1).Return(nil)
+			callAcquireLocks := c.mockRouterJobsDB.EXPECT().AcquireUpdateJobStatusLocks().Times(1).After(callBeginTransaction)
+			callUpdateStatus := c.mockRouterJobsDB.EXPECT().UpdateJobStatusInTxn(gomock.Any(), gomock.Any(), []string{CustomVal["GA"]}, nil).Times(1).After(callAcquireLocks).
+				Do(func(_ interface{}, statuses []*jobsdb.JobStatusT, _ interface{}, _ interface{}) {
+					Expect(statuses).To(HaveLen(1))
+					Expect(statuses[0].JobState).To(Equal(jobsdb.Succeeded.State))
+					Expect(statuses[0].ErrorCode).To(Equal("299"))
+					Expect(gjson.GetBytes(statuses[0].ErrorResponse, "response").String()).To(Equal(dryRunResponse))
+				})
+			callCommitTransaction := c.mockRouterJobsDB.EXPECT().CommitTransaction(gomock.Any()).Times(1).After(callUpdateStatus)
+			c.mockRouterJobsDB.EXPECT().ReleaseUpdateJobStatusLocks().Times(1).After(callCommitTransaction)
+
+			<-router.backendConfigInitialized
+			count := router.readAndProcess()
+			Expect(count).To(Equal(1))
+
+			time.Sleep(3 * time.Second)
+			Expect(requestRecorder.get(GADestinationID)).To(HaveLen(1))
+		})
+
 		It("should abort unprocessed jobs to ga destination because of bad payload", func() {
 			router := &HandleT{}
 
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"github.com/rudderlabs/rudder-server/config"
+)
+
+//Ordering policies of a destination, set with Router.<destinationID>.orderingPolicy or Router.<destType>.orderingPolicy
+const (
+	//UserOrderingPolicy delivers the events of a user in order, a failed event holds back the later events of its user
+	UserOrderingPolicy = "user"
+	//KeyOrderingPolicy delivers the events with the same value at Router.<destinationID>.orderingKey (eg. groupId) in order
+	KeyOrderingPolicy = "key"
+	//NoOrderingPolicy delivers events out of order, so that a failed event doesn't hold back any other event
+	NoOrderingPolicy = "none"
+)
+
+//OrderingConfigString looks for the ordering config key in destination, destination type & global router config, in that order
+func OrderingConfigString(destType, destinationID, key, defaultValue string) string {
+	for _, configKey := range []string{"Router." + destinationID + "." + key, "Router." + destType + "." + key, "Router." + key} {
+		if config.IsSet(configKey) {
+			return config.GetString(configKey, defaultValue)
+		}
+	}
+	return defaultValue
+}
+
+//OrderingKeyPath returns the path of the ordering key in events of the destination if it orders events by key, empty otherwise.
+//Processor sets the value at the path in the event as ordering_key in job parameters, as payload of the job is the output of transformer.
+func OrderingKeyPath(destType, destinationID string) string {
+	if OrderingConfigString(destType, destinationID, "orderingPolicy", "") != KeyOrderingPolicy {
+		return ""
+	}
+	return OrderingConfigString(destType, destinationID, "orderingKey", "")
+}
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"encoding/base64"
+	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,6 +33,8 @@ type SendPostResponse struct {
 	StatusCode          int
 	ResponseContentType string
 	ResponseBody        []byte
+	//RetryAfter is the wait asked by the destination with the Retry-After header
+	RetryAfter time.Duration
 }
 
 func Init() {
@@ -67,6 +71,25 @@ func ToBeDrained(job *jobsdb.JobT, destID, toAbortDestinationIDs string, destina
 	return false, ""
 }
 
+//ParseRetryAfter returns the wait of a Retry-After header, given either in seconds or as an http date.
+//It returns 0 for invalid values & dates in the past.
+func ParseRetryAfter(retryAfter string, now time.Time) time.Duration {
+	retryAfter = strings.TrimSpace(retryAfter)
+	if retryAfter == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if retryAt, err := http.ParseTime(retryAfter); err == nil && retryAt.After(now) {
+		return retryAt.Sub(now)
+	}
+	return 0
+}
+
 //rawMsg passed must be a valid JSON
 func EnhanceJSON(rawMsg []byte, key, val string) []byte {
 	resp, err := sjson.SetBytes(rawMsg, key, val)
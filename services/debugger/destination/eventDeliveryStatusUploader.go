@@ -7,6 +7,7 @@ import (
 	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
 	"github.com/rudderlabs/rudder-server/rruntime"
 	"github.com/rudderlabs/rudder-server/services/debugger"
+	taildebugger "github.com/rudderlabs/rudder-server/services/debugger/tail"
 	"github.com/rudderlabs/rudder-server/utils"
 	"github.com/rudderlabs/rudder-server/utils/logger"
 	"sync"
@@ -55,6 +56,21 @@ type EventDeliveryStatusUploader struct {
 //RecordEventDeliveryStatus is used to put the delivery status in the deliveryStatusesBatchChannel,
 //which will be processed by handleJobs.
 func RecordEventDeliveryStatus(destinationID string, deliveryStatus *DeliveryStatusT) bool {
+	if taildebugger.HasSubscribers() {
+		taildebugger.Record(&taildebugger.EventT{
+			Stage:         taildebugger.DeliveryStage,
+			SourceID:      deliveryStatus.SourceID,
+			DestinationID: destinationID,
+			EventName:     deliveryStatus.EventName,
+			EventType:     deliveryStatus.EventType,
+			UserID:        taildebugger.GetUserID(deliveryStatus.Payload),
+			Payload:       deliveryStatus.Payload,
+			JobState:      deliveryStatus.JobState,
+			StatusCode:    deliveryStatus.ErrorCode,
+			Response:      deliveryStatus.ErrorResponse,
+		})
+	}
+
 	//if disableEventUploads is true, return;
 	if disableEventDeliveryStatusUploads {
 		return false
@@ -0,0 +1,314 @@
+package taildebugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/rudderlabs/rudder-server/admin"
+	"github.com/rudderlabs/rudder-server/config"
+	"github.com/rudderlabs/rudder-server/utils/logger"
+	"github.com/rudderlabs/rudder-server/utils/misc"
+)
+
+// stages of the pipeline at which events are tailed
+const (
+	GatewayStage            = "gateway"
+	UserTransformationStage = "user_transformation"
+	DeliveryStage           = "delivery"
+)
+
+// EventT is an event seen at a stage of the pipeline, streamed to live tail subscribers
+type EventT struct {
+	Stage         string          `json:"stage"`
+	SourceID      string          `json:"sourceId"`
+	DestinationID string          `json:"destinationId,omitempty"`
+	EventName     string          `json:"eventName"`
+	EventType     string          `json:"eventType"`
+	UserID        string          `json:"userId"`
+	Payload       json.RawMessage `json:"payload"`
+	JobState      string          `json:"jobState,omitempty"`
+	StatusCode    string          `json:"statusCode,omitempty"`
+	Response      json.RawMessage `json:"response,omitempty"`
+	RecordedAt    time.Time       `json:"recordedAt"`
+}
+
+// FilterT selects the events streamed to a subscriber. Empty fields match all events.
+type FilterT struct {
+	Stages        []string
+	SourceID      string
+	DestinationID string
+	EventType     string
+	UserID        string
+}
+
+func (filter *FilterT) matches(event *EventT) bool {
+	if len(filter.Stages) > 0 && !misc.ContainsString(filter.Stages, event.Stage) {
+		return false
+	}
+	//delivery statuses of batched router jobs have comma separated source ids
+	if filter.SourceID != "" && !misc.ContainsString(strings.Split(event.SourceID, ","), filter.SourceID) {
+		return false
+	}
+	if filter.DestinationID != "" && filter.DestinationID != event.DestinationID {
+		return false
+	}
+	if filter.EventType != "" && filter.EventType != event.EventType {
+		return false
+	}
+	if filter.UserID != "" && filter.UserID != event.UserID {
+		return false
+	}
+	return true
+}
+
+var (
+	pkgLogger            logger.LoggerI
+	liveTailEnabled      bool
+	maxSubscribers       int
+	maxEventsPerSecond   int
+	subscriberBufferSize int
+	hub                  = newHub()
+)
+
+// paths of user id in event payloads, tried in order
+var userIDPaths = []string{"userId", "user_id", "anonymousId"}
+
+func Init() {
+	loadConfig()
+	pkgLogger = logger.NewLogger().Child("debugger").Child("tail")
+}
+
+func loadConfig() {
+	config.RegisterBoolConfigVariable(false, &liveTailEnabled, false, "LiveTail.enabled")
+	config.RegisterIntConfigVariable(5, &maxSubscribers, true, 1, "LiveTail.maxSubscribers")
+	config.RegisterIntConfigVariable(20, &maxEventsPerSecond, true, 1, "LiveTail.maxEventsPerSecond")
+	config.RegisterIntConfigVariable(100, &subscriberBufferSize, false, 1, "LiveTail.subscriberBufferSize")
+}
+
+// Setup exposes the live tail stream over the admin interface, if it is enabled
+func Setup() {
+	if !liveTailEnabled {
+		return
+	}
+	admin.RegisterHTTPHandler("/v1/tail", http.HandlerFunc(StreamHandler))
+}
+
+type subscriberT struct {
+	filter             FilterT
+	maxEventsPerSecond int
+	events             chan *EventT
+
+	mu           sync.Mutex
+	windowStart  time.Time
+	windowEvents int
+	dropped      int64
+}
+
+// offer sends the event to the subscriber, unless it is over its rate limit or isn't keeping up with the stream
+func (sub *subscriberT) offer(event *EventT, now time.Time) {
+	if !sub.filter.matches(event) {
+		return
+	}
+	sub.mu.Lock()
+	if now.Sub(sub.windowStart) >= time.Second {
+		sub.windowStart = now
+		sub.windowEvents = 0
+	}
+	if sub.windowEvents >= sub.maxEventsPerSecond {
+		sub.mu.Unlock()
+		atomic.AddInt64(&sub.dropped, 1)
+		return
+	}
+	sub.windowEvents++
+	sub.mu.Unlock()
+
+	select {
+	case sub.events <- event:
+	default:
+		atomic.AddInt64(&sub.dropped, 1)
+	}
+}
+
+type hubT struct {
+	mu          sync.RWMutex
+	subscribers map[*subscriberT]struct{}
+	count       int32
+}
+
+func newHub() *hubT {
+	return &hubT{subscribers: make(map[*subscriberT]struct{})}
+}
+
+func (h *hubT) subscribe(filter FilterT, eventsPerSecond int) (*subscriberT, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.subscribers) >= maxSubscribers {
+		return nil, fmt.Errorf("live tail has reached the limit of %d subscribers", maxSubscribers)
+	}
+	if eventsPerSecond <= 0 || eventsPerSecond > maxEventsPerSecond {
+		eventsPerSecond = maxEventsPerSecond
+	}
+	sub := &subscriberT{
+		filter:             filter,
+		maxEventsPerSecond: eventsPerSecond,
+		events:             make(chan *EventT, subscriberBufferSize),
+	}
+	h.subscribers[sub] = struct{}{}
+	atomic.StoreInt32(&h.count, int32(len(h.subscribers)))
+	return sub, nil
+}
+
+func (h *hubT) unsubscribe(sub *subscriberT) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscribers, sub)
+	atomic.StoreInt32(&h.count, int32(len(h.subscribers)))
+}
+
+func (h *hubT) hasSubscribers() bool {
+	return atomic.LoadInt32(&h.count) > 0
+}
+
+func (h *hubT) record(events []*EventT) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	now := time.Now()
+	for _, event := range events {
+		for sub := range h.subscribers {
+			sub.offer(event, now)
+		}
+	}
+}
+
+// HasSubscribers returns true if anyone is tailing events.
+// Callers check it before building events, so that the pipeline doesn't pay for live tail when nobody is watching.
+func HasSubscribers() bool {
+	return hub.hasSubscribers()
+}
+
+// Record streams the events to the subscribers whose filters match them
+func Record(events ...*EventT) {
+	if !hub.hasSubscribers() {
+		return
+	}
+	for _, event := range events {
+		if event.RecordedAt.IsZero() {
+			event.RecordedAt = time.Now()
+		}
+	}
+	hub.record(events)
+}
+
+// RecordGatewayEvents streams the events of a batch accepted by gateway
+func RecordGatewayEvents(sourceID, eventBatch string) {
+	if !hub.hasSubscribers() {
+		return
+	}
+	var events []*EventT
+	for _, event := range gjson.Get(eventBatch, "batch").Array() {
+		events = append(events, &EventT{
+			Stage:     GatewayStage,
+			SourceID:  sourceID,
+			EventName: event.Get("event").String(),
+			EventType: event.Get("type").String(),
+			UserID:    GetUserID([]byte(event.Raw)),
+			Payload:   json.RawMessage(event.Raw),
+		})
+	}
+	Record(events...)
+}
+
+// GetUserID returns the user id of an event payload, if it has one
+func GetUserID(payload []byte) string {
+	for _, path := range userIDPaths {
+		if userID := gjson.GetBytes(payload, path).String(); userID != "" {
+			return userID
+		}
+	}
+	return ""
+}
+
+/*
+StreamHandler streams events as server sent events, eg.
+curl --unix-socket /tmp/rudder-server.sock 'http://localhost/v1/tail?stage=gateway&source_id=<source_id>&event_type=track&rate=5'
+Query parameters stage (repeatable), source_id, destination_id, event_type & user_id filter the events,
+rate limits the events per second, capped by LiveTail.maxEventsPerSecond.
+*/
+func StreamHandler(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := FilterT{
+		Stages:        query["stage"],
+		SourceID:      query.Get("source_id"),
+		DestinationID: query.Get("destination_id"),
+		EventType:     query.Get("event_type"),
+		UserID:        query.Get("user_id"),
+	}
+	for _, stage := range filter.Stages {
+		if stage != GatewayStage && stage != UserTransformationStage && stage != DeliveryStage {
+			http.Error(w, fmt.Sprintf("invalid stage %q", stage), http.StatusBadRequest)
+			return
+		}
+	}
+	var eventsPerSecond int
+	if rate := query.Get("rate"); rate != "" {
+		var err error
+		if eventsPerSecond, err = strconv.Atoi(rate); err != nil {
+			http.Error(w, "invalid rate", http.StatusBadRequest)
+			return
+		}
+	}
+
+	sub, err := hub.subscribe(filter, eventsPerSecond)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+	defer hub.unsubscribe(sub)
+	pkgLogger.Infof("[Live tail] Subscriber connected with filter %+v", filter)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	//reports events dropped due to sampling since the last report, also keeps idle connections alive
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			pkgLogger.Info("[Live tail] Subscriber disconnected")
+			return
+		case event := <-sub.events:
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			if _, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Stage, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-ticker.C:
+			dropped := atomic.SwapInt64(&sub.dropped, 0)
+			if _, err = fmt.Fprintf(w, "event: dropped\ndata: {\"count\": %d}\n\n", dropped); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package taildebugger
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tidwall/gjson"
+
+	"github.com/rudderlabs/rudder-server/config"
+	"github.com/rudderlabs/rudder-server/utils/logger"
+)
+
+func initLiveTail() {
+	config.Load()
+	logger.Init()
+	Init()
+}
+
+var _ = Describe("liveTail", func() {
+	initLiveTail()
+
+	eventBatch := `{"writeKey": "write-key", "batch": [{"type": "track", "event": "Order Completed", "userId": "user1"}, {"type": "identify", "anonymousId": "anon1"}]}`
+
+	Context("filter", func() {
+		event := &EventT{Stage: DeliveryStage, SourceID: "source1,source2", DestinationID: "dest1", EventType: "track", UserID: "user1"}
+
+		It("matches events of all filtered fields", func() {
+			Expect((&FilterT{}).matches(event)).To(BeTrue())
+			Expect((&FilterT{Stages: []string{GatewayStage, DeliveryStage}, SourceID: "source2", DestinationID: "dest1", EventType: "track", UserID: "user1"}).matches(event)).To(BeTrue())
+		})
+
+		It("doesn't match events differing in any filtered field", func() {
+			Expect((&FilterT{Stages: []string{GatewayStage}}).matches(event)).To(BeFalse())
+			Expect((&FilterT{SourceID: "source"}).matches(event)).To(BeFalse())
+			Expect((&FilterT{DestinationID: "dest2"}).matches(event)).To(BeFalse())
+			Expect((&FilterT{EventType: "identify"}).matches(event)).To(BeFalse())
+			Expect((&FilterT{UserID: "user2"}).matches(event)).To(BeFalse())
+		})
+	})
+
+	Context("subscribers", func() {
+		It("doesn't build events when nobody is tailing", func() {
+			Expect(HasSubscribers()).To(BeFalse())
+			RecordGatewayEvents("source1", eventBatch)
+		})
+
+		It("streams matching events upto the rate limit & counts the dropped ones", func() {
+			sub, err := hub.subscribe(FilterT{Stages: []string{GatewayStage}}, 1)
+			Expect(err).NotTo(HaveOccurred())
+			defer hub.unsubscribe(sub)
+			Expect(HasSubscribers()).To(BeTrue())
+
+			RecordGatewayEvents("source1", eventBatch)
+			Record(&EventT{Stage: DeliveryStage, SourceID: "source1"})
+
+			Expect(sub.events).To(HaveLen(1))
+			event := <-sub.events
+			Expect(event.SourceID).To(Equal("source1"))
+			Expect(event.EventName).To(Equal("Order Completed"))
+			Expect(event.UserID).To(Equal("user1"))
+			Expect(event.RecordedAt).NotTo(BeZero())
+			Expect(sub.dropped).To(Equal(int64(1)))
+		})
+
+		It("limits the number of subscribers", func() {
+			var subs []*subscriberT
+			for i := 0; i < maxSubscribers; i++ {
+				sub, err := hub.subscribe(FilterT{}, 0)
+				Expect(err).NotTo(HaveOccurred())
+				subs = append(subs, sub)
+			}
+			_, err := hub.subscribe(FilterT{}, 0)
+			Expect(err).To(HaveOccurred())
+			for _, sub := range subs {
+				hub.unsubscribe(sub)
+			}
+			Expect(HasSubscribers()).To(BeFalse())
+		})
+	})
+
+	Context("stream handler", func() {
+		It("rejects invalid stages", func() {
+			recorder := httptest.NewRecorder()
+			StreamHandler(recorder, httptest.NewRequest("GET", "/v1/tail?stage=warehouse", nil))
+			Expect(recorder.Code).To(Equal(400))
+		})
+
+		It("streams events as server sent events", func() {
+			server := httptest.NewServer(http.HandlerFunc(StreamHandler))
+			defer server.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			req, err := http.NewRequestWithContext(ctx, "GET", server.URL+"/v1/tail?stage=gateway&event_type=identify", nil)
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+
+			Eventually(HasSubscribers, time.Second).Should(BeTrue())
+			RecordGatewayEvents("source1", eventBatch)
+
+			reader := bufio.NewReader(resp.Body)
+			line, err := reader.ReadString('\n')
+			Expect(err).NotTo(HaveOccurred())
+			Expect(line).To(Equal("event: gateway\n"))
+			line, err = reader.ReadString('\n')
+			Expect(err).NotTo(HaveOccurred())
+			data := strings.TrimPrefix(strings.TrimSpace(line), "data: ")
+			Expect(gjson.Get(data, "eventType").String()).To(Equal("identify"))
+			Expect(gjson.Get(data, "userId").String()).To(Equal("anon1"))
+			Expect(gjson.Get(data, "payload.anonymousId").String()).To(Equal("anon1"))
+
+			cancel()
+			Eventually(HasSubscribers, time.Second).Should(BeFalse())
+		})
+	})
+})
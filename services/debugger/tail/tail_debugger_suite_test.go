@@ -0,0 +1,13 @@
+package taildebugger_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestTailDebugger(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "TailDebugger Suite")
+}
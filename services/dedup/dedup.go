@@ -18,6 +18,7 @@ import (
 type DedupI interface {
 	FindDuplicates(messageIDs []string, allMessageIDsSet map[string]struct{}) (duplicateIndexes []int)
 	MarkProcessed(messageIDs []string)
+	DeleteMessageIDs(messageIDs []string)
 	PrintHistogram()
 }
 
@@ -128,6 +129,21 @@ func (d *DedupHandleT) MarkProcessed(messageIDs []string) {
 	d.writeToBadger(messageIDs)
 }
 
+//DeleteMessageIDs removes messageIDs from dedup store, eg. once the events of a user are deleted as per a regulation
+func (d *DedupHandleT) DeleteMessageIDs(messageIDs []string) {
+	//write batch splits deletes into multiple transactions, if they don't fit in one
+	wb := d.badgerDB.NewWriteBatch()
+	defer wb.Cancel()
+	for _, messageID := range messageIDs {
+		if err := wb.Delete([]byte(messageID)); err != nil {
+			panic(err)
+		}
+	}
+	if err := wb.Flush(); err != nil {
+		panic(err)
+	}
+}
+
 func (d *DedupHandleT) FindDuplicates(messageIDs []string, allMessageIDsSet map[string]struct{}) (duplicateIndexes []int) {
 	toRemoveMessageIndexesSet := make(map[int]struct{})
 	//Dedup within events batch in a web request
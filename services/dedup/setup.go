@@ -14,3 +14,8 @@ func GetInstance(clearDB *bool) DedupI {
 	}
 	return dedupManager
 }
+
+// GetSetupInstance returns the instance of DedupI if it has already been set up, nil otherwise
+func GetSetupInstance() DedupI {
+	return dedupManager
+}
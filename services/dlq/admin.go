@@ -0,0 +1,103 @@
+package dlq
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rudderlabs/rudder-server/admin"
+)
+
+// RegisterAdminHandlers exposes the dead letter queue over the admin rpc interface
+func RegisterAdminHandlers(dlq *HandleT) {
+	admin.RegisterAdminHandler("DeadLetterQueue", &DLQRpcHandler{dlq: dlq})
+}
+
+type DLQRpcHandler struct {
+	dlq *HandleT
+}
+
+/*
+List returns jobs in the dead letter queue as per the filter, eg.
+{"destination_id": "<destination_id>", "error_code": "400", "limit": 10}
+*/
+func (h *DLQRpcHandler) List(arg string, result *string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			pkgLogger.Error(r)
+			err = fmt.Errorf("Internal Rudder Server Error. Error: %v", r)
+		}
+	}()
+	if !enabled {
+		return fmt.Errorf("dead letter queue is not enabled")
+	}
+	var filter FilterT
+	if arg != "" {
+		if err = json.Unmarshal([]byte(arg), &filter); err != nil {
+			return err
+		}
+	}
+	response, err := h.dlq.List(filter)
+	if err != nil {
+		return err
+	}
+	return marshalResult(response, result)
+}
+
+/*
+Redrive redrives jobs back into router or batch router jobsdb, eg.
+{"job_ids": [1, 2], "payloads": {"2": <edited payload>}}
+*/
+func (h *DLQRpcHandler) Redrive(arg string, result *string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			pkgLogger.Error(r)
+			err = fmt.Errorf("Internal Rudder Server Error. Error: %v", r)
+		}
+	}()
+	if !enabled {
+		return fmt.Errorf("dead letter queue is not enabled")
+	}
+	var req RedriveRequestT
+	if err = json.Unmarshal([]byte(arg), &req); err != nil {
+		return err
+	}
+	response, err := h.dlq.Redrive(req)
+	if err != nil {
+		return err
+	}
+	return marshalResult(response, result)
+}
+
+/*
+Discard drops jobs from the dead letter queue, eg.
+{"job_ids": [1, 2]}
+*/
+func (h *DLQRpcHandler) Discard(arg string, result *string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			pkgLogger.Error(r)
+			err = fmt.Errorf("Internal Rudder Server Error. Error: %v", r)
+		}
+	}()
+	if !enabled {
+		return fmt.Errorf("dead letter queue is not enabled")
+	}
+	var req DiscardRequestT
+	if err = json.Unmarshal([]byte(arg), &req); err != nil {
+		return err
+	}
+	response, err := h.dlq.Discard(req)
+	if err != nil {
+		return err
+	}
+	return marshalResult(response, result)
+}
+
+func marshalResult(response interface{}, result *string) error {
+	formattedOutput, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return err
+	}
+	*result = string(formattedOutput)
+	return nil
+}
@@ -0,0 +1,411 @@
+package dlq
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	"github.com/rudderlabs/rudder-server/config"
+	"github.com/rudderlabs/rudder-server/jobsdb"
+	"github.com/rudderlabs/rudder-server/utils/logger"
+	"github.com/rudderlabs/rudder-server/utils/misc"
+)
+
+// stages of aborted jobs held in the dead letter queue, set in job parameters by router & batch router
+const (
+	RouterStage      = "router"
+	BatchRouterStage = "batch_router"
+)
+
+// parameters added to jobs when they are aborted, dropped from jobs being redriven
+var abortParameters = []string{"stage", "reason", "error_code"}
+
+// filter values are matched against job parameters in sql, so only plain values are allowed
+var filterValueRegex = regexp.MustCompile(`^[a-zA-Z0-9_\-]*$`)
+
+var (
+	pkgLogger    logger.LoggerI
+	enabled      bool
+	maxListLimit int
+	dlqInstance  *HandleT
+)
+
+func Init() {
+	loadConfig()
+	pkgLogger = logger.NewLogger().Child("dlq")
+}
+
+func loadConfig() {
+	config.RegisterBoolConfigVariable(false, &enabled, false, "DeadLetterQueue.enabled")
+	config.RegisterIntConfigVariable(1000, &maxListLimit, true, 1, "DeadLetterQueue.maxListLimit")
+}
+
+// IsEnabled returns true if aborted router & batch router jobs are held in the dead letter queue
+func IsEnabled() bool {
+	return enabled
+}
+
+// IsHeld returns true if the proc error job is an aborted router or batch router job,
+// which is to be held in the dead letter queue instead of being stashed
+func IsHeld(job *jobsdb.JobT) bool {
+	if !enabled {
+		return false
+	}
+	stage := gjson.GetBytes(job.Parameters, "stage").String()
+	return stage == RouterStage || stage == BatchRouterStage
+}
+
+// FilterT selects jobs in the dead letter queue. Empty fields match all jobs.
+type FilterT struct {
+	Stage         string `json:"stage"`
+	DestinationID string `json:"destination_id"`
+	ErrorCode     string `json:"error_code"`
+	AfterJobID    int64  `json:"after_job_id"`
+	Limit         int    `json:"limit"`
+}
+
+// Validate returns an error if the filter has an unknown stage or values which can't be matched against job parameters
+func (filter *FilterT) Validate() error {
+	if filter.Stage != "" && filter.Stage != RouterStage && filter.Stage != BatchRouterStage {
+		return fmt.Errorf("invalid stage %q, should be one of %q, %q", filter.Stage, RouterStage, BatchRouterStage)
+	}
+	if !filterValueRegex.MatchString(filter.DestinationID) {
+		return fmt.Errorf("invalid destination_id %q", filter.DestinationID)
+	}
+	if !filterValueRegex.MatchString(filter.ErrorCode) {
+		return fmt.Errorf("invalid error_code %q", filter.ErrorCode)
+	}
+	if filter.AfterJobID < 0 || filter.Limit < 0 {
+		return errors.New("after_job_id & limit can't be negative")
+	}
+	return nil
+}
+
+func (filter *FilterT) stages() []string {
+	if filter.Stage != "" {
+		return []string{filter.Stage}
+	}
+	return []string{RouterStage, BatchRouterStage}
+}
+
+func (filter *FilterT) parameterFilters(stage string) []jobsdb.ParameterFilterT {
+	parameterFilters := []jobsdb.ParameterFilterT{{Name: "stage", Value: stage}}
+	if filter.DestinationID != "" {
+		parameterFilters = append(parameterFilters, jobsdb.ParameterFilterT{Name: "destination_id", Value: filter.DestinationID})
+	}
+	if filter.ErrorCode != "" {
+		parameterFilters = append(parameterFilters, jobsdb.ParameterFilterT{Name: "error_code", Value: filter.ErrorCode})
+	}
+	return parameterFilters
+}
+
+// JobT is an aborted job in the dead letter queue
+type JobT struct {
+	JobID         int64           `json:"job_id"`
+	Stage         string          `json:"stage"`
+	DestinationID string          `json:"destination_id"`
+	ErrorCode     string          `json:"error_code"`
+	Reason        string          `json:"reason"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Payload       json.RawMessage `json:"payload"`
+	Parameters    json.RawMessage `json:"parameters"`
+}
+
+// ListResponseT is a page of jobs in the dead letter queue.
+// NextAfterJobID is set as after_job_id of the filter to fetch the next page, it is 0 on the last page.
+type ListResponseT struct {
+	Jobs           []*JobT `json:"jobs"`
+	NextAfterJobID int64   `json:"next_after_job_id"`
+}
+
+// RedriveRequestT redrives jobs back into router or batch router jobsdb.
+// Payloads of the jobs can be replaced by edited ones, keyed by job id.
+type RedriveRequestT struct {
+	JobIDs   []int64                   `json:"job_ids"`
+	Payloads map[int64]json.RawMessage `json:"payloads,omitempty"`
+}
+
+// DiscardRequestT drops jobs from the dead letter queue
+type DiscardRequestT struct {
+	JobIDs []int64 `json:"job_ids"`
+}
+
+// ResultT has the number of jobs redriven or discarded & the job ids which aren't in the dead letter queue
+type ResultT struct {
+	Count    int     `json:"count"`
+	NotFound []int64 `json:"not_found"`
+}
+
+// HandleT is the dead letter queue of aborted router & batch router jobs.
+// Jobs are held in proc error jobsdb in waiting state, until they are redriven (succeeded) or discarded (aborted).
+type HandleT struct {
+	//redriveLock serializes redrives, so that jobs being redriven aren't found waiting & redriven again by another request
+	redriveLock   sync.Mutex
+	errorDB       jobsdb.JobsDB
+	routerDB      jobsdb.JobsDB
+	batchRouterDB jobsdb.JobsDB
+}
+
+// New returns a dead letter queue on the proc error jobsdb, redriving jobs into router & batch router jobsdb
+func New(errorDB, routerDB, batchRouterDB jobsdb.JobsDB) *HandleT {
+	return &HandleT{
+		errorDB:       errorDB,
+		routerDB:      routerDB,
+		batchRouterDB: batchRouterDB,
+	}
+}
+
+// Setup sets up the dead letter queue instance used by the gateway & admin handlers
+func Setup(errorDB, routerDB, batchRouterDB jobsdb.JobsDB) *HandleT {
+	if dlqInstance == nil {
+		dlqInstance = New(errorDB, routerDB, batchRouterDB)
+	}
+	return dlqInstance
+}
+
+// GetSetupInstance returns the dead letter queue if it has been set up & is enabled, nil otherwise
+func GetSetupInstance() *HandleT {
+	if !enabled {
+		return nil
+	}
+	return dlqInstance
+}
+
+// List returns a page of jobs in the dead letter queue, ordered by job id
+func (dlq *HandleT) List(filter FilterT) (ListResponseT, error) {
+	if err := filter.Validate(); err != nil {
+		return ListResponseT{}, err
+	}
+	limit := filter.Limit
+	if limit == 0 || limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	jobs := dlq.heldJobs(filter, filter.AfterJobID, limit)
+	response := ListResponseT{Jobs: make([]*JobT, 0, len(jobs))}
+	for _, job := range jobs {
+		response.Jobs = append(response.Jobs, &JobT{
+			JobID:         job.JobID,
+			Stage:         gjson.GetBytes(job.Parameters, "stage").String(),
+			DestinationID: gjson.GetBytes(job.Parameters, "destination_id").String(),
+			ErrorCode:     gjson.GetBytes(job.Parameters, "error_code").String(),
+			Reason:        gjson.GetBytes(job.Parameters, "reason").String(),
+			CreatedAt:     job.CreatedAt,
+			Payload:       job.EventPayload,
+			Parameters:    job.Parameters,
+		})
+	}
+	if len(jobs) == limit {
+		response.NextAfterJobID = jobs[len(jobs)-1].JobID
+	}
+	return response, nil
+}
+
+// Redrive stores the jobs back into router or batch router jobsdb as per their stage, with edited payloads if any,
+// and marks them as succeeded in the dead letter queue in the same transaction, so that jobs are redriven only once.
+func (dlq *HandleT) Redrive(req RedriveRequestT) (ResultT, error) {
+	for jobID, payload := range req.Payloads {
+		if !misc.ContainsInt64(req.JobIDs, jobID) {
+			return ResultT{}, fmt.Errorf("edited payload of job %d which isn't being redriven", jobID)
+		}
+		if !json.Valid(payload) {
+			return ResultT{}, fmt.Errorf("invalid edited payload of job %d", jobID)
+		}
+	}
+
+	dlq.redriveLock.Lock()
+	defer dlq.redriveLock.Unlock()
+
+	jobs, notFound := dlq.findJobs(req.JobIDs)
+	if len(jobs) == 0 {
+		return ResultT{NotFound: notFound}, nil
+	}
+	var routerJobs, batchRouterJobs []*jobsdb.JobT
+	for _, job := range jobs {
+		payload := job.EventPayload
+		if editedPayload, ok := req.Payloads[job.JobID]; ok {
+			payload = editedPayload
+		}
+		redrivenJob := &jobsdb.JobT{
+			UUID:         uuid.Must(uuid.NewV4()),
+			UserID:       job.UserID,
+			CustomVal:    job.CustomVal,
+			EventCount:   job.EventCount,
+			EventPayload: payload,
+			Parameters:   redriveParameters(job.Parameters),
+		}
+		if gjson.GetBytes(job.Parameters, "stage").String() == BatchRouterStage {
+			batchRouterJobs = append(batchRouterJobs, redrivenJob)
+		} else {
+			routerJobs = append(routerJobs, redrivenJob)
+		}
+	}
+
+	txn := dlq.errorDB.BeginGlobalTransaction()
+	dlq.routerDB.AcquireStoreLock()
+	defer dlq.routerDB.ReleaseStoreLock()
+	dlq.batchRouterDB.AcquireStoreLock()
+	defer dlq.batchRouterDB.ReleaseStoreLock()
+	if len(routerJobs) > 0 {
+		if err := dlq.routerDB.StoreInTxn(txn, routerJobs); err != nil {
+			_ = txn.Rollback()
+			return ResultT{}, fmt.Errorf("storing redriven jobs into router jobsdb: %w", err)
+		}
+	}
+	if len(batchRouterJobs) > 0 {
+		if err := dlq.batchRouterDB.StoreInTxn(txn, batchRouterJobs); err != nil {
+			_ = txn.Rollback()
+			return ResultT{}, fmt.Errorf("storing redriven jobs into batch router jobsdb: %w", err)
+		}
+	}
+	dlq.errorDB.AcquireUpdateJobStatusLocks()
+	defer dlq.errorDB.ReleaseUpdateJobStatusLocks()
+	//UpdateJobStatusInTxn rolls back the transaction on error
+	err := dlq.errorDB.UpdateJobStatusInTxn(txn, jobStatuses(jobs, jobsdb.Succeeded.State, "redriven from dead letter queue"), nil, nil)
+	if err != nil {
+		return ResultT{}, fmt.Errorf("marking dead letter queue jobs as %s: %w", jobsdb.Succeeded.State, err)
+	}
+	dlq.errorDB.CommitTransaction(txn)
+	pkgLogger.Infof("[DLQ] Redrove %d router & %d batch router jobs", len(routerJobs), len(batchRouterJobs))
+	return ResultT{Count: len(jobs), NotFound: notFound}, nil
+}
+
+// Discard marks the jobs as aborted, dropping them from the dead letter queue
+func (dlq *HandleT) Discard(req DiscardRequestT) (ResultT, error) {
+	jobs, notFound := dlq.findJobs(req.JobIDs)
+	if err := dlq.markJobs(jobs, jobsdb.Aborted.State, "discarded from dead letter queue"); err != nil {
+		return ResultT{}, err
+	}
+	pkgLogger.Infof("[DLQ] Discarded %d jobs", len(jobs))
+	return ResultT{Count: len(jobs), NotFound: notFound}, nil
+}
+
+// AbortHeld marks all jobs held in the dead letter queue as aborted & returns their number.
+// It is run once the dead letter queue is disabled, since held jobs are neither stashed nor redriven otherwise.
+func (dlq *HandleT) AbortHeld() (int, error) {
+	var count int
+	var afterJobID int64
+	for {
+		jobs := dlq.heldJobs(FilterT{}, afterJobID, maxListLimit)
+		if err := dlq.markJobs(jobs, jobsdb.Aborted.State, "dead letter queue disabled"); err != nil {
+			return count, err
+		}
+		count += len(jobs)
+		if len(jobs) < maxListLimit {
+			return count, nil
+		}
+		afterJobID = jobs[len(jobs)-1].JobID
+	}
+}
+
+// heldJobs returns upto limit jobs in the dead letter queue matching the filter, with job id greater than afterJobID
+func (dlq *HandleT) heldJobs(filter FilterT, afterJobID int64, limit int) []*jobsdb.JobT {
+	var jobs []*jobsdb.JobT
+	for _, stage := range filter.stages() {
+		//NOTE: sending custom val filters array of size 1 to take advantage of cache in jobsdb.
+		jobs = append(jobs, dlq.errorDB.GetWaiting(jobsdb.GetQueryParamsT{
+			CustomValFilters:              []string{""},
+			IgnoreCustomValFiltersInQuery: true,
+			ParameterFilters:              filter.parameterFilters(stage),
+			JobCount:                      limit,
+			AfterJobID:                    afterJobID,
+		})...)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].JobID < jobs[j].JobID
+	})
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs
+}
+
+// findJobs pages through the dead letter queue from the smallest of the job ids,
+// returning the jobs found & the job ids which aren't in the dead letter queue
+func (dlq *HandleT) findJobs(jobIDs []int64) ([]*jobsdb.JobT, []int64) {
+	notFound := []int64{}
+	if len(jobIDs) == 0 {
+		return nil, notFound
+	}
+	wanted := make(map[int64]bool, len(jobIDs))
+	minJobID, maxJobID := jobIDs[0], jobIDs[0]
+	for _, jobID := range jobIDs {
+		wanted[jobID] = true
+		if jobID < minJobID {
+			minJobID = jobID
+		}
+		if jobID > maxJobID {
+			maxJobID = jobID
+		}
+	}
+
+	var found []*jobsdb.JobT
+	afterJobID := minJobID - 1
+	for len(wanted) > 0 && afterJobID < maxJobID {
+		jobs := dlq.heldJobs(FilterT{}, afterJobID, maxListLimit)
+		for _, job := range jobs {
+			if wanted[job.JobID] {
+				found = append(found, job)
+				delete(wanted, job.JobID)
+			}
+		}
+		if len(jobs) < maxListLimit {
+			break
+		}
+		afterJobID = jobs[len(jobs)-1].JobID
+	}
+
+	for jobID := range wanted {
+		notFound = append(notFound, jobID)
+	}
+	sort.Slice(notFound, func(i, j int) bool {
+		return notFound[i] < notFound[j]
+	})
+	return found, notFound
+}
+
+func (dlq *HandleT) markJobs(jobs []*jobsdb.JobT, state, reason string) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	err := dlq.errorDB.UpdateJobStatus(jobStatuses(jobs, state, reason), nil, nil)
+	if err != nil {
+		return fmt.Errorf("marking dead letter queue jobs as %s: %w", state, err)
+	}
+	return nil
+}
+
+func jobStatuses(jobs []*jobsdb.JobT, state, reason string) []*jobsdb.JobStatusT {
+	var statusList []*jobsdb.JobStatusT
+	for _, job := range jobs {
+		statusList = append(statusList, &jobsdb.JobStatusT{
+			JobID:         job.JobID,
+			AttemptNum:    job.LastJobStatus.AttemptNum + 1,
+			JobState:      state,
+			ExecTime:      time.Now(),
+			RetryTime:     time.Now(),
+			ErrorCode:     "",
+			ErrorResponse: misc.UpdateJSONWithNewKeyVal([]byte(`{}`), "reason", reason),
+			Parameters:    []byte(`{}`),
+		})
+	}
+	return statusList
+}
+
+func redriveParameters(parameters json.RawMessage) json.RawMessage {
+	redriven := []byte(parameters)
+	for _, key := range abortParameters {
+		if updated, err := sjson.DeleteBytes(redriven, key); err == nil {
+			redriven = updated
+		}
+	}
+	return redriven
+}
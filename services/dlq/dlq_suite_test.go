@@ -0,0 +1,13 @@
+package dlq_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestDLQ(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "DLQ Suite")
+}
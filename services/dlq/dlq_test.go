@@ -0,0 +1,208 @@
+package dlq_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tidwall/gjson"
+
+	"github.com/rudderlabs/rudder-server/config"
+	"github.com/rudderlabs/rudder-server/jobsdb"
+	mocksJobsDB "github.com/rudderlabs/rudder-server/mocks/jobsdb"
+	"github.com/rudderlabs/rudder-server/services/dlq"
+	"github.com/rudderlabs/rudder-server/utils/logger"
+)
+
+func initDLQ() {
+	os.Setenv("RSERVER_DEAD_LETTER_QUEUE_ENABLED", "true")
+	config.Load()
+	logger.Init()
+	dlq.Init()
+}
+
+func heldJob(jobID int64, stage, destinationID, errorCode string) *jobsdb.JobT {
+	return &jobsdb.JobT{
+		JobID:        jobID,
+		UserID:       "user1",
+		CustomVal:    "GA",
+		EventCount:   1,
+		EventPayload: []byte(`{"type": "track"}`),
+		Parameters:   []byte(`{"source_id": "source1", "destination_id": "` + destinationID + `", "stage": "` + stage + `", "reason": "{\"response\": \"bad request\"}", "error_code": "` + errorCode + `"}`),
+		LastJobStatus: jobsdb.JobStatusT{
+			JobID:      jobID,
+			JobState:   jobsdb.Waiting.State,
+			AttemptNum: 1,
+		},
+	}
+}
+
+var _ = Describe("dlq", func() {
+	initDLQ()
+
+	var (
+		mockCtrl          *gomock.Controller
+		mockErrorDB       *mocksJobsDB.MockJobsDB
+		mockRouterDB      *mocksJobsDB.MockJobsDB
+		mockBatchRouterDB *mocksJobsDB.MockJobsDB
+		deadLetterQueue   *dlq.HandleT
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockErrorDB = mocksJobsDB.NewMockJobsDB(mockCtrl)
+		mockRouterDB = mocksJobsDB.NewMockJobsDB(mockCtrl)
+		mockBatchRouterDB = mocksJobsDB.NewMockJobsDB(mockCtrl)
+		deadLetterQueue = dlq.New(mockErrorDB, mockRouterDB, mockBatchRouterDB)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("held jobs", func() {
+		It("holds aborted router & batch router jobs only", func() {
+			Expect(dlq.IsHeld(heldJob(1, dlq.RouterStage, "dest1", "400"))).To(BeTrue())
+			Expect(dlq.IsHeld(heldJob(2, dlq.BatchRouterStage, "dest1", ""))).To(BeTrue())
+			Expect(dlq.IsHeld(&jobsdb.JobT{Parameters: []byte(`{"destination_id": "dest1", "error_code": "400"}`)})).To(BeFalse())
+		})
+	})
+
+	Context("list", func() {
+		It("returns jobs of both stages ordered by job id, filtered by destination & error code", func() {
+			mockErrorDB.EXPECT().GetWaiting(gomock.Any()).DoAndReturn(func(params jobsdb.GetQueryParamsT) []*jobsdb.JobT {
+				Expect(params.AfterJobID).To(Equal(int64(5)))
+				Expect(params.JobCount).To(Equal(2))
+				Expect(params.ParameterFilters).To(ConsistOf(
+					jobsdb.ParameterFilterT{Name: "stage", Value: dlq.RouterStage},
+					jobsdb.ParameterFilterT{Name: "destination_id", Value: "dest1"},
+					jobsdb.ParameterFilterT{Name: "error_code", Value: "400"},
+				))
+				return []*jobsdb.JobT{heldJob(8, dlq.RouterStage, "dest1", "400"), heldJob(10, dlq.RouterStage, "dest1", "400")}
+			})
+			mockErrorDB.EXPECT().GetWaiting(gomock.Any()).DoAndReturn(func(params jobsdb.GetQueryParamsT) []*jobsdb.JobT {
+				Expect(params.ParameterFilters).To(ContainElement(jobsdb.ParameterFilterT{Name: "stage", Value: dlq.BatchRouterStage}))
+				return []*jobsdb.JobT{heldJob(6, dlq.BatchRouterStage, "dest1", "400")}
+			})
+
+			response, err := deadLetterQueue.List(dlq.FilterT{DestinationID: "dest1", ErrorCode: "400", AfterJobID: 5, Limit: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Jobs).To(HaveLen(2))
+			Expect(response.Jobs[0].JobID).To(Equal(int64(6)))
+			Expect(response.Jobs[0].Stage).To(Equal(dlq.BatchRouterStage))
+			Expect(response.Jobs[1].JobID).To(Equal(int64(8)))
+			Expect(response.Jobs[1].ErrorCode).To(Equal("400"))
+			Expect(string(response.Jobs[1].Payload)).To(Equal(`{"type": "track"}`))
+			Expect(response.NextAfterJobID).To(Equal(int64(8)))
+		})
+
+		It("rejects filters which can't be matched against job parameters", func() {
+			_, err := deadLetterQueue.List(dlq.FilterT{DestinationID: `dest1"}' OR 1=1 --`})
+			Expect(err).To(HaveOccurred())
+			_, err = deadLetterQueue.List(dlq.FilterT{Stage: "processor"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("redrive", func() {
+		It("stores jobs back into router & batch router jobsdb with edited payloads and marks them as succeeded", func() {
+			mockErrorDB.EXPECT().GetWaiting(gomock.Any()).Return([]*jobsdb.JobT{heldJob(2, dlq.RouterStage, "dest1", "400"), heldJob(4, dlq.RouterStage, "dest1", "500")})
+			mockErrorDB.EXPECT().GetWaiting(gomock.Any()).Return([]*jobsdb.JobT{heldJob(3, dlq.BatchRouterStage, "dest2", "")})
+			mockErrorDB.EXPECT().BeginGlobalTransaction().Return(nil)
+			for _, db := range []*mocksJobsDB.MockJobsDB{mockRouterDB, mockBatchRouterDB} {
+				db.EXPECT().AcquireStoreLock()
+				db.EXPECT().ReleaseStoreLock()
+			}
+			mockErrorDB.EXPECT().AcquireUpdateJobStatusLocks()
+			mockErrorDB.EXPECT().ReleaseUpdateJobStatusLocks()
+			mockRouterDB.EXPECT().StoreInTxn(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *sql.Tx, jobs []*jobsdb.JobT) error {
+				Expect(jobs).To(HaveLen(1))
+				Expect(jobs[0].UserID).To(Equal("user1"))
+				Expect(jobs[0].CustomVal).To(Equal("GA"))
+				Expect(string(jobs[0].EventPayload)).To(Equal(`{"type": "identify"}`))
+				Expect(gjson.GetBytes(jobs[0].Parameters, "destination_id").String()).To(Equal("dest1"))
+				for _, key := range []string{"stage", "reason", "error_code"} {
+					Expect(gjson.GetBytes(jobs[0].Parameters, key).Exists()).To(BeFalse())
+				}
+				return nil
+			})
+			mockBatchRouterDB.EXPECT().StoreInTxn(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *sql.Tx, jobs []*jobsdb.JobT) error {
+				Expect(jobs).To(HaveLen(1))
+				Expect(string(jobs[0].EventPayload)).To(Equal(`{"type": "track"}`))
+				return nil
+			})
+			mockErrorDB.EXPECT().UpdateJobStatusInTxn(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ *sql.Tx, statusList []*jobsdb.JobStatusT, _ []string, _ []jobsdb.ParameterFilterT) error {
+				Expect(statusList).To(HaveLen(2))
+				for _, status := range statusList {
+					Expect(status.JobState).To(Equal(jobsdb.Succeeded.State))
+					Expect(status.AttemptNum).To(Equal(2))
+				}
+				return nil
+			})
+			mockErrorDB.EXPECT().CommitTransaction(gomock.Any())
+
+			result, err := deadLetterQueue.Redrive(dlq.RedriveRequestT{
+				JobIDs:   []int64{2, 3, 5},
+				Payloads: map[int64]json.RawMessage{2: []byte(`{"type": "identify"}`)},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Count).To(Equal(2))
+			Expect(result.NotFound).To(Equal([]int64{5}))
+		})
+
+		It("doesn't store jobs which are already redriven", func() {
+			mockErrorDB.EXPECT().GetWaiting(gomock.Any()).Return(nil).Times(2)
+
+			result, err := deadLetterQueue.Redrive(dlq.RedriveRequestT{JobIDs: []int64{2}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Count).To(Equal(0))
+			Expect(result.NotFound).To(Equal([]int64{2}))
+		})
+
+		It("rejects edited payloads of jobs which aren't being redriven", func() {
+			_, err := deadLetterQueue.Redrive(dlq.RedriveRequestT{
+				JobIDs:   []int64{2},
+				Payloads: map[int64]json.RawMessage{3: []byte(`{}`)},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("discard", func() {
+		It("marks jobs as aborted", func() {
+			mockErrorDB.EXPECT().GetWaiting(gomock.Any()).Return([]*jobsdb.JobT{heldJob(7, dlq.RouterStage, "dest1", "400")}).Times(2)
+			mockErrorDB.EXPECT().UpdateJobStatus(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(statusList []*jobsdb.JobStatusT, _ []string, _ []jobsdb.ParameterFilterT) error {
+				Expect(statusList).To(HaveLen(1))
+				Expect(statusList[0].JobID).To(Equal(int64(7)))
+				Expect(statusList[0].JobState).To(Equal(jobsdb.Aborted.State))
+				return nil
+			})
+
+			result, err := deadLetterQueue.Discard(dlq.DiscardRequestT{JobIDs: []int64{7}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Count).To(Equal(1))
+			Expect(result.NotFound).To(BeEmpty())
+		})
+	})
+
+	Context("abort held", func() {
+		It("marks all held jobs as aborted", func() {
+			mockErrorDB.EXPECT().GetWaiting(gomock.Any()).Return([]*jobsdb.JobT{heldJob(7, dlq.RouterStage, "dest1", "400")})
+			mockErrorDB.EXPECT().GetWaiting(gomock.Any()).Return([]*jobsdb.JobT{heldJob(9, dlq.BatchRouterStage, "dest2", "")})
+			mockErrorDB.EXPECT().UpdateJobStatus(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(statusList []*jobsdb.JobStatusT, _ []string, _ []jobsdb.ParameterFilterT) error {
+				Expect(statusList).To(HaveLen(2))
+				for _, status := range statusList {
+					Expect(status.JobState).To(Equal(jobsdb.Aborted.State))
+				}
+				return nil
+			})
+
+			count, err := deadLetterQueue.AbortHeld()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(2))
+		})
+	})
+})
@@ -123,6 +123,10 @@ func (manager *AzureBlobStorageManager) Upload(file *os.File, prefixes ...string
 }
 
 func (manager *AzureBlobStorageManager) ListFilesWithPrefix(prefix string, maxItems int64) (fileObjects []*FileObject, err error) {
+	//listing is paginated across calls, nothing left to list once the last segment is returned.
+	if !manager.marker.NotDone() {
+		return []*FileObject{}, nil
+	}
 	ctx := context.Background()
 
 	containerURL, err := manager.getContainerURL()
@@ -140,11 +144,11 @@ func (manager *AzureBlobStorageManager) ListFilesWithPrefix(prefix string, maxIt
 	}
 
 	// List the blobs in the container
-	var marker string
-	response, err := containerURL.ListBlobsFlatSegment(ctx, azblob.Marker{Val: &marker}, segmentOptions)
+	response, err := containerURL.ListBlobsFlatSegment(ctx, manager.marker, segmentOptions)
 	if err != nil {
 		return
 	}
+	manager.marker = response.NextMarker
 
 	fileObjects = make([]*FileObject, len(response.Segment.BlobItems))
 	for idx := range response.Segment.BlobItems {
@@ -166,6 +170,9 @@ func (manager *AzureBlobStorageManager) Download(output *os.File, key string) er
 	// Here's how to download the blob
 	downloadResponse, err := blobURL.Download(ctx, 0, azblob.CountToEnd, azblob.BlobAccessConditions{}, false, azblob.ClientProvidedKeyOptions{})
 	if err != nil {
+		if storageErr, ok := err.(azblob.StorageError); ok && storageErr.ServiceCode() == azblob.ServiceCodeBlobNotFound {
+			return ErrKeyNotFound
+		}
 		return err
 	}
 
@@ -201,6 +208,8 @@ func (manager *AzureBlobStorageManager) GetDownloadKeyFromFileLocation(location
 
 type AzureBlobStorageManager struct {
 	Config *AzureBlobStorageConfig
+	//marker keeps track of the listing across successive ListFilesWithPrefix calls
+	marker azblob.Marker
 }
 
 func GetAzureBlogStorageConfig(config map[string]interface{}) *AzureBlobStorageConfig {
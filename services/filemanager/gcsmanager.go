@@ -59,6 +59,10 @@ func (manager *GCSManager) Upload(file *os.File, prefixes ...string) (UploadOutp
 
 func (manager *GCSManager) ListFilesWithPrefix(prefix string, maxItems int64) (fileObjects []*FileObject, err error) {
 	fileObjects = make([]*FileObject, 0)
+	//listing is paginated across calls, nothing left to list once the last page is returned.
+	if manager.listExhausted {
+		return
+	}
 	ctx := context.Background()
 
 	// Create GCS storage client
@@ -71,16 +75,15 @@ func (manager *GCSManager) ListFilesWithPrefix(prefix string, maxItems int64) (f
 		Prefix:    prefix,
 		Delimiter: "",
 	})
-	for {
-		if maxItems == 0 {
-			break
-		}
-		attrs, err := it.Next()
-		if err == iterator.Done || err != nil {
-			break
-		}
+	var objects []*storage.ObjectAttrs
+	nextPageToken, err := iterator.NewPager(it, int(maxItems), manager.pageToken).NextPage(&objects)
+	if err != nil {
+		return
+	}
+	manager.pageToken = nextPageToken
+	manager.listExhausted = nextPageToken == ""
+	for _, attrs := range objects {
 		fileObjects = append(fileObjects, &FileObject{attrs.Name, attrs.Updated})
-		maxItems--
 	}
 	return
 }
@@ -113,6 +116,9 @@ func (manager *GCSManager) Download(output *os.File, key string) error {
 	}
 	rc, err := client.Bucket(manager.Config.Bucket).Object(key).NewReader(ctx)
 	if err != nil {
+		if err == storage.ErrObjectNotExist {
+			return ErrKeyNotFound
+		}
 		return err
 	}
 	defer rc.Close()
@@ -141,6 +147,9 @@ func (manager *GCSManager) GetDownloadKeyFromFileLocation(location string) strin
 type GCSManager struct {
 	Config *GCSConfig
 	client *storage.Client
+	//pageToken & listExhausted keep track of the listing across successive ListFilesWithPrefix calls
+	pageToken     string
+	listExhausted bool
 }
 
 func GetGCSConfig(config map[string]interface{}) *GCSConfig {
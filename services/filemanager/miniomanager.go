@@ -64,6 +64,9 @@ func (manager *MinioManager) Download(file *os.File, key string) error {
 		return err
 	}
 	err = minioClient.FGetObject(manager.Config.Bucket, key, file.Name(), minio.GetObjectOptions{})
+	if err != nil && minio.ToErrorResponse(err).Code == ErrKeyNotFound.Error() {
+		return ErrKeyNotFound
+	}
 	return err
 }
 
@@ -111,6 +114,10 @@ func (manager *MinioManager) DeleteObjects(keys []string) (err error) {
 
 func (manager *MinioManager) ListFilesWithPrefix(prefix string, maxItems int64) (fileObjects []*FileObject, err error) {
 	fileObjects = make([]*FileObject, 0)
+	//listing is paginated across calls, nothing left to list once the last page is returned.
+	if manager.listExhausted {
+		return
+	}
 
 	// Created minio core
 	core, err := minio.NewCore(manager.Config.EndPoint, manager.Config.AccessKeyID, manager.Config.SecretAccessKey, manager.Config.UseSSL)
@@ -119,7 +126,7 @@ func (manager *MinioManager) ListFilesWithPrefix(prefix string, maxItems int64)
 	}
 
 	// List the Objects in the bucket
-	bucket, err := core.ListObjects(manager.Config.Bucket, prefix, prefix, "", int(maxItems))
+	bucket, err := core.ListObjects(manager.Config.Bucket, prefix, manager.marker, "", int(maxItems))
 	if err != nil {
 		return
 	}
@@ -127,6 +134,10 @@ func (manager *MinioManager) ListFilesWithPrefix(prefix string, maxItems int64)
 	for _, item := range bucket.Contents {
 		fileObjects = append(fileObjects, &FileObject{item.Key, item.LastModified})
 	}
+	manager.listExhausted = !bucket.IsTruncated || len(bucket.Contents) == 0
+	if len(bucket.Contents) > 0 {
+		manager.marker = bucket.Contents[len(bucket.Contents)-1].Key
+	}
 	return
 }
 
@@ -197,6 +208,9 @@ func GetMinioConfig(config map[string]interface{}) *MinioConfig {
 type MinioManager struct {
 	Config *MinioConfig
 	client *minio.Client
+	//marker & listExhausted keep track of the listing across successive ListFilesWithPrefix calls
+	marker        string
+	listExhausted bool
 }
 
 type MinioConfig struct {
@@ -83,6 +83,20 @@ type ClaimResponseT struct {
 	Err     error
 }
 
+// FairShareT decides how a published batch shares workers with other batches of the same priority
+// jobs are claimed for the key with the least executing jobs relative to its weight
+type FairShareT struct {
+	Key    string
+	Weight int
+}
+
+type QueueDepthT struct {
+	FairShareKey string `json:"fair_share_key"`
+	Status       string `json:"status"`
+	Priority     int    `json:"priority"`
+	Count        int64  `json:"count"`
+}
+
 func loadPGNotifierConfig() {
 	pgNotifierDBhost = config.GetEnv("PGNOTIFIER_DB_HOST", "localhost")
 	pgNotifierDBuser = config.GetEnv("PGNOTIFIER_DB_USER", "ubuntu")
@@ -270,16 +284,24 @@ func (notifier *PgNotifierT) claim(workerID string) (claim ClaimT, err error) {
 	var attempt int
 	var batchID, status, workspace string
 	var payload json.RawMessage
+	// among jobs of same priority, claim for the fair share key with least executing jobs per unit of weight
+	// executing jobs are counted per workspace & fair share key, as workspaces sharing the queue can publish with the same keys
 	stmt := fmt.Sprintf(`UPDATE %[1]s SET status='%[2]s',
 						updated_at = '%[3]s',
 						last_exec_time = '%[3]s',
 						worker_id = '%[4]v'
 						WHERE id = (
-						SELECT id
-						FROM %[1]s
-						WHERE status='%[5]s' OR status='%[6]s'
-						ORDER BY priority ASC, id ASC
-						FOR UPDATE SKIP LOCKED
+						SELECT q.id
+						FROM %[1]s q
+						LEFT JOIN (
+							SELECT COALESCE(workspace, '') AS workspace, COALESCE(fair_share_key, '') AS fair_share_key, count(*) AS executing
+							FROM %[1]s
+							WHERE status='%[2]s'
+							GROUP BY 1, 2
+						) e ON COALESCE(q.workspace, '') = e.workspace AND COALESCE(q.fair_share_key, '') = e.fair_share_key
+						WHERE q.status='%[5]s' OR q.status='%[6]s'
+						ORDER BY q.priority ASC, COALESCE(e.executing, 0)::float / GREATEST(q.weight, 1) ASC, q.id ASC
+						FOR UPDATE OF q SKIP LOCKED
 						LIMIT 1
 						)
 						RETURNING id, batch_id, status, payload, workspace, attempt;`, queueName, ExecutingState, GetCurrentSQLTimestamp(), workerID, WaitingState, FailedState)
@@ -315,7 +337,7 @@ func (notifier *PgNotifierT) claim(workerID string) (claim ClaimT, err error) {
 	return claim, nil
 }
 
-func (notifier *PgNotifierT) Publish(jobs []JobPayload, priority int) (ch chan []ResponseT, err error) {
+func (notifier *PgNotifierT) Publish(jobs []JobPayload, priority int, fairShare FairShareT) (ch chan []ResponseT, err error) {
 	publishStartTime := time.Now()
 	defer func() {
 		if err == nil {
@@ -332,7 +354,7 @@ func (notifier *PgNotifierT) Publish(jobs []JobPayload, priority int) (ch chan [
 		return
 	}
 
-	stmt, err := txn.Prepare(pq.CopyIn(queueName, "batch_id", "status", "payload", "workspace", "priority"))
+	stmt, err := txn.Prepare(pq.CopyIn(queueName, "batch_id", "status", "payload", "workspace", "priority", "fair_share_key", "weight"))
 	if err != nil {
 		return
 	}
@@ -341,7 +363,7 @@ func (notifier *PgNotifierT) Publish(jobs []JobPayload, priority int) (ch chan [
 	batchID := uuid.Must(uuid.NewV4()).String()
 	pkgLogger.Infof("PgNotifier: Inserting %d records into %s as batch: %s", len(jobs), queueName, batchID)
 	for _, job := range jobs {
-		_, err = stmt.Exec(batchID, WaitingState, string(job), notifier.workspaceIdentifier, priority)
+		_, err = stmt.Exec(batchID, WaitingState, string(job), notifier.workspaceIdentifier, priority, fairShare.Key, fairShare.Weight)
 		if err != nil {
 			return
 		}
@@ -366,6 +388,26 @@ func (notifier *PgNotifierT) Publish(jobs []JobPayload, priority int) (ch chan [
 	return
 }
 
+// GetQueueDepth returns count of jobs in the queue for the workspace by fair share key, status and priority
+func (notifier *PgNotifierT) GetQueueDepth() (queueDepth []QueueDepthT, err error) {
+	stmt := fmt.Sprintf(`SELECT COALESCE(fair_share_key, ''), status, COALESCE(priority, 0), count(*) FROM %s WHERE workspace=$1 GROUP BY 1, 2, 3 ORDER BY 3, 1, 2`, queueName)
+	rows, err := notifier.dbHandle.Query(stmt, notifier.workspaceIdentifier)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var depth QueueDepthT
+		err = rows.Scan(&depth.FairShareKey, &depth.Status, &depth.Priority, &depth.Count)
+		if err != nil {
+			return
+		}
+		queueDepth = append(queueDepth, depth)
+	}
+	err = rows.Err()
+	return
+}
+
 func (notifier *PgNotifierT) Subscribe(ctx context.Context, workerId string, jobsBufferSize int) chan ClaimT {
 
 	jobs := make(chan ClaimT, jobsBufferSize)
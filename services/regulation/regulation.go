@@ -0,0 +1,173 @@
+package regulation
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rudderlabs/rudder-server/config"
+	"github.com/rudderlabs/rudder-server/utils/logger"
+	"github.com/tidwall/gjson"
+)
+
+// actions taken while propagating a regulation into the pipeline, recorded in regulation_audit_log
+const (
+	SuppressUsersAction = "suppress_users"
+	AbortJobsAction     = "abort_jobs"
+	PurgeDedupAction    = "purge_dedup"
+)
+
+var (
+	pkgLogger                  logger.LoggerI
+	suppressionRefreshInterval time.Duration
+)
+
+// paths of user identifiers in event payloads, checked to find events of the users of a regulation
+// context_traits_* are the columns of the rows of warehouse payloads
+var (
+	userIDPaths = []string{"userId", "user_id", "message.userId"}
+	emailPaths  = []string{"context.traits.email", "traits.email", "email", "message.context.traits.email", "context_traits_email"}
+	phonePaths  = []string{"context.traits.phone", "traits.phone", "phone", "message.context.traits.phone", "context_traits_phone"}
+)
+
+func Init() {
+	loadConfig()
+	pkgLogger = logger.NewLogger().Child("regulation")
+}
+
+func loadConfig() {
+	config.RegisterDurationConfigVariable(time.Duration(30), &suppressionRefreshInterval, true, time.Second, []string{"Regulation.suppressionRefreshInterval", "Regulation.suppressionRefreshIntervalInS"}...)
+}
+
+// UserT is a user whose data has been deleted as per a regulation
+type UserT struct {
+	UserID string  `json:"userId"`
+	Email  *string `json:"email,omitempty"`
+	Phone  *string `json:"phone,omitempty"`
+}
+
+// PropagationRequestT is sent by regulation-worker once it completes a deletion job,
+// so that new & pending events of the users are dropped from the pipeline
+type PropagationRequestT struct {
+	JobID         int64   `json:"job_id"`
+	WorkspaceID   string  `json:"workspace_id"`
+	DestinationID string  `json:"destination_id"`
+	Users         []UserT `json:"users"`
+}
+
+// Validate returns an error if the request doesn't have the fields required to propagate it
+func (req *PropagationRequestT) Validate() error {
+	if req.JobID == 0 {
+		return fmt.Errorf("job_id not present in request")
+	}
+	if req.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id not present in request")
+	}
+	if len(req.Users) == 0 {
+		return fmt.Errorf("users not present in request")
+	}
+	for _, user := range req.Users {
+		if user.UserID == "" && user.Email == nil && user.Phone == nil {
+			return fmt.Errorf("user without userId, email & phone present in request")
+		}
+	}
+	return nil
+}
+
+// RecordAction adds an entry of `action` taken for `req` with `details` to regulation_audit_log
+func RecordAction(dbHandle *sql.DB, req PropagationRequestT, action string, details interface{}) error {
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+	sqlStatement := `INSERT INTO regulation_audit_log (regulation_job_id, workspace_id, destination_id, action, details) VALUES ($1, $2, $3, $4, $5)`
+	_, err = dbHandle.Exec(sqlStatement, req.JobID, req.WorkspaceID, req.DestinationID, action, detailsJSON)
+	if err != nil {
+		return fmt.Errorf("failed to record %s action of regulation job %d: %w", action, req.JobID, err)
+	}
+	pkgLogger.Infof("Recorded %s action of regulation job %d with details: %s", action, req.JobID, detailsJSON)
+	return nil
+}
+
+// UserMatcherT finds if an event belongs to one of the users of a regulation
+type UserMatcherT struct {
+	userIDs map[string]struct{}
+	emails  map[string]struct{}
+	phones  map[string]struct{}
+}
+
+func NewUserMatcher(users []UserT) *UserMatcherT {
+	matcher := &UserMatcherT{
+		userIDs: make(map[string]struct{}),
+		emails:  make(map[string]struct{}),
+		phones:  make(map[string]struct{}),
+	}
+	for _, user := range users {
+		if user.UserID != "" {
+			matcher.userIDs[user.UserID] = struct{}{}
+		}
+		if user.Email != nil && *user.Email != "" {
+			matcher.emails[*user.Email] = struct{}{}
+		}
+		if user.Phone != nil && *user.Phone != "" {
+			matcher.phones[*user.Phone] = struct{}{}
+		}
+	}
+	return matcher
+}
+
+// Matches returns true if any of the user identifiers in `event` belongs to one of the users.
+// Events transformed for router & batch router destinations are matched by the events nested in their payloads too.
+func (matcher *UserMatcherT) Matches(event gjson.Result) bool {
+	if matcher.matchesEvent(event) {
+		return true
+	}
+	for _, nestedEvent := range nestedEvents(event) {
+		if matcher.matchesEvent(nestedEvent) {
+			return true
+		}
+	}
+	return false
+}
+
+func (matcher *UserMatcherT) matchesEvent(event gjson.Result) bool {
+	return matchesAny(event, userIDPaths, matcher.userIDs) ||
+		matchesAny(event, emailPaths, matcher.emails) ||
+		matchesAny(event, phonePaths, matcher.phones)
+}
+
+// nestedEvents returns the events nested in router & batch router payloads:
+// the body of REST payloads, the events of batched REST payloads, which are a json encoded array, & the row of warehouse payloads
+func nestedEvents(event gjson.Result) []gjson.Result {
+	var events []gjson.Result
+	if body := event.Get("body.JSON"); body.IsObject() {
+		events = append(events, body)
+	}
+	if batch := event.Get("body.JSON_ARRAY.batch"); batch.Exists() {
+		if batch.Type == gjson.String {
+			batch = gjson.Parse(batch.String())
+		}
+		events = append(events, batch.Array()...)
+	}
+	if data := event.Get("data"); data.IsObject() {
+		events = append(events, data)
+	}
+	return events
+}
+
+func matchesAny(event gjson.Result, paths []string, values map[string]struct{}) bool {
+	if len(values) == 0 {
+		return false
+	}
+	for _, path := range paths {
+		value := event.Get(path)
+		if !value.Exists() || value.Type != gjson.String {
+			continue
+		}
+		if _, ok := values[value.String()]; ok {
+			return true
+		}
+	}
+	return false
+}
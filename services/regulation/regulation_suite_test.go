@@ -0,0 +1,13 @@
+package regulation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestRegulation(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Regulation Suite")
+}
@@ -0,0 +1,89 @@
+package regulation_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tidwall/gjson"
+
+	"github.com/rudderlabs/rudder-server/services/regulation"
+)
+
+var _ = Describe("regulation", func() {
+	email := "user2@example.com"
+	phone := "+1234567890"
+
+	Context("propagation request validation", func() {
+		It("accepts a request with users", func() {
+			req := regulation.PropagationRequestT{JobID: 1, WorkspaceID: "ws-1", Users: []regulation.UserT{{UserID: "user1"}}}
+			Expect(req.Validate()).To(Succeed())
+		})
+
+		It("rejects a request without job id", func() {
+			req := regulation.PropagationRequestT{WorkspaceID: "ws-1", Users: []regulation.UserT{{UserID: "user1"}}}
+			Expect(req.Validate()).NotTo(Succeed())
+		})
+
+		It("rejects a request without users", func() {
+			req := regulation.PropagationRequestT{JobID: 1, WorkspaceID: "ws-1"}
+			Expect(req.Validate()).NotTo(Succeed())
+		})
+
+		It("rejects a request having a user without identifiers", func() {
+			req := regulation.PropagationRequestT{JobID: 1, WorkspaceID: "ws-1", Users: []regulation.UserT{{UserID: "user1"}, {}}}
+			Expect(req.Validate()).NotTo(Succeed())
+		})
+	})
+
+	Context("user matcher", func() {
+		matcher := regulation.NewUserMatcher([]regulation.UserT{
+			{UserID: "user1"},
+			{Email: &email},
+			{Phone: &phone},
+		})
+
+		It("matches events by user id", func() {
+			Expect(matcher.Matches(gjson.Parse(`{"userId": "user1", "event": "test"}`))).To(BeTrue())
+			Expect(matcher.Matches(gjson.Parse(`{"message": {"userId": "user1"}}`))).To(BeTrue())
+		})
+
+		It("matches events by email & phone in traits", func() {
+			Expect(matcher.Matches(gjson.Parse(`{"userId": "other", "context": {"traits": {"email": "user2@example.com"}}}`))).To(BeTrue())
+			Expect(matcher.Matches(gjson.Parse(`{"userId": "other", "traits": {"phone": "+1234567890"}}`))).To(BeTrue())
+		})
+
+		It("matches router jobs by the event in their payload", func() {
+			//payload of a router job of a webhook destination, as returned by the transformer
+			payload := `{
+				"version": "1",
+				"type": "REST",
+				"method": "POST",
+				"endpoint": "https://example.com/webhook",
+				"headers": {"content-type": "application/json"},
+				"params": {},
+				"body": {
+					"JSON": {"type": "track", "event": "Product Purchased", "userId": "user1", "anonymousId": "anon-1", "messageId": "message-1"},
+					"JSON_ARRAY": {},
+					"XML": {},
+					"FORM": {}
+				},
+				"files": {},
+				"userId": "anon-1"
+			}`
+			Expect(matcher.Matches(gjson.Parse(payload))).To(BeTrue())
+			Expect(matcher.Matches(gjson.Parse(`{"body": {"JSON": {"userId": "other", "traits": {"email": "user2@example.com"}}}, "userId": "anon-1"}`))).To(BeTrue())
+			Expect(matcher.Matches(gjson.Parse(`{"body": {"JSON": {"userId": "other"}}, "userId": "anon-1"}`))).To(BeFalse())
+		})
+
+		It("matches batched router jobs & warehouse batch router jobs by their events", func() {
+			Expect(matcher.Matches(gjson.Parse(`{"body": {"JSON_ARRAY": {"batch": "[{\"userId\": \"other\"}, {\"userId\": \"user1\"}]"}}}`))).To(BeTrue())
+			Expect(matcher.Matches(gjson.Parse(`{"body": {"JSON_ARRAY": {"batch": "[{\"userId\": \"other\"}]"}}}`))).To(BeFalse())
+			Expect(matcher.Matches(gjson.Parse(`{"metadata": {"table": "tracks"}, "data": {"user_id": "other", "context_traits_phone": "+1234567890"}}`))).To(BeTrue())
+		})
+
+		It("doesn't match events of other users", func() {
+			Expect(matcher.Matches(gjson.Parse(`{"userId": "user2", "context": {"traits": {"email": "other@example.com"}}}`))).To(BeFalse())
+			Expect(matcher.Matches(gjson.Parse(`{"anonymousId": "user1"}`))).To(BeFalse())
+			Expect(matcher.Matches(gjson.Parse(`{"userId": 1}`))).To(BeFalse())
+		})
+	})
+})
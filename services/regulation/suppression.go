@@ -0,0 +1,129 @@
+package regulation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// SuppressionStoreT keeps users suppressed as per regulations in suppressed_users table.
+// It implements types.SuppressUserI, with a cache of suppressed users refreshed periodically
+// so that users suppressed through any of the gateway nodes are suppressed by all of them.
+// Users are suppressed per workspace, since the same user id can belong to different users in other workspaces.
+type SuppressionStoreT struct {
+	dbHandle               *sql.DB
+	workspaceIDForWriteKey func(writeKey string) string
+	mu                     sync.RWMutex
+	// userIDs maps workspace id to the user ids suppressed in it
+	userIDs map[string]map[string]struct{}
+}
+
+// NewSuppressionStore returns a store resolving the workspace of requests from their write key with workspaceIDForWriteKey
+func NewSuppressionStore(dbHandle *sql.DB, workspaceIDForWriteKey func(writeKey string) string) *SuppressionStoreT {
+	return &SuppressionStoreT{
+		dbHandle:               dbHandle,
+		workspaceIDForWriteKey: workspaceIDForWriteKey,
+		userIDs:                make(map[string]map[string]struct{}),
+	}
+}
+
+// Suppress adds the users of `req` to suppressed users & returns the number of users suppressed.
+// Users are suppressed by userId as that is what is checked at gateway.
+func (store *SuppressionStoreT) Suppress(req PropagationRequestT) (int, error) {
+	userIDs := make([]string, 0, len(req.Users))
+	for _, user := range req.Users {
+		if user.UserID != "" {
+			userIDs = append(userIDs, user.UserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+
+	sqlStatement := `INSERT INTO suppressed_users (workspace_id, user_id, regulation_job_id)
+		SELECT $1, user_id, $2 FROM unnest($3::text[]) AS user_id
+		ON CONFLICT (workspace_id, user_id) DO NOTHING`
+	_, err := store.dbHandle.Exec(sqlStatement, req.WorkspaceID, req.JobID, pq.Array(userIDs))
+	if err != nil {
+		return 0, fmt.Errorf("failed to suppress users of regulation job %d: %w", req.JobID, err)
+	}
+
+	store.mu.Lock()
+	addUserIDs(store.userIDs, req.WorkspaceID, userIDs...)
+	store.mu.Unlock()
+	return len(userIDs), nil
+}
+
+func addUserIDs(workspaceUserIDs map[string]map[string]struct{}, workspaceID string, userIDs ...string) {
+	if _, ok := workspaceUserIDs[workspaceID]; !ok {
+		workspaceUserIDs[workspaceID] = make(map[string]struct{})
+	}
+	for _, userID := range userIDs {
+		workspaceUserIDs[workspaceID][userID] = struct{}{}
+	}
+}
+
+// IsSuppressedUser returns true if the user has been suppressed as per a regulation in the workspace of the write key,
+// for all sources of the workspace
+func (store *SuppressionStoreT) IsSuppressedUser(userID, sourceID, writeKey string) bool {
+	if userID == "" {
+		return false
+	}
+	workspaceID := store.workspaceIDForWriteKey(writeKey)
+	if workspaceID == "" {
+		return false
+	}
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	_, ok := store.userIDs[workspaceID][userID]
+	return ok
+}
+
+// Refresh reloads suppressed users from suppressed_users table
+func (store *SuppressionStoreT) Refresh() error {
+	rows, err := store.dbHandle.Query(`SELECT workspace_id, user_id FROM suppressed_users`)
+	if err != nil {
+		return fmt.Errorf("failed to query suppressed users: %w", err)
+	}
+	defer rows.Close()
+
+	userIDs := make(map[string]map[string]struct{})
+	for rows.Next() {
+		var workspaceID, userID string
+		if err = rows.Scan(&workspaceID, &userID); err != nil {
+			return fmt.Errorf("failed to scan suppressed user: %w", err)
+		}
+		addUserIDs(userIDs, workspaceID, userID)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to read suppressed users: %w", err)
+	}
+
+	store.mu.Lock()
+	store.userIDs = userIDs
+	store.mu.Unlock()
+	return nil
+}
+
+// StartRefreshLoop refreshes suppressed users every Regulation.suppressionRefreshInterval till ctx is done
+func (store *SuppressionStoreT) StartRefreshLoop(ctx context.Context) {
+	for {
+		if err := store.Refresh(); err != nil {
+			pkgLogger.Errorf("Failed to refresh suppressed users: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(suppressionRefreshInterval):
+		}
+	}
+}
+
+// DBHandle returns the handle used by the store, to record actions in the same database
+func (store *SuppressionStoreT) DBHandle() *sql.DB {
+	return store.dbHandle
+}
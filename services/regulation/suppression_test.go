@@ -0,0 +1,33 @@
+package regulation
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("suppression store", func() {
+	workspaceIDs := map[string]string{
+		"write-key-1": "ws-1",
+		"write-key-2": "ws-2",
+	}
+	store := NewSuppressionStore(nil, func(writeKey string) string {
+		return workspaceIDs[writeKey]
+	})
+	addUserIDs(store.userIDs, "ws-1", "user1")
+	addUserIDs(store.userIDs, "ws-2", "user2")
+
+	It("suppresses users in the workspace they are suppressed in", func() {
+		Expect(store.IsSuppressedUser("user1", "source-1", "write-key-1")).To(BeTrue())
+		Expect(store.IsSuppressedUser("user2", "source-2", "write-key-2")).To(BeTrue())
+	})
+
+	It("doesn't suppress users with the same user id in other workspaces", func() {
+		Expect(store.IsSuppressedUser("user1", "source-2", "write-key-2")).To(BeFalse())
+		Expect(store.IsSuppressedUser("user2", "source-1", "write-key-1")).To(BeFalse())
+	})
+
+	It("doesn't suppress users of unknown write keys", func() {
+		Expect(store.IsSuppressedUser("user1", "", "unknown-write-key")).To(BeFalse())
+		Expect(store.IsSuppressedUser("", "source-1", "write-key-1")).To(BeFalse())
+	})
+})
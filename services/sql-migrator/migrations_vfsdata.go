@@ -21,314 +21,347 @@ var MigrationAssets = func() http.FileSystem {
 	fs := vfsgen۰FS{
 		"/": &vfsgen۰DirInfo{
 			name:    "/",
-			modTime: time.Date(2021, 12, 9, 14, 21, 9, 256036373, time.UTC),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 		},
 		"/jobsdb": &vfsgen۰DirInfo{
 			name:    "jobsdb",
-			modTime: time.Date(2022, 1, 21, 7, 6, 43, 835795303, time.UTC),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 		},
 		"/jobsdb/000001_create_tables.down.tmpl": &vfsgen۰CompressedFileInfo{
 			name:             "000001_create_tables.down.tmpl",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 28932867, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 265,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\x8e\xb1\x8a\x83\x40\x10\x86\xfb\x7d\x8a\xbf\xb8\x56\x5f\xc0\xea\x0e\x3d\x10\x0e\x4e\xa2\x45\x52\x2d\x2b\x6e\x44\x91\x55\x76\x47\x50\x86\x79\xf7\x10\x13\x93\x90\x26\xe5\xcc\x7c\xff\xfc\x5f\x14\x21\xf5\xe3\x04\xe3\x56\xd8\xa5\x0b\xd4\xb9\x16\x8d\x21\x13\x2c\x05\xc5\xec\x8d\x6b\x2d\xe2\xf4\xbe\x11\x51\x00\x90\x1e\xfe\x0b\x54\xdf\x3f\x7f\x19\x98\xbf\xe2\xc2\xdb\x73\xb7\x88\xe8\x7e\xac\x83\x66\x8e\x45\x92\x4f\x9c\x0e\x64\x68\x7e\xd0\xcc\xd6\x35\x22\x4a\xed\x42\xb4\x4e\x16\x3b\x67\xf5\x75\x54\xb7\x77\xa7\x22\x7b\x3b\x24\xcf\x58\x3f\xce\xde\x99\x01\x64\xea\x61\x0f\x6c\xfd\xf9\x2f\xb2\x63\x5e\x56\x25\x98\x5f\x45\x36\x3c\x51\xea\x12\x00\x00\xff\xff\xa5\xa4\xe3\xe3\x09\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\xce\xc1\x6a\x84\x40\x0c\x06\xe0\x7b\x9e\xe2\x3f\xf4\xaa\x2f\xe0\xa9\x45\x0b\x42\xa1\x52\x3d\xb4\xa7\x61\xc4\x54\x14\x19\x65\x66\x04\x25\xe4\xdd\x4b\xdb\x75\x57\xf6\xb2\xc7\x24\x5f\x92\x3f\x49\x90\xfb\x79\x81\x75\x3b\x78\x1b\x42\x1c\x5c\x8f\xce\x46\x1b\x38\x06\x12\xf1\xd6\xf5\x8c\x34\xbf\x74\x54\x09\x00\xf2\x8f\xf7\x0a\xcd\xf3\xcb\x5b\x01\x91\xa7\xb4\xf2\xfc\x3d\x6c\xaa\x66\x9c\xdb\x60\x44\x52\xd5\xec\x91\x33\x21\xda\xb8\x5e\xb5\x08\xbb\x4e\x95\xe8\x08\x14\xf7\x85\x71\x38\x36\xbf\x25\xfd\x9f\xfb\xaa\x8a\xbb\x41\x76\x5b\x1b\xe7\xd5\x3b\x3b\x21\xda\x76\x62\x3a\xfd\x2f\x5f\x51\x7c\x96\x75\x53\x43\xe4\x1c\xe4\x8f\x67\x44\x3f\x03\x00\xa5\xa4\xe3\xe3\x09\x01\x00\x00"),
 		},
 		"/jobsdb/000001_create_tables.up.tmpl": &vfsgen۰CompressedFileInfo{
 			name:             "000001_create_tables.up.tmpl",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 29041090, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 1358,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x54\x51\x6f\xe2\x38\x10\x7e\xe7\x57\xcc\x03\x12\xad\x04\x9c\x74\xf7\x76\x7d\x0a\xe0\xdb\x4d\x0f\x02\x82\x74\xaf\x7d\x8a\x26\xf6\x00\xee\x1a\x3b\x67\x3b\x2d\x51\x94\xff\x7e\x72\x48\xba\x57\xb6\x2b\x35\x8f\x33\xdf\xf7\xcd\xcc\x97\x19\x4f\x26\x10\x6b\xe9\x25\x2a\x78\x21\xeb\xa4\xd1\x60\xf6\x70\x6f\x72\xb7\x98\x81\xc7\x5c\x91\x1b\x43\x8e\x8e\x04\x18\x0d\x9e\x4e\x85\x42\x4f\x20\xd0\x23\x14\xd6\xbc\x48\x41\x02\xf2\x0a\x08\xf9\xb1\xa7\x49\xed\x3c\x6a\x4e\x83\xc9\x04\xe6\x47\xe2\xdf\xe1\xd9\xe4\x4e\xe4\xbf\x39\xf2\x65\x31\x3d\x18\xd8\x1b\x0b\xa8\x14\xe0\x0b\x4a\x15\x8a\xbc\x57\x9e\x0e\x02\xf5\xde\x94\x56\xa3\x72\x97\x36\x06\xf3\x2d\x8b\x52\x06\x69\x34\x5b\x32\x88\xff\x82\x64\x9d\x02\x7b\x8c\x77\xe9\x0e\xea\x7a\xba\xb1\xb4\x97\xe7\xa6\xc9\x9e\x2f\x2c\xb8\x19\x00\x00\x48\x01\xb3\xf8\xcb\x8e\x6d\xe3\x68\x09\x9b\x6d\xbc\x8a\xb6\x4f\xf0\x37\x7b\x1a\xb7\x59\x53\x90\x45\x1f\x66\xfe\x16\x6d\xe7\x5f\xa3\xed\xcd\x1f\xbf\xdf\xb6\xc2\xc9\xc3\x72\x79\xc1\x08\xa3\x09\x66\xeb\xf5\x92\x45\xc9\x15\x2b\x2b\xb0\x52\x06\x05\xdc\xef\xd6\xc9\xec\x8a\xe7\x3c\x5a\x9f\x79\x79\x22\x48\xe3\x15\xdb\xa5\xd1\x6a\x73\x05\x21\x2d\xae\x00\xb7\x77\xdd\xe4\x79\xe0\x7b\x02\x5f\x15\x34\x86\xd2\x5d\x4c\x0e\x36\x76\x3f\x65\xb0\x58\xc3\x70\x08\x33\xf6\x25\x4e\x5a\xb1\xde\x9e\xa7\x0d\x0b\xb8\xac\xe5\x67\x81\xdf\xa6\xc3\x17\xed\x80\x25\x0f\xab\x9b\xb7\x40\xff\x8d\x5e\x51\x7a\xa9\x0f\xa3\xf1\xcf\x29\x3a\x13\x2f\x7f\x95\x74\x25\xe7\x44\x82\xc4\x47\xc9\x4e\x34\xb3\xe4\x6d\xf5\x11\x60\x8f\x52\x7d\x4c\xc5\xdc\x58\x4f\x62\x74\x7b\xd7\xe6\xd8\xe3\x9c\x6d\xd2\x78\x9d\xbc\x21\xff\xf9\xca\x12\x10\x65\xa1\x24\x0f\x63\x9a\xfc\x99\xb8\x87\x34\x44\x75\xa9\xd4\xdd\x80\x25\x0b\x18\x0e\x2f\x76\x2e\xd0\xa3\x23\xdf\x39\x07\x68\x09\xb4\xf1\xc0\x2d\xa1\x27\x01\x42\x5a\xe2\x5e\x55\xc1\xe1\x93\x3c\x74\x1b\xe1\xb8\x95\x85\x77\x63\xf0\x47\x6a\x29\x3d\xfc\x45\x62\xb7\xe8\x23\xd7\x05\x17\x3b\xd8\x97\x9a\xb7\xbc\xb0\xfc\x84\x62\x1a\x0a\xa7\x47\x82\x7f\x4b\xb2\xd5\xe5\x0f\x4a\xfd\x03\x7f\x2a\x9d\x07\x54\xaf\x58\xf5\x22\x6d\x21\xf1\xbe\xd5\xd2\x49\x7d\x68\x13\xe1\x36\x9c\x07\xc7\x8f\x74\xc2\xfe\x54\xdb\x22\xdf\xba\xb3\x2d\x0b\x11\x30\xed\x6d\xd1\x59\xba\xe0\xfd\xb5\x1e\x47\x0d\x39\x81\xa0\xbd\xd4\x24\xc6\xbd\xfe\xbb\xab\xfe\x4e\x15\x8c\x3a\xcb\xdc\x08\xbc\x01\xe9\xc3\xc2\xd3\xcf\xaa\x52\x0b\xc9\xc9\x75\xb3\x4a\x07\xd2\x01\x6a\xa0\x33\x9e\x0a\x45\xe1\x21\xc1\x37\xec\xb5\xb3\x7d\xf1\x1f\xf1\xbe\x0d\xf7\xe7\x60\x32\x09\x92\x75\x6d\x51\x1f\x08\xa6\x7d\x37\x4d\x13\xc2\xed\x2a\x2f\x53\xb6\xed\xde\x82\xba\x1e\xfe\xff\xfc\x73\x97\xd5\xf5\xb4\x69\x20\x5a\x2c\x60\xbe\x5e\x3e\xac\x12\xd0\xf4\x9a\x71\xa3\xca\x93\x86\x94\x3d\xa6\x77\x9f\x91\x69\x2f\xa8\xfc\xa4\x58\x5d\x93\x16\x4d\xf3\x5f\x00\x00\x00\xff\xff\x7d\x20\xde\x2b\x4e\x05\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x54\xc1\x6e\xe2\x48\x10\xbd\xf3\x15\xef\x80\x44\x22\x01\x2b\xed\xde\x36\x27\x03\xbd\x33\xce\x82\x41\xe0\xcc\x26\x27\x54\x76\x17\xd0\x19\xd3\xed\xed\x6e\x27\x41\x16\xff\xbe\x6a\x63\x67\x36\xcc\x8c\x34\x7d\x42\x55\xef\xbd\xaa\x7a\xae\x62\x34\x42\xac\x95\x57\x54\xe0\x85\xad\x53\x46\xc3\xec\x70\x6f\x32\x37\x9b\xc0\x53\x56\xb0\x1b\x22\x23\xc7\x12\x46\xc3\xf3\xb1\x2c\xc8\x33\x24\x79\x42\x69\xcd\x8b\x92\x2c\x91\x9d\xc0\x94\x1f\x3a\x9a\xd2\xce\x93\xce\xb9\x37\x1a\x61\x7a\xe0\xfc\x2b\x9e\x4d\xe6\x64\xf6\x9b\x63\x5f\x95\xe3\xbd\xc1\xce\x58\x50\x51\x80\x5e\x48\x15\xa1\xc8\x47\xe5\x71\x2f\x50\xef\x4d\x65\x35\x15\xee\xd2\x46\x6f\xba\x16\x51\x2a\x90\x46\x93\xb9\x40\xfc\x17\x92\x65\x0a\xf1\x18\x6f\xd2\x0d\xea\x7a\xbc\xb2\xbc\x53\x6f\xe7\xf3\xf6\xf9\xc2\xc2\x4d\x0f\x00\x94\xc4\x24\xfe\xb4\x11\xeb\x38\x9a\x63\xb5\x8e\x17\xd1\xfa\x09\x7f\x8b\xa7\x61\x93\x35\x25\x5b\xf2\x61\xe6\x2f\xd1\x7a\xfa\x39\x5a\xdf\xfc\xf1\xfb\x6d\x23\x9c\x3c\xcc\xe7\x17\x8c\x34\x9a\x31\x59\x2e\xe7\x22\x4a\xae\x58\xdb\x92\x4e\x85\x21\x89\xfb\xcd\x32\x99\x5c\xf1\x9c\x27\xeb\xb7\x5e\x1d\x19\x69\xbc\x10\x9b\x34\x5a\xac\xae\x20\xac\xe5\x15\xe0\xf6\xae\x9d\x3c\x0b\x7c\xcf\xf0\xa7\x92\x87\xa8\xdc\xc5\xe4\x60\x63\xfb\x51\x7a\xb3\x25\xfa\x7d\x4c\xc4\xa7\x38\x69\xc4\x3a\x7b\x9e\x56\x22\xe0\xb6\x0d\x7f\x1b\xf8\x4d\x3a\xbc\x68\x03\x91\x3c\x2c\x6e\xde\x03\xdd\x1b\xbc\x92\xf2\x4a\xef\x07\xc3\xef\x53\xfc\xc6\x79\xf5\xb3\xa4\xab\xf2\x9c\x59\xb2\x1c\x0c\x7f\x2a\xba\xb5\xec\xed\xe9\x47\x80\x1d\xa9\xe2\xc7\x54\xca\x8c\xf5\x2c\x07\xb7\x77\x4d\x4e\x3c\x4e\xc5\x2a\x8d\x97\xc9\x3b\xf2\x9f\xcf\x22\x81\xac\xca\x42\xe5\x61\x4c\x93\x3d\x73\xee\x91\x86\xa8\xae\x8a\xe2\xae\x27\x92\x19\xfa\xfd\x8b\x9d\x33\xf2\xe4\xd8\xb7\xce\x81\x2c\x43\x1b\x8f\xdc\x32\x79\x96\x90\xca\x72\xee\x8b\x53\x70\xf8\xa8\xf6\xed\x46\xb8\xdc\xaa\xd2\xbb\x21\xfc\x81\x1b\x4a\x07\x7f\x51\xd4\x2e\xfa\xc0\xb5\xc1\xd9\x06\xbb\x4a\xe7\x0d\x2f\x2c\x3f\x93\x1c\x87\xc2\xe9\x81\xf1\x6f\xc5\xf6\x74\xf9\x82\x4a\x7f\xc3\x1f\x2b\xe7\x41\xc5\x2b\x9d\x3a\x91\xa6\x90\xfc\xd8\x6a\xe5\x94\xde\x37\x89\x70\x1b\xce\xc3\xe5\x07\x3e\x52\x77\xaa\x4d\x91\x2f\x97\xdf\xa8\x4a\x19\x30\xcd\x6d\xf1\x9b\x72\xc1\xfb\x6b\xbd\x9c\x34\x32\x86\xe4\x9d\xd2\x2c\x87\x9d\xfe\x87\xab\xfe\xca\x27\x0c\x5a\xcb\xdc\x00\xde\x40\xf9\xb0\xf0\xfc\xbd\xaa\xd2\x52\xe5\xec\xda\x59\x95\x83\x72\x20\x0d\x7e\xa3\x63\x59\x30\xcc\x0e\xf4\x8e\xbd\x76\xb6\x2b\xfe\x2d\xde\xb5\xe1\xfe\xec\x8d\x46\x41\xb2\xae\x2d\xe9\x3d\x63\xdc\x75\x73\x3e\x87\x70\x78\xd1\x3c\x15\xeb\xf6\xbf\xa0\xae\xfb\xff\x3f\xff\xcc\x6d\xeb\x7a\x7c\x3e\x23\x9a\xcd\x30\x5d\xce\x1f\x16\x09\x34\xbf\x6e\x73\x53\x54\x47\x8d\x54\x3c\xa6\x77\xbf\x22\xd3\x5c\x50\xf5\x8b\x62\x75\xcd\x5a\x9e\xcf\xff\x0d\x00\x7d\x20\xde\x2b\x4e\x05\x00\x00"),
 		},
 		"/jobsdb/000002_alter_dataset_tables.down.tmpl": &vfsgen۰CompressedFileInfo{
 			name:             "000002_alter_dataset_tables.down.tmpl",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 29137131, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 802,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xa4\x91\x41\x6a\xf3\x30\x10\x85\xf7\x39\xc5\x2c\x0c\xf9\x7f\x68\x72\x01\xaf\x9c\x58\x6d\x03\x8e\x6c\x52\x99\xb6\x2b\x21\x5b\x93\xa0\x60\xec\x20\xc9\x34\x41\xe8\xee\xc5\x76\x1a\x48\x71\x42\x69\x67\x61\xf0\xcc\x9b\x6f\xc4\x7b\xce\x69\x51\xef\x10\xe6\xb1\xb0\xc2\xa0\x35\xde\x4f\x00\x00\xa2\x84\x91\x0d\xb0\x68\x91\x10\x70\x2e\x98\x67\x1a\xb7\xea\xe8\x3d\xdf\x37\x85\xe1\xce\xcd\xbd\x87\x78\x93\x66\xb0\x4c\x93\x7c\x4d\xa1\x35\xa8\xb9\x92\xe1\x8f\x97\x07\xcd\x79\xbb\xd4\x28\x2c\x4a\x2e\x2c\x18\xb4\x10\x93\xc7\x28\x4f\x18\xd0\x3c\x49\x7e\x49\xc4\xe3\x41\x69\x1c\x07\xf6\xc4\xd9\x0c\x34\x0e\x77\x61\xdf\x14\xdc\x58\x61\x91\xdb\xd3\x01\xa1\xfb\xf4\x9a\x38\x85\x20\x80\x05\x79\x5a\xd1\xfe\xbf\xab\xe5\x86\x44\x8c\x00\x7b\xcf\xc8\xb7\xbd\x8b\xa4\x7f\xf0\x0b\x10\x9a\xaf\xff\x5d\x35\xbf\x6a\xfa\x21\x94\x55\xf5\x6e\xfa\x30\x3e\xc6\x23\x96\xed\x3d\x81\x69\xcb\x12\x51\xa2\xbc\x25\x38\x1f\xe0\x1a\xad\x3e\xdd\x12\x6d\x85\xaa\x6e\x23\x44\xd1\x68\x8b\x72\xfa\x3f\xbc\x9a\x93\xb7\x25\xc9\xd8\x2a\xa5\x57\xdd\xd7\x67\x42\x41\xb6\x87\x4a\x95\x9d\x1d\x4d\xb1\xc7\xd2\x02\xeb\xba\x75\x5b\x55\x03\x82\xd0\x18\x82\xe0\xec\xff\xbd\x44\x7b\x53\xdb\xd1\x5c\x2f\x96\x8f\x25\x10\xfe\x95\xac\xe4\x80\x5d\x51\x16\x4e\x9c\xc3\x5a\x7a\xff\x19\x00\x00\xff\xff\xea\x0e\x28\x43\x22\x03\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xa4\x91\x51\x6b\xc2\x30\x10\xc7\xdf\xfd\x14\xf7\x50\xe8\x06\xea\x17\xe8\x53\xb5\xd9\x26\xd4\xb4\xb8\x94\x6d\x4f\x25\x6d\x4e\x89\x94\x56\x92\x94\x29\x21\xdf\x7d\xb4\xd5\x81\xa3\xca\xd8\xee\x21\x90\xbb\xff\xfd\x0e\xfe\x7f\x6b\x15\xaf\x77\x08\xf3\x88\x1b\xae\xd1\x68\xe7\x26\x00\x00\x61\xcc\xc8\x06\x58\xb8\x88\x09\x58\xeb\xcd\x53\x85\x5b\x79\x74\x2e\xdf\x37\x85\xce\xad\x9d\x3b\x07\xd1\x26\x49\x61\x99\xc4\xd9\x9a\x42\xab\x51\xe5\x52\x04\xbf\x5e\x1e\x34\xe7\xed\x52\x21\x37\x28\x72\x6e\x40\xa3\x81\x88\x3c\x85\x59\xcc\x80\x66\x71\xfc\x47\x22\x1e\x0f\x52\xe1\x38\xb0\x27\xce\x66\xa0\x70\xb8\x0b\xfb\xa6\xc8\xb5\xe1\x06\x73\x73\x3a\x20\x74\x4f\xaf\x89\x12\xf0\x3c\x58\x90\xe7\x15\xed\xff\x5d\x2d\x37\x24\x64\x04\xd8\x47\x4a\x7e\xec\x7d\x4b\xba\x0a\x5f\x81\xd0\x6c\xfd\x70\xd5\xbc\x94\xff\xc9\xa5\x91\xf5\xce\x9f\x8e\x8f\xf1\x88\x65\x7b\x4f\xa0\xdb\xb2\x44\x14\x28\xfc\xe9\xdd\x03\xb9\x42\xa3\x4e\xb7\x44\x5b\x2e\xab\xdb\x08\x5e\x34\xca\xa0\xf0\x1f\x83\xab\x39\x79\x5f\x92\x94\xad\x12\x7a\xd5\x7d\x7b\x21\x14\x44\x7b\xa8\x64\xd9\xd9\xd1\x14\x7b\x2c\x0d\xb0\xae\x5b\xb7\x55\x35\x20\x08\x8d\xc0\xf3\xce\xfe\xdf\x4b\xb4\x37\xb5\x1d\xcd\xf5\x32\xc5\xb1\x04\x82\xff\x92\xa5\x18\xb0\x2b\xca\x82\x89\xb5\x58\x0b\xe7\xbe\x06\x00\xea\x0e\x28\x43\x22\x03\x00\x00"),
 		},
 		"/jobsdb/000002_alter_dataset_tables.up.tmpl": &vfsgen۰CompressedFileInfo{
 			name:             "000002_alter_dataset_tables.up.tmpl",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 29238384, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 718,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xa4\x91\x51\x6b\x9c\x40\x14\x85\xdf\xfd\x15\xe7\x41\x48\x0a\xd9\x85\x42\xe9\x8b\x4f\xee\x3a\xc9\x0a\x66\x14\x77\xb6\xd9\x3e\xc9\xb8\xde\x46\x83\xa8\xcc\x8c\x69\xc2\x30\xff\xbd\x8c\xdb\x6e\x28\x14\x52\xda\x37\xef\xf5\xdc\xef\xde\x73\xc6\x5a\x25\x87\x47\xc2\x3a\x91\x46\x6a\x32\xda\xb9\x00\x00\xe2\x4c\xb0\x12\x22\xde\x64\x0c\xd6\x86\xeb\x42\xd1\xb7\xee\xc5\xb9\xea\x69\xac\x75\x65\xed\xda\xb9\x9f\x9a\x6d\x9e\x1d\xee\x39\x4e\x8a\xa4\xa1\xa6\x92\x06\x9a\x0c\x12\x76\x1b\x1f\x32\x01\x9e\x3f\x5c\x7f\x88\xfe\x0d\x49\x2f\x53\xa7\xe8\x7f\x89\x49\xf2\x8b\x97\xde\x82\xe7\x02\xec\x98\xee\xc5\x1e\xb3\x26\x55\x75\x0d\x04\x3b\x8a\xa5\xcf\x0f\x59\x76\xd9\x72\xb5\xfa\x78\x15\x05\xcb\x96\xd5\x0a\xdb\x71\x78\x26\x65\xf0\x34\xd6\x95\x36\xd2\x10\xcc\x88\x67\xa9\x4e\xad\x54\x37\x68\xd4\x38\xc1\xbc\x4e\xf4\xf6\xbf\xf2\xe5\xbb\x37\x2e\xda\xf9\x8f\xde\xdf\x36\x89\xaf\x05\xc3\x97\xb8\xdc\xee\xe2\xf2\xfa\xf3\xa7\xbf\xb0\xfe\x0e\xd6\x7b\xf6\xcc\x4d\x7a\x97\x72\x11\x05\x17\x97\xf7\xdd\x63\x6b\xa0\x4d\xd7\xf7\xa8\xc9\x07\xd4\xa0\x7e\xc5\x68\x5a\x52\x7e\x52\x37\x35\xba\x41\x1b\x39\x9c\xe8\x06\xbd\xd4\x06\xe3\x40\x98\xa7\xc6\xbf\x3c\xbe\xfb\xb9\x73\x16\x2d\x2d\x79\xac\x17\x72\x92\x23\x0c\xb1\x61\x77\x29\x5f\xea\xa5\x57\xe6\xc5\xf9\x8a\xdf\x23\x8b\x2e\x0a\x76\xdc\xb2\x42\xa4\x39\xc7\xc3\x8e\x71\xe4\x62\xc7\xca\x3d\x84\xff\x1e\xe6\xbe\x3f\x0b\x19\x4f\x10\x86\x51\x60\x2d\x0d\x8d\x73\xc1\x8f\x00\x00\x00\xff\xff\x3e\x89\xb1\x86\xce\x02\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xa4\x91\x51\x6b\x9c\x40\x14\x85\xdf\xfd\x15\xe7\x41\x48\x0a\xd9\x85\x42\xe9\x8b\x4f\xee\x3a\xc9\x0a\x66\x5c\xdc\xd9\x66\xfb\x24\xe3\xce\x6d\x34\x88\xca\xcc\x98\x26\x0c\xf3\xdf\x8b\xda\x6e\x28\x14\x52\xda\x37\xef\xf5\xdc\xef\xde\x73\xc6\x39\x2d\xbb\x47\xc2\x3a\x91\x56\x1a\xb2\xc6\xfb\x00\x00\xe2\x4c\xb0\x02\x22\xde\x64\x0c\xce\x85\xeb\xbd\xa6\x6f\xcd\x8b\xf7\xe5\x53\x5f\x99\xd2\xb9\xb5\xf7\x3f\x35\xdb\x3c\x3b\xde\x73\x9c\x35\x49\x4b\xaa\x94\x16\x86\x2c\x12\x76\x1b\x1f\x33\x01\x9e\x3f\x5c\x7f\x88\xfe\x0d\x49\x2f\x43\xa3\xe9\x7f\x89\x49\xf2\x8b\x97\xde\x82\xe7\x02\xec\x94\x1e\xc4\x01\xa3\x21\x5d\x36\x0a\x82\x9d\xc4\xdc\xe7\xc7\x2c\xbb\x6c\xb9\x5a\x7d\xbc\x8a\x82\x79\xcb\x6a\x85\x6d\xdf\x3d\x93\xb6\x78\xea\xab\xd2\x58\x69\x09\xb6\xc7\xb3\xd4\xe7\x5a\xea\x1b\x28\xdd\x0f\xb0\xaf\x03\xbd\xfd\x2f\xa7\xf2\xdd\x1b\x67\xed\xf8\x47\xef\x6f\x9b\xc4\xd7\x3d\xc3\x97\xb8\xd8\xee\xe2\xe2\xfa\xf3\xa7\xbf\xb0\xfe\x0e\xb6\x51\x0b\x73\x93\xde\xa5\x5c\x44\xc1\xc5\xe5\x7d\xf3\x58\x5b\x18\xdb\xb4\x2d\x2a\x9a\x02\x52\xa8\x5e\xd1\xdb\x9a\xf4\x34\x69\x54\x85\xa6\x33\x56\x76\x67\xba\x41\x2b\x8d\x45\xdf\x11\xc6\x41\x4d\x2f\x8f\xef\xd3\xdc\x92\x45\x4d\x73\x1e\xeb\x99\x9c\xe4\x08\x43\x6c\xd8\x5d\xca\xe7\x7a\xee\x15\xf9\x7e\xb9\xe2\xf7\xc8\xa2\x8b\x82\x9d\xb6\x6c\x2f\xd2\x9c\xe3\x61\xc7\x38\x72\xb1\x63\xc5\x01\x62\xfa\xee\xc6\xb6\x5d\x84\x8c\x27\x08\xc3\x28\x70\x8e\x3a\xe5\x7d\xf0\x63\x00\x3e\x89\xb1\x86\xce\x02\x00\x00"),
 		},
 		"/jobsdb/000003_alter_journal_table.down.tmpl": &vfsgen۰FileInfo{
 			name:    "000003_alter_journal_table.down.tmpl",
-			modTime: time.Date(2021, 8, 2, 20, 4, 39, 29325665, time.UTC),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			content: []byte("\x2d\x2d\x20\x44\x72\x6f\x70\x20\x6a\x6f\x75\x72\x6e\x61\x6c\x20\x74\x61\x62\x6c\x65\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x7b\x7b\x24\x2e\x50\x72\x65\x66\x69\x78\x7d\x7d\x5f\x6a\x6f\x75\x72\x6e\x61\x6c\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x6f\x77\x6e\x65\x72\x3b"),
 		},
 		"/jobsdb/000003_alter_journal_table.up.tmpl": &vfsgen۰FileInfo{
 			name:    "000003_alter_journal_table.up.tmpl",
-			modTime: time.Date(2021, 8, 2, 20, 4, 39, 29411562, time.UTC),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			content: []byte("\x2d\x2d\x20\x41\x64\x64\x20\x6f\x77\x6e\x65\x72\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x74\x6f\x20\x4a\x6f\x75\x72\x6e\x61\x6c\x20\x74\x61\x62\x6c\x65\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x7b\x7b\x24\x2e\x50\x72\x65\x66\x69\x78\x7d\x7d\x5f\x6a\x6f\x75\x72\x6e\x61\x6c\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x6f\x77\x6e\x65\x72\x20\x54\x45\x58\x54\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x27\x27\x3b"),
 		},
 		"/jobsdb/000004_alter_status_table.down.tmpl": &vfsgen۰FileInfo{
 			name:    "000004_alter_status_table.down.tmpl",
-			modTime: time.Date(2021, 8, 23, 19, 23, 27, 647807160, time.UTC),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			content: []byte("\x2d\x2d\x20\x44\x72\x6f\x70\x20\x73\x74\x61\x74\x75\x73\x20\x74\x61\x62\x6c\x65\x0a\x7b\x7b\x72\x61\x6e\x67\x65\x20\x2e\x44\x61\x74\x61\x73\x65\x74\x73\x7d\x7d\x0a\x20\x20\x20\x20\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x7b\x7b\x24\x2e\x50\x72\x65\x66\x69\x78\x7d\x7d\x5f\x6a\x6f\x62\x5f\x73\x74\x61\x74\x75\x73\x5f\x7b\x7b\x2e\x7d\x7d\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x70\x61\x72\x61\x6d\x65\x74\x65\x72\x73\x3b\x0a\x7b\x7b\x65\x6e\x64\x7d\x7d"),
 		},
 		"/jobsdb/000004_alter_status_table.up.tmpl": &vfsgen۰CompressedFileInfo{
 			name:             "000004_alter_status_table.up.tmpl",
-			modTime:          time.Date(2021, 8, 23, 19, 23, 27, 648276368, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 177,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x44\xcd\xbd\x6a\xc3\x30\x14\x47\xf1\x5d\x4f\xf1\x1f\x0a\x9e\xec\x07\x70\x27\xb9\x92\xc1\x45\xb5\x4b\x2d\x43\x37\x23\xd7\x6a\x48\xf0\x47\x90\x64\x08\x5c\xee\xbb\x07\x92\x21\xeb\x19\xce\x2f\xcf\x21\xe7\x19\x57\x17\xdc\xea\x93\x0f\xf8\xdb\x97\x63\xdd\x90\x76\xc4\xe4\xd2\x11\x91\xdc\xb4\x78\x41\x14\xdc\x76\xf2\x28\x94\x4b\x2e\xfa\x14\x99\x05\x00\x48\x63\xf5\x0f\xac\xac\x8c\x06\xd1\x5b\xf1\x1d\xfc\xff\xf9\xc6\x3c\x5e\xf6\x69\x7c\x1e\x46\xa2\x82\x19\x52\x29\x7c\x74\x66\xf8\x6a\xd1\xd4\x68\x3b\x0b\xfd\xdb\xf4\xb6\x7f\xd9\x11\x9f\x7d\xd7\x56\x50\xba\x96\x83\xb1\xc8\x88\xb3\xb2\x7c\xb4\x77\x41\xe4\xb7\x99\x59\xdc\x03\x00\x00\xff\xff\x22\x41\x65\x37\xb1\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x44\xcd\xbd\x6a\xc3\x30\x14\x47\xf1\x5d\x4f\xf1\x1f\x0a\x9e\xec\x07\x70\x27\xb9\x92\xc1\x45\xb5\x4b\x2d\x43\x37\x23\xd7\x6a\x48\xf0\x47\x90\x64\x08\x5c\xee\xbb\x07\x92\x21\xeb\x19\xce\x2f\xcf\x21\xe7\x19\x57\x17\xdc\xea\x93\x0f\xf8\xdb\x97\x63\xdd\x90\x76\xc4\xe4\xd2\x11\x91\xdc\xb4\x78\x41\x14\xdc\x76\xf2\x28\x94\x4b\x2e\xfa\x14\x99\x05\x00\x48\x63\xf5\x0f\xac\xac\x8c\x06\xd1\x5b\xf1\x1d\xfc\xff\xf9\xc6\x3c\x5e\xf6\x69\x7c\x1e\x46\xa2\x82\x19\x52\x29\x7c\x74\x66\xf8\x6a\xd1\xd4\x68\x3b\x0b\xfd\xdb\xf4\xb6\x7f\xd9\x11\x9f\x7d\xd7\x56\x50\xba\x96\x83\xb1\xc8\x88\xb3\xb2\x7c\xb4\x77\x41\xe4\xb7\x99\x59\xdc\x07\x00\x22\x41\x65\x37\xb1\x00\x00\x00"),
 		},
 		"/jobsdb/000005_alter_dataset_table.down.tmpl": &vfsgen۰FileInfo{
 			name:    "000005_alter_dataset_table.down.tmpl",
-			modTime: time.Date(2021, 10, 29, 10, 50, 52, 395831071, time.UTC),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			content: []byte("\x7b\x7b\x72\x61\x6e\x67\x65\x20\x2e\x44\x61\x74\x61\x73\x65\x74\x73\x7d\x7d\x0a\x20\x20\x20\x20\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x7b\x7b\x24\x2e\x50\x72\x65\x66\x69\x78\x7d\x7d\x5f\x6a\x6f\x62\x73\x5f\x7b\x7b\x2e\x7d\x7d\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x65\x76\x65\x6e\x74\x5f\x63\x6f\x75\x6e\x74\x3b\x0a\x7b\x7b\x65\x6e\x64\x7d\x7d\x0a"),
 		},
 		"/jobsdb/000005_alter_dataset_table.up.tmpl": &vfsgen۰FileInfo{
 			name:    "000005_alter_dataset_table.up.tmpl",
-			modTime: time.Date(2021, 10, 29, 10, 50, 52, 395968759, time.UTC),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			content: []byte("\x7b\x7b\x72\x61\x6e\x67\x65\x20\x2e\x44\x61\x74\x61\x73\x65\x74\x73\x7d\x7d\x0a\x20\x20\x20\x20\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x7b\x7b\x24\x2e\x50\x72\x65\x66\x69\x78\x7d\x7d\x5f\x6a\x6f\x62\x73\x5f\x7b\x7b\x2e\x7d\x7d\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x65\x76\x65\x6e\x74\x5f\x63\x6f\x75\x6e\x74\x20\x49\x4e\x54\x45\x47\x45\x52\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x31\x3b\x0a\x7b\x7b\x65\x6e\x64\x7d\x7d\x0a"),
 		},
 		"/jobsdb/000006_alter_dataset_table.down.tmpl": &vfsgen۰FileInfo{
 			name:    "000006_alter_dataset_table.down.tmpl",
-			modTime: time.Date(2022, 1, 21, 7, 17, 11, 111253551, time.UTC),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			content: []byte("\x7b\x7b\x72\x61\x6e\x67\x65\x20\x2e\x44\x61\x74\x61\x73\x65\x74\x73\x7d\x7d\x0a\x20\x20\x20\x20\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x7b\x7b\x24\x2e\x50\x72\x65\x66\x69\x78\x7d\x7d\x5f\x6a\x6f\x62\x73\x5f\x7b\x7b\x2e\x7d\x7d\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x77\x6f\x72\x6b\x73\x70\x61\x63\x65\x5f\x69\x64\x3b\x0a\x7b\x7b\x65\x6e\x64\x7d\x7d\x0a"),
 		},
 		"/jobsdb/000006_alter_dataset_table.up.tmpl": &vfsgen۰FileInfo{
 			name:    "000006_alter_dataset_table.up.tmpl",
-			modTime: time.Date(2022, 1, 21, 7, 17, 13, 264671662, time.UTC),
-			content: []byte("\x7b\x7b\x72\x61\x6e\x67\x65\x20\x2e\x44\x61\x74\x61\x73\x65\x74\x73\x7d\x7d\x0a\x20\x20\x20\x20\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x7b\x7b\x24\x2e\x50\x72\x65\x66\x69\x78\x7d\x7d\x5f\x6a\x6f\x62\x73\x5f\x7b\x7b\x2e\x7d\x7d\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x77\x6f\x72\x6b\x73\x70\x61\x63\x65\x5f\x69\x64\x20\x54\x45\x58\x54\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x27\x27\x20\x41\x46\x54\x45\x52\x20\x6a\x6f\x62\x5f\x69\x64\x3b\x0a\x7b\x7b\x65\x6e\x64\x7d\x7d\x20\x0a"),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
+			content: []byte("\x7b\x7b\x72\x61\x6e\x67\x65\x20\x2e\x44\x61\x74\x61\x73\x65\x74\x73\x7d\x7d\x0a\x20\x20\x20\x20\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x7b\x7b\x24\x2e\x50\x72\x65\x66\x69\x78\x7d\x7d\x5f\x6a\x6f\x62\x73\x5f\x7b\x7b\x2e\x7d\x7d\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x77\x6f\x72\x6b\x73\x70\x61\x63\x65\x5f\x69\x64\x20\x54\x45\x58\x54\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x27\x27\x3b\x0a\x7b\x7b\x65\x6e\x64\x7d\x7d\x20\x0a"),
 		},
 		"/node": &vfsgen۰DirInfo{
 			name:    "node",
-			modTime: time.Date(2021, 10, 29, 10, 50, 52, 396404742, time.UTC),
+			modTime: time.Date(2026, 10, 18, 21, 12, 15, 1239750, time.UTC),
 		},
 		"/node/000001_create_event_schema.down.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000001_create_event_schema.down.sql",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 29550160, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 279,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd2\xd5\xd5\xe5\xd2\xd5\xd5\x55\x70\x2d\x4b\xcd\x2b\x51\xf0\xcd\x4f\x49\xcd\x29\x06\x09\x70\x71\xb9\x04\xf9\x07\x28\x84\x38\x3a\xf9\xb8\x2a\x78\xba\x29\xb8\x46\x78\x06\x87\x04\x2b\xa4\x82\x94\xc5\xe7\x82\x95\x59\x43\xd5\x78\xfa\xb9\xb8\x46\x60\x57\x13\x5f\x5e\x94\x59\x92\x1a\x9f\x9d\x5a\x19\x9f\x99\x97\x92\x5a\x61\xcd\xc5\x05\xb3\x50\x21\x38\x39\x23\x35\x37\x51\x21\x2c\xb5\xa8\x38\x33\x3f\x0f\x9f\xa5\xc5\x60\x95\xf1\x65\x50\x95\xc4\xd8\x9b\x99\x02\xb3\x90\x18\xa5\x50\x0b\x32\x12\x8b\x33\xa0\xda\x00\x01\x00\x00\xff\xff\xcc\x7f\x3b\xaa\x17\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd2\xd5\xd5\xe5\xd2\xd5\xd5\x55\x70\x2d\x4b\xcd\x2b\x51\xf0\xcd\x4f\x49\xcd\x29\x06\x09\x70\x71\xb9\x04\xf9\x07\x28\x84\x38\x3a\xf9\xb8\x2a\x78\xba\x29\xb8\x46\x78\x06\x87\x04\x2b\xa4\x82\x94\xc5\xe7\x82\x95\x59\x43\xd5\x78\xfa\xb9\xb8\x46\x60\x57\x13\x5f\x5e\x94\x59\x92\x1a\x9f\x9d\x5a\x19\x9f\x99\x97\x92\x5a\x61\xcd\xc5\x05\xb3\x50\x21\x38\x39\x23\x35\x37\x51\x21\x2c\xb5\xa8\x38\x33\x3f\x0f\x9f\xa5\xc5\x60\x95\xf1\x65\x50\x95\xc4\xd8\x9b\x99\x02\xb3\x90\x18\xa5\x50\x0b\x32\x12\x8b\x33\xa0\xda\x00\x03\x00\xcc\x7f\x3b\xaa\x17\x01\x00\x00"),
 		},
 		"/node/000001_create_event_schema.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000001_create_event_schema.up.sql",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 29650614, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 945,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\x91\x4d\x6f\xe2\x30\x10\x86\xcf\xc9\xaf\x98\x1b\x44\x22\x97\x5d\x69\x0f\x70\x32\x60\x76\xbd\xcd\x07\x4d\x1c\x0a\x27\x2b\xc5\x83\x70\x0b\xa1\x4a\x0c\x2d\xaa\xfa\xdf\x2b\x87\xcf\x40\x11\x70\xcd\x3c\xf3\x4e\xfc\xbc\xae\xeb\xda\xae\xeb\x02\x5d\x61\xa6\xc1\x5f\x48\x9c\x15\xe6\x83\x6d\x77\x22\x4a\x38\x05\x4e\xda\x1e\x05\xd6\x83\x20\xe4\x40\x87\x2c\xe6\x31\xa0\x81\xc5\xbc\x84\xa1\x6e\x5b\x96\x92\x10\xd3\x88\x11\x0f\xfa\x11\xf3\x49\x34\x82\x07\x3a\x6a\xd8\x96\xb5\x5c\x2a\x09\x03\x12\x75\xfe\x91\xa8\xfe\xfb\x8f\x53\xa6\x04\x89\xe7\x99\xe1\x7b\xae\x34\x8a\x57\x5c\x1f\x88\x5f\x55\x62\x73\x48\xaf\xdf\x10\x38\x1d\xf2\x1f\x66\xe5\x4f\x08\x25\x31\xd3\x6a\xa2\x30\xaf\x72\xd0\xa5\x3d\x92\x78\x1c\x6a\x35\xb3\x32\xce\x31\xd5\x28\x45\xaa\x81\x33\x9f\xc6\x9c\xf8\xfd\x73\x36\x08\x9f\xea\x8e\xd3\xda\x1b\x60\x41\x97\x0e\x2f\x1b\x10\xfb\x67\x08\x95\x49\xfc\x80\x30\x38\x11\xb4\x07\x4c\xe8\x4e\x38\xc4\xe3\x29\xce\x53\x18\x60\x5e\xa8\x45\x76\x5d\x7a\x51\xf2\x62\xb5\xe5\x77\xde\xdb\xec\xef\xbd\xea\xe1\xcc\xde\xc5\x8a\xb6\x47\xa7\x69\x31\xbd\x58\xd2\x86\x81\xff\x71\x18\xb4\x2b\x83\x39\xea\x54\xa6\xfa\x74\x74\x28\xe5\xf3\xab\xd6\x6c\xbe\x14\x8b\xec\xd9\xe0\x13\x95\x17\x5a\x14\x88\xd9\xd5\x76\x0c\x3e\x4b\x6f\xa5\xef\xe8\x52\xc9\x43\x89\x67\xc2\xab\xa0\xd3\xda\x85\x26\x01\x7b\x4c\x6e\xca\x3e\xd2\x79\xf3\x9d\x06\x1c\x6d\x39\xad\xef\x00\x00\x00\xff\xff\x0d\x70\xf8\x26\xb1\x03\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\x91\x4d\x6f\xe2\x30\x10\x86\xcf\xc9\xaf\x98\x1b\x44\x22\x97\x5d\x69\x0f\x70\x32\x60\x76\xbd\xcd\x07\x4d\x1c\x0a\x27\x2b\xc5\x83\x70\x0b\xa1\x4a\x0c\x2d\xaa\xfa\xdf\x2b\x87\xcf\x40\x11\x70\xcd\x3c\xf3\x4e\xfc\xbc\xae\xeb\xda\xae\xeb\x02\x5d\x61\xa6\xc1\x5f\x48\x9c\x15\xe6\x83\x6d\x77\x22\x4a\x38\x05\x4e\xda\x1e\x05\xd6\x83\x20\xe4\x40\x87\x2c\xe6\x31\xa0\x81\xc5\xbc\x84\xa1\x6e\x5b\x96\x92\x10\xd3\x88\x11\x0f\xfa\x11\xf3\x49\x34\x82\x07\x3a\x6a\xd8\x96\xb5\x5c\x2a\x09\x03\x12\x75\xfe\x91\xa8\xfe\xfb\x8f\x53\xa6\x04\x89\xe7\x99\xe1\x7b\xae\x34\x8a\x57\x5c\x1f\x88\x5f\x55\x62\x73\x48\xaf\xdf\x10\x38\x1d\xf2\x1f\x66\xe5\x4f\x08\x25\x31\xd3\x6a\xa2\x30\xaf\x72\xd0\xa5\x3d\x92\x78\x1c\x6a\x35\xb3\x32\xce\x31\xd5\x28\x45\xaa\x81\x33\x9f\xc6\x9c\xf8\xfd\x73\x36\x08\x9f\xea\x8e\xd3\xda\x1b\x60\x41\x97\x0e\x2f\x1b\x10\xfb\x67\x08\x95\x49\xfc\x80\x30\x38\x11\xb4\x07\x4c\xe8\x4e\x38\xc4\xe3\x29\xce\x53\x18\x60\x5e\xa8\x45\x76\x5d\x7a\x51\xf2\x62\xb5\xe5\x77\xde\xdb\xec\xef\xbd\xea\xe1\xcc\xde\xc5\x8a\xb6\x47\xa7\x69\x31\xbd\x58\xd2\x86\x81\xff\x71\x18\xb4\x2b\x83\x39\xea\x54\xa6\xfa\x74\x74\x28\xe5\xf3\xab\xd6\x6c\xbe\x14\x8b\xec\xd9\xe0\x13\x95\x17\x5a\x14\x88\xd9\xd5\x76\x0c\x3e\x4b\x6f\xa5\xef\xe8\x52\xc9\x43\x89\x67\xc2\xab\xa0\xd3\xda\x85\x26\x01\x7b\x4c\x6e\xca\x3e\xd2\x79\xf3\x9d\x06\x1c\x6d\x39\xad\xef\x01\x00\x0d\x70\xf8\x26\xb1\x03\x00\x00"),
 		},
 		"/node/000002_create_col_counters_event_schema.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000002_create_col_counters_event_schema.up.sql",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 29756647, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 309,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x90\x4f\x4b\x03\x31\x10\x47\xcf\xcd\xa7\xf8\xdd\xaa\x87\x80\xe7\xf6\x94\xed\xa6\x12\x89\x59\xec\x26\xe0\x6d\x89\xdd\x80\x91\x36\xd1\xfc\xe9\x45\xfc\xee\x92\x05\x3d\x89\xf4\x38\xcc\xf0\xde\x63\x28\xa5\x84\x52\x0a\x36\xcf\xd8\xc5\x53\x3d\x07\x94\x88\x5c\x62\x72\x38\xc6\x1a\x8a\x4b\x19\xb3\x2d\xb6\x5d\x11\xc2\xa4\xe6\x07\x68\xd6\x49\x8e\x7c\x7c\x75\x67\x3b\x5d\x5c\xca\x3e\x86\x0c\xb2\x5a\xb1\xbe\xc7\x6e\x90\xe6\x51\x41\xec\xa1\x06\x0d\xfe\x2c\x46\x3d\xe2\x3d\xf9\x8b\x2d\x6e\x6a\x24\x3c\x8c\x83\xea\x96\xad\x32\x52\xa2\xe7\x7b\x66\xa4\xc6\xfa\xf3\x6b\xbd\xd9\xbc\xe5\x18\x5e\xb6\xff\x89\x7e\x3d\x6a\xd4\x07\x26\x94\x46\x0d\xfe\xa3\xba\xa9\x56\x3f\xc3\x28\xf1\x64\x38\x6e\xda\x70\x7b\x1d\xe7\x8f\xde\x12\x8b\x3d\x4d\xcb\x03\xd0\x89\xfb\x26\xf9\xc9\xbc\xdb\x92\xef\x00\x00\x00\xff\xff\x22\x3a\x04\x17\x35\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x90\x4f\x4b\x03\x31\x10\x47\xcf\xcd\xa7\xf8\xdd\xaa\x87\x80\xe7\xf6\x94\xed\xa6\x12\x89\x59\xec\x26\xe0\x6d\x89\xdd\x80\x91\x36\xd1\xfc\xe9\x45\xfc\xee\x92\x05\x3d\x89\xf4\x38\xcc\xf0\xde\x63\x28\xa5\x84\x52\x0a\x36\xcf\xd8\xc5\x53\x3d\x07\x94\x88\x5c\x62\x72\x38\xc6\x1a\x8a\x4b\x19\xb3\x2d\xb6\x5d\x11\xc2\xa4\xe6\x07\x68\xd6\x49\x8e\x7c\x7c\x75\x67\x3b\x5d\x5c\xca\x3e\x86\x0c\xb2\x5a\xb1\xbe\xc7\x6e\x90\xe6\x51\x41\xec\xa1\x06\x0d\xfe\x2c\x46\x3d\xe2\x3d\xf9\x8b\x2d\x6e\x6a\x24\x3c\x8c\x83\xea\x96\xad\x32\x52\xa2\xe7\x7b\x66\xa4\xc6\xfa\xf3\x6b\xbd\xd9\xbc\xe5\x18\x5e\xb6\xff\x89\x7e\x3d\x6a\xd4\x07\x26\x94\x46\x0d\xfe\xa3\xba\xa9\x56\x3f\xc3\x28\xf1\x64\x38\x6e\xda\x70\x7b\x1d\xe7\x8f\xde\x12\x8b\x3d\x4d\xcb\x03\xd0\x89\xfb\x26\xf9\xc9\xbc\xdb\x92\xef\x01\x00\x22\x3a\x04\x17\x35\x01\x00\x00"),
 		},
 		"/node/000002_del_col_counters_event_schema.down.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000002_del_col_counters_event_schema.down.sql",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 29852522, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 230,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x8f\x41\x0a\x83\x30\x10\x45\xf7\x9e\xe2\x5f\x20\x27\x70\x95\x5a\x0b\x82\xd5\xa2\x29\x74\x17\x82\x09\x34\xa0\x99\x36\x99\xf1\xfc\xc5\x9e\xa0\x74\xff\xff\x7b\x3c\xa5\x54\xa5\x94\x82\xf6\x1e\x0d\xad\xb2\x25\x30\xa1\x30\xe5\x80\x85\x24\x71\xc8\x05\xde\xb1\x3b\x56\x55\xa5\x7b\xd3\x4e\x30\xfa\xd4\xb7\x28\xcb\x33\x6c\xce\xee\x21\x97\x48\xa9\xe0\x3c\x8d\x37\x34\x63\x7f\xbf\x0e\xe8\x2e\x68\x1f\xdd\x6c\x66\xbc\x72\xdc\x1d\x07\x7b\x30\xea\x5f\xfe\xc3\x6c\x26\xdd\x0d\x06\x92\xe2\x5b\x82\x15\x89\xbe\xfe\x43\xcc\xc4\x6e\xb5\xdf\x86\xfa\x13\x00\x00\xff\xff\x73\xa7\x01\x8c\xe6\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x8f\x41\x0a\x83\x30\x10\x45\xf7\x9e\xe2\x5f\x20\x27\x70\x95\x5a\x0b\x82\xd5\xa2\x29\x74\x17\x82\x09\x34\xa0\x99\x36\x99\xf1\xfc\xc5\x9e\xa0\x74\xff\xff\x7b\x3c\xa5\x54\xa5\x94\x82\xf6\x1e\x0d\xad\xb2\x25\x30\xa1\x30\xe5\x80\x85\x24\x71\xc8\x05\xde\xb1\x3b\x56\x55\xa5\x7b\xd3\x4e\x30\xfa\xd4\xb7\x28\xcb\x33\x6c\xce\xee\x21\x97\x48\xa9\xe0\x3c\x8d\x37\x34\x63\x7f\xbf\x0e\xe8\x2e\x68\x1f\xdd\x6c\x66\xbc\x72\xdc\x1d\x07\x7b\x30\xea\x5f\xfe\xc3\x6c\x26\xdd\x0d\x06\x92\xe2\x5b\x82\x15\x89\xbe\xfe\x43\xcc\xc4\x6e\xb5\xdf\x86\xfa\x33\x00\x73\xa7\x01\x8c\xe6\x00\x00\x00"),
 		},
 		"/node/000003_add_event_model_columns.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000003_add_event_model_columns.up.sql",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 29942584, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 553,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\xd0\xc1\x4a\xc3\x40\x10\x80\xe1\x73\xf3\x14\x73\xab\x1e\x16\x3c\xb7\xa7\x8d\x49\x25\xb2\xd9\x88\xd9\xa0\xb7\x30\x66\x07\x5d\xc9\xce\x4a\x76\xda\x8b\xf8\xee\x62\x41\x41\x7a\x2b\xf4\x3a\x03\xdf\x3f\x8c\x52\xaa\x50\x4a\x81\xf6\x3e\xf0\x2b\x4c\x69\xde\x47\xce\x20\x09\x22\x06\x16\x0c\x0c\x11\xb3\xd0\x02\x79\x7a\xa3\x88\x80\xec\x61\x4a\x7b\x16\x5a\x32\x04\x06\x3a\x10\xcb\x18\x93\xa7\x39\xff\x48\x45\xa1\x8d\xab\x1f\xc1\xe9\xd2\xd4\xff\xb7\xab\x95\xae\x2a\xb8\xed\xcc\xd0\x5a\x68\x76\x60\x3b\x07\xf5\x73\xd3\xbb\xfe\x17\xbf\xef\x3b\x5b\x1e\xe7\x76\x30\x06\xaa\x7a\xa7\x07\xe3\x60\xfd\xf9\xb5\xde\x6c\xde\x73\xe2\x97\xed\x59\x7c\x24\x41\x8f\x72\xb9\xc0\xc7\x12\x0e\x28\x34\x5e\x34\x22\x49\x70\x1e\x8f\xcf\x87\xb2\xb9\x6b\xac\xfb\xb3\x6f\xce\x13\x67\xcc\x32\x66\x22\x06\xd7\xb4\x75\xef\x74\xfb\x70\x7a\xb7\xed\x9e\xae\xae\xb7\xdf\x01\x00\x00\xff\xff\x31\x24\x19\x95\x29\x02\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\xd0\xc1\x4a\xc3\x40\x10\x80\xe1\x73\xf3\x14\x73\xab\x1e\x16\x3c\xb7\xa7\x8d\x49\x25\xb2\xd9\x88\xd9\xa0\xb7\x30\x66\x07\x5d\xc9\xce\x4a\x76\xda\x8b\xf8\xee\x62\x41\x41\x7a\x2b\xf4\x3a\x03\xdf\x3f\x8c\x52\xaa\x50\x4a\x81\xf6\x3e\xf0\x2b\x4c\x69\xde\x47\xce\x20\x09\x22\x06\x16\x0c\x0c\x11\xb3\xd0\x02\x79\x7a\xa3\x88\x80\xec\x61\x4a\x7b\x16\x5a\x32\x04\x06\x3a\x10\xcb\x18\x93\xa7\x39\xff\x48\x45\xa1\x8d\xab\x1f\xc1\xe9\xd2\xd4\xff\xb7\xab\x95\xae\x2a\xb8\xed\xcc\xd0\x5a\x68\x76\x60\x3b\x07\xf5\x73\xd3\xbb\xfe\x17\xbf\xef\x3b\x5b\x1e\xe7\x76\x30\x06\xaa\x7a\xa7\x07\xe3\x60\xfd\xf9\xb5\xde\x6c\xde\x73\xe2\x97\xed\x59\x7c\x24\x41\x8f\x72\xb9\xc0\xc7\x12\x0e\x28\x34\x5e\x34\x22\x49\x70\x1e\x8f\xcf\x87\xb2\xb9\x6b\xac\xfb\xb3\x6f\xce\x13\x67\xcc\x32\x66\x22\x06\xd7\xb4\x75\xef\x74\xfb\x70\x7a\xb7\xed\x9e\xae\xae\xb7\xdf\x03\x00\x31\x24\x19\x95\x29\x02\x00\x00"),
 		},
 		"/node/000003_remove_event_model_columns.down.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000003_remove_event_model_columns.down.sql",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 30029727, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 390,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\xcf\x41\x4a\xc6\x30\x10\xc5\xf1\x7d\x4e\xf1\x2e\x90\x13\x7c\xab\xaa\x15\x0a\xd5\x4a\x5b\xc1\x5d\x18\x9a\xc1\x06\x92\x89\x64\xa6\x3d\xbf\x28\x6e\x5c\xf6\x5b\x3f\x7e\x7f\x78\xde\x7b\xe7\xbd\xc7\xcc\xa5\x9e\x49\x3e\xb1\xd5\x7c\x14\x51\xd8\x4e\x06\x6a\x8c\x43\x39\xc2\x2a\x0a\x25\x31\x4a\x82\x42\x6a\xdc\xa0\xdb\xce\x85\x40\x12\xb1\xd5\x43\x8c\x9b\x22\x09\xf8\x64\xb1\x50\x6a\xe4\xac\x3f\x65\xe7\xba\x71\xed\x67\xac\xdd\xc3\xd8\xff\x5b\xf1\x34\x4f\x6f\x78\x9c\xc6\xf7\x97\x57\x0c\xcf\xe8\x3f\x86\x65\x5d\xfe\xba\xb7\xab\xac\xb0\x51\x24\xbb\x0e\xbf\x5a\x3a\xc9\x38\xdc\x85\xad\x1a\xe5\xf0\xfb\xff\xb2\xcd\xa4\x16\x94\x59\x6e\xee\x3b\x00\x00\xff\xff\x23\x26\xf4\xfe\x86\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\xcf\x41\x4a\xc6\x30\x10\xc5\xf1\x7d\x4e\xf1\x2e\x90\x13\x7c\xab\xaa\x15\x0a\xd5\x4a\x5b\xc1\x5d\x18\x9a\xc1\x06\x92\x89\x64\xa6\x3d\xbf\x28\x6e\x5c\xf6\x5b\x3f\x7e\x7f\x78\xde\x7b\xe7\xbd\xc7\xcc\xa5\x9e\x49\x3e\xb1\xd5\x7c\x14\x51\xd8\x4e\x06\x6a\x8c\x43\x39\xc2\x2a\x0a\x25\x31\x4a\x82\x42\x6a\xdc\xa0\xdb\xce\x85\x40\x12\xb1\xd5\x43\x8c\x9b\x22\x09\xf8\x64\xb1\x50\x6a\xe4\xac\x3f\x65\xe7\xba\x71\xed\x67\xac\xdd\xc3\xd8\xff\x5b\xf1\x34\x4f\x6f\x78\x9c\xc6\xf7\x97\x57\x0c\xcf\xe8\x3f\x86\x65\x5d\xfe\xba\xb7\xab\xac\xb0\x51\x24\xbb\x0e\xbf\x5a\x3a\xc9\x38\xdc\x85\xad\x1a\xe5\xf0\xfb\xff\xb2\xcd\xa4\x16\x94\x59\x6e\xee\x7b\x00\x23\x26\xf4\xfe\x86\x01\x00\x00"),
 		},
 		"/node/000004_create_ops.down.sql": &vfsgen۰FileInfo{
 			name:    "000004_create_ops.down.sql",
-			modTime: time.Date(2021, 8, 2, 20, 4, 39, 30116804, time.UTC),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			content: []byte("\x2d\x2d\x2d\x0a\x2d\x2d\x2d\x20\x4f\x70\x65\x72\x61\x74\x69\x6f\x6e\x73\x0a\x2d\x2d\x2d\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6f\x70\x65\x72\x61\x74\x69\x6f\x6e\x73\x3b"),
 		},
 		"/node/000004_create_ops.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000004_create_ops.up.sql",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 30208871, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 375,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\x8d\xb1\x4e\xf3\x30\x14\x46\x67\xfb\x29\xbe\xad\x89\x54\x0f\xff\x3f\x21\x31\x39\xc5\x55\x0d\x8e\x5d\x39\x0e\xa5\x2c\x91\xd5\x78\x88\x04\x71\x94\xb8\x03\x3c\x3d\x4a\x84\x22\xba\xc0\xdd\xee\x3d\x47\xe7\x32\xc6\x28\x63\x0c\x66\x08\xa3\x4f\x5d\xec\xa7\x79\xa5\x74\x67\x05\x77\x02\x8e\x17\x4a\x40\xee\xa1\x8d\x83\x78\x91\x95\xab\x10\x57\x15\x19\x25\xa4\x6b\x51\x09\x2b\xb9\xc2\xd1\xca\x92\xdb\x33\x9e\xc4\x79\x4b\x09\x59\x3d\x3c\x73\xbb\x3b\x70\x9b\xfd\xfb\x7f\x97\x2f\x25\x5d\x2b\xb5\xa5\xf8\x9e\xc1\x7f\xbc\x45\xdf\xe2\xb1\x32\xba\xf8\xc1\x09\x69\x63\x1f\x50\x18\xa3\x04\xd7\x37\x20\x0e\xcd\x94\x7c\xba\x4e\x37\xed\x99\x5c\xc6\xe0\x53\x68\x1b\x9f\xe0\x64\x29\x2a\xc7\xcb\x23\x4e\xd2\x1d\x4c\xed\x96\x0b\x5e\x8d\x16\x6b\x0c\x0f\x62\xcf\x6b\xe5\x90\x69\x73\xca\x72\xf8\x84\xd4\xbd\x07\x7c\xce\x9f\x37\xd7\x74\xd9\x2c\xd5\x29\xf9\x31\x35\x0b\xf9\xa5\x3a\x9b\xa1\x6f\xff\xf4\xf2\xfb\xaf\x00\x00\x00\xff\xff\xdd\x5b\x28\x06\x77\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\x8d\xb1\x4e\xf3\x30\x14\x46\x67\xfb\x29\xbe\xad\x89\x54\x0f\xff\x3f\x21\x31\x39\xc5\x55\x0d\x8e\x5d\x39\x0e\xa5\x2c\x91\xd5\x78\x88\x04\x71\x94\xb8\x03\x3c\x3d\x4a\x84\x22\xba\xc0\xdd\xee\x3d\x47\xe7\x32\xc6\x28\x63\x0c\x66\x08\xa3\x4f\x5d\xec\xa7\x79\xa5\x74\x67\x05\x77\x02\x8e\x17\x4a\x40\xee\xa1\x8d\x83\x78\x91\x95\xab\x10\x57\x15\x19\x25\xa4\x6b\x51\x09\x2b\xb9\xc2\xd1\xca\x92\xdb\x33\x9e\xc4\x79\x4b\x09\x59\x3d\x3c\x73\xbb\x3b\x70\x9b\xfd\xfb\x7f\x97\x2f\x25\x5d\x2b\xb5\xa5\xf8\x9e\xc1\x7f\xbc\x45\xdf\xe2\xb1\x32\xba\xf8\xc1\x09\x69\x63\x1f\x50\x18\xa3\x04\xd7\x37\x20\x0e\xcd\x94\x7c\xba\x4e\x37\xed\x99\x5c\xc6\xe0\x53\x68\x1b\x9f\xe0\x64\x29\x2a\xc7\xcb\x23\x4e\xd2\x1d\x4c\xed\x96\x0b\x5e\x8d\x16\x6b\x0c\x0f\x62\xcf\x6b\xe5\x90\x69\x73\xca\x72\xf8\x84\xd4\xbd\x07\x7c\xce\x9f\x37\xd7\x74\xd9\x2c\xd5\x29\xf9\x31\x35\x0b\xf9\xa5\x3a\x9b\xa1\x6f\xff\xf4\xf2\xfb\xaf\x01\x00\xdd\x5b\x28\x06\x77\x01\x00\x00"),
 		},
 		"/node/000005_alter_event_schemas_autovacuum.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000005_alter_event_schemas_autovacuum.up.sql",
-			modTime:          time.Date(2021, 10, 29, 10, 50, 52, 396165550, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 333,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd2\xd5\xd5\xe5\xd2\xd5\xd5\x55\x70\x2d\x4b\xcd\x2b\x51\xf0\xcd\x4f\x49\xcd\x29\x06\x09\x70\x71\x39\xfa\x84\xb8\x06\x29\x84\x38\x3a\xf9\xb8\x2a\xa4\x82\x64\xe3\x73\xc1\xb2\x0a\xc1\xae\x21\x0a\x1a\x89\xa5\x25\xf9\x65\x89\xc9\xa5\xa5\xb9\xf1\x50\xaa\x38\x39\x31\x27\x35\x3e\x2d\x31\xb9\x24\xbf\x48\xc1\x56\xc1\x40\xcf\xc0\x50\xd3\x9a\x64\x53\x92\xf3\x8b\x4b\xe2\x53\x52\x73\x12\x2b\x41\x66\x68\x5a\x73\x71\xc1\x5c\xa8\x10\x9c\x9c\x91\x9a\x9b\xa8\x10\x96\x5a\x54\x9c\x99\x9f\x87\xc5\x95\xc5\x60\x05\xf1\x65\x50\x05\xe4\x3b\x94\x48\x83\x30\xdc\x0a\x08\x00\x00\xff\xff\x58\x51\xf4\x80\x4d\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd2\xd5\xd5\xe5\xd2\xd5\xd5\x55\x70\x2d\x4b\xcd\x2b\x51\xf0\xcd\x4f\x49\xcd\x29\x06\x09\x70\x71\x39\xfa\x84\xb8\x06\x29\x84\x38\x3a\xf9\xb8\x2a\xa4\x82\x64\xe3\x73\xc1\xb2\x0a\xc1\xae\x21\x0a\x1a\x89\xa5\x25\xf9\x65\x89\xc9\xa5\xa5\xb9\xf1\x50\xaa\x38\x39\x31\x27\x35\x3e\x2d\x31\xb9\x24\xbf\x48\xc1\x56\xc1\x40\xcf\xc0\x50\xd3\x9a\x64\x53\x92\xf3\x8b\x4b\xe2\x53\x52\x73\x12\x2b\x41\x66\x68\x5a\x73\x71\xc1\x5c\xa8\x10\x9c\x9c\x91\x9a\x9b\xa8\x10\x96\x5a\x54\x9c\x99\x9f\x87\xc5\x95\xc5\x60\x05\xf1\x65\x50\x05\xe4\x3b\x94\x48\x83\x30\xdc\x0a\x18\x00\x58\x51\xf4\x80\x4d\x01\x00\x00"),
 		},
 		"/node/000006_add_archived_to_event_schemas_tables.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000006_add_archived_to_event_schemas_tables.up.sql",
-			modTime:          time.Date(2021, 10, 29, 10, 50, 52, 396315047, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 261,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xa4\xce\x41\xaa\x83\x30\x18\x04\xe0\xb5\x39\xc5\x5c\x20\x27\x78\xab\xf8\x8c\x20\xfc\xcf\xc0\x33\x42\x77\x9a\x9a\xbf\x28\xc4\x04\x8c\xf5\xfc\xa5\x85\x2e\xec\xb6\xeb\x61\xbe\x19\x29\xa5\x90\x52\x42\x79\x8f\x29\x85\xfb\x1a\x31\xba\x6d\x9a\x97\x83\xfd\x88\x3d\x81\x0f\x8e\xfb\xb0\x26\xcf\x21\xc3\x45\x8f\x3c\xcd\xbc\xba\xe1\xe0\x2d\x2f\x29\x66\xec\xee\x1a\x38\x3f\x11\x21\x14\x59\xfd\x0f\xab\x4a\xd2\xa7\xa2\x28\x0a\x55\x55\xf8\x35\xd4\xff\xb5\x68\x6a\xb4\xc6\x42\x5f\x9a\xce\x76\x78\xaf\xa1\x34\x86\x5e\x41\xdb\x13\xa1\xd2\xb5\xea\xc9\xe2\xe6\x42\xe6\x9f\x93\xfc\xf1\xe0\x7b\xfc\x11\x00\x00\xff\xff\x37\xf4\x3d\x62\x05\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xa4\xce\x41\xaa\x83\x30\x18\x04\xe0\xb5\x39\xc5\x5c\x20\x27\x78\xab\xf8\x8c\x20\xfc\xcf\xc0\x33\x42\x77\x9a\x9a\xbf\x28\xc4\x04\x8c\xf5\xfc\xa5\x85\x2e\xec\xb6\xeb\x61\xbe\x19\x29\xa5\x90\x52\x42\x79\x8f\x29\x85\xfb\x1a\x31\xba\x6d\x9a\x97\x83\xfd\x88\x3d\x81\x0f\x8e\xfb\xb0\x26\xcf\x21\xc3\x45\x8f\x3c\xcd\xbc\xba\xe1\xe0\x2d\x2f\x29\x66\xec\xee\x1a\x38\x3f\x11\x21\x14\x59\xfd\x0f\xab\x4a\xd2\xa7\xa2\x28\x0a\x55\x55\xf8\x35\xd4\xff\xb5\x68\x6a\xb4\xc6\x42\x5f\x9a\xce\x76\x78\xaf\xa1\x34\x86\x5e\x41\xdb\x13\xa1\xd2\xb5\xea\xc9\xe2\xe6\x42\xe6\x9f\x93\xfc\xf1\xe0\x7b\xfc\x31\x00\x37\xf4\x3d\x62\x05\x01\x00\x00"),
 		},
 		"/node/000006_remove_archived_from_event_schemas_tables.down.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000006_remove_archived_from_event_schemas_tables.down.sql",
-			modTime:          time.Date(2021, 10, 29, 10, 50, 52, 396493928, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 201,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\xcd\x4d\xaa\xc2\x30\x14\x47\xf1\x79\x57\xf1\xdf\xc0\x5d\xc1\x1b\xf5\x69\x85\x42\xb4\x92\x46\x70\xd6\xc6\xe4\x6a\x03\xf9\x80\xa4\x66\xfd\xe2\xc0\x41\x9d\xb8\x80\xdf\x39\x44\xd4\x10\x11\x24\x87\x54\x5d\x7c\xc0\x24\xff\x0c\x98\x75\x36\x8b\xab\x6c\x67\xdc\x73\x0a\xe0\xca\x71\x9d\x42\xb2\xec\x0b\x74\xb4\x28\x66\xe1\xa0\xa7\xca\xb9\xb8\x14\x0b\x56\x7d\xf3\x5c\xde\xa9\xa6\x69\x85\xea\x24\x54\xfb\x2f\xba\x2d\xdc\xcb\xe1\x8c\xdd\x20\x2e\xc7\x13\xfa\x03\xba\x6b\x3f\xaa\x11\x9f\xd5\xdf\x06\x7e\x0f\x7e\xd9\x57\x00\x00\x00\xff\xff\x94\xfc\xf5\xe6\xc9\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\xcd\x4d\xaa\xc2\x30\x14\x47\xf1\x79\x57\xf1\xdf\xc0\x5d\xc1\x1b\xf5\x69\x85\x42\xb4\x92\x46\x70\xd6\xc6\xe4\x6a\x03\xf9\x80\xa4\x66\xfd\xe2\xc0\x41\x9d\xb8\x80\xdf\x39\x44\xd4\x10\x11\x24\x87\x54\x5d\x7c\xc0\x24\xff\x0c\x98\x75\x36\x8b\xab\x6c\x67\xdc\x73\x0a\xe0\xca\x71\x9d\x42\xb2\xec\x0b\x74\xb4\x28\x66\xe1\xa0\xa7\xca\xb9\xb8\x14\x0b\x56\x7d\xf3\x5c\xde\xa9\xa6\x69\x85\xea\x24\x54\xfb\x2f\xba\x2d\xdc\xcb\xe1\x8c\xdd\x20\x2e\xc7\x13\xfa\x03\xba\x6b\x3f\xaa\x11\x9f\xd5\xdf\x06\x7e\x0f\x7e\xd9\xd7\x00\x94\xfc\xf5\xe6\xc9\x00\x00\x00"),
+		},
+		"/node/000007_create_regulation_tables.up.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000007_create_regulation_tables.up.sql",
+			modTime:          time.Date(2026, 10, 18, 21, 12, 15, 1239750, time.UTC),
+			uncompressedSize: 866,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x92\x41\x73\xd3\x30\x10\x85\xcf\xf1\xaf\x78\x27\x6a\xcf\xd4\x37\x86\x4b\x4f\x4a\xab\x52\x81\x23\x17\x5b\xa6\x29\x17\x8d\x88\x17\x57\xd4\x48\x1e\x4b\x9e\x00\xbf\x9e\x71\xc2\x40\x3a\x1d\x42\x2e\xbd\x6a\xdf\xbe\x7d\xfb\xad\xf2\x3c\x4f\xf2\x3c\x47\x13\x68\x0c\x08\xd3\x30\x8c\x14\x02\xb5\x30\x11\x9d\x89\xb4\x35\x3f\xf0\x0a\x66\x6a\x6d\x44\xef\x3b\xf8\x2f\x30\x9b\x68\xbd\x0b\x88\xe6\x91\x1c\xb6\x0f\xb6\x27\x0c\xa3\x1f\x4c\x67\xa2\x75\x1d\x46\xea\xa6\xde\xec\x35\xd6\x45\x8f\xf8\x40\x18\xec\x40\xbd\x75\x34\x0f\x4b\x92\xcb\x8a\x33\xc5\xa1\xd8\xb2\xe0\x10\xd7\x90\xa5\x02\x5f\x8b\x5a\xd5\x07\x11\xf4\xb4\xcb\x94\x26\x8b\x85\x6d\xb1\x14\x6f\x6b\x5e\x09\x56\xe0\xb6\x12\x2b\x56\xdd\xe3\x3d\xbf\x3f\x4f\x16\x8b\xad\x1f\x1f\xc3\x60\x36\xa4\x6d\x8b\x8f\xac\xba\xbc\x61\x55\xfa\xe6\x75\xb6\x33\x95\x4d\x51\xcc\xa2\xd9\x6a\xae\x2b\xbe\x56\x4f\x0a\x7f\xc3\xea\xaf\xfe\xb3\xde\x0f\x12\xf2\xa9\x68\x33\x92\x89\xd4\x6a\x13\xa1\xc4\x8a\xd7\x8a\xad\x6e\x71\x27\xd4\x4d\xd9\xa8\xdd\x0b\x3e\x95\x92\xff\x69\xc1\x15\xbf\x66\x4d\xa1\x90\xca\xf2\x2e\xcd\x66\x94\xd1\x7e\x23\xfc\xf4\x8e\x70\x36\xc5\xcd\x59\x36\xbb\x36\x52\x7c\x68\x38\xd2\xc3\x05\xce\xf1\x3b\x69\x96\x5d\x1c\xc5\x74\x90\x7b\x77\x1c\x3d\x1f\xe7\x7f\xa8\x4e\x5a\xf6\x24\x9e\x2d\x85\x68\xdd\xde\xea\x88\x6c\xff\x55\x8e\xb8\x44\x63\xfb\x80\x77\x75\x29\x97\x2f\x4c\xfc\x80\xa7\x90\x57\x7c\x7d\x02\x4f\xfd\x8c\x97\xb6\xae\xa5\xef\x28\xe5\x3f\xf8\x3f\x6b\xc8\x2e\x92\x5f\x03\x00\xf9\x45\x70\xcf\x62\x03\x00\x00"),
+		},
+		"/node/000007_drop_regulation_tables.down.sql": &vfsgen۰FileInfo{
+			name:    "000007_drop_regulation_tables.down.sql",
+			modTime: time.Date(2026, 10, 18, 21, 12, 15, 6588364, time.UTC),
+			content: []byte("\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x67\x75\x6c\x61\x74\x69\x6f\x6e\x5f\x61\x75\x64\x69\x74\x5f\x6c\x6f\x67\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x73\x75\x70\x70\x72\x65\x73\x73\x65\x64\x5f\x75\x73\x65\x72\x73\x3b\x0a"),
 		},
 		"/pg_notifier_queue": &vfsgen۰DirInfo{
 			name:    "pg_notifier_queue",
-			modTime: time.Date(2022, 1, 21, 7, 5, 2, 42177779, time.UTC),
+			modTime: time.Date(2026, 10, 18, 20, 52, 32, 642264825, time.UTC),
 		},
 		"/pg_notifier_queue/0000001_pg_notifier_queue_init.down.sql": &vfsgen۰CompressedFileInfo{
 			name:             "0000001_pg_notifier_queue_init.down.sql",
-			modTime:          time.Date(2021, 12, 9, 14, 21, 9, 256190960, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 137,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x48\x8f\xcf\xcb\x2f\xc9\x4c\xcb\x4c\x2d\x8a\x2f\x2c\x4d\x2d\x4d\xb5\xe6\xe2\x82\x28\x8c\x0c\xc0\xa5\xae\xb8\x34\xa9\x38\xb9\x28\x33\x09\xc4\x2c\x49\x2c\x29\x2d\x26\x46\x0f\x58\x61\x7c\x49\x65\x41\xaa\x35\x20\x00\x00\xff\xff\xd9\x51\xd7\xeb\x89\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x48\x8f\xcf\xcb\x2f\xc9\x4c\xcb\x4c\x2d\x8a\x2f\x2c\x4d\x2d\x4d\xb5\xe6\xe2\x82\x28\x8c\x0c\xc0\xa5\xae\xb8\x34\xa9\x38\xb9\x28\x33\x09\xc4\x2c\x49\x2c\x29\x2d\x26\x46\x0f\x58\x61\x7c\x49\x65\x41\xaa\x35\x60\x00\xd9\x51\xd7\xeb\x89\x00\x00\x00"),
 		},
 		"/pg_notifier_queue/0000001_pg_notifier_queue_init.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "0000001_pg_notifier_queue_init.up.sql",
-			modTime:          time.Date(2021, 12, 9, 14, 21, 9, 256352487, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 1220,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x54\xc1\x72\xda\x30\x10\x3d\x5b\x5f\xb1\x07\x66\x80\x19\x3c\xd3\x43\xa7\x17\x4e\x06\x94\xc4\xad\x91\x19\x5b\x34\x70\xf2\xc8\xf6\x26\x55\xeb\x60\x55\x5e\x4d\xc2\xdf\x77\xc0\xc5\x9e\xd0\x96\x94\xdc\xbc\x7e\x6f\xdf\x4a\x6f\x57\xeb\xfb\x3e\xf3\x7d\x1f\x62\x83\x56\x91\xae\x77\xcd\x21\x64\x6c\x11\xc3\x60\x00\x33\x7e\x1b\x0a\x06\x00\x30\x4f\x78\x20\x39\xc8\xed\x8a\x83\x79\xcc\x76\x35\xe9\x07\x8d\x36\x6b\x48\x91\x6b\x32\xda\x1b\x64\x5e\x90\x02\x17\xeb\xe5\x88\x79\xde\xf0\x59\x69\xd2\xbb\xc7\xe1\xe4\x10\xe0\x0b\x16\xae\x0f\x1b\x57\x14\x88\x25\x96\x6d\xf8\xa0\x74\x75\xfa\x56\x79\x6d\x09\xcb\x21\xf3\x3c\x6f\x3c\x65\x1e\xdf\xcc\xf9\x4a\x86\xb1\x60\x9e\x77\x7f\xc7\x05\x94\xce\x54\xba\x50\x84\x59\x9d\x7f\xc7\x82\x40\x1e\xfe\xee\x5c\x55\x4d\x19\x17\x0b\x18\x0c\xa6\x8c\x9d\x4e\x1b\xcc\x22\x0e\xe1\x0d\x88\x58\x02\xdf\x84\xa9\x4c\x5f\x1d\xfe\xa7\x43\x87\x30\x3a\x5e\x50\x97\x30\x0b\x6f\x53\x9e\x84\x41\x04\xab\x24\x5c\x06\xc9\x16\xbe\xf0\xed\xe4\x88\xe6\x8a\x8a\x6f\x99\x2e\xe1\x6b\x90\xcc\xef\x82\x64\xf4\xe9\xe3\xf8\xa8\x2a\xd6\x51\xd4\x52\x5a\x27\xfe\x65\xce\x19\x99\x6a\xa3\x8b\x0b\x62\x46\xed\xab\x5a\x95\xf0\x39\x8d\xc5\xec\x0c\x2b\x2c\x2a\xc2\x32\x53\x04\x32\x5c\xf2\x54\x06\xcb\x55\x47\x81\x05\xbf\x09\xd6\x91\x04\x11\xdf\x8f\xc6\x6d\x82\x33\xe5\x75\x09\x95\x6a\x28\x3b\xf4\x2c\x23\xfd\x84\x7d\x52\x8b\x2a\x22\x7c\x32\x04\xe9\x32\x88\xa2\x50\xc8\x4e\xe1\x43\x8b\xa3\xb5\xb5\x05\xc9\x37\xb2\x8d\x9f\x6b\xfb\x03\xed\x99\x79\x3d\xd4\x18\x55\xe0\x9f\x90\xb1\xba\xb6\x9a\xf6\xa0\x77\x34\xee\x5b\x1a\x8a\x05\xdf\xbc\xd5\xd2\x93\xf1\xba\x7c\x81\x58\xfc\xad\xe5\x2d\xe1\x6a\xdd\xd3\x18\x5c\x50\x3e\x51\xae\xd6\xee\xbc\xc8\x8e\xc3\x71\xa1\x44\xc7\x9c\xb4\x73\xf4\x5e\x7b\xfa\x8a\x6f\x19\x35\xe9\x3b\x75\x28\xf6\xdf\xcb\xc1\xe5\x4d\x61\x75\xde\x3d\x85\xd7\x2b\x22\x77\xcd\xfe\xf7\x06\xb0\x88\x43\x78\xef\x7b\xff\x15\x00\x00\xff\xff\x11\x3c\xce\x03\xc4\x04\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x54\xc1\x6e\xda\x40\x10\x3d\x7b\xbf\x62\x0e\x48\x80\x84\xa5\x1e\xaa\x5e\x38\x19\xd8\x24\x6e\xcd\x1a\xd9\x4b\x03\x27\x6b\x6d\x4f\xd2\x6d\x1d\xbc\x5d\xcf\x2a\xe1\xef\x2b\x70\xb1\x15\xda\x10\x91\x1b\xc3\x7b\xf3\x76\xfd\xde\xec\xf8\xbe\xcf\x7c\xdf\x87\xd8\xa0\x55\xa4\xeb\x5d\x73\x28\x19\x5b\xc4\x30\x18\xc0\x8c\xdf\x86\x82\x01\x00\xcc\x13\x1e\x48\x0e\x72\xbb\xe2\x60\x1e\xb3\x5d\x4d\xfa\x41\xa3\xcd\x1a\x52\xe4\x9a\x8c\xf6\x06\x99\x17\xa4\xc0\xc5\x7a\x39\x62\x9e\x37\x7c\x56\x9a\xf4\xee\x71\x38\x39\x14\xf8\x82\x85\xeb\xcb\xc6\x15\x05\x62\x89\x65\x5b\x3e\x28\x5d\x9d\x7e\xab\xbc\xb6\x84\xe5\x90\x79\x9e\x37\x9e\x32\x8f\x6f\xe6\x7c\x25\xc3\x58\x30\xcf\xbb\xbf\xe3\x02\x4a\x67\x2a\x5d\x28\xc2\xac\xce\x7f\x62\x41\x20\x0f\xff\xee\x5c\x55\x4d\x19\x17\x0b\x18\x0c\xa6\x8c\x9d\x6e\x1b\xcc\x22\x0e\xe1\x0d\x88\x58\x02\xdf\x84\xa9\x4c\x5f\x5d\xfe\xb7\x43\x87\x30\x3a\x7e\xa0\x2e\x61\x16\xde\xa6\x3c\x09\x83\x08\x56\x49\xb8\x0c\x92\x2d\x7c\xe3\xdb\xc9\x11\xcd\x15\x15\x3f\x32\x5d\xc2\xf7\x20\x99\xdf\x05\xc9\xe8\xcb\xe7\xf1\x51\x55\xac\xa3\xa8\xa5\xb4\x4e\xbc\x65\xce\x19\x99\x6a\xa3\x8b\x0b\x62\x46\xed\xab\x5a\x95\xf0\x35\x8d\xc5\xec\x0c\x2b\x2c\x2a\xc2\x32\x53\x04\x32\x5c\xf2\x54\x06\xcb\x55\x47\x81\x05\xbf\x09\xd6\x91\x04\x11\xdf\x8f\xc6\x6d\x83\x33\xe5\x75\x0d\x95\x6a\x28\x3b\x64\x96\x91\x7e\xc2\xbe\xa9\x45\x15\x11\x3e\x19\x82\x74\x19\x44\x51\x28\x64\xa7\xf0\xa9\xc5\xd1\xda\xda\x82\xe4\x1b\xd9\xd6\xcf\xb5\xfd\x85\xf6\xcc\xbc\x1e\x6a\x8c\x2a\xf0\x5f\xc8\x58\x5d\x5b\x4d\x7b\xd0\x3b\x1a\xf7\x91\x86\x62\xc1\x37\xef\x45\x7a\x32\x5e\x97\x2f\x10\x8b\xff\x45\xde\x12\xae\xd6\x3d\x8d\xc1\x05\xe5\x13\xe5\x6a\xed\xce\x8b\xec\x38\x1c\x17\x8e\xe8\x98\x93\x76\x8e\x3e\x6a\x4f\x7f\xe2\x7b\x46\x4d\xfa\xa4\xc6\xd3\x2b\x96\x83\xcb\x9b\xc2\xea\xbc\x7b\x0a\xaf\x57\x44\xee\x9a\xfd\xdf\x0d\x60\x11\x87\xf0\xd1\xf7\xfe\x67\x00\x11\x3c\xce\x03\xc4\x04\x00\x00"),
 		},
 		"/pg_notifier_queue/0000002_pg_notifier_queue_null_topic.down.sql": &vfsgen۰CompressedFileInfo{
 			name:             "0000002_pg_notifier_queue_null_topic.down.sql",
-			modTime:          time.Date(2022, 1, 21, 7, 5, 2, 42144862, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 302,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\xce\xd1\xca\x82\x30\x18\xc6\xf1\x73\xaf\xe2\x39\xfc\x3e\x70\x57\xe0\x91\xd9\x1b\x08\x6b\x03\x9d\xe0\xd9\x10\x5b\x31\x02\xb7\xdc\xa4\x2e\x3f\x54\x90\xa0\x3a\xe8\x70\xec\xf7\xf2\x7f\x18\x63\x09\x63\x0c\xd2\x9b\xb1\x8b\xd6\x0d\x61\x7e\x26\x49\x51\x51\xae\x08\xa5\xd8\x53\x8b\xf2\x00\x21\x15\xa8\x2d\x6b\x55\xc3\x5f\xf4\xe0\xa2\x3d\x5b\x33\xea\xdb\x64\x26\xa3\xef\x6e\xbc\x06\xdf\xf5\x46\x47\xe7\x6d\xaf\xed\xe9\x01\x29\xde\x21\xfe\x36\x99\x62\xa1\xff\xd9\x8f\xa9\x10\xbb\x38\x85\x97\xe2\xf7\xd6\x4a\x53\x6c\x76\x8e\xe5\x5c\x51\x05\x95\xef\x38\x7d\xb8\x59\x7f\x0b\xc9\x9b\xa3\x58\x07\xa2\x26\xb5\x2c\x12\x0d\xe7\xd9\x33\x00\x00\xff\xff\x91\x78\x54\x38\x2e\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\xce\xd1\xca\x82\x30\x18\xc6\xf1\x73\xaf\xe2\x39\xfc\x3e\x70\x57\xe0\x91\xd9\x1b\x08\x6b\x03\x9d\xe0\xd9\x10\x5b\x31\x02\xb7\xdc\xa4\x2e\x3f\x54\x90\xa0\x3a\xe8\x70\xec\xf7\xf2\x7f\x18\x63\x09\x63\x0c\xd2\x9b\xb1\x8b\xd6\x0d\x61\x7e\x26\x49\x51\x51\xae\x08\xa5\xd8\x53\x8b\xf2\x00\x21\x15\xa8\x2d\x6b\x55\xc3\x5f\xf4\xe0\xa2\x3d\x5b\x33\xea\xdb\x64\x26\xa3\xef\x6e\xbc\x06\xdf\xf5\x46\x47\xe7\x6d\xaf\xed\xe9\x01\x29\xde\x21\xfe\x36\x99\x62\xa1\xff\xd9\x8f\xa9\x10\xbb\x38\x85\x97\xe2\xf7\xd6\x4a\x53\x6c\x76\x8e\xe5\x5c\x51\x05\x95\xef\x38\x7d\xb8\x59\x7f\x0b\xc9\x9b\xa3\x58\x07\xa2\x26\xb5\x2c\x12\x0d\xe7\xd9\x73\x00\x91\x78\x54\x38\x2e\x01\x00\x00"),
 		},
 		"/pg_notifier_queue/0000002_pg_notifier_queue_null_topic.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "0000002_pg_notifier_queue_null_topic.up.sql",
-			modTime:          time.Date(2022, 1, 21, 7, 5, 2, 42205113, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 210,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\xcd\x41\x0a\xc2\x30\x10\x85\xe1\x7d\x4e\x31\x17\x98\x13\x74\x55\x6d\x84\x40\x4c\xa4\x4d\xa1\xbb\x10\x6a\x94\x20\x34\x31\x99\xa0\xc7\x17\xdb\x8d\xe0\xa6\xcb\x61\xf8\xde\x8f\x88\x0c\x11\x41\x27\x9f\x1d\x85\xb8\x94\xef\xc9\x58\xd7\xeb\x0b\x08\xd5\xf1\x09\xc4\x09\xf8\x24\x06\x33\x40\xba\xdb\x25\x52\xb8\x05\x9f\xed\xb3\xfa\xea\xed\x2b\xe6\x47\x49\x6e\xf6\x96\x62\x0a\xb3\x0d\xd7\x77\xb3\x1b\x17\x72\x54\xcb\xcf\xc6\xa6\x5b\x69\x78\x0f\xa6\x3d\x48\xfe\x8f\x60\xfb\x1e\xb5\x1c\xcf\x0a\xd6\x28\xac\x39\xa5\x0d\xa8\x51\xca\xe6\x13\x00\x00\xff\xff\xf4\x66\xf0\xcc\xd2\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\xcd\x41\x0a\xc2\x30\x10\x85\xe1\x7d\x4e\x31\x17\x98\x13\x74\x55\x6d\x84\x40\x4c\xa4\x4d\xa1\xbb\x10\x6a\x94\x20\x34\x31\x99\xa0\xc7\x17\xdb\x8d\xe0\xa6\xcb\x61\xf8\xde\x8f\x88\x0c\x11\x41\x27\x9f\x1d\x85\xb8\x94\xef\xc9\x58\xd7\xeb\x0b\x08\xd5\xf1\x09\xc4\x09\xf8\x24\x06\x33\x40\xba\xdb\x25\x52\xb8\x05\x9f\xed\xb3\xfa\xea\xed\x2b\xe6\x47\x49\x6e\xf6\x96\x62\x0a\xb3\x0d\xd7\x77\xb3\x1b\x17\x72\x54\xcb\xcf\xc6\xa6\x5b\x69\x78\x0f\xa6\x3d\x48\xfe\x8f\x60\xfb\x1e\xb5\x1c\xcf\x0a\xd6\x28\xac\x39\xa5\x0d\xa8\x51\xca\xe6\x33\x00\xf4\x66\xf0\xcc\xd2\x00\x00\x00"),
+		},
+		"/pg_notifier_queue/0000003_pg_notifier_queue_fair_share.down.sql": &vfsgen۰CompressedFileInfo{
+			name:             "0000003_pg_notifier_queue_fair_share.down.sql",
+			modTime:          time.Date(2026, 10, 18, 20, 52, 32, 643792906, time.UTC),
+			uncompressedSize: 220,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd2\xd5\xd5\xe5\xd2\xd5\xd5\x55\xf0\x2f\x48\x2d\x4a\x2c\xc9\xcc\xcf\x2b\x06\x71\xb9\xb8\x5c\x82\xfc\x03\x14\x3c\xfd\x5c\x5c\x23\x14\x3c\xdd\x14\x5c\x23\x3c\x83\x43\x82\x15\x0a\xd2\xe3\xf3\xf2\x4b\x32\xd3\x32\x53\x8b\xe2\x0b\x4b\x53\x4b\x53\xe3\x8b\x4b\x12\x4b\x4a\x8b\xe3\xd3\x12\x33\x8b\xe2\x8b\x33\x12\x8b\x52\xe3\xb3\x53\x2b\xe3\x33\x53\x2a\xac\xb9\xb8\x1c\x7d\x42\x5c\x83\x14\x42\x1c\x9d\x7c\x5c\x31\x75\x2a\x80\x2d\x70\xf6\xf7\x09\xf5\xf5\x43\xb2\xa1\x3c\x35\x33\x3d\xa3\x84\x4c\xcd\xa8\xae\xb0\xe6\x02\x0c\x00\x8f\x83\xb7\xe6\xdc\x00\x00\x00"),
+		},
+		"/pg_notifier_queue/0000003_pg_notifier_queue_fair_share.up.sql": &vfsgen۰CompressedFileInfo{
+			name:             "0000003_pg_notifier_queue_fair_share.up.sql",
+			modTime:          time.Date(2026, 10, 18, 20, 52, 32, 642264825, time.UTC),
+			uncompressedSize: 313,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x8f\x3f\x4b\xc4\x30\x18\xc6\xf7\x7c\x8a\x67\xbc\x03\x33\x08\xe2\xd2\x29\x36\x39\x0c\xc4\x04\x72\xa9\x74\x0b\x01\xd3\x36\x08\x6d\x4d\x52\xd4\x6f\x2f\xea\x64\xbb\x39\xbe\x2f\xbf\xe7\x1f\xa5\x94\x50\x4a\x61\xd6\x98\x43\x4d\xcb\x5c\xbe\x4f\x42\x98\x72\xc2\xc2\xb1\x07\x25\xb0\x8e\x7e\x5e\x6a\x1a\x52\xcc\xfe\x6d\x8b\x5b\x04\xe3\x1c\xad\x51\xdd\x93\x86\xbc\x40\x1b\x07\xd1\xcb\xab\xbb\x62\x08\x29\xfb\x32\x85\x1c\xfd\x6b\xfc\xc4\x33\xb3\xed\x23\xb3\xa7\xfb\xbb\x73\xf3\x6f\xcf\xf7\x98\xc6\xa9\x42\x6a\xf7\xf3\xd5\x9d\x52\xe0\xe2\xc2\x3a\xe5\x70\xdb\x10\xd2\x5a\xc1\x9c\x80\xd4\x5c\xf4\x3b\xe9\x21\xc5\x97\x1a\xea\x56\xfc\xdf\x9e\x3e\xbd\x7c\xc0\xe8\x23\x8e\xd3\x2f\x7f\xb3\x1b\x76\x6e\xc8\xd7\x00\x99\x3d\xb6\xbb\x39\x01\x00\x00"),
 		},
 		"/reports": &vfsgen۰DirInfo{
 			name:    "reports",
-			modTime: time.Date(2021, 10, 29, 10, 50, 52, 396746221, time.UTC),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 		},
 		"/reports/000001_create_reports.down.sql": &vfsgen۰FileInfo{
 			name:    "000001_create_reports.down.sql",
-			modTime: time.Date(2021, 8, 2, 20, 4, 39, 30424056, time.UTC),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			content: []byte("\x2d\x2d\x2d\x0a\x2d\x2d\x2d\x20\x52\x65\x70\x6f\x72\x74\x73\x0a\x2d\x2d\x2d\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x72\x65\x70\x6f\x72\x74\x73\x3b"),
 		},
 		"/reports/000001_create_reports.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000001_create_reports.up.sql",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 30519476, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 651,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x91\xcf\x4e\xf3\x30\x10\xc4\xcf\xf1\x53\xec\xb1\x95\xbe\xdc\x3e\x71\xe1\xe4\x54\x06\x0c\x21\x41\x8e\x41\xed\x29\x72\x93\x95\x30\x6d\xed\xc8\xde\xc0\xeb\x23\xb7\x20\xa0\xff\xe0\x66\xcf\xfc\x66\xac\xf5\xe6\x79\xce\xf2\x3c\x07\x85\x83\x0f\x14\xd3\x99\xb1\x99\x12\x5c\x0b\xd0\xbc\x28\x05\xc8\x2b\xa8\x6a\x0d\x62\x2e\x1b\xdd\x40\xd8\x71\x30\x61\x59\x66\x7b\x28\xe4\x75\x23\x94\xe4\x25\x3c\x28\x79\xcf\xd5\x02\xee\xc4\xe2\x1f\xcb\xb2\x37\x1f\x56\x71\x30\x1d\xb6\xb6\x87\x27\xae\x66\x37\x5c\x4d\x2e\xfe\x4f\xb7\x5d\xd5\x63\x59\x26\xc8\x99\x0d\x6e\xa1\x93\x84\x75\x91\x8c\x3b\xdf\x12\xfd\x18\x0e\x88\x64\xf4\x18\xc9\x3a\x43\xd6\xbb\x3f\xe4\x97\x86\xba\xe7\x23\x2d\x1f\x36\x99\xb8\xfa\xc5\x0d\xa3\x3b\x4d\xbc\xf8\xe5\x79\xf3\x78\xda\xba\x76\x18\xf7\xc5\x43\x65\xb7\x16\xec\x5b\x43\x69\x27\xb2\xd2\x3f\x47\x24\x43\x63\x6c\x3b\xdf\x23\xc8\x4a\x6f\x25\xb3\x19\xd6\xd8\x06\x8c\x83\x77\x11\x41\x8b\xf9\x77\x1d\x5f\xd1\x11\xdc\x36\x75\x55\x7c\x15\x9c\xfc\xc2\xce\x8f\xee\xf3\xe1\x74\x27\x0c\x1b\xeb\xcc\xba\x4d\x39\x84\xa2\xae\x4b\xc1\xab\xdd\x3c\x96\xec\xbe\xc1\xb2\x6c\x7a\xc9\xde\x03\x00\x00\xff\xff\xd1\xe5\x67\xe5\x8b\x02\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x91\xcf\x4e\xf3\x30\x10\xc4\xcf\xf1\x53\xec\xb1\x95\xbe\xdc\x3e\x71\xe1\xe4\x54\x06\x0c\x21\x41\x8e\x41\xed\x29\x72\x93\x95\x30\x6d\xed\xc8\xde\xc0\xeb\x23\xb7\x20\xa0\xff\xe0\x66\xcf\xfc\x66\xac\xf5\xe6\x79\xce\xf2\x3c\x07\x85\x83\x0f\x14\xd3\x99\xb1\x99\x12\x5c\x0b\xd0\xbc\x28\x05\xc8\x2b\xa8\x6a\x0d\x62\x2e\x1b\xdd\x40\xd8\x71\x30\x61\x59\x66\x7b\x28\xe4\x75\x23\x94\xe4\x25\x3c\x28\x79\xcf\xd5\x02\xee\xc4\xe2\x1f\xcb\xb2\x37\x1f\x56\x71\x30\x1d\xb6\xb6\x87\x27\xae\x66\x37\x5c\x4d\x2e\xfe\x4f\xb7\x5d\xd5\x63\x59\x26\xc8\x99\x0d\x6e\xa1\x93\x84\x75\x91\x8c\x3b\xdf\x12\xfd\x18\x0e\x88\x64\xf4\x18\xc9\x3a\x43\xd6\xbb\x3f\xe4\x97\x86\xba\xe7\x23\x2d\x1f\x36\x99\xb8\xfa\xc5\x0d\xa3\x3b\x4d\xbc\xf8\xe5\x79\xf3\x78\xda\xba\x76\x18\xf7\xc5\x43\x65\xb7\x16\xec\x5b\x43\x69\x27\xb2\xd2\x3f\x47\x24\x43\x63\x6c\x3b\xdf\x23\xc8\x4a\x6f\x25\xb3\x19\xd6\xd8\x06\x8c\x83\x77\x11\x41\x8b\xf9\x77\x1d\x5f\xd1\x11\xdc\x36\x75\x55\x7c\x15\x9c\xfc\xc2\xce\x8f\xee\xf3\xe1\x74\x27\x0c\x1b\xeb\xcc\xba\x4d\x39\x84\xa2\xae\x4b\xc1\xab\xdd\x3c\x96\xec\xbe\xc1\xb2\x6c\x7a\xc9\xde\x07\x00\xd1\xe5\x67\xe5\x8b\x02\x00\x00"),
 		},
 		"/reports/000002_alter_reports.down.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000002_alter_reports.down.sql",
-			modTime:          time.Date(2021, 10, 29, 10, 50, 52, 396672519, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 169,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x4a\x2d\xc8\x2f\x2a\x29\xe6\x72\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x28\xce\x2f\x2d\x4a\x4e\x8d\x4f\x4e\x2c\x49\x4d\xcf\x2f\xaa\xd4\xc1\x26\x99\x92\x9a\x96\x99\x97\x59\x92\x99\x9f\x17\x9f\x99\x82\xaa\x22\x25\xb5\xb8\x24\x33\x2f\x11\x2c\x87\x47\x59\x6a\x59\x6a\x5e\x49\x7c\x5e\x62\x6e\x2a\x36\xf1\x92\xca\x82\x54\x6b\x40\x00\x00\x00\xff\xff\xce\x5c\x0a\x81\xa9\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x4a\x2d\xc8\x2f\x2a\x29\xe6\x72\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x28\xce\x2f\x2d\x4a\x4e\x8d\x4f\x4e\x2c\x49\x4d\xcf\x2f\xaa\xd4\xc1\x26\x99\x92\x9a\x96\x99\x97\x59\x92\x99\x9f\x17\x9f\x99\x82\xaa\x22\x25\xb5\xb8\x24\x33\x2f\x11\x2c\x87\x47\x59\x6a\x59\x6a\x5e\x49\x7c\x5e\x62\x6e\x2a\x36\xf1\x92\xca\x82\x54\x6b\xc0\x00\xce\x5c\x0a\x81\xa9\x00\x00\x00"),
 		},
 		"/reports/000002_alter_reports.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000002_alter_reports.up.sql",
-			modTime:          time.Date(2021, 10, 29, 10, 50, 52, 397152079, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 315,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\xcc\x31\x0a\xc2\x30\x14\x06\xe0\xbd\xa7\xf8\xb7\x2e\xde\xc0\x29\xda\x14\x0a\xb1\x05\xfb\x0a\xdd\x42\x68\x9e\x92\xc1\xa4\x24\x4f\xa1\xb7\x17\x5c\x1d\xa4\x1e\xe0\xfb\x94\x21\x7d\x05\xa9\x93\xd1\xc8\xbc\xa6\x2c\xa5\x52\x4d\x83\xf3\x60\xa6\x4b\x8f\xae\x45\x3f\x10\xf4\xdc\x8d\x34\xa2\xa4\x67\x5e\xd8\x2e\x4e\xf8\x9e\xf2\x06\xd2\x33\xa1\xd1\xad\x9a\x0c\xa1\xae\x0f\x3f\xa5\xe7\x5b\x88\x41\x42\x8a\x36\xf8\x1d\xdc\x73\x91\x10\xdd\x07\xfe\x7b\xf0\x8b\xa3\xd8\xe8\x1e\xbc\x1b\xc9\xb6\x7e\xa1\x63\xf5\x0e\x00\x00\xff\xff\x16\xf8\xd2\x7c\x3b\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\xcc\x31\x0a\xc2\x30\x14\x06\xe0\xbd\xa7\xf8\xb7\x2e\xde\xc0\x29\xda\x14\x0a\xb1\x05\xfb\x0a\xdd\x42\x68\x9e\x92\xc1\xa4\x24\x4f\xa1\xb7\x17\x5c\x1d\xa4\x1e\xe0\xfb\x94\x21\x7d\x05\xa9\x93\xd1\xc8\xbc\xa6\x2c\xa5\x52\x4d\x83\xf3\x60\xa6\x4b\x8f\xae\x45\x3f\x10\xf4\xdc\x8d\x34\xa2\xa4\x67\x5e\xd8\x2e\x4e\xf8\x9e\xf2\x06\xd2\x33\xa1\xd1\xad\x9a\x0c\xa1\xae\x0f\x3f\xa5\xe7\x5b\x88\x41\x42\x8a\x36\xf8\x1d\xdc\x73\x91\x10\xdd\x07\xfe\x7b\xf0\x8b\xa3\xd8\xe8\x1e\xbc\x1b\xc9\xb6\x7e\xa1\x63\xf5\x1e\x00\x16\xf8\xd2\x7c\x3b\x01\x00\x00"),
 		},
 		"/warehouse": &vfsgen۰DirInfo{
 			name:    "warehouse",
-			modTime: time.Date(2021, 10, 29, 10, 50, 52, 397383618, time.UTC),
+			modTime: time.Date(2026, 10, 18, 20, 46, 38, 549148419, time.UTC),
 		},
 		"/warehouse/000001_create_tables.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000001_create_tables.up.sql",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 30673802, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 4017,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd4\x57\x4f\x73\x9b\x3e\x10\x3d\xff\xf8\x14\x7b\xf0\x21\x9e\x89\x6f\xbf\xe9\x85\x13\xb6\xd5\x84\x16\x83\x07\x94\xd6\x39\x69\x54\x50\x12\xcd\x10\xf0\x80\xdc\xa6\xdf\xbe\x03\x22\x20\x30\xc8\x4a\xe2\x64\xda\x9b\xc7\xbb\xfb\xd8\x3f\x6f\xf5\x24\x6b\xb1\xb0\x16\x0b\xf8\xf5\x40\x4a\x41\xef\x79\x76\x4f\xee\x78\xca\xca\xea\x6f\x6b\x15\x22\x07\x23\xc0\xce\xd2\x43\xe0\x7e\x06\x3f\xc0\x80\x76\x6e\x84\xa3\x23\x7f\xb8\xb0\x00\x00\x78\x02\x4b\xf7\x2a\x42\xa1\xeb\x78\xb0\x0d\xdd\x8d\x13\xde\xc2\x57\x74\x7b\x59\x5b\xd3\x3c\xa6\x82\xe7\x19\x60\xb4\xc3\x35\x9a\x7f\xe3\x79\xd2\x56\xe6\x87\x22\x66\x84\x27\xf0\xcd\x09\x57\xd7\x4e\x78\xf1\xe9\xff\xf9\xc0\x27\x61\xa5\xe0\x59\x0d\xa1\x77\x2c\xe3\x07\xf6\x48\xe1\x4b\x14\xf8\xcb\x81\x89\x15\x45\x5e\xd4\x09\x34\xae\x82\x8a\x43\xa9\x62\xc9\xff\xef\x78\x51\x0a\xc2\x7e\xb2\x4c\x10\x2a\x00\xbb\x1b\x14\x61\x67\xb3\x6d\x2a\xa1\x1a\xa3\xc8\x05\x4d\xa5\xb5\xac\xda\xe1\xfa\xcd\xb7\xe2\x82\x51\xc1\x92\x5e\xc8\x20\xbd\xc3\x3e\x99\x76\x99\xdb\x96\xe5\x78\x18\x85\xcd\x48\x8e\x86\x56\x21\x38\xeb\x35\xac\x02\xef\x66\xe3\x0f\x46\xa6\xaf\x68\x32\x4c\x5b\xea\x64\xd4\x48\x0f\xec\x96\x50\xae\xbf\x46\xbb\x13\x84\x22\x3c\x21\x3c\x4b\xd8\x13\x04\xfe\x08\xdb\x5a\xba\x5c\x0e\x58\x71\xaa\x47\x20\x8d\x4d\xd2\xcd\xf4\xf1\xed\x16\xa9\x14\xb0\x2d\x6b\x1d\xc0\x6c\x06\x4b\x74\xe5\xfa\x75\xa5\xeb\x30\xd8\x4a\x3f\x05\xb1\x0a\x67\x44\xfc\xde\x33\xbb\x76\x42\xbb\x15\xda\x62\x37\xf0\xe1\xfb\x35\xf2\x21\xc0\xd7\x28\x8c\x00\x57\xbf\xb3\x43\x9a\xda\x16\xf2\xd7\x30\x9b\xd9\x56\xb7\x75\x69\x4e\x13\xe3\x95\xeb\x9c\x8d\xf6\x4d\x2d\x9c\x48\xd7\x96\x8b\x1f\xb6\x8b\xaa\x63\xd5\x29\x8d\xab\xa0\x3f\x52\x46\x32\xfa\xc8\xc6\xb2\x7a\xdd\x5a\x8d\xf0\x41\xe9\x62\x8f\x0c\xea\xe7\xab\x41\x57\x39\xe8\xa3\xcf\xc9\xfe\x0e\x97\x34\xfd\xef\xb7\x98\x74\xe9\xf5\x16\x43\xe5\xc4\xe4\x56\x5c\x2a\xc5\xcd\x55\xfa\x1d\xf6\x55\xbc\x09\xf7\x1a\x4f\x33\xe2\x19\x10\xa8\x4a\xa5\xdc\xd3\x98\x7d\x30\xc9\x4a\x41\x0b\x41\x74\xab\xc1\xb2\x44\x6b\x97\x08\x6d\xdf\xc7\xc2\x27\x8d\xc7\x72\xf3\x52\xe9\xaa\x2d\x6f\xd6\x28\x69\x7c\x62\xf1\x98\x7e\xf1\x47\x9e\xdd\x97\xea\x97\xde\x41\xb9\x9e\x99\xf7\xf7\x68\x96\xae\x27\xd3\xab\xae\x36\xeb\xe4\x92\x37\x45\x13\x49\x83\xde\x1e\xb7\xfb\x25\x6d\x73\x73\xac\xd1\xd3\x62\x1c\xfa\x05\xaa\xf9\x1c\x74\x0e\xbd\x94\x58\xe7\x90\x4b\x79\x8c\x19\x9e\x5a\xff\x0d\x03\x8c\x0e\xaf\x2e\xdf\x9e\x9f\x56\xaa\x0c\x97\x7b\x78\xf9\xec\x18\x27\x78\x85\xa4\xb9\x47\x76\x41\xef\xb0\x8b\xfd\x26\xbd\x50\xd6\xf4\x58\x2a\x7b\x9a\xf2\x15\x6d\xd5\x33\xbc\x07\x45\xd4\xb9\x4c\x89\xe1\x60\xda\x6a\xc8\x50\x02\xcd\xd3\x7e\x25\xe9\x55\xc4\x73\x50\x5f\x2a\x83\xd1\xcb\x4c\x7a\xbe\x81\xed\xff\x80\x8c\x9b\x3f\xf1\x4e\xde\x0f\xeb\xb1\xb5\x1c\x3c\xea\xa3\xee\x84\x3d\xfd\xa8\x69\x30\x06\xc1\x6d\xdb\xfa\x8f\x9c\xe7\xc1\x0d\x6f\x6f\xad\xfb\xdc\xfe\x13\x00\x00\xff\xff\x89\x94\x7c\x27\xb1\x0f\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd4\x57\x41\x73\x9b\x3c\x10\x3d\x7f\xfc\x8a\x3d\xf8\x10\xcf\xc4\xb7\x6f\x7a\xe1\x84\x6d\x35\xa1\xb5\xc1\x83\x95\xd6\x39\x69\x54\xa3\x24\x9a\xc1\xe0\x01\xb9\x4d\xff\x7d\x07\x44\x40\xc8\x20\x2b\x8e\x93\x69\x6f\x1e\xef\xdb\x65\xb5\xfb\x9e\x1e\x38\x93\x89\x33\x99\xc0\xaf\x27\x52\x08\xfa\xc8\xd3\x47\xf2\xc0\x13\x56\x94\x7f\x3b\xb3\x08\x79\x18\x01\xf6\xa6\x0b\x04\xfe\x67\x08\x42\x0c\x68\xe3\xaf\xf1\xfa\x08\x0f\x57\x0e\x00\x00\x8f\x61\xea\xdf\xac\x51\xe4\x7b\x0b\x58\x45\xfe\xd2\x8b\xee\xe1\x2b\xba\xbf\xae\xa2\x49\xb6\xa5\x82\x67\x29\x60\xb4\xc1\x55\xb5\xe0\x6e\xb1\x90\xb1\x22\x3b\xe4\x5b\x46\x78\x0c\xdf\xbc\x68\x76\xeb\x45\x57\x9f\xfe\x1f\x6b\x98\x98\x15\x82\xa7\x55\x09\x33\xb0\xd8\x3e\xb1\x1d\x85\x2f\xeb\x30\x98\x6a\x21\x96\xe7\x59\x5e\x35\x50\x43\x05\x15\x87\x42\xad\x25\xff\x7f\xe0\x79\x21\x08\xfb\xc9\x52\x41\xa8\x00\xec\x2f\xd1\x1a\x7b\xcb\x55\x7d\x12\x6a\x08\x8a\x4c\xd0\x44\x46\x8b\x72\x1c\x7e\x50\x3f\x6b\x9b\x33\x2a\x58\xdc\x49\xd1\xda\x3b\xec\xe3\x61\xc8\xd8\x75\x1c\x6f\x81\x51\x54\xaf\xe4\x68\x69\x00\x00\xde\x7c\x0e\xb3\x70\x71\xb7\x0c\xb4\x95\x99\x4f\x34\x98\x96\xd0\x73\xb2\x7a\x66\xe0\x36\x84\xf2\x83\x39\xda\x9c\x20\x14\xe1\x31\xe1\x69\xcc\x9e\x21\x0c\x7a\xd8\xd6\xd0\xe5\x5a\x63\xc5\xa9\x19\x81\x0c\xd6\x4d\xd7\xdb\xc7\xf7\x2b\xa4\x52\xc0\x75\x9c\x79\x08\xa3\x11\x4c\xd1\x8d\x1f\x54\x27\x9d\x47\xe1\x4a\xe2\x94\x8a\x65\x3a\x23\xe2\xf7\x9e\xb9\x15\x08\x6d\x66\x68\x85\xfd\x30\x80\xef\xb7\x28\x80\x10\xdf\xa2\x68\x0d\xb8\xfc\x9d\x1e\x92\xc4\x75\x50\x30\x87\xd1\xc8\x75\x5a\xd5\x25\x19\x8d\xad\x25\xd7\x82\xad\xf4\xa6\x1e\x9c\xf0\xb8\xc3\xc5\x0f\xd3\xa2\x0a\x2c\x27\x65\x80\x0a\xfa\x23\x61\x24\xa5\x3b\xd6\xd7\xd5\x79\xb2\xea\xe1\x83\x32\xc5\x0e\x19\xd4\xc7\x97\x8b\x2e\x7b\x30\x67\x5f\x92\xfd\x6d\x5d\x52\xcf\xbf\x3b\x62\xd2\xb6\xd7\x11\x86\xca\x89\x41\x55\x5c\x2b\x87\x1b\xab\xf4\x3b\xec\xcb\x7c\x1b\xee\xd5\x48\x3b\xe2\x59\x10\xa8\x6c\xa5\xd8\xd3\x2d\xfb\x60\x92\x15\x82\xe6\x82\x98\xa4\xc1\xd2\xd8\x18\x97\x15\x9a\xb9\xf7\xa5\x0f\x06\x8f\xed\xe6\xb5\xd6\x55\x45\xde\xec\x51\x32\xf8\xcc\xb6\x3d\x31\xc1\x77\x3c\x7d\x2c\xd4\x27\xbd\x83\x73\xbd\x30\xef\xef\xf1\x2c\xd3\x4c\x86\xa5\xae\x0e\xeb\xa4\xc8\xeb\x43\x13\x49\x83\x8e\x8e\x1b\x7d\xc9\xd8\xd8\xbe\x56\xef\x6d\xd1\x5f\xfa\x15\xae\xf9\x92\x74\x09\xbf\x94\xb5\x2e\x61\x97\xf2\x1a\xb3\xbc\xb5\xfe\xd3\x13\xac\x2e\xaf\xb6\xdf\x0e\xce\x68\x55\x96\xe2\xd6\x5f\x3e\x5b\xc6\x09\xbe\x63\x3a\xe7\x3a\x2e\xd2\x26\xbd\x83\x16\xbb\x43\x7a\xa5\xad\x99\x6b\xa9\xec\xa9\x8f\xaf\x78\xab\x99\xe1\x9d\x52\x44\xdd\xcb\x90\x19\x6a\xdb\x56\x53\x74\x0b\xb4\x6f\xfb\x4c\xd2\xab\x15\x2f\x41\x7d\xe9\x0c\x56\x5f\x66\x12\xf9\x06\xb6\xff\x03\x36\x6e\xff\x89\x77\xf2\xfd\xb0\x5a\x5b\xc3\xc1\xa3\x39\x9a\x6e\xd8\xd3\x1f\x35\x75\x0d\x2d\xb9\x19\x5b\xf7\x23\xe7\x65\x71\xfa\xdb\x5b\x03\x1f\xbb\x7f\x06\x00\x89\x94\x7c\x27\xb1\x0f\x00\x00"),
 		},
 		"/warehouse/000002_alter_wh_table_uploads.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000002_alter_wh_table_uploads.up.sql",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 30779604, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 201,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\xcc\x31\x0a\xc2\x30\x18\xc5\xf1\x3d\xa7\x78\xa3\x0e\x39\x41\xa7\xb4\x8d\x25\x10\x53\x31\x5f\xa1\x4e\x21\xd6\x80\x42\x4c\x84\xa6\x7a\x7d\x51\x5c\xc4\xc1\xf5\xbd\x1f\x7f\xc6\x39\xe3\x1c\x8f\xb3\x2b\xfe\x18\x83\x5b\x6e\x31\xfb\xd3\xfc\x9a\x99\xd0\x24\xf7\x20\x51\x6b\xf9\x03\x20\xda\x16\x4d\xaf\x87\xad\x81\xda\xc0\xf4\x04\x39\x2a\x4b\x16\x31\x4f\xbe\x5c\x72\x02\xc9\x91\xaa\x7f\x95\xf7\x39\xe5\xb8\x5c\x13\x4a\x2e\x3e\xba\x70\x0f\xa9\xcc\xa0\xc3\x4e\xa2\x56\x9d\x32\x84\xc1\x2a\xd3\xa1\x11\x96\x56\x5f\x46\xd8\x8f\x58\x57\xec\x19\x00\x00\xff\xff\x10\xd9\xe2\xb0\xc9\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\xcc\x31\x0a\xc2\x30\x18\xc5\xf1\x3d\xa7\x78\xa3\x0e\x39\x41\xa7\xb4\x8d\x25\x10\x53\x31\x5f\xa1\x4e\x21\xd6\x80\x42\x4c\x84\xa6\x7a\x7d\x51\x5c\xc4\xc1\xf5\xbd\x1f\x7f\xc6\x39\xe3\x1c\x8f\xb3\x2b\xfe\x18\x83\x5b\x6e\x31\xfb\xd3\xfc\x9a\x99\xd0\x24\xf7\x20\x51\x6b\xf9\x03\x20\xda\x16\x4d\xaf\x87\xad\x81\xda\xc0\xf4\x04\x39\x2a\x4b\x16\x31\x4f\xbe\x5c\x72\x02\xc9\x91\xaa\x7f\x95\xf7\x39\xe5\xb8\x5c\x13\x4a\x2e\x3e\xba\x70\x0f\xa9\xcc\xa0\xc3\x4e\xa2\x56\x9d\x32\x84\xc1\x2a\xd3\xa1\x11\x96\x56\x5f\x46\xd8\x8f\x58\x57\xec\x39\x00\x10\xd9\xe2\xb0\xc9\x00\x00\x00"),
 		},
 		"/warehouse/000003_wh_uploads_add_metadata_column.up.sql": &vfsgen۰FileInfo{
 			name:    "000003_wh_uploads_add_metadata_column.up.sql",
-			modTime: time.Date(2021, 8, 2, 20, 4, 39, 30887020, time.UTC),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			content: []byte("\x0a\x2d\x2d\x0a\x2d\x2d\x20\x77\x68\x5f\x75\x70\x6c\x6f\x61\x64\x73\x0a\x2d\x2d\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x77\x68\x5f\x75\x70\x6c\x6f\x61\x64\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x6d\x65\x74\x61\x64\x61\x74\x61\x20\x4a\x53\x4f\x4e\x42\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x27\x7b\x7d\x27\x3b\x0a"),
 		},
 		"/warehouse/000004_add_upd_col_to_wh_schemas_and_uniq_constraint.sql.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000004_add_upd_col_to_wh_schemas_and_uniq_constraint.sql.up.sql",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 31006591, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 460,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x90\x41\x6b\xdc\x40\x0c\x85\xef\xfe\x15\x0f\x93\x43\x02\x75\xfb\x03\x72\x72\x62\xb7\x18\xb6\x76\x9b\xf5\x42\x6e\x46\xcc\x28\x9d\x01\x5b\xe3\xce\x68\x70\xf3\xef\xcb\x24\x81\xee\xa9\x3a\x49\x7a\xe2\xbd\x0f\x35\x4d\xd5\x34\x38\xdc\x92\x8c\xe3\x8d\x52\xd5\x34\x55\xd5\x9e\xe6\xfe\x09\x73\xfb\x70\xea\xaf\x24\xb4\x5d\x87\xc7\xe9\x74\xf9\x3e\x62\xf8\x8a\x71\x9a\xd1\x3f\x0f\xe7\xf9\x8c\xbc\x5b\x52\xb6\x0b\x29\xd4\x6f\x9c\x94\xb6\xfd\xbe\x2a\xc6\x64\x6d\x02\xc1\x04\x49\x1a\xc9\x8b\x42\xc3\xb5\xe5\xe1\xbc\x71\xa0\x75\x0d\x47\x42\x4e\x45\xb5\x01\x79\x4f\x1c\xf5\xf6\xdd\xf6\x8b\x97\x32\xdd\x21\x08\x24\x08\xf6\xe8\x37\x8a\xaf\x30\x61\xcd\x9b\x14\x60\x98\xc8\xa4\x5c\x82\x34\x92\x24\x32\xea\x83\x7c\x86\x7f\x81\x3a\xbe\x0e\xa7\x35\x32\xd9\x57\xf0\x1f\x9f\x34\x15\x55\x60\x03\x27\x48\x50\xe7\xe5\x57\xd5\x4d\xb8\xb9\xa9\x00\xe0\xa1\xff\x36\x8c\x6f\x5d\xa9\xff\x7e\x64\x3c\xcf\x4f\xed\x30\xce\xc8\xe2\x7f\x67\x5e\x0e\xb7\x78\xcb\xa2\xfe\xc5\x73\xc4\x65\x1c\x7e\x5e\xfa\xdb\x3a\x85\x1c\x0d\x2f\xde\xd6\x9f\x6a\xcb\x49\xbd\x50\xc1\x7c\x5f\x08\x6d\x9c\x76\x32\x5c\xdf\xdd\xbf\x85\xf6\xcf\x8f\xfd\x8f\x79\x98\xfe\x21\x1c\x05\x36\xa8\xe3\xf8\x01\x2e\x79\x5d\x3f\x8e\xc7\xae\x60\xff\x0d\x00\x00\xff\xff\x88\x0e\xff\xc3\xcc\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x90\x41\x6b\xdc\x40\x0c\x85\xef\xfe\x15\x0f\x93\x43\x02\x75\xfb\x03\x72\x72\x62\xb7\x18\xb6\x76\x9b\xf5\x42\x6e\x46\xcc\x28\x9d\x01\x5b\xe3\xce\x68\x70\xf3\xef\xcb\x24\x81\xee\xa9\x3a\x49\x7a\xe2\xbd\x0f\x35\x4d\xd5\x34\x38\xdc\x92\x8c\xe3\x8d\x52\xd5\x34\x55\xd5\x9e\xe6\xfe\x09\x73\xfb\x70\xea\xaf\x24\xb4\x5d\x87\xc7\xe9\x74\xf9\x3e\x62\xf8\x8a\x71\x9a\xd1\x3f\x0f\xe7\xf9\x8c\xbc\x5b\x52\xb6\x0b\x29\xd4\x6f\x9c\x94\xb6\xfd\xbe\x2a\xc6\x64\x6d\x02\xc1\x04\x49\x1a\xc9\x8b\x42\xc3\xb5\xe5\xe1\xbc\x71\xa0\x75\x0d\x47\x42\x4e\x45\xb5\x01\x79\x4f\x1c\xf5\xf6\xdd\xf6\x8b\x97\x32\xdd\x21\x08\x24\x08\xf6\xe8\x37\x8a\xaf\x30\x61\xcd\x9b\x14\x60\x98\xc8\xa4\x5c\x82\x34\x92\x24\x32\xea\x83\x7c\x86\x7f\x81\x3a\xbe\x0e\xa7\x35\x32\xd9\x57\xf0\x1f\x9f\x34\x15\x55\x60\x03\x27\x48\x50\xe7\xe5\x57\xd5\x4d\xb8\xb9\xa9\x00\xe0\xa1\xff\x36\x8c\x6f\x5d\xa9\xff\x7e\x64\x3c\xcf\x4f\xed\x30\xce\xc8\xe2\x7f\x67\x5e\x0e\xb7\x78\xcb\xa2\xfe\xc5\x73\xc4\x65\x1c\x7e\x5e\xfa\xdb\x3a\x85\x1c\x0d\x2f\xde\xd6\x9f\x6a\xcb\x49\xbd\x50\xc1\x7c\x5f\x08\x6d\x9c\x76\x32\x5c\xdf\xdd\xbf\x85\xf6\xcf\x8f\xfd\x8f\x79\x98\xfe\x21\x1c\x05\x36\xa8\xe3\xf8\x01\x2e\x79\x5d\x3f\x8e\xc7\xae\x60\xff\x1d\x00\x88\x0e\xff\xc3\xcc\x01\x00\x00"),
 		},
 		"/warehouse/000005_create_table_schema_versions.sql.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000005_create_table_schema_versions.sql.up.sql",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 31113371, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 1709,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xac\x94\xc1\x72\x9b\x3c\x14\x85\xf7\x7a\x8a\x3b\x8c\x17\xf6\x0c\xe4\xdf\xfc\xd3\x8d\x57\x32\x96\x09\x2d\x11\x54\x88\xd4\x59\x79\x54\xa3\x18\xcd\x38\x22\x23\xe1\x24\x7e\xfb\x8e\xc0\x06\xa7\x75\xda\x3a\xa9\x37\x06\x74\xf5\x9d\xa3\x7b\x0f\x04\x01\x0a\x02\x78\xae\x56\x76\x5d\xc9\x07\xb1\x7a\x92\xc6\xaa\x5a\x5b\x14\x04\x08\x85\x8c\x60\x4e\x80\xe3\x59\x42\x20\x5e\x00\x4d\x39\x90\x65\x9c\xf3\xfc\xcc\x0e\x18\x23\xf8\xeb\x9f\x2a\x61\x16\x47\x39\x61\x31\x4e\x20\x63\xf1\x0d\x66\x77\xf0\x85\xdc\xf9\x17\x30\x06\x0b\xaf\x68\xce\x24\x2d\x92\xe4\x12\x94\xad\x77\x66\x2d\x1d\xe7\x16\xb3\xf0\x1a\xb3\xf1\xa7\xff\x27\xef\x22\x69\xf1\x20\xed\xa3\x58\xcb\x0f\x93\x4a\x69\x1b\xa5\x45\xa3\x6a\xfd\x2f\x8c\x9d\xe2\x9a\xfd\xe3\xc7\xfd\x75\xbd\x87\xcf\x79\x4a\x67\xef\x02\x48\x63\x6a\x03\x9c\x2c\xf9\x25\xbb\xd6\x95\xd0\x1b\x59\xae\x44\x03\x3c\xbe\x21\x39\xc7\x37\x59\x2f\x3f\x99\xf6\xa1\x8d\xe9\x9c\x2c\xff\x18\xda\xd5\x69\x88\x56\x4a\x97\xf2\x05\x52\x7a\x36\xdd\xa7\x95\x4e\x27\x08\x60\x6d\xa4\x68\x24\x08\x68\x8c\xda\x6c\xa4\x81\xfb\x9d\x5e\xbb\x0e\x83\xd2\x56\x9a\xc6\x82\xd2\x4d\x7d\x8e\x76\x5f\x1b\x90\x4f\xd2\xec\x0f\x95\xff\xed\x1e\x4b\x87\xaa\xf5\x50\x6d\xa1\x11\xdf\xb7\xf2\x78\xa0\x94\x01\x23\x59\x82\x43\x02\x8b\x82\x86\x3c\x76\x3e\x85\x91\x55\xbd\xb3\xf2\x28\x50\x29\xdb\xd4\x66\x3f\x9e\xb4\x1d\x65\x84\x17\x8c\xe6\xc0\x59\x1c\x45\x84\xb5\xcf\x12\x4c\xa3\x02\x47\x04\xb2\x24\x8b\xf2\xaf\x09\xc2\x39\x1a\x8d\xd0\x8c\x44\x31\x6d\x0b\x62\x9a\x13\xc6\x21\xa6\x3c\x3d\xe3\x7c\xec\x9d\xf6\xc1\xf3\xbd\xfe\xd5\xf1\x7c\xf0\xfa\xf4\x7b\xbe\xf7\x3a\xbf\x6e\xf5\xe7\x08\xba\xdd\x2d\xc9\xf3\xbd\x36\x0c\x9e\xef\x0d\xe3\xf5\xba\x33\xdc\xe2\xa4\x20\xf9\x58\xcb\xe7\x2b\x55\xfa\xe0\xfe\x7b\xc9\xee\xb6\x17\xed\x6e\x5f\xeb\xfe\xfa\xcc\x29\x1f\x38\xad\x78\x77\xdd\xea\x77\x97\xdd\x28\x9c\x85\xc9\xf4\xa4\x8d\x6e\x6d\x8a\x08\x9d\xc3\x14\x8d\x46\x6f\x04\xe0\xb9\x92\x1a\x9a\x4a\x1a\x09\xca\x82\x38\x4c\x17\x6a\x03\xe7\x06\x3c\x20\x6c\xcb\x10\xda\x8a\x36\x3f\x57\xa0\xee\x1d\xa6\xe7\x8a\xad\x91\xa2\xdc\x83\x7c\x51\xb6\xb1\x6e\x49\x43\x59\x4b\x0b\xba\x6e\x2a\xa5\x37\x68\x9e\xc2\x68\xd4\xba\x1d\x26\xd9\xbe\x2e\x9d\xc3\x23\xe7\xcd\xc4\xac\x8e\x15\x78\xc1\x09\x3b\x86\x20\x65\x50\x64\xf3\x36\x7d\x14\x86\xc9\x5b\x0f\x16\x29\x03\x82\xc3\x6b\x60\xe9\x37\x20\x4b\x12\x16\x9c\x40\xc6\xd2\x90\xcc\x0b\x46\x7e\x13\xcc\xae\xa5\x64\x19\x92\xcc\x45\x18\x0d\x5f\x73\xa9\xa1\x76\xad\x3b\x1c\x4f\xef\xb6\xdb\x43\x31\x9d\xbb\xc3\x21\xf4\x23\x00\x00\xff\xff\xfd\x83\x8c\x84\xad\x06\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xac\x94\xd1\x73\xa2\x3a\x18\xc5\xdf\xf3\x57\x9c\x61\x7c\xd0\x19\xe9\x7d\xb9\x73\x5f\x7c\x42\x8c\x96\x7b\x29\x70\x43\xe8\xda\x27\x27\x2b\xa9\x64\xc6\x86\x4e\x82\x6d\xfd\xef\x77\x02\x2a\x76\xd7\xee\x6e\xdb\xe5\x45\x20\x5f\x7e\xe7\xe4\xfb\x8e\xf8\x3e\xf1\x7d\x3c\x57\x2b\xbb\xae\xe4\x83\x58\x3d\x49\x63\x55\xad\x2d\xf1\x7d\x42\x42\x46\x03\x4e\xc1\x83\x69\x4c\x11\xcd\x91\xa4\x1c\x74\x19\xe5\x3c\xbf\xb0\x03\x43\x82\xdf\xbe\x54\x89\x69\xb4\xc8\x29\x8b\x82\x18\x19\x8b\x6e\x02\x76\x87\xff\xe8\xdd\xf8\x1d\x8c\xde\xc2\x2b\x9a\x33\x99\x14\x71\xfc\x1e\x94\xad\x77\x66\x2d\x1d\xe7\x36\x60\xe1\x75\xc0\x86\xff\xfc\x3d\xfa\x10\x49\x8b\x07\x69\x1f\xc5\x5a\x7e\x9a\x54\x4a\xdb\x28\x2d\x1a\x55\xeb\x3f\x61\xec\x1c\xd7\xec\x1f\x3f\xef\xaf\xeb\x3d\xfe\xcd\xd3\x64\xfa\x21\x80\x34\xa6\x36\xe0\x74\xc9\xdf\xb3\x6b\x5d\x09\xbd\x91\xe5\x4a\x34\xe0\xd1\x0d\xcd\x79\x70\x93\x9d\xe4\x47\x93\x53\x68\xa3\x64\x46\x97\xbf\x0c\xed\xea\x3c\x44\x2b\xa5\x4b\xf9\x82\x34\xb9\x98\xee\xf3\x4a\xa7\xe3\xfb\x58\x1b\x29\x1a\x09\x81\xc6\xa8\xcd\x46\x1a\xdc\xef\xf4\xda\x75\x18\x4a\x5b\x69\x1a\x0b\xa5\x9b\xfa\x12\xed\xbe\x36\x90\x4f\xd2\xec\x0f\x95\x7f\xed\x1e\x4b\x87\xaa\x75\x5f\x6d\xd1\x88\xaf\x5b\x79\x3c\x50\xca\xc0\x68\x16\x07\x21\xc5\xbc\x48\x42\x1e\x39\x9f\xc2\xc8\xaa\xde\x59\x79\x14\xa8\x94\x6d\x6a\xb3\x1f\x8e\x08\x00\x30\xca\x0b\x96\xe4\xe0\x2c\x5a\x2c\x28\x6b\xdf\xc5\x41\xb2\x28\x82\x05\x45\x16\x67\x8b\xfc\xff\x98\x04\x39\x19\x0c\xc8\x94\x2e\xa2\xa4\x2d\x88\x92\x9c\x32\x8e\x28\xe1\xe9\x05\xe7\x43\xef\xbc\x0f\xde\xd8\x3b\xfd\x75\xbc\x31\xbc\x53\xfa\xbd\xb1\xf7\x3a\xbf\x6e\xf5\xfb\x08\xba\xdd\x2d\xc9\x1b\x7b\x6d\x18\xbc\xb1\xd7\x8f\xd7\xeb\xce\x70\x1b\xc4\x05\xcd\x87\x5a\x3e\x5f\xa9\x72\x0c\xf7\x7b\x92\xec\x1e\x4f\xa2\xdd\xe3\x6b\xdd\x1f\xdf\x39\xe5\x03\xa7\x15\xef\xee\x5b\xfd\xee\xb6\x1b\x85\xb3\x30\x9a\x9c\xb5\xd1\xad\x4d\x08\x4d\x66\x98\x90\xc1\xe0\x8d\x00\x3c\x57\x52\xa3\xa9\xa4\x91\x50\x16\xe2\x30\x5d\xd4\x06\x97\x06\xdc\x23\x6c\xcb\x10\xda\x8a\x36\x3f\x57\x50\xf7\x0e\x73\xe2\x8a\xad\x91\xa2\xdc\x43\xbe\x28\xdb\x58\xb7\xa4\x51\xd6\xd2\x42\xd7\x4d\xa5\xf4\x86\xcc\x52\x0c\x06\x04\x00\xfa\x49\xba\xeb\xe0\xf0\xc8\x79\x33\x31\xab\x63\x45\x30\xe7\x94\x1d\x43\x90\x32\x14\xd9\xac\x4d\x5f\x82\x7e\xf2\xd6\xc3\x3c\x65\xa0\x41\x78\x0d\x96\x7e\x01\x5d\xd2\xb0\xe0\x14\x19\x4b\x43\x3a\x2b\x18\xfd\x49\x30\xbb\x96\xd2\x65\x48\x33\x17\x61\xd2\x7f\xcd\xa5\x46\xed\x5a\x77\x38\x9e\xde\x6d\xb7\x87\xe2\x64\xe6\x0e\x47\xc8\xb7\x01\x00\xfd\x83\x8c\x84\xad\x06\x00\x00"),
 		},
 		"/warehouse/000006_add_wh_table_uploads_unique_constraint.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000006_add_wh_table_uploads_unique_constraint.up.sql",
-			modTime:          time.Date(2021, 9, 12, 17, 19, 8, 399439130, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 958,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\x93\x5f\x6f\x9b\x3c\x14\xc6\xaf\xf1\xa7\x78\x2e\x22\x05\xa4\xb4\xd2\x7b\x8d\xfa\x4a\xb4\xf1\xb4\x48\x69\xd8\x08\x5d\xb5\x2b\xcb\x85\x03\xb1\x06\x76\x6a\xec\x25\x1f\x7f\x02\x9a\x3f\x65\xdd\xc6\x15\x3e\xcf\x39\xcf\xcf\xc7\x3e\x5e\xf2\x35\xcf\x39\x3e\x65\xe9\x23\x0e\x3b\xe1\xe4\x4b\x43\xc2\xef\x1b\x23\xcb\x8e\x05\xcf\x9f\x79\xc6\xa1\x4a\x28\x8d\x90\x05\xc1\x96\xaf\xf9\x43\x0e\x55\xb2\x20\x08\x86\x9a\x3e\x7a\x8a\x0f\xbf\x41\x96\x3e\x8b\xcd\xd3\xe3\x3d\xcf\xc2\x08\xe9\x37\x9e\x21\xfc\x92\x64\xf9\x2a\x5f\xa5\x1b\xdc\x7f\xef\x29\xa3\xbf\x50\xe5\x02\x23\x50\xcb\x96\x90\x66\x4b\x9e\xf5\x19\xaa\xc4\x92\x6f\x1f\x22\x24\x5b\x58\x73\x10\xda\xb7\x2f\x64\x17\xa3\xbd\xbb\x1d\xe9\x03\x7e\x0c\x4d\xf7\x0d\xc7\x7a\x21\x42\x6d\x8d\xdf\x53\x39\xed\x2a\x18\xfb\x1a\x8a\x3f\x4c\xb9\xbd\x50\xf1\x3f\xfe\x63\x41\x14\x33\x56\x58\x92\x8e\x60\x2c\x2c\xed\x1b\x59\x10\x2a\xaf\x0b\xa7\x8c\xc6\x28\x89\xc2\xe8\xce\x59\xa9\xb4\x13\xaa\x12\xda\x38\x41\x47\xd5\xb9\x0e\x21\x03\x00\x37\xb6\xe9\xe8\xe8\x16\x28\xde\x2d\x2e\x85\xdd\x6b\x33\x04\x59\xc4\x2c\x39\x6f\x75\x87\x9f\x46\x95\x48\xb6\x6c\x36\x63\x2f\x54\x2b\x3d\x98\xdd\xdc\x60\x6d\xcc\x0f\x54\xc6\xc2\x78\x7b\x65\x31\xc8\xaa\x82\x36\x0e\x27\x7e\x47\x0d\x15\xee\x9a\xd3\xd3\x87\xcc\xc9\x57\x59\xd3\x42\xe9\xca\xd8\x56\xf6\xbd\x89\xae\xd8\x51\x2b\x6f\xaf\x4a\x0b\xd3\xf8\x56\x0b\xdf\xc9\xfa\x43\x8b\xc3\x8e\x2c\x5d\x5f\xec\xdd\xa9\x75\x48\x5d\x4e\x37\x81\xbb\xb7\xb3\x88\xe0\x76\xa4\xcf\x86\x74\xa4\xc2\x3b\x9a\x9c\x4d\x3c\xe8\xa4\x4b\xa8\x2a\x66\xa4\xcb\x98\xcd\x66\x68\xa4\xae\xbd\xac\x09\xf3\x7d\xb3\xaf\xbb\xd7\x66\x1e\x33\xf6\x36\xab\xff\xb8\x9b\xf0\x0c\x9c\x4f\xc7\x68\xbe\xb8\x68\x5e\xab\x57\x4f\xef\x74\x71\x1e\xe4\xeb\xc4\x64\x9d\xf3\x0c\x79\x72\xbf\xe6\xbf\xbd\x27\x24\xcb\x25\x1e\xd2\xcd\x36\xcf\x92\xd5\x26\xc7\x5f\x4d\xf1\xb4\x59\x7d\x7d\xe2\x08\xff\xf4\x5e\xa2\x78\x1e\xfd\x0a\x00\x00\xff\xff\x92\x3a\x8e\x8b\xbe\x03\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\x93\x5f\x6f\x9b\x3c\x14\xc6\xaf\xf1\xa7\x78\x2e\x22\x05\xa4\xb4\xd2\x7b\x8d\xfa\x4a\xb4\xf1\xb4\x48\x69\xd8\x08\x5d\xb5\x2b\xcb\x85\x03\xb1\x06\x76\x6a\xec\x25\x1f\x7f\x02\x9a\x3f\x65\xdd\xc6\x15\x3e\xcf\x39\xcf\xcf\xc7\x3e\x5e\xf2\x35\xcf\x39\x3e\x65\xe9\x23\x0e\x3b\xe1\xe4\x4b\x43\xc2\xef\x1b\x23\xcb\x8e\x05\xcf\x9f\x79\xc6\xa1\x4a\x28\x8d\x90\x05\xc1\x96\xaf\xf9\x43\x0e\x55\xb2\x20\x08\x86\x9a\x3e\x7a\x8a\x0f\xbf\x41\x96\x3e\x8b\xcd\xd3\xe3\x3d\xcf\xc2\x08\xe9\x37\x9e\x21\xfc\x92\x64\xf9\x2a\x5f\xa5\x1b\xdc\x7f\xef\x29\xa3\xbf\x50\xe5\x02\x23\x50\xcb\x96\x90\x66\x4b\x9e\xf5\x19\xaa\xc4\x92\x6f\x1f\x22\x24\x5b\x58\x73\x10\xda\xb7\x2f\x64\x17\xa3\xbd\xbb\x1d\xe9\x03\x7e\x0c\x4d\xf7\x0d\xc7\x7a\x21\x42\x6d\x8d\xdf\x53\x39\xed\x2a\x18\xfb\x1a\x8a\x3f\x4c\xb9\xbd\x50\xf1\x3f\xfe\x63\x41\x14\x33\x56\x58\x92\x8e\x60\x2c\x2c\xed\x1b\x59\x10\x2a\xaf\x0b\xa7\x8c\xc6\x28\x89\xc2\xe8\xce\x59\xa9\xb4\x13\xaa\x12\xda\x38\x41\x47\xd5\xb9\x0e\x21\x03\x00\x37\xb6\xe9\xe8\xe8\x16\x28\xde\x2d\x2e\x85\xdd\x6b\x33\x04\x59\xc4\x2c\x39\x6f\x75\x87\x9f\x46\x95\x48\xb6\x6c\x36\x63\x2f\x54\x2b\x3d\x98\xdd\xdc\x60\x6d\xcc\x0f\x54\xc6\xc2\x78\x7b\x65\x31\xc8\xaa\x82\x36\x0e\x27\x7e\x47\x0d\x15\xee\x9a\xd3\xd3\x87\xcc\xc9\x57\x59\xd3\x42\xe9\xca\xd8\x56\xf6\xbd\x89\xae\xd8\x51\x2b\x6f\xaf\x4a\x0b\xd3\xf8\x56\x0b\xdf\xc9\xfa\x43\x8b\xc3\x8e\x2c\x5d\x5f\xec\xdd\xa9\x75\x48\x5d\x4e\x37\x81\xbb\xb7\xb3\x88\xe0\x76\xa4\xcf\x86\x74\xa4\xc2\x3b\x9a\x9c\x4d\x3c\xe8\xa4\x4b\xa8\x2a\x66\xa4\xcb\x98\xcd\x66\x68\xa4\xae\xbd\xac\x09\xf3\x7d\xb3\xaf\xbb\xd7\x66\x1e\x33\xf6\x36\xab\xff\xb8\x9b\xf0\x0c\x9c\x4f\xc7\x68\xbe\xb8\x68\x5e\xab\x57\x4f\xef\x74\x71\x1e\xe4\xeb\xc4\x64\x9d\xf3\x0c\x79\x72\xbf\xe6\xbf\xbd\x27\x24\xcb\x25\x1e\xd2\xcd\x36\xcf\x92\xd5\x26\xc7\x5f\x4d\xf1\xb4\x59\x7d\x7d\xe2\x08\xff\xf4\x5e\xa2\x78\x1e\xfd\x1a\x00\x92\x3a\x8e\x8b\xbe\x03\x00\x00"),
 		},
 		"/warehouse/000007_add_wh_uploads_indexes.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000007_add_wh_uploads_indexes.up.sql",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 31305710, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 451,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x90\xc1\xaa\xc2\x30\x10\x45\xf7\xfd\x8a\xbb\xeb\x2b\x24\x5f\xf0\x56\xa2\x11\xba\x69\xc1\x76\xd1\x5d\x18\x9a\x80\x01\x4d\x0b\x99\xa2\x42\x3f\x5e\x8a\x58\xab\x18\x14\x57\xc3\xc0\xdc\x39\x67\x46\x4a\x9c\xf6\x7a\xe8\x0f\x1d\x99\x00\x29\x93\x64\xbd\x53\xab\x5a\x21\x2f\x36\xaa\x41\xbe\x45\x51\xd6\x50\x4d\x5e\xd5\xd5\x62\x52\x3b\xa3\x8d\x0d\xec\x3c\xb1\xeb\xfc\xd4\x7a\x3a\xda\xd0\x53\x6b\x75\x60\xe2\x21\x68\xe7\x8d\x3d\xa3\x2c\x96\x80\x3f\x67\x04\x9e\x83\x02\x73\x52\xe0\x16\xcd\xfe\xbf\xd5\x88\x3a\xbc\x85\x47\xc1\xbf\x01\xf9\xd2\xcf\xc7\x46\x45\xda\xce\xb7\xc4\xd6\x13\x5b\xf3\xd9\x6a\x5a\x79\xff\x82\x78\x15\xc6\x38\x22\xd5\xe9\x54\x1e\xe6\x59\x72\x0d\x00\x00\xff\xff\x8e\x18\xb9\x4e\xc3\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x90\xc1\xaa\xc2\x30\x10\x45\xf7\xfd\x8a\xbb\xeb\x2b\x24\x5f\xf0\x56\xa2\x11\xba\x69\xc1\x76\xd1\x5d\x18\x9a\x80\x01\x4d\x0b\x99\xa2\x42\x3f\x5e\x8a\x58\xab\x18\x14\x57\xc3\xc0\xdc\x39\x67\x46\x4a\x9c\xf6\x7a\xe8\x0f\x1d\x99\x00\x29\x93\x64\xbd\x53\xab\x5a\x21\x2f\x36\xaa\x41\xbe\x45\x51\xd6\x50\x4d\x5e\xd5\xd5\x62\x52\x3b\xa3\x8d\x0d\xec\x3c\xb1\xeb\xfc\xd4\x7a\x3a\xda\xd0\x53\x6b\x75\x60\xe2\x21\x68\xe7\x8d\x3d\xa3\x2c\x96\x80\x3f\x67\x04\x9e\x83\x02\x73\x52\xe0\x16\xcd\xfe\xbf\xd5\x88\x3a\xbc\x85\x47\xc1\xbf\x01\xf9\xd2\xcf\xc7\x46\x45\xda\xce\xb7\xc4\xd6\x13\x5b\xf3\xd9\x6a\x5a\x79\xff\x82\x78\x15\xc6\x38\x22\xd5\xe9\x54\x1e\xe6\x59\x72\x1d\x00\x8e\x18\xb9\x4e\xc3\x01\x00\x00"),
 		},
 		"/warehouse/000008_add_wh_tables_indices.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000008_add_wh_tables_indices.up.sql",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 31426098, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 563,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\x90\xc1\xaa\x83\x30\x10\x45\xf7\x7e\xc5\x2c\x15\x92\x2f\x70\xf5\x78\x4d\xc1\x8d\x42\x75\xe1\x6e\x48\x9b\x54\x07\x6c\x2c\x4d\xc4\x7e\x7e\x51\x4b\x35\x42\xc5\xee\x1c\x39\xb9\xf7\x70\x39\x87\xbe\xc6\xa6\x95\x0a\xaf\xd4\x68\x0b\x9c\x07\xc1\xff\x49\xfc\x15\x02\x92\xf4\x20\x4a\x48\x8e\x90\x66\x05\x88\x32\xc9\x8b\xdc\x87\xd1\x3a\x59\x91\xa9\xc6\x0b\x49\x21\x19\xa5\x9f\x90\xa5\xab\xcc\x70\xc5\x45\xf1\x0f\x1d\xa4\xd0\xb6\xdd\xe3\x32\x16\x28\x6d\x1d\x19\xe9\xa8\x35\xc3\xe9\xe4\xb9\xd1\x68\xe4\x4d\x7f\xad\x26\xc5\xe0\xf3\x9e\x81\x1f\xc0\x60\x4e\x18\xa4\xa6\x35\xa6\x7f\xdd\x7d\x88\xd9\x33\x88\xc7\x63\x5f\xbf\x3f\x47\x73\x27\x5d\x67\x3d\x39\x3f\x3d\x5c\xe2\x0c\x26\x7e\x56\x59\x0e\xb7\x47\xc5\xe3\x37\xa7\x5b\x2a\xf9\x2d\xdb\x93\x45\xf1\x2b\x00\x00\xff\xff\x42\xa7\x96\x5e\x33\x02\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\x90\xc1\xaa\x83\x30\x10\x45\xf7\x7e\xc5\x2c\x15\x92\x2f\x70\xf5\x78\x4d\xc1\x8d\x42\x75\xe1\x6e\x48\x9b\x54\x07\x6c\x2c\x4d\xc4\x7e\x7e\x51\x4b\x35\x42\xc5\xee\x1c\x39\xb9\xf7\x70\x39\x87\xbe\xc6\xa6\x95\x0a\xaf\xd4\x68\x0b\x9c\x07\xc1\xff\x49\xfc\x15\x02\x92\xf4\x20\x4a\x48\x8e\x90\x66\x05\x88\x32\xc9\x8b\xdc\x87\xd1\x3a\x59\x91\xa9\xc6\x0b\x49\x21\x19\xa5\x9f\x90\xa5\xab\xcc\x70\xc5\x45\xf1\x0f\x1d\xa4\xd0\xb6\xdd\xe3\x32\x16\x28\x6d\x1d\x19\xe9\xa8\x35\xc3\xe9\xe4\xb9\xd1\x68\xe4\x4d\x7f\xad\x26\xc5\xe0\xf3\x9e\x81\x1f\xc0\x60\x4e\x18\xa4\xa6\x35\xa6\x7f\xdd\x7d\x88\xd9\x33\x88\xc7\x63\x5f\xbf\x3f\x47\x73\x27\x5d\x67\x3d\x39\x3f\x3d\x5c\xe2\x0c\x26\x7e\x56\x59\x0e\xb7\x47\xc5\xe3\x37\xa7\x5b\x2a\xf9\x2d\xdb\x93\x45\xf1\x6b\x00\x42\xa7\x96\x5e\x33\x02\x00\x00"),
 		},
 		"/warehouse/000009_add_wh_load_file_index_on_table_staging_file.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000009_add_wh_load_file_index_on_table_staging_file.up.sql",
-			modTime:          time.Date(2021, 8, 2, 20, 4, 39, 31518157, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 143,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd2\xd5\x55\x28\xcf\x88\xcf\xc9\x4f\x4c\x89\x4f\xcb\xcc\x49\x2d\x56\xd0\xd5\xe5\xe2\x72\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\xf0\xf3\x0f\x51\x70\x8d\xf0\x0c\x0e\x09\x46\x55\x1c\x5f\x5c\x92\x98\x9e\x99\x97\x0e\xe6\xc5\x67\xa6\xc4\x97\x24\x26\xe5\xa4\xc6\xe7\x25\xe6\xa6\xc6\x67\xe6\xa5\xa4\x56\x28\xf8\xfb\xa1\x19\xaf\x81\xa6\x45\x47\x01\xa1\x47\xd3\x9a\x0b\x10\x00\x00\xff\xff\x88\xa2\x4a\x76\x8f\x00\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd2\xd5\x55\x28\xcf\x88\xcf\xc9\x4f\x4c\x89\x4f\xcb\xcc\x49\x2d\x56\xd0\xd5\xe5\xe2\x72\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\xf0\xf3\x0f\x51\x70\x8d\xf0\x0c\x0e\x09\x46\x55\x1c\x5f\x5c\x92\x98\x9e\x99\x97\x0e\xe6\xc5\x67\xa6\xc4\x97\x24\x26\xe5\xa4\xc6\xe7\x25\xe6\xa6\xc6\x67\xe6\xa5\xa4\x56\x28\xf8\xfb\xa1\x19\xaf\x81\xa6\x45\x47\x01\xa1\x47\xd3\x9a\x0b\x30\x00\x88\xa2\x4a\x76\x8f\x00\x00\x00"),
 		},
 		"/warehouse/000010_add_metadata_to_wh_staging_files.up.sql": &vfsgen۰FileInfo{
 			name:    "000010_add_metadata_to_wh_staging_files.up.sql",
-			modTime: time.Date(2021, 8, 2, 20, 4, 39, 31609605, time.UTC),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			content: []byte("\x0a\x2d\x2d\x0a\x2d\x2d\x20\x77\x68\x5f\x73\x74\x61\x67\x69\x6e\x67\x5f\x46\x69\x6c\x65\x73\x0a\x2d\x2d\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x77\x68\x5f\x73\x74\x61\x67\x69\x6e\x67\x5f\x66\x69\x6c\x65\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x6d\x65\x74\x61\x64\x61\x74\x61\x20\x4a\x53\x4f\x4e\x42\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x27\x7b\x7d\x27\x3b\x0a"),
 		},
 		"/warehouse/000011_add_wh_loadfiles_metadata_column.up.sql": &vfsgen۰FileInfo{
 			name:    "000011_add_wh_loadfiles_metadata_column.up.sql",
-			modTime: time.Date(2021, 8, 23, 19, 23, 27, 648424058, time.UTC),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			content: []byte("\x2d\x2d\x20\x77\x68\x5f\x6c\x6f\x61\x64\x5f\x66\x69\x6c\x65\x73\x20\x2d\x2d\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x77\x68\x5f\x6c\x6f\x61\x64\x5f\x66\x69\x6c\x65\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x6d\x65\x74\x61\x64\x61\x74\x61\x20\x4a\x53\x4f\x4e\x42\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x27\x7b\x7d\x27\x3b\x0a"),
 		},
 		"/warehouse/000012_add_mergedSchema_to_wh_uploads.up.sql": &vfsgen۰FileInfo{
 			name:    "000012_add_mergedSchema_to_wh_uploads.up.sql",
-			modTime: time.Date(2021, 8, 23, 19, 23, 27, 648535094, time.UTC),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			content: []byte("\x0a\x2d\x2d\x0a\x2d\x2d\x20\x77\x68\x5f\x75\x70\x6c\x6f\x61\x64\x73\x0a\x2d\x2d\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x77\x68\x5f\x75\x70\x6c\x6f\x61\x64\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x6d\x65\x72\x67\x65\x64\x73\x63\x68\x65\x6d\x61\x20\x4a\x53\x4f\x4e\x42\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x27\x7b\x7d\x27\x3b\x0a"),
 		},
 		"/warehouse/000013_add_in_progress_to_wh_uploads.up.sql": &vfsgen۰FileInfo{
 			name:    "000013_add_in_progress_to_wh_uploads.up.sql",
-			modTime: time.Date(2021, 10, 29, 10, 50, 52, 397307255, time.UTC),
+			modTime: time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			content: []byte("\x0a\x2d\x2d\x0a\x2d\x2d\x20\x77\x68\x5f\x75\x70\x6c\x6f\x61\x64\x73\x0a\x2d\x2d\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x77\x68\x5f\x75\x70\x6c\x6f\x61\x64\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x69\x6e\x5f\x70\x72\x6f\x67\x72\x65\x73\x73\x20\x42\x4f\x4f\x4c\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x66\x61\x6c\x73\x65\x3b\x0a"),
 		},
 		"/warehouse/000014_add_and_drop_wh_uploads_index_for_in_progress.up.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000014_add_and_drop_wh_uploads_index_for_in_progress.up.sql",
-			modTime:          time.Date(2021, 10, 29, 10, 50, 52, 397439607, time.UTC),
+			modTime:          time.Date(2022, 7, 19, 9, 58, 41, 0, time.UTC),
 			uncompressedSize: 335,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\x8f\x41\xca\x83\x30\x10\x46\xf7\x39\xc5\xec\xfc\x05\x73\x02\x57\x3f\x35\x05\x37\x5a\xd4\x85\xbb\x21\x98\xa1\x0d\xb4\x49\x30\x23\x6d\xc1\xc3\x17\x29\xb4\xda\x55\xbb\x1a\x18\x1e\xef\xe3\x09\x29\x85\x94\x70\x3d\xe1\x14\xce\x5e\x9b\xb8\x3c\xc4\xae\x51\xff\x9d\x82\xb2\x2a\x54\x0f\xe5\x1e\xaa\xba\x03\xd5\x97\x6d\xd7\xae\x48\x34\x14\xd9\x3a\xcd\xd6\x3b\xe4\x7b\x20\xb4\x0e\xc3\xe8\x8f\x23\xc5\x88\x91\x35\x4f\x5b\xc6\x1a\x74\xfa\x42\x31\xe8\x81\x70\xf0\x6e\xd0\x4c\x4e\x33\x19\xb4\xce\xd0\x0d\xea\x6a\x65\x87\xbf\x4f\x7d\x06\x2b\x7f\x06\xcf\x81\x6c\xcb\x59\x03\xf3\x0c\x09\x26\xcb\x79\x8d\xa5\x69\x2e\x44\xd1\xd4\x87\x77\xd1\x17\x35\xbf\x17\xe4\xe2\x11\x00\x00\xff\xff\x14\xb0\xfa\x98\x4f\x01\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\x8f\x41\xca\x83\x30\x10\x46\xf7\x39\xc5\xec\xfc\x05\x73\x02\x57\x3f\x35\x05\x37\x5a\xd4\x85\xbb\x21\x98\xa1\x0d\xb4\x49\x30\x23\x6d\xc1\xc3\x17\x29\xb4\xda\x55\xbb\x1a\x18\x1e\xef\xe3\x09\x29\x85\x94\x70\x3d\xe1\x14\xce\x5e\x9b\xb8\x3c\xc4\xae\x51\xff\x9d\x82\xb2\x2a\x54\x0f\xe5\x1e\xaa\xba\x03\xd5\x97\x6d\xd7\xae\x48\x34\x14\xd9\x3a\xcd\xd6\x3b\xe4\x7b\x20\xb4\x0e\xc3\xe8\x8f\x23\xc5\x88\x91\x35\x4f\x5b\xc6\x1a\x74\xfa\x42\x31\xe8\x81\x70\xf0\x6e\xd0\x4c\x4e\x33\x19\xb4\xce\xd0\x0d\xea\x6a\x65\x87\xbf\x4f\x7d\x06\x2b\x7f\x06\xcf\x81\x6c\xcb\x59\x03\xf3\x0c\x09\x26\xcb\x79\x8d\xa5\x69\x2e\x44\xd1\xd4\x87\x77\xd1\x17\x35\xbf\x17\xe4\xe2\x31\x00\x14\xb0\xfa\x98\x4f\x01\x00\x00"),
+		},
+		"/warehouse/000015_create_wh_backfills.up.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000015_create_wh_backfills.up.sql",
+			modTime:          time.Date(2026, 10, 18, 20, 46, 38, 554308936, time.UTC),
+			uncompressedSize: 690,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\xd2\x41\x6b\xc2\x30\x14\x07\xf0\x7b\x3f\xc5\xbb\xd9\x82\x81\x1d\xc6\x2e\x82\x10\x35\x6e\x61\xb5\x4a\x1b\x87\x9e\x42\xd6\x44\x17\x56\x5b\x49\x5e\xd9\xf6\xed\x87\xd6\x95\x59\xb0\xdb\xb1\xf4\xc7\xfb\x3f\xde\x3f\x84\x04\x84\xc0\xc7\x9b\x7c\x55\xf9\xfb\xce\x16\x85\x0f\x08\x09\x82\x69\xca\xa8\x60\x20\xe8\x24\x66\xc0\xe7\x90\x2c\x05\xb0\x0d\xcf\x44\x76\x65\x21\x0c\x00\x00\xac\x86\x09\x7f\xcc\x58\xca\x69\x0c\xab\x94\x2f\x68\xba\x85\x67\xb6\x1d\x9e\xff\xfa\xaa\x76\xb9\x91\x56\xc3\x0b\x4d\xa7\x4f\x34\x0d\x1f\xee\xa3\xf3\xc4\x64\x1d\xc7\x8d\xd1\xc6\xa3\x2d\x15\xda\xaa\xfc\x3f\xc4\xaf\xa3\xe9\xa1\x1e\x95\x43\x89\xf6\x60\x40\xf0\x05\xcb\x04\x5d\xac\x3a\xc4\x94\xba\x1f\x78\x54\x58\xfb\xfe\x90\xbd\x2d\xf7\x72\x67\x0b\xe3\x65\x5e\xd5\x25\x9e\x6e\xc1\x13\xd1\x42\x98\xb1\x39\x5d\xc7\x02\xee\x2e\xa1\xce\x55\x0e\x04\xdb\x88\xe6\x3b\x77\x46\xa1\xd1\x52\xe1\xcd\x35\xea\xa3\xbe\x4d\xa2\x51\xdb\x17\x4f\x66\x6c\xd3\xd3\x97\x6c\xbb\x90\xd7\x17\x97\xb6\xd4\xe6\x13\x96\x49\xa7\xde\xd6\x0f\x3b\x15\x9d\x42\x9b\x87\x53\x1f\x8b\x4a\x69\x0f\x84\xfc\xb5\xc6\x45\xb6\xf3\xbb\xb9\x3f\x93\xc2\xf0\x60\x50\x69\x85\x8a\x8c\xc7\x83\x5f\x7a\x10\x45\xa3\xe0\x7b\x00\x3a\x6d\x00\x19\xb2\x02\x00\x00"),
 		},
 	}
 	fs["/"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
@@ -364,12 +397,16 @@ var MigrationAssets = func() http.FileSystem {
 		fs["/node/000005_alter_event_schemas_autovacuum.up.sql"].(os.FileInfo),
 		fs["/node/000006_add_archived_to_event_schemas_tables.up.sql"].(os.FileInfo),
 		fs["/node/000006_remove_archived_from_event_schemas_tables.down.sql"].(os.FileInfo),
+		fs["/node/000007_create_regulation_tables.up.sql"].(os.FileInfo),
+		fs["/node/000007_drop_regulation_tables.down.sql"].(os.FileInfo),
 	}
 	fs["/pg_notifier_queue"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
 		fs["/pg_notifier_queue/0000001_pg_notifier_queue_init.down.sql"].(os.FileInfo),
 		fs["/pg_notifier_queue/0000001_pg_notifier_queue_init.up.sql"].(os.FileInfo),
 		fs["/pg_notifier_queue/0000002_pg_notifier_queue_null_topic.down.sql"].(os.FileInfo),
 		fs["/pg_notifier_queue/0000002_pg_notifier_queue_null_topic.up.sql"].(os.FileInfo),
+		fs["/pg_notifier_queue/0000003_pg_notifier_queue_fair_share.down.sql"].(os.FileInfo),
+		fs["/pg_notifier_queue/0000003_pg_notifier_queue_fair_share.up.sql"].(os.FileInfo),
 	}
 	fs["/reports"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
 		fs["/reports/000001_create_reports.down.sql"].(os.FileInfo),
@@ -392,6 +429,7 @@ var MigrationAssets = func() http.FileSystem {
 		fs["/warehouse/000012_add_mergedSchema_to_wh_uploads.up.sql"].(os.FileInfo),
 		fs["/warehouse/000013_add_in_progress_to_wh_uploads.up.sql"].(os.FileInfo),
 		fs["/warehouse/000014_add_and_drop_wh_uploads_index_for_in_progress.up.sql"].(os.FileInfo),
+		fs["/warehouse/000015_create_wh_backfills.up.sql"].(os.FileInfo),
 	}
 
 	return fs
@@ -0,0 +1,22 @@
+---
+--- Users suppressed at gateway & audit log of actions taken while propagating regulations into the pipeline
+---
+
+CREATE TABLE IF NOT EXISTS suppressed_users (
+		id BIGSERIAL PRIMARY KEY,
+		workspace_id VARCHAR(64) NOT NULL,
+		user_id TEXT NOT NULL,
+		regulation_job_id BIGINT NOT NULL,
+		created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (NOW() at time zone 'utc'),
+		UNIQUE (workspace_id, user_id));
+
+CREATE TABLE IF NOT EXISTS regulation_audit_log (
+		id BIGSERIAL PRIMARY KEY,
+		regulation_job_id BIGINT NOT NULL,
+		workspace_id VARCHAR(64) NOT NULL,
+		destination_id VARCHAR(64) NOT NULL,
+		action VARCHAR(64) NOT NULL,
+		details JSONB NOT NULL,
+		created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (NOW() at time zone 'utc'));
+
+CREATE INDEX IF NOT EXISTS regulation_audit_log_regulation_job_id_index ON regulation_audit_log (regulation_job_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS regulation_audit_log;
+DROP TABLE IF EXISTS suppressed_users;
@@ -0,0 +1,9 @@
+---
+--- Operations
+---
+
+DROP INDEX IF EXISTS pg_notifier_queue_status_fair_share_key_idx;
+
+ALTER TABLE pg_notifier_queue DROP COLUMN IF EXISTS weight;
+
+ALTER TABLE pg_notifier_queue DROP COLUMN IF EXISTS fair_share_key;
@@ -0,0 +1,9 @@
+---
+--- Operations
+---
+
+ALTER TABLE pg_notifier_queue ADD COLUMN IF NOT EXISTS fair_share_key VARCHAR(64);
+
+ALTER TABLE pg_notifier_queue ADD COLUMN IF NOT EXISTS weight INT NOT NULL DEFAULT 1;
+
+CREATE INDEX IF NOT EXISTS pg_notifier_queue_status_fair_share_key_idx ON pg_notifier_queue (status, fair_share_key);
@@ -0,0 +1,22 @@
+--
+-- wh_backfills
+--
+
+CREATE TABLE IF NOT EXISTS wh_backfills (
+    id BIGSERIAL PRIMARY KEY,
+    source_id VARCHAR(64) NOT NULL,
+    destination_id VARCHAR(64) NOT NULL,
+    destination_type VARCHAR(64) NOT NULL,
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP NOT NULL,
+    status VARCHAR(64) NOT NULL,
+    staging_files_count BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL);
+
+CREATE INDEX IF NOT EXISTS wh_backfills_source_id_destination_id_index ON wh_backfills (source_id, destination_id);
+
+-- wh_uploads --
+
+CREATE INDEX IF NOT EXISTS wh_uploads_backfill_id_index ON wh_uploads ((metadata->>'backfill_id'));
@@ -22,12 +22,15 @@ const (
 
 var (
 	DiffStatus = "diff"
+	//FilteredStatus is the status of events which are intentionally not sent to destinations
+	FilteredStatus = "filtered"
 
 	//Module names
 	GATEWAY                = "gateway"
 	TRACKINGPLAN_VALIDATOR = "tracking_plan_validator"
 	USER_TRANSFORMER       = "user_transformer"
 	EVENT_FILTER           = "event_filter"
+	CONSENT_FILTER         = "consent_filter"
 	DEST_TRANSFORMER       = "dest_transformer"
 	ROUTER                 = "router"
 	BATCH_ROUTER           = "batch_router"
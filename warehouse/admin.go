@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/rudderlabs/rudder-server/admin"
+	"github.com/rudderlabs/rudder-server/services/pgnotifier"
 	"github.com/rudderlabs/rudder-server/warehouse/manager"
 	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
 )
@@ -102,3 +103,49 @@ func (wh *WarehouseAdmin) QueryWhTables(tableUploadReq TableUploadReqT, reply *[
 	*reply = bytes
 	return nil
 }
+
+func (wh *WarehouseAdmin) QueryWhDiscards(uploadReq UploadReqT, reply *[]byte) error {
+	uploadReq.API = UploadAPI
+	res, err := uploadReq.GetWhUploadDiscards()
+	if err != nil {
+		return err
+	}
+	bytes, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	*reply = bytes
+	return nil
+}
+
+type QueueReqT struct {
+	DestinationType string
+}
+
+type QueueDepthResT struct {
+	SlaveJobs []pgnotifier.QueueDepthT `json:"slave_jobs"`
+	Uploads   []UploadQueueDepthT      `json:"uploads"`
+	Clusters  []ClusterQueueDepthT     `json:"clusters"`
+}
+
+// QueryWhQueue returns depth of the upload queue and the slave job queue on pgnotifier
+func (wh *WarehouseAdmin) QueryWhQueue(queueReq QueueReqT, reply *[]byte) error {
+	var res QueueDepthResT
+	var err error
+	res.Uploads, res.Clusters, err = getUploadQueueDepth(queueReq.DestinationType)
+	if err != nil {
+		return err
+	}
+	if notifier.GetDBHandle() != nil {
+		res.SlaveJobs, err = notifier.GetQueueDepth()
+		if err != nil {
+			return err
+		}
+	}
+	bytes, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	*reply = bytes
+	return nil
+}
@@ -0,0 +1,53 @@
+package warehouse
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+var _ = Describe("Upload admission", func() {
+	newUploadJob := func(id int64, host string) *UploadJobT {
+		return &UploadJobT{
+			upload: &UploadT{ID: id},
+			warehouse: warehouseutils.WarehouseT{
+				Type:        "RS",
+				Destination: backendconfig.DestinationT{ID: host, Config: map[string]interface{}{"host": host}},
+			},
+		}
+	}
+
+	uploadIDs := func(uploadJobs []*UploadJobT) []int64 {
+		var ids []int64
+		for _, uploadJob := range uploadJobs {
+			ids = append(ids, uploadJob.upload.ID)
+		}
+		return ids
+	}
+
+	It("should admit all upload jobs without an admission limit", func() {
+		wh := &HandleT{clusterInProgressMap: map[string]int{"RS:cluster-1": 10}}
+		uploadJobs := []*UploadJobT{newUploadJob(1, "cluster-1"), newUploadJob(2, "cluster-1")}
+		Expect(uploadIDs(wh.admitUploadJobs(uploadJobs))).To(Equal([]int64{1, 2}))
+	})
+
+	It("should admit upload jobs till their cluster reaches the admission limit", func() {
+		wh := &HandleT{maxConcurrentUploadsPerCluster: 2, clusterInProgressMap: map[string]int{"RS:cluster-1": 1}}
+		uploadJobs := []*UploadJobT{
+			newUploadJob(1, "cluster-1"),
+			newUploadJob(2, "cluster-2"),
+			newUploadJob(3, "cluster-1"),
+			newUploadJob(4, "cluster-2"),
+			newUploadJob(5, "cluster-2"),
+		}
+		Expect(uploadIDs(wh.admitUploadJobs(uploadJobs))).To(Equal([]int64{1, 2, 4}))
+	})
+
+	It("should not admit upload jobs of clusters already at the admission limit", func() {
+		wh := &HandleT{maxConcurrentUploadsPerCluster: 1, clusterInProgressMap: map[string]int{"RS:cluster-1": 1}}
+		uploadJobs := []*UploadJobT{newUploadJob(1, "cluster-1"), newUploadJob(2, "cluster-2")}
+		Expect(uploadIDs(wh.admitUploadJobs(uploadJobs))).To(Equal([]int64{2}))
+	})
+})
@@ -2,8 +2,10 @@ package warehouse
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -336,6 +338,62 @@ func (uploadReq UploadReqT) TriggerWHUpload() (response *proto.TriggerWhUploadsR
 	return
 }
 
+// GetWhUploadDiscards lists the columns whose values were written to rudder_discards for an upload
+// along with their counts and sample values
+func (uploadReq UploadReqT) GetWhUploadDiscards() ([]DiscardedColumnT, error) {
+	err := uploadReq.validateReq()
+	if err != nil {
+		return []DiscardedColumnT{}, err
+	}
+	query := uploadReq.generateQuery(`source_id, start_load_file_id, end_load_file_id`)
+	uploadReq.API.log.Debug(query)
+	var sourceID string
+	var startLoadFileID, endLoadFileID sql.NullInt64
+	err = uploadReq.API.dbHandle.QueryRow(query).Scan(&sourceID, &startLoadFileID, &endLoadFileID)
+	if err != nil {
+		uploadReq.API.log.Errorf(err.Error())
+		return []DiscardedColumnT{}, err
+	}
+	if !uploadReq.authorizeSource(sourceID) {
+		pkgLogger.Errorf(`Unauthorized request for upload:%d with sourceId:%s in workspaceId:%s`, uploadReq.UploadId, sourceID, uploadReq.WorkspaceID)
+		return []DiscardedColumnT{}, errors.New("Unauthorized request")
+	}
+	// load files are not generated yet
+	if startLoadFileID.Int64 == 0 || endLoadFileID.Int64 == 0 {
+		return []DiscardedColumnT{}, nil
+	}
+
+	query = fmt.Sprintf(`SELECT metadata->'discarded_columns' FROM %s WHERE id >= $1 AND id <= $2 AND lower(table_name) = $3 AND metadata ? 'discarded_columns'`, warehouseutils.WarehouseLoadFilesTable)
+	uploadReq.API.log.Debug(query)
+	rows, err := uploadReq.API.dbHandle.Query(query, startLoadFileID.Int64, endLoadFileID.Int64, warehouseutils.DiscardsTable)
+	if err != nil {
+		uploadReq.API.log.Errorf(err.Error())
+		return []DiscardedColumnT{}, err
+	}
+	defer rows.Close()
+	var discardedColumnsList [][]DiscardedColumnT
+	for rows.Next() {
+		var rawDiscardedColumns json.RawMessage
+		err = rows.Scan(&rawDiscardedColumns)
+		if err != nil {
+			uploadReq.API.log.Errorf(err.Error())
+			return []DiscardedColumnT{}, err
+		}
+		var discardedColumns []DiscardedColumnT
+		err = json.Unmarshal(rawDiscardedColumns, &discardedColumns)
+		if err != nil {
+			uploadReq.API.log.Errorf(err.Error())
+			return []DiscardedColumnT{}, err
+		}
+		discardedColumnsList = append(discardedColumnsList, discardedColumns)
+	}
+	discardedColumns := mergeDiscardedColumns(discardedColumnsList)
+	sort.Slice(discardedColumns, func(i, j int) bool {
+		return discardedColumns[i].Count > discardedColumns[j].Count
+	})
+	return discardedColumns, nil
+}
+
 func (tableUploadReq TableUploadReqT) GetWhTableUploads() ([]*proto.WHTable, error) {
 	err := tableUploadReq.validateReq()
 	if err != nil {
@@ -168,7 +168,7 @@ func archiveUploads(dbHandle *sql.DB) {
 		hasUsedRudderStorage := usedRudderStorage(u.uploadMetdata)
 
 		// archive staging files
-		stmt := fmt.Sprintf(`SELECT id, location FROM %s WHERE source_id='%s' AND destination_id='%s' AND id >= %d and id <= %d`, warehouseutils.WarehouseStagingFilesTable, u.sourceID, u.destID, u.startStagingFileId, u.endStagingFileId)
+		stmt := fmt.Sprintf(`SELECT id, location FROM %s WHERE source_id='%s' AND destination_id='%s' AND id >= %d and id <= %d AND %s`, warehouseutils.WarehouseStagingFilesTable, u.sourceID, u.destID, u.startStagingFileId, u.endStagingFileId, backfillFilterSQL(gjson.GetBytes(u.uploadMetdata, "backfill_id").Int()))
 
 		stagingFileRows, err := txn.Query(stmt)
 		if err != nil {
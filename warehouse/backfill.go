@@ -0,0 +1,641 @@
+package warehouse
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iancoleman/strcase"
+	"github.com/rudderlabs/rudder-server/config"
+	"github.com/rudderlabs/rudder-server/rruntime"
+	"github.com/rudderlabs/rudder-server/services/archiver"
+	"github.com/rudderlabs/rudder-server/services/filemanager"
+	"github.com/rudderlabs/rudder-server/utils/misc"
+	"github.com/rudderlabs/rudder-server/utils/timeutil"
+	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// backfill statuses
+const (
+	BackfillRestoring  = "restoring"
+	BackfillInProgress = "in_progress"
+	BackfillSucceeded  = "succeeded"
+	BackfillFailed     = "failed"
+)
+
+// BackfillReqT requests re-syncing the staging files of a source and destination created in [StartTime, EndTime]
+type BackfillReqT struct {
+	SourceID      string    `json:"source_id"`
+	DestinationID string    `json:"destination_id"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+}
+
+type BackfillT struct {
+	ID                int64            `json:"id"`
+	SourceID          string           `json:"source_id"`
+	DestinationID     string           `json:"destination_id"`
+	DestinationType   string           `json:"destination_type"`
+	StartTime         time.Time        `json:"start_time"`
+	EndTime           time.Time        `json:"end_time"`
+	Status            string           `json:"status"`
+	StagingFilesCount int64            `json:"staging_files_count"`
+	Uploads           map[string]int64 `json:"uploads"` // upload status -> count
+	Error             string           `json:"error,omitempty"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+}
+
+type backfillStagingFileT struct {
+	ID           int64
+	Location     string
+	Schema       json.RawMessage
+	TotalEvents  int64
+	FirstEventAt sql.NullTime
+	LastEventAt  sql.NullTime
+	CreatedAt    time.Time
+	Metadata     json.RawMessage
+}
+
+// archivedStagingFileT is a wh_staging_files record as backed up by the archiver
+type archivedStagingFileT struct {
+	ID           int64           `json:"id"`
+	Location     string          `json:"location"`
+	Schema       json.RawMessage `json:"schema"`
+	TotalEvents  int64           `json:"total_events"`
+	FirstEventAt string          `json:"first_event_at"`
+	LastEventAt  string          `json:"last_event_at"`
+	CreatedAt    string          `json:"created_at"`
+	Metadata     json.RawMessage `json:"metadata"`
+}
+
+// notBackfillSQL filters out uploads and staging files created by backfills from live syncs
+const notBackfillSQL = `(metadata->>'backfill_id') IS NULL`
+
+func backfillFilterSQL(backfillID int64) string {
+	if backfillID == 0 {
+		return notBackfillSQL
+	}
+	return fmt.Sprintf(`(metadata->>'backfill_id') = '%d'`, backfillID)
+}
+
+func (req BackfillReqT) validate() error {
+	if req.SourceID == "" || req.DestinationID == "" {
+		return errors.New("source_id and destination_id are required")
+	}
+	if req.StartTime.IsZero() || req.EndTime.IsZero() {
+		return errors.New("start_time and end_time are required")
+	}
+	if !req.EndTime.After(req.StartTime) {
+		return errors.New("end_time should be after start_time")
+	}
+	return nil
+}
+
+// CreateBackfill records a backfill and asynchronously re-enqueues the staging files in the requested time range
+// from wh_staging_files and the archived staging file backups into new uploads
+func CreateBackfill(req BackfillReqT) (backfillID int64, err error) {
+	err = req.validate()
+	if err != nil {
+		return
+	}
+
+	connectionsMapLock.RLock()
+	warehouse, ok := connectionsMap[req.DestinationID][req.SourceID]
+	connectionsMapLock.RUnlock()
+	if !ok {
+		err = fmt.Errorf("No warehouse destination found for source id '%s' and destination id '%s'", req.SourceID, req.DestinationID)
+		return
+	}
+
+	now := timeutil.Now()
+	sqlStatement := fmt.Sprintf(`INSERT INTO %s (source_id, destination_id, destination_type, start_time, end_time, status, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $7) RETURNING id`, warehouseutils.WarehouseBackfillsTable)
+	err = dbHandle.QueryRow(sqlStatement, req.SourceID, req.DestinationID, warehouse.Type, req.StartTime.UTC(), req.EndTime.UTC(), BackfillRestoring, now).Scan(&backfillID)
+	if err != nil {
+		return
+	}
+	pkgLogger.Infof("[WH]: Created backfill:%d for %s from %v to %v", backfillID, warehouse.Identifier, req.StartTime, req.EndTime)
+
+	rruntime.Go(func() {
+		runBackfill(backfillID, warehouse, req)
+	})
+	return
+}
+
+func runBackfill(backfillID int64, warehouse warehouseutils.WarehouseT, req BackfillReqT) {
+	stagingFiles, err := getStagingFilesForBackfill(warehouse, req.StartTime, req.EndTime)
+	if err == nil {
+		var archivedStagingFiles []*backfillStagingFileT
+		archivedStagingFiles, err = getArchivedStagingFilesForBackfill(warehouse, req.StartTime, req.EndTime)
+		stagingFiles = append(stagingFiles, archivedStagingFiles...)
+	}
+	if err == nil {
+		err = restoreStagingFilesForBackfill(backfillID, warehouse, dedupBackfillStagingFiles(stagingFiles))
+	}
+	if err != nil {
+		pkgLogger.Errorf("[WH]: Failed to run backfill:%d for %s: %v", backfillID, warehouse.Identifier, err)
+		setBackfillStatus(backfillID, BackfillFailed, err)
+	}
+}
+
+func setBackfillStatus(backfillID int64, status string, statusErr error) {
+	var errStr sql.NullString
+	if statusErr != nil {
+		errStr = sql.NullString{String: statusErr.Error(), Valid: true}
+	}
+	sqlStatement := fmt.Sprintf(`UPDATE %s SET status=$1, error=$2, updated_at=$3 WHERE id=$4`, warehouseutils.WarehouseBackfillsTable)
+	_, err := dbHandle.Exec(sqlStatement, status, errStr, timeutil.Now(), backfillID)
+	if err != nil {
+		pkgLogger.Errorf("[WH]: Failed to set status %s for backfill:%d: %v", status, backfillID, err)
+	}
+}
+
+func getStagingFilesForBackfill(warehouse warehouseutils.WarehouseT, startTime, endTime time.Time) ([]*backfillStagingFileT, error) {
+	sqlStatement := fmt.Sprintf(`SELECT id, location, schema, COALESCE(total_events, 0), first_event_at, last_event_at, created_at, COALESCE(metadata, '{}'::jsonb)
+								FROM %[1]s
+								WHERE source_id=$1 AND destination_id=$2 AND created_at >= $3 AND created_at <= $4 AND %[2]s
+								ORDER BY id ASC`,
+		warehouseutils.WarehouseStagingFilesTable, notBackfillSQL)
+	rows, err := dbHandle.Query(sqlStatement, warehouse.Source.ID, warehouse.Destination.ID, startTime.UTC(), endTime.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("Query: %s failed with Error : %w", sqlStatement, err)
+	}
+	defer rows.Close()
+
+	var stagingFiles []*backfillStagingFileT
+	for rows.Next() {
+		var stagingFile backfillStagingFileT
+		err = rows.Scan(&stagingFile.ID, &stagingFile.Location, &stagingFile.Schema, &stagingFile.TotalEvents, &stagingFile.FirstEventAt, &stagingFile.LastEventAt, &stagingFile.CreatedAt, &stagingFile.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan result from query: %s\nwith Error : %w", sqlStatement, err)
+		}
+		stagingFiles = append(stagingFiles, &stagingFile)
+	}
+	return stagingFiles, rows.Err()
+}
+
+// getArchivedStagingFilesForBackfill reads the staging file records backed up by the archiver for the source and destination
+// Staging files stored in rudder storage are skipped as they are deleted on archival
+func getArchivedStagingFilesForBackfill(warehouse warehouseutils.WarehouseT, startTime, endTime time.Time) ([]*backfillStagingFileT, error) {
+	if !archiver.IsArchiverObjectStorageConfigured() {
+		return nil, nil
+	}
+	fManager, err := filemanager.New(&filemanager.SettingsT{
+		Provider: config.GetEnv("JOBS_BACKUP_STORAGE_PROVIDER", "S3"),
+		Config:   filemanager.GetProviderConfigFromEnv(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error in creating a file manager for:%s. Error: %w", config.GetEnv("JOBS_BACKUP_STORAGE_PROVIDER", "S3"), err)
+	}
+
+	prefix := fmt.Sprintf(`%s.%s.%s.`, strcase.ToKebab(warehouseutils.WarehouseStagingFilesTable), warehouse.Source.ID, warehouse.Destination.ID)
+	if configuredPrefix := fManager.GetConfiguredPrefix(); configuredPrefix != "" {
+		prefix = strings.TrimSuffix(configuredPrefix, "/") + "/" + prefix
+	}
+	keys, err := listFileKeysWithPrefix(fManager, prefix, int64(backfillArchiveListMaxItems))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list archived staging files with prefix %s: %w", prefix, err)
+	}
+
+	tmpDirPath, err := misc.CreateTMPDIR()
+	if err != nil {
+		return nil, err
+	}
+	// download archives into a directory of this backfill, as the tmp dir is shared
+	tmpDirPath, err = os.MkdirTemp(tmpDirPath, "backfill-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDirPath)
+
+	var stagingFiles []*backfillStagingFileT
+	for _, key := range keys {
+		filePath := filepath.Join(tmpDirPath, misc.RudderArchives, filepath.Base(key))
+		archivedStagingFiles, err := readArchivedStagingFiles(fManager, key, filePath)
+		if err != nil {
+			return nil, err
+		}
+		for _, archivedStagingFile := range archivedStagingFiles {
+			createdAt, err := parseArchivedTime(archivedStagingFile.CreatedAt)
+			if err != nil || createdAt.Before(startTime) || createdAt.After(endTime) {
+				continue
+			}
+			// skip staging files restored by earlier backfills and staging files deleted from rudder storage
+			if gjson.GetBytes(archivedStagingFile.Metadata, "backfill_id").Exists() || usedRudderStorage(archivedStagingFile.Metadata) {
+				continue
+			}
+			stagingFile := backfillStagingFileT{
+				ID:          archivedStagingFile.ID,
+				Location:    archivedStagingFile.Location,
+				Schema:      archivedStagingFile.Schema,
+				TotalEvents: archivedStagingFile.TotalEvents,
+				CreatedAt:   createdAt,
+				Metadata:    archivedStagingFile.Metadata,
+			}
+			if firstEventAt, err := parseArchivedTime(archivedStagingFile.FirstEventAt); err == nil {
+				stagingFile.FirstEventAt = sql.NullTime{Time: firstEventAt, Valid: true}
+			}
+			if lastEventAt, err := parseArchivedTime(archivedStagingFile.LastEventAt); err == nil {
+				stagingFile.LastEventAt = sql.NullTime{Time: lastEventAt, Valid: true}
+			}
+			stagingFiles = append(stagingFiles, &stagingFile)
+		}
+	}
+	return stagingFiles, nil
+}
+
+// listFileKeysWithPrefix lists the keys of all the files with the prefix, as file managers list a page of them per call
+// Listing stops at the first page shorter than maxItems, or without new keys, as some file managers return the same page on every call
+func listFileKeysWithPrefix(fManager filemanager.FileManager, prefix string, maxItems int64) ([]string, error) {
+	var keys []string
+	listedKeys := map[string]bool{}
+	for {
+		fileObjects, err := fManager.ListFilesWithPrefix(prefix, maxItems)
+		if err != nil {
+			return nil, err
+		}
+		newKeys := 0
+		for _, fileObject := range fileObjects {
+			if listedKeys[fileObject.Key] {
+				continue
+			}
+			listedKeys[fileObject.Key] = true
+			keys = append(keys, fileObject.Key)
+			newKeys++
+		}
+		if int64(len(fileObjects)) < maxItems || newKeys == 0 {
+			return keys, nil
+		}
+	}
+}
+
+func readArchivedStagingFiles(fManager filemanager.FileManager, key, filePath string) ([]*archivedStagingFileT, error) {
+	err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer misc.RemoveFilePaths(filePath)
+	defer file.Close()
+
+	err = fManager.Download(file, key)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to download archived staging files %s: %w", key, err)
+	}
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+	gzReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read archived staging files %s: %w", key, err)
+	}
+	defer gzReader.Close()
+
+	var archivedStagingFiles []*archivedStagingFileT
+	scanner := bufio.NewScanner(gzReader)
+	maxCapacity := maxStagingFileReadBufferCapacityInK * 1024
+	scanner.Buffer(make([]byte, maxCapacity), maxCapacity)
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(scanner.Text()), ","))
+		if line == "" {
+			continue
+		}
+		var archivedStagingFile archivedStagingFileT
+		err = json.Unmarshal([]byte(line), &archivedStagingFile)
+		if err != nil {
+			pkgLogger.Errorf("[WH]: Failed to unmarshal archived staging file record in %s: %v", key, err)
+			continue
+		}
+		archivedStagingFiles = append(archivedStagingFiles, &archivedStagingFile)
+	}
+	return archivedStagingFiles, scanner.Err()
+}
+
+// parseArchivedTime parses timestamps as serialised by postgres json_agg
+func parseArchivedTime(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02T15:04:05.999999", time.RFC3339Nano} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Failed to parse time: %q", value)
+}
+
+func dedupBackfillStagingFiles(stagingFiles []*backfillStagingFileT) []*backfillStagingFileT {
+	sort.SliceStable(stagingFiles, func(i, j int) bool {
+		return stagingFiles[i].CreatedAt.Before(stagingFiles[j].CreatedAt)
+	})
+	seenLocations := map[string]bool{}
+	var dedupedStagingFiles []*backfillStagingFileT
+	for _, stagingFile := range stagingFiles {
+		if seenLocations[stagingFile.Location] {
+			continue
+		}
+		seenLocations[stagingFile.Location] = true
+		dedupedStagingFiles = append(dedupedStagingFiles, stagingFile)
+	}
+	return dedupedStagingFiles
+}
+
+// restoreStagingFilesForBackfill inserts copies of the staging files tagged with the backfill id and creates uploads for them in a single txn
+// Tagged staging files and uploads are skipped by live syncs
+func restoreStagingFilesForBackfill(backfillID int64, warehouse warehouseutils.WarehouseT, stagingFiles []*backfillStagingFileT) (err error) {
+	txn, err := dbHandle.Begin()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			txn.Rollback()
+		}
+	}()
+
+	sqlStatement := fmt.Sprintf(`INSERT INTO %s (location, schema, source_id, destination_id, status, total_events, first_event_at, last_event_at, created_at, updated_at, metadata)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9, $10) RETURNING id`, warehouseutils.WarehouseStagingFilesTable)
+	stmt, err := txn.Prepare(sqlStatement)
+	if err != nil {
+		return
+	}
+	defer stmt.Close()
+
+	var restoredStagingFiles []*StagingFileT
+	for _, stagingFile := range stagingFiles {
+		metadata := stagingFile.Metadata
+		if len(metadata) == 0 || string(metadata) == "null" {
+			metadata = json.RawMessage(`{}`)
+		}
+		metadata, err = sjson.SetBytes(metadata, "backfill_id", backfillID)
+		if err != nil {
+			return
+		}
+		metadata, err = sjson.SetBytes(metadata, "backfilled_staging_file_id", stagingFile.ID)
+		if err != nil {
+			return
+		}
+
+		var restoredStagingFile StagingFileT
+		err = stmt.QueryRow(stagingFile.Location, stagingFile.Schema, warehouse.Source.ID, warehouse.Destination.ID, warehouseutils.StagingFileWaitingState, stagingFile.TotalEvents, stagingFile.FirstEventAt, stagingFile.LastEventAt, timeutil.Now(), metadata).Scan(&restoredStagingFile.ID)
+		if err != nil {
+			return
+		}
+		restoredStagingFile.FirstEventAt = stagingFile.FirstEventAt.Time
+		restoredStagingFile.LastEventAt = stagingFile.LastEventAt.Time
+		restoredStagingFile.UseRudderStorage = usedRudderStorage(metadata)
+		restoredStagingFiles = append(restoredStagingFiles, &restoredStagingFile)
+	}
+
+	for _, stagingFilesInUpload := range planBackfillUploads(restoredStagingFiles) {
+		err = insertBackfillUpload(txn, backfillID, warehouse, stagingFilesInUpload)
+		if err != nil {
+			return
+		}
+	}
+
+	status := BackfillInProgress
+	if len(restoredStagingFiles) == 0 {
+		status = BackfillSucceeded
+	}
+	sqlStatement = fmt.Sprintf(`UPDATE %s SET status=$1, staging_files_count=$2, updated_at=$3 WHERE id=$4`, warehouseutils.WarehouseBackfillsTable)
+	_, err = txn.Exec(sqlStatement, status, len(restoredStagingFiles), timeutil.Now(), backfillID)
+	if err != nil {
+		return
+	}
+	err = txn.Commit()
+	if err != nil {
+		return
+	}
+	pkgLogger.Infof("[WH]: Restored %d staging files for backfill:%d of %s", len(restoredStagingFiles), backfillID, warehouse.Identifier)
+	return
+}
+
+// planBackfillUploads splits the staging files into uploads of at most stagingFilesBatchSize files,
+// starting a new upload whenever the files switch between rudder storage and the destination bucket
+func planBackfillUploads(stagingFiles []*StagingFileT) [][]*StagingFileT {
+	var uploads [][]*StagingFileT
+	var stagingFilesInUpload []*StagingFileT
+	for idx, stagingFile := range stagingFiles {
+		if len(stagingFilesInUpload) > 0 && stagingFile.UseRudderStorage != stagingFiles[idx-1].UseRudderStorage {
+			uploads = append(uploads, stagingFilesInUpload)
+			stagingFilesInUpload = []*StagingFileT{}
+		}
+		stagingFilesInUpload = append(stagingFilesInUpload, stagingFile)
+		if len(stagingFilesInUpload) == stagingFilesBatchSize || idx == len(stagingFiles)-1 {
+			uploads = append(uploads, stagingFilesInUpload)
+			stagingFilesInUpload = []*StagingFileT{}
+		}
+	}
+	return uploads
+}
+
+func insertBackfillUpload(txn *sql.Tx, backfillID int64, warehouse warehouseutils.WarehouseT, stagingFiles []*StagingFileT) error {
+	sqlStatement := fmt.Sprintf(`INSERT INTO %s (source_id, namespace, destination_id, destination_type, start_staging_file_id, end_staging_file_id, start_load_file_id, end_load_file_id, status, schema, error, metadata, first_event_at, last_event_at, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6 ,$7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`, warehouseutils.WarehouseUploadsTable)
+
+	startStagingFile := stagingFiles[0]
+	endStagingFile := stagingFiles[len(stagingFiles)-1]
+	metadata, err := json.Marshal(map[string]interface{}{
+		"use_rudder_storage": startStagingFile.UseRudderStorage,
+		"load_file_type":     getLoadFileType(warehouse.Type),
+		"priority":           backfillUploadPriority,
+		"backfill_id":        backfillID,
+	})
+	if err != nil {
+		return err
+	}
+	var firstEventAt, lastEventAt time.Time
+	if !startStagingFile.FirstEventAt.IsZero() {
+		firstEventAt = startStagingFile.FirstEventAt
+	}
+	if !endStagingFile.LastEventAt.IsZero() {
+		lastEventAt = endStagingFile.LastEventAt
+	}
+	now := timeutil.Now()
+	_, err = txn.Exec(sqlStatement, warehouse.Source.ID, warehouse.Namespace, warehouse.Destination.ID, warehouse.Type, startStagingFile.ID, endStagingFile.ID, 0, 0, Waiting, "{}", "{}", metadata, firstEventAt, lastEventAt, now, now)
+	return err
+}
+
+// GetBackfill returns the backfill along with counts of its uploads by status
+func GetBackfill(backfillID int64) (backfill BackfillT, err error) {
+	var backfillErr sql.NullString
+	sqlStatement := fmt.Sprintf(`SELECT id, source_id, destination_id, destination_type, start_time, end_time, status, staging_files_count, error, created_at, updated_at FROM %s WHERE id=$1`, warehouseutils.WarehouseBackfillsTable)
+	err = dbHandle.QueryRow(sqlStatement, backfillID).Scan(&backfill.ID, &backfill.SourceID, &backfill.DestinationID, &backfill.DestinationType, &backfill.StartTime, &backfill.EndTime, &backfill.Status, &backfill.StagingFilesCount, &backfillErr, &backfill.CreatedAt, &backfill.UpdatedAt)
+	if err != nil {
+		return
+	}
+	backfill.Error = backfillErr.String
+
+	backfill.Uploads, err = getBackfillUploadCounts(backfillID)
+	if err != nil {
+		return
+	}
+	// status is persisted by the backfill status tracker, the one derived from the uploads is returned till then
+	backfill.Status = backfillStatusOf(backfill.Status, backfill.Uploads)
+	return
+}
+
+// getBackfillUploadCounts returns the number of uploads of the backfill by their status
+func getBackfillUploadCounts(backfillID int64) (map[string]int64, error) {
+	sqlStatement := fmt.Sprintf(`SELECT status, COUNT(*) FROM %s WHERE %s GROUP BY status`, warehouseutils.WarehouseUploadsTable, backfillFilterSQL(backfillID))
+	rows, err := dbHandle.Query(sqlStatement)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	uploads := map[string]int64{}
+	for rows.Next() {
+		var status string
+		var count int64
+		err = rows.Scan(&status, &count)
+		if err != nil {
+			return nil, err
+		}
+		uploads[status] = count
+	}
+	return uploads, rows.Err()
+}
+
+// backfillStatusOf derives the status of in progress backfills from the statuses of their uploads
+// Backfills succeed once all their uploads are exported, and fail if any of them is aborted
+func backfillStatusOf(status string, uploads map[string]int64) string {
+	if status != BackfillInProgress {
+		return status
+	}
+	var totalUploads, exportedUploads, abortedUploads int64
+	for uploadStatus, count := range uploads {
+		totalUploads += count
+		switch uploadStatus {
+		case ExportedData:
+			exportedUploads += count
+		case Aborted:
+			abortedUploads += count
+		}
+	}
+	if totalUploads == 0 || exportedUploads+abortedUploads != totalUploads {
+		return status
+	}
+	if abortedUploads > 0 {
+		return BackfillFailed
+	}
+	return BackfillSucceeded
+}
+
+// runBackfillStatusTracker periodically persists the status of in progress backfills whose uploads are done
+func runBackfillStatusTracker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backfillStatusTrackFrequency):
+		}
+		updateBackfillStatuses()
+	}
+}
+
+func updateBackfillStatuses() {
+	sqlStatement := fmt.Sprintf(`SELECT id FROM %s WHERE status=$1`, warehouseutils.WarehouseBackfillsTable)
+	rows, err := dbHandle.Query(sqlStatement, BackfillInProgress)
+	if err != nil {
+		pkgLogger.Errorf("[WH]: Failed to query in progress backfills: %v", err)
+		return
+	}
+	var backfillIDs []int64
+	for rows.Next() {
+		var backfillID int64
+		err = rows.Scan(&backfillID)
+		if err != nil {
+			pkgLogger.Errorf("[WH]: Failed to scan in progress backfills: %v", err)
+			rows.Close()
+			return
+		}
+		backfillIDs = append(backfillIDs, backfillID)
+	}
+	rows.Close()
+
+	for _, backfillID := range backfillIDs {
+		uploads, err := getBackfillUploadCounts(backfillID)
+		if err != nil {
+			pkgLogger.Errorf("[WH]: Failed to count uploads of backfill:%d: %v", backfillID, err)
+			continue
+		}
+		if status := backfillStatusOf(BackfillInProgress, uploads); status != BackfillInProgress {
+			pkgLogger.Infof("[WH]: Backfill:%d %s", backfillID, status)
+			setBackfillStatus(backfillID, status, nil)
+		}
+	}
+}
+
+// backfillHandler creates a backfill on POST and returns the progress of a backfill on GET with id query param
+func backfillHandler(w http.ResponseWriter, r *http.Request) {
+	pkgLogger.LogRequest(r)
+
+	switch r.Method {
+	case http.MethodPost:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			pkgLogger.Errorf("[WH]: Error reading body: %v", err)
+			http.Error(w, "can't read body", http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		var backfillReq BackfillReqT
+		err = json.Unmarshal(body, &backfillReq)
+		if err != nil {
+			pkgLogger.Errorf("[WH]: Error unmarshalling body: %v", err)
+			http.Error(w, "can't unmarshall body", http.StatusBadRequest)
+			return
+		}
+
+		backfillID, err := CreateBackfill(backfillReq)
+		if err != nil {
+			pkgLogger.Errorf("[WH]: Error creating backfill: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resBody, _ := json.Marshal(map[string]int64{"id": backfillID})
+		w.Write(resBody)
+	case http.MethodGet:
+		backfillID, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid backfill id", http.StatusBadRequest)
+			return
+		}
+		backfill, err := GetBackfill(backfillID)
+		if err == sql.ErrNoRows {
+			http.Error(w, "backfill not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			pkgLogger.Errorf("[WH]: Error getting backfill:%d : %v", backfillID, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resBody, err := json.Marshal(backfill)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write(resBody)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,151 @@
+package warehouse
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mock_filemanager "github.com/rudderlabs/rudder-server/mocks/services/filemanager"
+	"github.com/rudderlabs/rudder-server/services/filemanager"
+)
+
+var _ = Describe("Backfill", func() {
+	Describe("listFileKeysWithPrefix", func() {
+		var (
+			ctrl     *gomock.Controller
+			fManager *mock_filemanager.MockFileManager
+		)
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			fManager = mock_filemanager.NewMockFileManager(ctrl)
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		fileObjects := func(keys ...string) []*filemanager.FileObject {
+			var objects []*filemanager.FileObject
+			for _, key := range keys {
+				objects = append(objects, &filemanager.FileObject{Key: key, LastModified: time.Now()})
+			}
+			return objects
+		}
+
+		It("should list all the pages", func() {
+			gomock.InOrder(
+				fManager.EXPECT().ListFilesWithPrefix("wh-staging-files.", int64(2)).Return(fileObjects("a", "b"), nil),
+				fManager.EXPECT().ListFilesWithPrefix("wh-staging-files.", int64(2)).Return(fileObjects("c", "d"), nil),
+				fManager.EXPECT().ListFilesWithPrefix("wh-staging-files.", int64(2)).Return(fileObjects("e"), nil),
+			)
+			keys, err := listFileKeysWithPrefix(fManager, "wh-staging-files.", 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal([]string{"a", "b", "c", "d", "e"}))
+		})
+
+		It("should stop at an empty page", func() {
+			gomock.InOrder(
+				fManager.EXPECT().ListFilesWithPrefix("wh-staging-files.", int64(2)).Return(fileObjects("a", "b"), nil),
+				fManager.EXPECT().ListFilesWithPrefix("wh-staging-files.", int64(2)).Return(fileObjects(), nil),
+			)
+			keys, err := listFileKeysWithPrefix(fManager, "wh-staging-files.", 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal([]string{"a", "b"}))
+		})
+
+		It("should stop when file managers return the same page again", func() {
+			fManager.EXPECT().ListFilesWithPrefix("wh-staging-files.", int64(2)).Return(fileObjects("a", "b"), nil).Times(2)
+			keys, err := listFileKeysWithPrefix(fManager, "wh-staging-files.", 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal([]string{"a", "b"}))
+		})
+
+		It("should return listing errors", func() {
+			gomock.InOrder(
+				fManager.EXPECT().ListFilesWithPrefix("wh-staging-files.", int64(2)).Return(fileObjects("a", "b"), nil),
+				fManager.EXPECT().ListFilesWithPrefix("wh-staging-files.", int64(2)).Return(nil, errors.New("access denied")),
+			)
+			_, err := listFileKeysWithPrefix(fManager, "wh-staging-files.", 2)
+			Expect(err).To(MatchError("access denied"))
+		})
+	})
+
+	Describe("dedupBackfillStagingFiles", func() {
+		It("should keep the earliest staging file of each location", func() {
+			now := time.Now()
+			stagingFiles := dedupBackfillStagingFiles([]*backfillStagingFileT{
+				{ID: 3, Location: "b", CreatedAt: now.Add(time.Minute)},
+				{ID: 1, Location: "a", CreatedAt: now},
+				{ID: 2, Location: "a", CreatedAt: now.Add(2 * time.Minute)},
+			})
+			Expect(stagingFiles).To(HaveLen(2))
+			Expect(stagingFiles[0].ID).To(Equal(int64(1)))
+			Expect(stagingFiles[1].ID).To(Equal(int64(3)))
+		})
+	})
+
+	Describe("planBackfillUploads", func() {
+		var batchSize int
+
+		BeforeEach(func() {
+			batchSize = stagingFilesBatchSize
+			stagingFilesBatchSize = 2
+		})
+
+		AfterEach(func() {
+			stagingFilesBatchSize = batchSize
+		})
+
+		uploadIDs := func(uploads [][]*StagingFileT) [][]int64 {
+			var ids [][]int64
+			for _, upload := range uploads {
+				var uploadIDs []int64
+				for _, stagingFile := range upload {
+					uploadIDs = append(uploadIDs, stagingFile.ID)
+				}
+				ids = append(ids, uploadIDs)
+			}
+			return ids
+		}
+
+		It("should split staging files in batches", func() {
+			uploads := planBackfillUploads([]*StagingFileT{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}})
+			Expect(uploadIDs(uploads)).To(Equal([][]int64{{1, 2}, {3, 4}, {5}}))
+		})
+
+		It("should not mix staging files in rudder storage with others in uploads", func() {
+			uploads := planBackfillUploads([]*StagingFileT{{ID: 1}, {ID: 2, UseRudderStorage: true}, {ID: 3, UseRudderStorage: true}, {ID: 4, UseRudderStorage: true}, {ID: 5}})
+			Expect(uploadIDs(uploads)).To(Equal([][]int64{{1}, {2, 3}, {4}, {5}}))
+		})
+
+		It("should plan no uploads without staging files", func() {
+			Expect(planBackfillUploads(nil)).To(BeEmpty())
+		})
+	})
+
+	Describe("backfillStatusOf", func() {
+		It("should keep backfills in progress till all their uploads are done", func() {
+			Expect(backfillStatusOf(BackfillInProgress, map[string]int64{})).To(Equal(BackfillInProgress))
+			Expect(backfillStatusOf(BackfillInProgress, map[string]int64{ExportedData: 2, Waiting: 1})).To(Equal(BackfillInProgress))
+			Expect(backfillStatusOf(BackfillInProgress, map[string]int64{Aborted: 1, getFailedState(ExportedData): 1})).To(Equal(BackfillInProgress))
+		})
+
+		It("should succeed backfills once all their uploads are exported", func() {
+			Expect(backfillStatusOf(BackfillInProgress, map[string]int64{ExportedData: 3})).To(Equal(BackfillSucceeded))
+		})
+
+		It("should fail backfills with aborted uploads", func() {
+			Expect(backfillStatusOf(BackfillInProgress, map[string]int64{ExportedData: 2, Aborted: 1})).To(Equal(BackfillFailed))
+		})
+
+		It("should not change backfills which aren't in progress", func() {
+			for _, status := range []string{BackfillRestoring, BackfillSucceeded, BackfillFailed} {
+				Expect(backfillStatusOf(status, map[string]int64{Aborted: 1})).To(Equal(status))
+			}
+		})
+	})
+})
@@ -0,0 +1,199 @@
+package warehouse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxCronIterations bounds the search for the previous scheduled time
+// skipping by months, days and hours this is enough to cover several years of schedule
+const maxCronIterations = 100000
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	cronSchedulesCache     = map[string]*CronScheduleT{}
+	cronSchedulesCacheLock sync.RWMutex
+)
+
+// CronScheduleT is a parsed cron expression with standard five fields (minute hour day-of-month month day-of-week)
+type CronScheduleT struct {
+	minutes     []bool
+	hours       []bool
+	daysOfMonth []bool
+	months      []bool
+	daysOfWeek  []bool
+	// anyDayOfMonth and anyDayOfWeek are set if the field is *
+	// if both fields are restricted, a day matches if either of them match
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+	location      *time.Location
+}
+
+// ParseCronExpression parses a cron expression to be evaluated in the given IANA timezone, defaults to UTC
+// eg. `0 */3 * * 1-5` with timezone `America/New_York` runs every 3 hours on weekdays in New York time
+func ParseCronExpression(expression, timezone string) (*CronScheduleT, error) {
+	location := time.UTC
+	if timezone != "" {
+		var err error
+		location, err = time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+		}
+	}
+
+	expression = strings.TrimSpace(expression)
+	if macro, ok := cronMacros[strings.ToLower(expression)]; ok {
+		expression = macro
+	}
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expression, len(fields))
+	}
+
+	schedule := &CronScheduleT{
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+		location:      location,
+	}
+	var err error
+	if schedule.minutes, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("invalid minute field in cron expression %q: %w", expression, err)
+	}
+	if schedule.hours, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("invalid hour field in cron expression %q: %w", expression, err)
+	}
+	if schedule.daysOfMonth, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("invalid day of month field in cron expression %q: %w", expression, err)
+	}
+	if schedule.months, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("invalid month field in cron expression %q: %w", expression, err)
+	}
+	// day of week accepts both 0 and 7 for sunday
+	if schedule.daysOfWeek, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("invalid day of week field in cron expression %q: %w", expression, err)
+	}
+	if schedule.daysOfWeek[7] {
+		schedule.daysOfWeek[0] = true
+	}
+	return schedule, nil
+}
+
+// getCronSchedule returns the parsed schedule from cache, parsing the expression if seen for the first time
+func getCronSchedule(expression, timezone string) (*CronScheduleT, error) {
+	key := fmt.Sprintf(`%s-%s`, expression, timezone)
+	cronSchedulesCacheLock.RLock()
+	schedule, ok := cronSchedulesCache[key]
+	cronSchedulesCacheLock.RUnlock()
+	if ok {
+		return schedule, nil
+	}
+	schedule, err := ParseCronExpression(expression, timezone)
+	if err != nil {
+		return nil, err
+	}
+	cronSchedulesCacheLock.Lock()
+	cronSchedulesCache[key] = schedule
+	cronSchedulesCacheLock.Unlock()
+	return schedule, nil
+}
+
+// parseCronField parses a comma separated list of values, ranges (a-b) and steps (*/n, a-b/n, a/n)
+func parseCronField(field string, min, max int) ([]bool, error) {
+	values := make([]bool, max+1)
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			var err error
+			rangePart = part[:idx]
+			step, err = strconv.Atoi(part[idx+1:])
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+		}
+
+		var start, end int
+		switch {
+		case rangePart == "*":
+			start, end = min, max
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, fmt.Errorf("invalid range in %q", part)
+			}
+			if end, err = strconv.Atoi(bounds[1]); err != nil {
+				return nil, fmt.Errorf("invalid range in %q", part)
+			}
+		default:
+			var err error
+			if start, err = strconv.Atoi(rangePart); err != nil {
+				return nil, fmt.Errorf("invalid value in %q", part)
+			}
+			end = start
+			// a/n runs from a till the max value
+			if rangePart != part {
+				end = max
+			}
+		}
+
+		if start < min || end > max || start > end {
+			return nil, fmt.Errorf("%q out of range %d-%d", part, min, max)
+		}
+		for val := start; val <= end; val += step {
+			values[val] = true
+		}
+	}
+	return values, nil
+}
+
+func (schedule *CronScheduleT) dayMatches(t time.Time) bool {
+	dayOfMonthMatches := schedule.daysOfMonth[t.Day()]
+	dayOfWeekMatches := schedule.daysOfWeek[int(t.Weekday())]
+	if !schedule.anyDayOfMonth && !schedule.anyDayOfWeek {
+		return dayOfMonthMatches || dayOfWeekMatches
+	}
+	return dayOfMonthMatches && dayOfWeekMatches
+}
+
+// Prev returns the latest scheduled time at or before t
+// returns zero time if the schedule never matches eg. `0 0 31 2 *`
+func (schedule *CronScheduleT) Prev(t time.Time) time.Time {
+	loc := schedule.location
+	t = t.In(loc)
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+	for i := 0; i < maxCronIterations; i++ {
+		if !schedule.months[int(t.Month())] {
+			// last minute of the previous month
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc).Add(-time.Minute)
+			continue
+		}
+		if !schedule.dayMatches(t) {
+			// last minute of the previous day
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc).Add(-time.Minute)
+			continue
+		}
+		if !schedule.hours[t.Hour()] {
+			// last minute of the previous hour
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc).Add(-time.Minute)
+			continue
+		}
+		if !schedule.minutes[t.Minute()] {
+			t = t.Add(-time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
@@ -0,0 +1,69 @@
+package warehouse_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/rudderlabs/rudder-server/warehouse"
+)
+
+var _ = Describe("Cron", func() {
+	Describe("ParseCronExpression", func() {
+		It("should reject invalid expressions", func() {
+			for _, expression := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+				_, err := ParseCronExpression(expression, "")
+				Expect(err).To(HaveOccurred(), expression)
+			}
+			_, err := ParseCronExpression("* * * * *", "Mars/Olympus_Mons")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Prev", func() {
+		currentTime := time.Date(2021, time.November, 10, 21, 37, 12, 0, time.UTC) // Wednesday
+
+		It("should return the closest previous scheduled time", func() {
+			schedule, err := ParseCronExpression("*/15 * * * *", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Prev(currentTime)).To(BeTemporally("==", time.Date(2021, time.November, 10, 21, 30, 0, 0, time.UTC)))
+
+			schedule, err = ParseCronExpression("0 9-17/4 * * *", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Prev(currentTime)).To(BeTemporally("==", time.Date(2021, time.November, 10, 17, 0, 0, 0, time.UTC)))
+		})
+
+		It("should roll back to previous days and months", func() {
+			schedule, err := ParseCronExpression("30 22 * * 1,5", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Prev(currentTime)).To(BeTemporally("==", time.Date(2021, time.November, 8, 22, 30, 0, 0, time.UTC)))
+
+			schedule, err = ParseCronExpression("@monthly", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Prev(currentTime)).To(BeTemporally("==", time.Date(2021, time.November, 1, 0, 0, 0, 0, time.UTC)))
+
+			schedule, err = ParseCronExpression("0 0 1 3 *", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Prev(currentTime)).To(BeTemporally("==", time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("should match either day of month or day of week if both are set", func() {
+			schedule, err := ParseCronExpression("0 0 1 * 2", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Prev(currentTime)).To(BeTemporally("==", time.Date(2021, time.November, 9, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("should evaluate the schedule in the given timezone", func() {
+			schedule, err := ParseCronExpression("0 9 * * *", "Asia/Kolkata")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Prev(currentTime)).To(BeTemporally("==", time.Date(2021, time.November, 10, 3, 30, 0, 0, time.UTC)))
+		})
+
+		It("should return zero time for schedules that never match", func() {
+			schedule, err := ParseCronExpression("0 0 31 2 *", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Prev(currentTime).IsZero()).To(BeTrue())
+		})
+	})
+})
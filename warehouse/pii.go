@@ -0,0 +1,165 @@
+package warehouse
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/rudderlabs/rudder-server/utils/misc"
+)
+
+const (
+	PIIActionHash     = "hash"
+	PIIActionTruncate = "truncate"
+	PIIActionNullify  = "nullify"
+	PIIActionTokenize = "tokenize"
+)
+
+const (
+	piiRulesConfigKey        = "piiRules"
+	piiRulesVersionConfigKey = "piiRulesVersion"
+	piiTokenPrefix           = "tok_"
+)
+
+// piiStringColumnTypes are the column types whose values can be hashed, tokenized or truncated
+var piiStringColumnTypes = []string{"string", "text"}
+
+// PIIRuleT describes how values of the columns matching Table/Column glob patterns
+// are rewritten before they are written into load files
+type PIIRuleT struct {
+	Table  string `json:"table"`
+	Column string `json:"column"`
+	Action string `json:"action"`
+	Salt   string `json:"salt"`
+	Length int    `json:"length"`
+}
+
+// PIIRulesT is the versioned set of PII rules configured on a warehouse destination
+type PIIRulesT struct {
+	Version string
+	Rules   []PIIRuleT
+}
+
+// GetPIIRules reads the PII rules from the destination config
+// Rules with an unknown action are skipped
+// Truncate rules without a positive length are rejected and nullify the columns instead, so that they are never loaded as is
+func GetPIIRules(destConfig interface{}) (piiRules PIIRulesT) {
+	configMap, ok := destConfig.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if version, ok := configMap[piiRulesVersionConfigKey].(string); ok {
+		piiRules.Version = version
+	}
+	rawRules, ok := configMap[piiRulesConfigKey]
+	if !ok {
+		return
+	}
+	rulesJSON, err := json.Marshal(rawRules)
+	if err != nil {
+		pkgLogger.Errorf("[WH]: Failed to marshal pii rules: %v", err)
+		return
+	}
+	var rules []PIIRuleT
+	err = json.Unmarshal(rulesJSON, &rules)
+	if err != nil {
+		pkgLogger.Errorf("[WH]: Failed to unmarshal pii rules: %v", err)
+		return
+	}
+	for _, rule := range rules {
+		switch rule.Action {
+		case PIIActionTruncate:
+			if rule.Length <= 0 {
+				pkgLogger.Errorf("[WH]: Rejecting truncate pii rule for %s.%s without a positive length, nullifying the column instead", rule.Table, rule.Column)
+				rule.Action = PIIActionNullify
+			}
+			piiRules.Rules = append(piiRules.Rules, rule)
+		case PIIActionHash, PIIActionNullify, PIIActionTokenize:
+			piiRules.Rules = append(piiRules.Rules, rule)
+		default:
+			pkgLogger.Errorf("[WH]: Skipping pii rule for %s.%s with unknown action: %s", rule.Table, rule.Column, rule.Action)
+		}
+	}
+	return
+}
+
+// IsEmpty returns true if there are no rules to be applied
+func (piiRules PIIRulesT) IsEmpty() bool {
+	return len(piiRules.Rules) == 0
+}
+
+// getRule returns the first rule matching the table and column
+// Patterns are matched case-insensitively as table and column names are provider cased
+func (piiRules PIIRulesT) getRule(tableName, columnName string) (PIIRuleT, bool) {
+	tableName = strings.ToLower(tableName)
+	columnName = strings.ToLower(columnName)
+	for _, rule := range piiRules.Rules {
+		tableMatched, err := path.Match(strings.ToLower(rule.Table), tableName)
+		if err != nil || !tableMatched {
+			continue
+		}
+		columnMatched, err := path.Match(strings.ToLower(rule.Column), columnName)
+		if err != nil || !columnMatched {
+			continue
+		}
+		return rule, true
+	}
+	return PIIRuleT{}, false
+}
+
+// Mask rewrites the values of the columns of the event matching the rules, removing the nullified ones
+// Rules are matched on the column names of the staging file, so it has to be applied before the columns are evolved
+func (piiRules PIIRulesT) Mask(tableName string, event *BatchRouterEventT) {
+	for columnName, columnType := range event.Metadata.Columns {
+		columnVal, ok := event.Data[columnName]
+		if !ok {
+			continue
+		}
+		maskedVal, masked := piiRules.Apply(tableName, columnName, columnType, columnVal)
+		if !masked {
+			continue
+		}
+		if maskedVal == nil {
+			delete(event.Data, columnName)
+			continue
+		}
+		event.Data[columnName] = maskedVal
+	}
+}
+
+// Apply rewrites the column value as per the first matching rule
+// Values of non string columns matching a rule are always nullified since hashed or truncated values would not fit the column type
+// Returns the value to be written to the load file and false if no rule matched
+func (piiRules PIIRulesT) Apply(tableName, columnName, columnType string, columnVal interface{}) (interface{}, bool) {
+	rule, ok := piiRules.getRule(tableName, columnName)
+	if !ok {
+		return columnVal, false
+	}
+	if columnVal == nil {
+		return nil, true
+	}
+	strVal, isString := columnVal.(string)
+	if rule.Action == PIIActionNullify || !misc.ContainsString(piiStringColumnTypes, columnType) || !isString {
+		return nil, true
+	}
+	switch rule.Action {
+	case PIIActionHash:
+		hash := sha256.Sum256([]byte(rule.Salt + strVal))
+		return hex.EncodeToString(hash[:]), true
+	case PIIActionTokenize:
+		mac := hmac.New(sha256.New, []byte(rule.Salt))
+		mac.Write([]byte(strVal))
+		return fmt.Sprintf(`%s%s`, piiTokenPrefix, hex.EncodeToString(mac.Sum(nil))), true
+	case PIIActionTruncate:
+		runes := []rune(strVal)
+		if len(runes) > rule.Length {
+			return string(runes[:rule.Length]), true
+		}
+		return strVal, true
+	}
+	return nil, true
+}
@@ -0,0 +1,104 @@
+package warehouse_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/rudderlabs/rudder-server/warehouse"
+)
+
+var _ = Describe("PII", func() {
+	destConfig := map[string]interface{}{
+		"piiRulesVersion": "v2",
+		"piiRules": []interface{}{
+			map[string]interface{}{"table": "*", "column": "email", "action": "hash", "salt": "s3cr3t"},
+			map[string]interface{}{"table": "tracks", "column": "context_ip", "action": "truncate", "length": 3},
+			map[string]interface{}{"table": "identifies", "column": "phone*", "action": "nullify"},
+			map[string]interface{}{"table": "users", "column": "ssn", "action": "tokenize", "salt": "s3cr3t"},
+			map[string]interface{}{"table": "users", "column": "name", "action": "unknown"},
+		},
+	}
+
+	Describe("GetPIIRules", func() {
+		It("should read version and valid rules from destination config", func() {
+			piiRules := GetPIIRules(destConfig)
+			Expect(piiRules.Version).To(Equal("v2"))
+			Expect(piiRules.Rules).To(HaveLen(4))
+		})
+
+		It("should nullify columns of truncate rules without a positive length", func() {
+			piiRules := GetPIIRules(map[string]interface{}{
+				"piiRules": []interface{}{
+					map[string]interface{}{"table": "*", "column": "email", "action": "truncate"},
+					map[string]interface{}{"table": "*", "column": "phone", "action": "truncate", "length": -1},
+				},
+			})
+			Expect(piiRules.Rules).To(HaveLen(2))
+			for _, column := range []string{"email", "phone"} {
+				val, ok := piiRules.Apply("users", column, "string", "john@example.com")
+				Expect(ok).To(BeTrue())
+				Expect(val).To(BeNil())
+			}
+		})
+
+		It("should return empty rules when not configured", func() {
+			Expect(GetPIIRules(map[string]interface{}{}).IsEmpty()).To(BeTrue())
+			Expect(GetPIIRules(nil).IsEmpty()).To(BeTrue())
+		})
+	})
+
+	Describe("Apply", func() {
+		var piiRules PIIRulesT
+
+		BeforeEach(func() {
+			piiRules = GetPIIRules(destConfig)
+		})
+
+		It("should hash matching columns with salt", func() {
+			val, ok := piiRules.Apply("pages", "EMAIL", "string", "john@example.com")
+			Expect(ok).To(BeTrue())
+			Expect(val).To(HaveLen(64))
+			Expect(val).NotTo(ContainSubstring("john"))
+
+			sameVal, _ := piiRules.Apply("tracks", "email", "string", "john@example.com")
+			Expect(sameVal).To(Equal(val))
+		})
+
+		It("should truncate matching columns", func() {
+			val, ok := piiRules.Apply("tracks", "context_ip", "string", "127.0.0.1")
+			Expect(ok).To(BeTrue())
+			Expect(val).To(Equal("127"))
+		})
+
+		It("should nullify matching columns", func() {
+			val, ok := piiRules.Apply("identifies", "phone_number", "string", "+1 555 0100")
+			Expect(ok).To(BeTrue())
+			Expect(val).To(BeNil())
+		})
+
+		It("should tokenize matching columns", func() {
+			val, ok := piiRules.Apply("users", "ssn", "string", "123-45-6789")
+			Expect(ok).To(BeTrue())
+			Expect(val).To(HavePrefix("tok_"))
+		})
+
+		It("should hash text columns matching a hash rule", func() {
+			val, ok := piiRules.Apply("pages", "email", "text", "john@example.com")
+			Expect(ok).To(BeTrue())
+			stringVal, _ := piiRules.Apply("pages", "email", "string", "john@example.com")
+			Expect(val).To(Equal(stringVal))
+		})
+
+		It("should nullify non string columns matching a rule", func() {
+			val, ok := piiRules.Apply("users", "ssn", "int", 123456789)
+			Expect(ok).To(BeTrue())
+			Expect(val).To(BeNil())
+		})
+
+		It("should leave columns without a matching rule untouched", func() {
+			val, ok := piiRules.Apply("users", "name", "string", "John")
+			Expect(ok).To(BeFalse())
+			Expect(val).To(Equal("John"))
+		})
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -18,16 +19,50 @@ var (
 	minUploadBackoff    time.Duration
 	maxUploadBackoff    time.Duration
 	startUploadAlways   bool
+	syncVolumeMaxWait   time.Duration
+	// syncVolumeCheckInterval throttles the pending volume queries of each warehouse, as they sum up its pending staging files
+	syncVolumeCheckInterval    time.Duration
+	syncVolumeCheckedAtMap     map[string]time.Time
+	syncVolumeCheckedAtMapLock sync.Mutex
+)
+
+// Priority classes can be set per destination (priorityClass in destination config) or per workspace (Warehouse.scheduler.<workspaceID>.priorityClass)
+// They decide the priority of uploads created for the destination and its weight while fair sharing slave workers
+const (
+	PriorityClassHigh   = "high"
+	PriorityClassNormal = "normal"
+	PriorityClassLow    = "low"
+)
+
+const (
+	priorityClassConfigKey = "priorityClass"
+	defaultUploadPriority  = 100
+)
+
+var (
+	priorityClassUploadPriority = map[string]int{
+		PriorityClassHigh:   75,
+		PriorityClassNormal: defaultUploadPriority,
+		PriorityClassLow:    150,
+	}
+	priorityClassWeight = map[string]int{
+		PriorityClassHigh:   4,
+		PriorityClassNormal: 2,
+		PriorityClassLow:    1,
+	}
 )
 
 func Init3() {
 	scheduledTimesCache = map[string][]int{}
+	syncVolumeCheckedAtMap = map[string]time.Time{}
 	loadConfigScheduling()
 }
 
 func loadConfigScheduling() {
 	config.RegisterDurationConfigVariable(time.Duration(60), &minUploadBackoff, true, time.Second, []string{"Warehouse.minUploadBackoff", "Warehouse.minUploadBackoffInS"}...)
 	config.RegisterDurationConfigVariable(time.Duration(1800), &maxUploadBackoff, true, time.Second, []string{"Warehouse.maxUploadBackoff", "Warehouse.maxUploadBackoffInS"}...)
+	config.RegisterDurationConfigVariable(time.Duration(180), &syncVolumeMaxWait, true, time.Minute, []string{"Warehouse.syncVolumeMaxWait", "Warehouse.syncVolumeMaxWaitInMin"}...)
+	config.RegisterDurationConfigVariable(time.Duration(60), &syncVolumeCheckInterval, true, time.Second, []string{"Warehouse.syncVolumeCheckInterval", "Warehouse.syncVolumeCheckIntervalInS"}...)
 }
 
 // ScheduledTimes returns all possible start times (minutes from start of day) as per schedule
@@ -97,10 +132,10 @@ func GetPrevScheduledTime(syncFrequency, syncStartAt string, currTime time.Time)
 	return timeutil.StartOfDay(now).Add(time.Minute * time.Duration(allStartTimes[pos]))
 }
 
-// getLastUploadCreatedAt returns the start time of the last upload
+// getLastUploadCreatedAt returns the start time of the last upload, leaving out backfills so that they don't delay syncs of live data
 func (wh *HandleT) getLastUploadCreatedAt(warehouse warehouseutils.WarehouseT) time.Time {
 	var t sql.NullTime
-	sqlStatement := fmt.Sprintf(`select created_at from %s where source_id='%s' and destination_id='%s' order by id desc limit 1`, warehouseutils.WarehouseUploadsTable, warehouse.Source.ID, warehouse.Destination.ID)
+	sqlStatement := fmt.Sprintf(`select created_at from %s where source_id='%s' and destination_id='%s' and %s order by id desc limit 1`, warehouseutils.WarehouseUploadsTable, warehouse.Source.ID, warehouse.Destination.ID, notBackfillSQL)
 	err := wh.dbHandle.QueryRow(sqlStatement).Scan(&t)
 	if err != nil && err != sql.ErrNoRows {
 		panic(fmt.Errorf("Query: %s\nfailed with Error : %w", sqlStatement, err))
@@ -111,6 +146,63 @@ func (wh *HandleT) getLastUploadCreatedAt(warehouse warehouseutils.WarehouseT) t
 	return t.Time
 }
 
+// getSyncVolumeThresholds returns min pending events and bytes configured to trigger a sync
+// numbers in destination config can either be json numbers or strings
+// Setting either of them turns off the cron & frequency schedules of the destination, see canCreateUpload
+func getSyncVolumeThresholds(warehouse warehouseutils.WarehouseT) (minEvents, minBytes int64) {
+	parse := func(key string) int64 {
+		switch val := warehouse.Destination.Config[key].(type) {
+		case float64:
+			return int64(val)
+		case string:
+			intVal, _ := strconv.ParseInt(val, 10, 64)
+			return intVal
+		}
+		return 0
+	}
+	return parse(warehouseutils.SyncMinEvents), parse(warehouseutils.SyncMinBytes)
+}
+
+// syncVolumeCheckDue returns true if the pending volume of the warehouse wasn't checked in the last syncVolumeCheckInterval, marking it as checked
+func syncVolumeCheckDue(warehouse warehouseutils.WarehouseT) bool {
+	syncVolumeCheckedAtMapLock.Lock()
+	defer syncVolumeCheckedAtMapLock.Unlock()
+	now := timeutil.Now()
+	if checkedAt, ok := syncVolumeCheckedAtMap[warehouse.Identifier]; ok && now.Sub(checkedAt) < syncVolumeCheckInterval {
+		return false
+	}
+	syncVolumeCheckedAtMap[warehouse.Identifier] = now
+	return true
+}
+
+// syncVolumeExceeded returns true if staging files pending to be synced add up to minEvents or minBytes
+// or if the oldest pending staging file has waited for more than syncVolumeMaxWait, so low volume sources still sync eventually
+// The pending volume is checked at most once every syncVolumeCheckInterval per warehouse
+func (wh *HandleT) syncVolumeExceeded(warehouse warehouseutils.WarehouseT, minEvents, minBytes int64) bool {
+	if !syncVolumeCheckDue(warehouse) {
+		return false
+	}
+	sqlStatement := fmt.Sprintf(`SELECT COALESCE(SUM(total_events), 0), COALESCE(SUM((metadata->>'total_bytes')::bigint), 0), MIN(created_at)
+								FROM %[1]s
+								WHERE id > COALESCE((SELECT end_staging_file_id FROM %[2]s WHERE destination_type='%[3]s' AND source_id='%[4]s' AND destination_id='%[5]s' AND %[6]s ORDER BY id DESC LIMIT 1), 0)
+								AND source_id='%[4]s' AND destination_id='%[5]s' AND %[6]s`,
+		warehouseutils.WarehouseStagingFilesTable, warehouseutils.WarehouseUploadsTable, warehouse.Type, warehouse.Source.ID, warehouse.Destination.ID, notBackfillSQL)
+	var pendingEvents, pendingBytes int64
+	var oldestPendingAt sql.NullTime
+	err := wh.dbHandle.QueryRow(sqlStatement).Scan(&pendingEvents, &pendingBytes, &oldestPendingAt)
+	if err != nil {
+		pkgLogger.Errorf("[WH]: Failed to get pending staging files volume for %s: %v", warehouse.Identifier, err)
+		return false
+	}
+	if !oldestPendingAt.Valid {
+		return false
+	}
+	if (minEvents > 0 && pendingEvents >= minEvents) || (minBytes > 0 && pendingBytes >= minBytes) {
+		return true
+	}
+	return timeutil.Now().Sub(oldestPendingAt.Time) >= syncVolumeMaxWait
+}
+
 func GetExludeWindowStartEndTimes(excludeWindow map[string]interface{}) (string, string) {
 	var startTime, endTime string
 	if time, ok := excludeWindow[warehouseutils.ExcludeWindowStartTime].(string); ok {
@@ -163,6 +255,19 @@ func (wh *HandleT) canCreateUpload(warehouse warehouseutils.WarehouseT) bool {
 	if CheckCurrentTimeExistsInExcludeWindow(timeutil.Now(), excludeWindowStartTime, excludeWindowEndTime) {
 		return false
 	}
+	// volume triggered syncs start once enough events or bytes are pending instead of on schedule
+	// syncCronExpression, syncFrequency & syncStartAt are ignored in this mode, syncs of low volume sources start after Warehouse.syncVolumeMaxWait
+	if minEvents, minBytes := getSyncVolumeThresholds(warehouse); minEvents > 0 || minBytes > 0 {
+		return wh.syncVolumeExceeded(warehouse, minEvents, minBytes)
+	}
+	if cronExpression := warehouseutils.GetConfigValue(warehouseutils.SyncCronExpression, warehouse); cronExpression != "" {
+		schedule, err := getCronSchedule(cronExpression, warehouseutils.GetConfigValue(warehouseutils.SyncTimezone, warehouse))
+		if err == nil {
+			// start upload only if no upload has started since the last cron tick
+			return wh.getLastUploadCreatedAt(warehouse).Before(schedule.Prev(timeutil.Now()))
+		}
+		pkgLogger.Errorf("[WH]: Invalid sync cron expression for %s, falling back to sync frequency: %v", warehouse.Identifier, err)
+	}
 	syncFrequency := warehouseutils.GetConfigValue(warehouseutils.SyncFrequency, warehouse)
 	syncStartAt := warehouseutils.GetConfigValue(warehouseutils.SyncStartAt, warehouse)
 	if syncFrequency == "" || syncStartAt == "" {
@@ -175,6 +280,156 @@ func (wh *HandleT) canCreateUpload(warehouse warehouseutils.WarehouseT) bool {
 	return lastUploadCreatedAt.Before(prevScheduledTime)
 }
 
+func isValidPriorityClass(priorityClass string) bool {
+	_, ok := priorityClassUploadPriority[priorityClass]
+	return ok
+}
+
+// GetPriorityClass returns the priority class of the warehouse
+// priority class set in destination config takes precedence over the one set for the workspace
+func GetPriorityClass(warehouse warehouseutils.WarehouseT) string {
+	if priorityClass, ok := warehouse.Destination.Config[priorityClassConfigKey].(string); ok && isValidPriorityClass(priorityClass) {
+		return priorityClass
+	}
+	if warehouse.Source.WorkspaceID != "" {
+		priorityClass := config.GetString(fmt.Sprintf(`Warehouse.scheduler.%s.priorityClass`, warehouse.Source.WorkspaceID), "")
+		if isValidPriorityClass(priorityClass) {
+			return priorityClass
+		}
+	}
+	return PriorityClassNormal
+}
+
+// GetFairShareKey returns the key by which slave jobs are fair shared
+// jobs are shared across workspaces so that one workspace with lots of staging files does not starve others
+func GetFairShareKey(warehouse warehouseutils.WarehouseT) string {
+	if warehouse.Source.WorkspaceID != "" {
+		return warehouse.Source.WorkspaceID
+	}
+	return warehouse.Destination.ID
+}
+
+// GetFairShareWeight returns the weight of the warehouse while fair sharing slave workers
+func GetFairShareWeight(warehouse warehouseutils.WarehouseT) int {
+	return priorityClassWeight[GetPriorityClass(warehouse)]
+}
+
+// GetClusterIdentifier returns the identifier of the warehouse cluster the destination loads into
+// eg. host for Redshift, account for Snowflake and project for BigQuery
+// falls back to destination id if none of them are set
+func GetClusterIdentifier(warehouse warehouseutils.WarehouseT) string {
+	for _, key := range []string{"host", "account", "project"} {
+		if val, ok := warehouse.Destination.Config[key].(string); ok && val != "" {
+			return fmt.Sprintf(`%s:%s`, warehouse.Type, val)
+		}
+	}
+	return fmt.Sprintf(`%s:%s`, warehouse.Type, warehouse.Destination.ID)
+}
+
+// getClustersAtAdmissionLimit returns the worker identifiers of warehouses whose cluster is already running max allowed uploads
+func (wh *HandleT) getClustersAtAdmissionLimit() (identifiers []string) {
+	if wh.maxConcurrentUploadsPerCluster <= 0 {
+		return
+	}
+	wh.configSubscriberLock.RLock()
+	defer wh.configSubscriberLock.RUnlock()
+	wh.inProgressMapLock.RLock()
+	defer wh.inProgressMapLock.RUnlock()
+	for _, warehouse := range wh.warehouses {
+		if wh.clusterInProgressMap[GetClusterIdentifier(warehouse)] >= wh.maxConcurrentUploadsPerCluster {
+			identifiers = append(identifiers, wh.workerIdentifier(warehouse))
+		}
+	}
+	return
+}
+
+// admitUploadJobs drops upload jobs that would take their cluster over the admission limit
+// dropped jobs remain waiting and are picked up once the cluster has capacity
+func (wh *HandleT) admitUploadJobs(uploadJobs []*UploadJobT) (admittedJobs []*UploadJobT) {
+	if wh.maxConcurrentUploadsPerCluster <= 0 {
+		return uploadJobs
+	}
+	wh.inProgressMapLock.RLock()
+	defer wh.inProgressMapLock.RUnlock()
+	admittedCount := map[string]int{}
+	for _, uploadJob := range uploadJobs {
+		cluster := GetClusterIdentifier(uploadJob.warehouse)
+		if wh.clusterInProgressMap[cluster]+admittedCount[cluster] >= wh.maxConcurrentUploadsPerCluster {
+			pkgLogger.Infof(`[WH]: Skipping upload:%d as cluster:%s is running max allowed uploads: %d`, uploadJob.upload.ID, cluster, wh.maxConcurrentUploadsPerCluster)
+			continue
+		}
+		admittedCount[cluster]++
+		admittedJobs = append(admittedJobs, uploadJob)
+	}
+	return
+}
+
+type UploadQueueDepthT struct {
+	DestinationType string `json:"destination_type"`
+	Priority        int    `json:"priority"`
+	Waiting         int64  `json:"waiting"`
+	InProgress      int64  `json:"in_progress"`
+}
+
+type ClusterQueueDepthT struct {
+	Cluster    string `json:"cluster"`
+	Waiting    int64  `json:"waiting"`
+	InProgress int64  `json:"in_progress"`
+}
+
+// getUploadQueueDepth returns count of pending uploads by destination type and priority and by warehouse cluster
+func getUploadQueueDepth(destType string) (uploads []UploadQueueDepthT, clusters []ClusterQueueDepthT, err error) {
+	var destTypeFilterSQL string
+	if destType != "" {
+		destTypeFilterSQL = fmt.Sprintf(`AND destination_type = '%s'`, destType)
+	}
+	sqlStatement := fmt.Sprintf(`SELECT destination_type, source_id, destination_id, COALESCE(metadata->>'priority', '100')::int, in_progress, count(*) FROM %s WHERE status != '%s' AND status != '%s' %s GROUP BY 1, 2, 3, 4, 5`, warehouseutils.WarehouseUploadsTable, ExportedData, Aborted, destTypeFilterSQL)
+	rows, err := dbHandle.Query(sqlStatement)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	uploadsIdx := map[string]int{}
+	clustersIdx := map[string]int{}
+	for rows.Next() {
+		var uploadDestType, sourceID, destID string
+		var priority int
+		var inProgress bool
+		var count int64
+		err = rows.Scan(&uploadDestType, &sourceID, &destID, &priority, &inProgress, &count)
+		if err != nil {
+			return
+		}
+
+		key := fmt.Sprintf(`%s:%d`, uploadDestType, priority)
+		if _, ok := uploadsIdx[key]; !ok {
+			uploadsIdx[key] = len(uploads)
+			uploads = append(uploads, UploadQueueDepthT{DestinationType: uploadDestType, Priority: priority})
+		}
+		cluster := fmt.Sprintf(`%s:%s`, uploadDestType, destID)
+		connectionsMapLock.RLock()
+		if warehouse, ok := connectionsMap[destID][sourceID]; ok {
+			cluster = GetClusterIdentifier(warehouse)
+		}
+		connectionsMapLock.RUnlock()
+		if _, ok := clustersIdx[cluster]; !ok {
+			clustersIdx[cluster] = len(clusters)
+			clusters = append(clusters, ClusterQueueDepthT{Cluster: cluster})
+		}
+
+		if inProgress {
+			uploads[uploadsIdx[key]].InProgress += count
+			clusters[clustersIdx[cluster]].InProgress += count
+		} else {
+			uploads[uploadsIdx[key]].Waiting += count
+			clusters[clustersIdx[cluster]].Waiting += count
+		}
+	}
+	err = rows.Err()
+	return
+}
+
 func durationBeforeNextAttempt(attempt int64) time.Duration { //Add state(retryable/non-retryable) as an argument to decide backoff etc)
 	var d time.Duration
 	b := backoff.NewExponentialBackOff()
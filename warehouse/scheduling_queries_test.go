@@ -0,0 +1,210 @@
+package warehouse
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
+	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+//fakeQueryDriver is a sql driver answering queries with the rows returned by queryHandler, to test scheduling queries without a database
+type fakeQueryDriver struct{}
+
+var (
+	registerFakeQueryDriver sync.Once
+	queryHandler            func(query string) [][]driver.Value
+	executedQueries         []string
+)
+
+func openFakeQueryDB(handler func(query string) [][]driver.Value) *sql.DB {
+	registerFakeQueryDriver.Do(func() {
+		sql.Register("fake-query", fakeQueryDriver{})
+	})
+	queryHandler = handler
+	executedQueries = nil
+	db, err := sql.Open("fake-query", "")
+	Expect(err).NotTo(HaveOccurred())
+	return db
+}
+
+func (fakeQueryDriver) Open(string) (driver.Conn, error) { return fakeQueryConn{}, nil }
+
+type fakeQueryConn struct{}
+
+func (fakeQueryConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeQueryStmt{query: query}, nil
+}
+func (fakeQueryConn) Close() error              { return nil }
+func (fakeQueryConn) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+type fakeQueryStmt struct {
+	query string
+}
+
+func (fakeQueryStmt) Close() error                               { return nil }
+func (fakeQueryStmt) NumInput() int                              { return -1 }
+func (fakeQueryStmt) Exec([]driver.Value) (driver.Result, error) { return driver.ResultNoRows, nil }
+func (stmt fakeQueryStmt) Query([]driver.Value) (driver.Rows, error) {
+	executedQueries = append(executedQueries, stmt.query)
+	return &fakeQueryRows{rows: queryHandler(stmt.query)}, nil
+}
+
+type fakeQueryRows struct {
+	rows [][]driver.Value
+}
+
+func (rows *fakeQueryRows) Columns() []string {
+	if len(rows.rows) == 0 {
+		return []string{"column"}
+	}
+	return make([]string, len(rows.rows[0]))
+}
+
+func (*fakeQueryRows) Close() error { return nil }
+
+func (rows *fakeQueryRows) Next(dest []driver.Value) error {
+	if len(rows.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, rows.rows[0])
+	rows.rows = rows.rows[1:]
+	return nil
+}
+
+var _ = Describe("Scheduling queries", func() {
+	var warehouse warehouseutils.WarehouseT
+
+	BeforeEach(func() {
+		warehouse = warehouseutils.WarehouseT{
+			Type:        "POSTGRES",
+			Source:      backendconfig.SourceT{ID: "source-1"},
+			Destination: backendconfig.DestinationT{ID: "dest-1", Config: map[string]interface{}{}},
+			Identifier:  "POSTGRES:source-1:dest-1",
+		}
+	})
+
+	Describe("canCreateUpload", func() {
+		It("should not let a backfill move the live sync schedule", func() {
+			now := time.Now().UTC()
+			liveUploadAt, backfillUploadAt := now.Add(-2*time.Hour), now
+			wh := &HandleT{dbHandle: openFakeQueryDB(func(query string) [][]driver.Value {
+				if strings.Contains(query, notBackfillSQL) {
+					return [][]driver.Value{{liveUploadAt}}
+				}
+				return [][]driver.Value{{backfillUploadAt}}
+			})}
+
+			warehouse.Destination.Config[warehouseutils.SyncFrequency] = "60"
+			warehouse.Destination.Config[warehouseutils.SyncStartAt] = "00:00"
+			Expect(wh.canCreateUpload(warehouse)).To(BeTrue())
+
+			delete(warehouse.Destination.Config, warehouseutils.SyncFrequency)
+			delete(warehouse.Destination.Config, warehouseutils.SyncStartAt)
+			warehouse.Destination.Config[warehouseutils.SyncCronExpression] = "0 * * * *"
+			Expect(wh.canCreateUpload(warehouse)).To(BeTrue())
+			Expect(executedQueries).To(HaveLen(2))
+		})
+	})
+
+	Describe("volume triggered syncs", func() {
+		var (
+			pendingEvents, pendingBytes int64
+			oldestPendingAt             interface{}
+			wh                          *HandleT
+			checkInterval               time.Duration
+		)
+
+		volumeQueries := func() int {
+			count := 0
+			for _, query := range executedQueries {
+				if strings.Contains(query, warehouseutils.WarehouseStagingFilesTable) {
+					count++
+				}
+			}
+			return count
+		}
+
+		BeforeEach(func() {
+			pendingEvents, pendingBytes, oldestPendingAt = 0, 0, time.Now().UTC()
+			wh = &HandleT{dbHandle: openFakeQueryDB(func(query string) [][]driver.Value {
+				if strings.Contains(query, warehouseutils.WarehouseStagingFilesTable) {
+					return [][]driver.Value{{pendingEvents, pendingBytes, oldestPendingAt}}
+				}
+				return [][]driver.Value{{time.Now().UTC()}}
+			})}
+			checkInterval = syncVolumeCheckInterval
+			syncVolumeCheckInterval = 0
+			syncVolumeCheckedAtMap = map[string]time.Time{}
+		})
+
+		AfterEach(func() {
+			syncVolumeCheckInterval = checkInterval
+		})
+
+		It("should read thresholds from json numbers or strings", func() {
+			warehouse.Destination.Config[warehouseutils.SyncMinEvents] = float64(100)
+			warehouse.Destination.Config[warehouseutils.SyncMinBytes] = "2048"
+			minEvents, minBytes := getSyncVolumeThresholds(warehouse)
+			Expect(minEvents).To(Equal(int64(100)))
+			Expect(minBytes).To(Equal(int64(2048)))
+		})
+
+		It("should sync once pending events reach min events", func() {
+			warehouse.Destination.Config[warehouseutils.SyncMinEvents] = float64(100)
+			pendingEvents, pendingBytes = 99, 1<<30
+			Expect(wh.canCreateUpload(warehouse)).To(BeFalse())
+			pendingEvents = 100
+			Expect(wh.canCreateUpload(warehouse)).To(BeTrue())
+		})
+
+		It("should sync once pending bytes reach min bytes", func() {
+			warehouse.Destination.Config[warehouseutils.SyncMinBytes] = "2048"
+			pendingEvents, pendingBytes = 1<<30, 2047
+			Expect(wh.canCreateUpload(warehouse)).To(BeFalse())
+			pendingBytes = 2048
+			Expect(wh.canCreateUpload(warehouse)).To(BeTrue())
+		})
+
+		It("should sync low volume once the oldest pending staging file waited for syncVolumeMaxWait, and not without pending staging files", func() {
+			warehouse.Destination.Config[warehouseutils.SyncMinEvents] = float64(100)
+			oldestPendingAt = time.Now().UTC().Add(-syncVolumeMaxWait)
+			Expect(wh.canCreateUpload(warehouse)).To(BeTrue())
+			oldestPendingAt = nil
+			Expect(wh.canCreateUpload(warehouse)).To(BeFalse())
+		})
+
+		It("should follow the schedule of the destination without thresholds", func() {
+			warehouse.Destination.Config[warehouseutils.SyncFrequency] = "60"
+			warehouse.Destination.Config[warehouseutils.SyncStartAt] = "00:00"
+			pendingEvents = 1 << 30
+			Expect(wh.canCreateUpload(warehouse)).To(BeFalse())
+			Expect(volumeQueries()).To(BeZero())
+		})
+
+		It("should check pending volume of each warehouse once per syncVolumeCheckInterval", func() {
+			syncVolumeCheckInterval = time.Hour
+			warehouse.Destination.Config[warehouseutils.SyncMinEvents] = float64(100)
+			pendingEvents = 100
+			Expect(wh.canCreateUpload(warehouse)).To(BeTrue())
+			Expect(wh.canCreateUpload(warehouse)).To(BeFalse())
+			Expect(volumeQueries()).To(Equal(1))
+
+			otherWarehouse := warehouse
+			otherWarehouse.Identifier = "POSTGRES:source-1:dest-2"
+			Expect(wh.canCreateUpload(otherWarehouse)).To(BeTrue())
+			Expect(volumeQueries()).To(Equal(2))
+
+			syncVolumeCheckInterval = 0
+			Expect(wh.canCreateUpload(warehouse)).To(BeTrue())
+			Expect(volumeQueries()).To(Equal(3))
+		})
+	})
+})
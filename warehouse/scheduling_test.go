@@ -8,8 +8,10 @@ import (
 
 	"github.com/rudderlabs/rudder-server/admin"
 	"github.com/rudderlabs/rudder-server/config"
+	backendconfig "github.com/rudderlabs/rudder-server/config/backend-config"
 	"github.com/rudderlabs/rudder-server/utils/logger"
 	. "github.com/rudderlabs/rudder-server/warehouse"
+	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
 )
 
 func initWarehouse() {
@@ -108,5 +110,50 @@ var _ = Describe("Warehouse", func() {
 				Expect(CheckCurrentTimeExistsInExcludeWindow(currentTime, startTime, endTime)).To(Equal(false))
 			})
 		})
+
+		Describe("Priority classes", func() {
+			It("should read priority class from destination config", func() {
+				warehouse := warehouseutils.WarehouseT{
+					Source:      backendconfig.SourceT{WorkspaceID: "workspace-1"},
+					Destination: backendconfig.DestinationT{ID: "dest-1", Config: map[string]interface{}{"priorityClass": PriorityClassHigh}},
+				}
+				Expect(GetPriorityClass(warehouse)).To(Equal(PriorityClassHigh))
+				Expect(GetFairShareWeight(warehouse)).To(Equal(4))
+				Expect(GetFairShareKey(warehouse)).To(Equal("workspace-1"))
+			})
+
+			It("should default to normal priority class", func() {
+				warehouse := warehouseutils.WarehouseT{
+					Destination: backendconfig.DestinationT{ID: "dest-1", Config: map[string]interface{}{"priorityClass": "urgent"}},
+				}
+				Expect(GetPriorityClass(warehouse)).To(Equal(PriorityClassNormal))
+				Expect(GetFairShareWeight(warehouse)).To(Equal(2))
+				Expect(GetFairShareKey(warehouse)).To(Equal("dest-1"))
+			})
+		})
+
+		Describe("GetClusterIdentifier", func() {
+			It("should identify cluster by host, account or project", func() {
+				Expect(GetClusterIdentifier(warehouseutils.WarehouseT{
+					Type:        "RS",
+					Destination: backendconfig.DestinationT{ID: "dest-1", Config: map[string]interface{}{"host": "cluster-1.redshift.amazonaws.com"}},
+				})).To(Equal("RS:cluster-1.redshift.amazonaws.com"))
+				Expect(GetClusterIdentifier(warehouseutils.WarehouseT{
+					Type:        "SNOWFLAKE",
+					Destination: backendconfig.DestinationT{ID: "dest-1", Config: map[string]interface{}{"account": "xy12345"}},
+				})).To(Equal("SNOWFLAKE:xy12345"))
+				Expect(GetClusterIdentifier(warehouseutils.WarehouseT{
+					Type:        "BQ",
+					Destination: backendconfig.DestinationT{ID: "dest-1", Config: map[string]interface{}{"project": "analytics"}},
+				})).To(Equal("BQ:analytics"))
+			})
+
+			It("should fall back to destination id", func() {
+				Expect(GetClusterIdentifier(warehouseutils.WarehouseT{
+					Type:        "S3_DATALAKE",
+					Destination: backendconfig.DestinationT{ID: "dest-1", Config: map[string]interface{}{}},
+				})).To(Equal("S3_DATALAKE:dest-1"))
+			})
+		})
 	})
 })
@@ -255,6 +255,16 @@ func (sh *SchemaHandleT) consolidateStagingFilesSchemaUsingWarehouseSchema() war
 		rows.Close()
 
 		consolidatedSchema = mergeSchema(schemaInLocalDB, schemas, consolidatedSchema, sh.warehouse.Type)
+		// add suffixed columns for values conflicting with the column type in warehouse
+		if policy := GetSchemaDriftPolicy(sh.warehouse.Destination.Config); policy == SchemaDriftPolicyEvolve || policy == SchemaDriftPolicyCoerce {
+			for tableName, columnMap := range getEvolvedColumns(schemaInLocalDB, schemas, sh.warehouse.Type, policy) {
+				for columnName, columnType := range columnMap {
+					if _, ok := consolidatedSchema[tableName][columnName]; !ok {
+						consolidatedSchema[tableName][columnName] = columnType
+					}
+				}
+			}
+		}
 
 		count += stagingFilesSchemaPaginationSize
 		if count >= len(sh.stagingFiles) {
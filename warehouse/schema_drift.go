@@ -0,0 +1,204 @@
+package warehouse
+
+import (
+	"encoding/json"
+	"fmt"
+
+	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+// Schema drift policies decide what happens to a value whose inferred type conflicts with the column type in the warehouse
+const (
+	// SchemaDriftPolicyQuarantine nullifies the value in the table and writes it to rudder_discards
+	SchemaDriftPolicyQuarantine = "quarantine"
+	// SchemaDriftPolicyStrict fails the upload
+	SchemaDriftPolicyStrict = "strict"
+	// SchemaDriftPolicyEvolve writes the value to a new column suffixed with the conflicting type eg. amount_string
+	SchemaDriftPolicyEvolve = "evolve"
+	// SchemaDriftPolicyCoerce casts the value to a string and writes it to a new column suffixed with string eg. amount_string,
+	// so that values of all conflicting types of a column are loaded into the same string column
+	SchemaDriftPolicyCoerce = "coerce"
+)
+
+const schemaDriftPolicyConfigKey = "schemaDriftPolicy"
+
+// DiscardedColumnT summarises values of a column that were written to rudder_discards while generating load files
+type DiscardedColumnT struct {
+	TableName    string   `json:"table_name"`
+	ColumnName   string   `json:"column_name"`
+	Count        int      `json:"count"`
+	SampleValues []string `json:"sample_values"`
+}
+
+// GetSchemaDriftPolicy returns the schema drift policy set in the destination config
+// Defaults to quarantine which is how type conflicts have always been handled
+func GetSchemaDriftPolicy(destConfig interface{}) string {
+	configMap, ok := destConfig.(map[string]interface{})
+	if !ok {
+		return SchemaDriftPolicyQuarantine
+	}
+	policy, _ := configMap[schemaDriftPolicyConfigKey].(string)
+	switch policy {
+	case SchemaDriftPolicyStrict, SchemaDriftPolicyEvolve, SchemaDriftPolicyCoerce:
+		return policy
+	}
+	return SchemaDriftPolicyQuarantine
+}
+
+// isTypeConflict returns true if values of columnType cannot be loaded into a column of existingDataType
+func isTypeConflict(existingDataType, columnType string) bool {
+	if existingDataType == columnType {
+		return false
+	}
+	_, ok := handleSchemaChange(existingDataType, columnType, nil)
+	return !ok
+}
+
+// GetEvolvedColumnName returns the name of the column to which values of a conflicting type are written under evolve & coerce policies
+func GetEvolvedColumnName(destType, columnName, columnType string) string {
+	return columnName + warehouseutils.ToProviderCase(destType, fmt.Sprintf(`_%s`, columnType))
+}
+
+// evolvedColumnType returns the type of the column to which values of columnType conflicting with the column type in the warehouse are written
+func evolvedColumnType(policy, columnType string) string {
+	if policy == SchemaDriftPolicyCoerce {
+		return "string"
+	}
+	return columnType
+}
+
+// getEvolvedColumns returns the suffixed columns to be added to the upload schema under evolve & coerce policies,
+// for columns in staging file schemas whose type conflicts with the type in the warehouse
+func getEvolvedColumns(currentSchema warehouseutils.SchemaT, schemaList []warehouseutils.SchemaT, destType, policy string) warehouseutils.SchemaT {
+	evolvedColumns := warehouseutils.SchemaT{}
+	for _, schema := range schemaList {
+		for tableName, columnMap := range schema {
+			currentTableSchema, ok := currentSchema[tableName]
+			if !ok {
+				continue
+			}
+			for columnName, columnType := range columnMap {
+				existingDataType, ok := currentTableSchema[columnName]
+				if !ok || !isTypeConflict(existingDataType, columnType) {
+					continue
+				}
+				if evolvedColumns[tableName] == nil {
+					evolvedColumns[tableName] = map[string]string{}
+				}
+				evolvedType := evolvedColumnType(policy, columnType)
+				evolvedColumns[tableName][GetEvolvedColumnName(destType, columnName, evolvedType)] = evolvedType
+			}
+		}
+	}
+	return evolvedColumns
+}
+
+// evolveColumns moves values of columns with a conflicting type to their evolved columns if present in the upload schema,
+// casting them to strings under coerce policy
+func evolveColumns(event *BatchRouterEventT, tableSchema map[string]string, destType, policy string) {
+	for columnName, columnType := range event.Metadata.Columns {
+		existingDataType, ok := tableSchema[columnName]
+		if !ok || !isTypeConflict(existingDataType, columnType) {
+			continue
+		}
+		evolvedType := evolvedColumnType(policy, columnType)
+		evolvedColumnName := GetEvolvedColumnName(destType, columnName, evolvedType)
+		if _, ok := tableSchema[evolvedColumnName]; !ok {
+			continue
+		}
+		if columnVal, ok := event.Data[columnName]; ok {
+			if policy == SchemaDriftPolicyCoerce {
+				columnVal = coerceToString(columnVal)
+			}
+			event.Data[evolvedColumnName] = columnVal
+		}
+		event.Metadata.Columns[evolvedColumnName] = evolvedType
+		delete(event.Data, columnName)
+		delete(event.Metadata.Columns, columnName)
+	}
+}
+
+// resolveTypeConflict returns the value to be loaded for a value whose type differs from the column type in the warehouse, as per the schema drift policy
+// Values moved to evolved columns under evolve & coerce policies don't conflict anymore, the rest are quarantined unless the policy is strict
+// returns false if the value is to be discarded and an error if the upload is to be failed
+func resolveTypeConflict(policy, tableName, columnName, existingDataType, columnType string, columnVal interface{}) (interface{}, bool, error) {
+	newColumnVal, ok := handleSchemaChange(existingDataType, columnType, columnVal)
+	if ok {
+		return newColumnVal, true, nil
+	}
+	switch policy {
+	case SchemaDriftPolicyStrict:
+		return nil, false, fmt.Errorf("Type conflict in column %s of table %s: expected %s but received %s with schema drift policy: %s", columnName, tableName, existingDataType, columnType, policy)
+	}
+	return nil, false, nil
+}
+
+// coerceToString casts columnVal to a string, arrays & objects are cast to their json
+func coerceToString(columnVal interface{}) interface{} {
+	switch val := columnVal.(type) {
+	case nil:
+		return nil
+	case string:
+		return val
+	case []interface{}, map[string]interface{}:
+		marshalledVal, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprintf("%v", val)
+		}
+		return string(marshalledVal)
+	}
+	return fmt.Sprintf("%v", columnVal)
+}
+
+// recordDiscard adds the discarded value to the per column summary returned with the discards load file
+func (jobRun *JobRunT) recordDiscard(tableName, columnName string, columnVal interface{}) {
+	if jobRun.discardedColumnsMap == nil {
+		jobRun.discardedColumnsMap = map[string]map[string]*DiscardedColumnT{}
+	}
+	if jobRun.discardedColumnsMap[tableName] == nil {
+		jobRun.discardedColumnsMap[tableName] = map[string]*DiscardedColumnT{}
+	}
+	discardedColumn, ok := jobRun.discardedColumnsMap[tableName][columnName]
+	if !ok {
+		discardedColumn = &DiscardedColumnT{TableName: tableName, ColumnName: columnName}
+		jobRun.discardedColumnsMap[tableName][columnName] = discardedColumn
+	}
+	discardedColumn.Count++
+	if len(discardedColumn.SampleValues) < maxDiscardSampleValues {
+		discardedColumn.SampleValues = append(discardedColumn.SampleValues, fmt.Sprintf("%v", columnVal))
+	}
+}
+
+func (jobRun *JobRunT) getDiscardedColumns() (discardedColumns []DiscardedColumnT) {
+	for _, columnMap := range jobRun.discardedColumnsMap {
+		for _, discardedColumn := range columnMap {
+			discardedColumns = append(discardedColumns, *discardedColumn)
+		}
+	}
+	return
+}
+
+// mergeDiscardedColumns sums up counts and samples of discarded columns across load files
+func mergeDiscardedColumns(discardedColumnsList [][]DiscardedColumnT) []DiscardedColumnT {
+	var merged []DiscardedColumnT
+	indexMap := map[string]int{}
+	for _, discardedColumns := range discardedColumnsList {
+		for _, discardedColumn := range discardedColumns {
+			key := fmt.Sprintf(`%s.%s`, discardedColumn.TableName, discardedColumn.ColumnName)
+			idx, ok := indexMap[key]
+			if !ok {
+				indexMap[key] = len(merged)
+				merged = append(merged, DiscardedColumnT{TableName: discardedColumn.TableName, ColumnName: discardedColumn.ColumnName})
+				idx = len(merged) - 1
+			}
+			merged[idx].Count += discardedColumn.Count
+			for _, sampleValue := range discardedColumn.SampleValues {
+				if len(merged[idx].SampleValues) >= maxDiscardSampleValues {
+					break
+				}
+				merged[idx].SampleValues = append(merged[idx].SampleValues, sampleValue)
+			}
+		}
+	}
+	return merged
+}
@@ -0,0 +1,163 @@
+package warehouse
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	warehouseutils "github.com/rudderlabs/rudder-server/warehouse/utils"
+)
+
+var _ = Describe("Schema drift", func() {
+	Describe("GetSchemaDriftPolicy", func() {
+		It("should return the policy set in destination config", func() {
+			for _, policy := range []string{SchemaDriftPolicyStrict, SchemaDriftPolicyEvolve, SchemaDriftPolicyCoerce, SchemaDriftPolicyQuarantine} {
+				Expect(GetSchemaDriftPolicy(map[string]interface{}{"schemaDriftPolicy": policy})).To(Equal(policy))
+			}
+		})
+
+		It("should default to quarantine", func() {
+			Expect(GetSchemaDriftPolicy(map[string]interface{}{})).To(Equal(SchemaDriftPolicyQuarantine))
+			Expect(GetSchemaDriftPolicy(map[string]interface{}{"schemaDriftPolicy": "unknown"})).To(Equal(SchemaDriftPolicyQuarantine))
+			Expect(GetSchemaDriftPolicy(nil)).To(Equal(SchemaDriftPolicyQuarantine))
+		})
+	})
+
+	Describe("GetEvolvedColumnName", func() {
+		It("should suffix the column with the conflicting type in provider case", func() {
+			Expect(GetEvolvedColumnName("POSTGRES", "amount", "string")).To(Equal("amount_string"))
+			Expect(GetEvolvedColumnName("SNOWFLAKE", "AMOUNT", "string")).To(Equal("AMOUNT_STRING"))
+		})
+	})
+
+	Describe("coerceToString", func() {
+		It("should cast values to strings", func() {
+			for _, testCase := range []struct {
+				value    interface{}
+				expected interface{}
+			}{
+				{12.5, "12.5"},
+				{12, "12"},
+				{true, "true"},
+				{"twelve", "twelve"},
+				{[]interface{}{"a", 1.0}, `["a",1]`},
+				{map[string]interface{}{"a": 1.0}, `{"a":1}`},
+			} {
+				Expect(coerceToString(testCase.value)).To(Equal(testCase.expected), "%v", testCase.value)
+			}
+			Expect(coerceToString(nil)).To(BeNil())
+		})
+	})
+
+	Describe("resolveTypeConflict", func() {
+		It("should load values compatible with the column type under all policies", func() {
+			for _, policy := range []string{SchemaDriftPolicyStrict, SchemaDriftPolicyCoerce, SchemaDriftPolicyQuarantine} {
+				value, ok, err := resolveTypeConflict(policy, "tracks", "amount", "string", "int", 12)
+				Expect(err).To(BeNil())
+				Expect(ok).To(BeTrue())
+				Expect(value).To(Equal("12"))
+			}
+		})
+
+		It("should fail the upload on conflicts with strict policy", func() {
+			_, ok, err := resolveTypeConflict(SchemaDriftPolicyStrict, "tracks", "amount", "int", "string", "twelve")
+			Expect(ok).To(BeFalse())
+			Expect(err).To(MatchError(ContainSubstring("Type conflict in column amount of table tracks")))
+		})
+
+		It("should discard conflicting values which aren't moved to evolved columns", func() {
+			for _, policy := range []string{SchemaDriftPolicyCoerce, SchemaDriftPolicyEvolve, SchemaDriftPolicyQuarantine} {
+				_, ok, err := resolveTypeConflict(policy, "tracks", "amount", "int", "string", "12")
+				Expect(err).To(BeNil())
+				Expect(ok).To(BeFalse())
+			}
+		})
+	})
+
+	Describe("getEvolvedColumns", func() {
+		It("should add string columns for conflicting columns under coerce policy and typed ones under evolve policy", func() {
+			currentSchema := warehouseutils.SchemaT{"tracks": {"amount": "int", "id": "string"}}
+			schemaList := []warehouseutils.SchemaT{{"tracks": {"amount": "boolean", "id": "int"}}}
+			Expect(getEvolvedColumns(currentSchema, schemaList, "POSTGRES", SchemaDriftPolicyCoerce)).To(Equal(warehouseutils.SchemaT{"tracks": {"amount_string": "string"}}))
+			Expect(getEvolvedColumns(currentSchema, schemaList, "POSTGRES", SchemaDriftPolicyEvolve)).To(Equal(warehouseutils.SchemaT{"tracks": {"amount_boolean": "boolean"}}))
+		})
+	})
+
+	Describe("evolveColumns", func() {
+		newEvent := func() *BatchRouterEventT {
+			return &BatchRouterEventT{
+				Metadata: MetadataT{Table: "tracks", Columns: map[string]string{"amount": "string", "email": "string", "id": "string"}},
+				Data:     DataT{"amount": "12", "email": "john@example.com", "id": "1"},
+			}
+		}
+
+		It("should move values of conflicting columns to their evolved columns in the upload schema", func() {
+			event := newEvent()
+			evolveColumns(event, map[string]string{"amount": "int", "amount_string": "string", "email": "string", "id": "string"}, "POSTGRES", SchemaDriftPolicyEvolve)
+			Expect(event.Data).To(Equal(DataT{"amount_string": "12", "email": "john@example.com", "id": "1"}))
+			Expect(event.Metadata.Columns).To(Equal(map[string]string{"amount_string": "string", "email": "string", "id": "string"}))
+		})
+
+		It("should cast values of conflicting columns to strings in their string columns under coerce policy", func() {
+			event := &BatchRouterEventT{
+				Metadata: MetadataT{Table: "tracks", Columns: map[string]string{"amount": "string", "flag": "boolean", "id": "string"}},
+				Data:     DataT{"amount": "twelve", "flag": true, "id": "1"},
+			}
+			evolveColumns(event, map[string]string{"amount": "int", "amount_string": "string", "flag": "datetime", "flag_string": "string", "id": "string"}, "POSTGRES", SchemaDriftPolicyCoerce)
+			Expect(event.Data).To(Equal(DataT{"amount_string": "twelve", "flag_string": "true", "id": "1"}))
+			Expect(event.Metadata.Columns).To(Equal(map[string]string{"amount_string": "string", "flag_string": "string", "id": "string"}))
+		})
+
+		It("should leave conflicting columns without evolved columns in the upload schema", func() {
+			event := newEvent()
+			evolveColumns(event, map[string]string{"amount": "int", "email": "string", "id": "string"}, "POSTGRES", SchemaDriftPolicyEvolve)
+			Expect(event.Data).To(Equal(newEvent().Data))
+		})
+
+		It("should mask pii of columns which are evolved", func() {
+			event := newEvent()
+			piiRules := GetPIIRules(map[string]interface{}{
+				"piiRules": []interface{}{
+					map[string]interface{}{"table": "*", "column": "email", "action": "hash"},
+					map[string]interface{}{"table": "*", "column": "amount", "action": "nullify"},
+				},
+			})
+			piiRules.Mask("tracks", event)
+			evolveColumns(event, map[string]string{"amount": "int", "amount_string": "string", "email": "int", "email_string": "string", "id": "string"}, "POSTGRES", SchemaDriftPolicyEvolve)
+
+			Expect(event.Data).To(HaveKey("email_string"))
+			Expect(event.Data["email_string"]).To(HaveLen(64))
+			Expect(event.Data["email_string"]).NotTo(ContainSubstring("john"))
+			Expect(event.Data).NotTo(HaveKey("amount_string"))
+			Expect(event.Data["id"]).To(Equal("1"))
+		})
+	})
+
+	Describe("mergeDiscardedColumns", func() {
+		var sampleValues int
+
+		BeforeEach(func() {
+			sampleValues = maxDiscardSampleValues
+			maxDiscardSampleValues = 3
+		})
+
+		AfterEach(func() {
+			maxDiscardSampleValues = sampleValues
+		})
+
+		It("should sum up counts and samples of columns across load files", func() {
+			merged := mergeDiscardedColumns([][]DiscardedColumnT{
+				{
+					{TableName: "tracks", ColumnName: "amount", Count: 2, SampleValues: []string{"a", "b"}},
+					{TableName: "pages", ColumnName: "amount", Count: 1, SampleValues: []string{"c"}},
+				},
+				{
+					{TableName: "tracks", ColumnName: "amount", Count: 3, SampleValues: []string{"d", "e", "f"}},
+				},
+			})
+			Expect(merged).To(Equal([]DiscardedColumnT{
+				{TableName: "tracks", ColumnName: "amount", Count: 5, SampleValues: []string{"a", "b", "d"}},
+				{TableName: "pages", ColumnName: "amount", Count: 1, SampleValues: []string{"c"}},
+			}))
+		})
+	})
+})
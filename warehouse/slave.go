@@ -41,6 +41,7 @@ type JobRunT struct {
 	tableEventCountMap   map[string]int
 	stagingFileReader    *gzip.Reader
 	whIdentifier         string
+	discardedColumnsMap  map[string]map[string]*DiscardedColumnT
 }
 
 func (jobRun *JobRunT) setStagingFileReader() (reader *gzip.Reader, endOfFile bool) {
@@ -159,11 +160,12 @@ type loadFileUploadJob struct {
 }
 
 type loadFileUploadOutputT struct {
-	TableName     string
-	Location      string
-	TotalRows     int
-	ContentLength int64
-	StagingFileID int64
+	TableName        string
+	Location         string
+	TotalRows        int
+	ContentLength    int64
+	StagingFileID    int64
+	DiscardedColumns []DiscardedColumnT `json:",omitempty"`
 }
 
 func (jobRun *JobRunT) uploadLoadFilesToObjectStorage() ([]loadFileUploadOutputT, error) {
@@ -208,13 +210,17 @@ func (jobRun *JobRunT) uploadLoadFilesToObjectStorage() ([]loadFileUploadOutputT
 						uploadErrorChan <- err
 						return
 					}
-					loadFileOutputChan <- loadFileUploadOutputT{
+					loadFileOutput := loadFileUploadOutputT{
 						TableName:     tableName,
 						Location:      uploadOutput.Location,
 						ContentLength: loadFileStats.Size(),
 						TotalRows:     jobRun.tableEventCountMap[tableName],
 						StagingFileID: stagingFileId,
 					}
+					if tableName == job.getDiscardsTable() {
+						loadFileOutput.DiscardedColumns = jobRun.getDiscardedColumns()
+					}
+					loadFileOutputChan <- loadFileOutput
 				}
 
 			}
@@ -360,6 +366,9 @@ func processStagingFile(job PayloadT, workerIndex int) (loadFileUploadOutputs []
 	}
 
 	sortedTableColumnMap := job.getSortedColumnMapForAllTables()
+	// pii rules are applied while generating load files so that raw values never reach the warehouse
+	piiRules := GetPIIRules(job.DestinationConfig)
+	schemaDriftPolicy := GetSchemaDriftPolicy(job.DestinationConfig)
 
 	reader, endOfFile := jobRun.setStagingFileReader()
 	if endOfFile {
@@ -407,6 +416,13 @@ func processStagingFile(job PayloadT, workerIndex int) (loadFileUploadOutputs []
 		}
 
 		tableName := batchRouterEvent.Metadata.Table
+		// pii rules are written for the columns in the staging file, hence they are masked before evolving renames them
+		if !piiRules.IsEmpty() {
+			piiRules.Mask(tableName, &batchRouterEvent)
+		}
+		if schemaDriftPolicy == SchemaDriftPolicyEvolve || schemaDriftPolicy == SchemaDriftPolicyCoerce {
+			evolveColumns(&batchRouterEvent, job.UploadSchema[tableName], job.DestinationType, schemaDriftPolicy)
+		}
 		columnData := batchRouterEvent.Data
 
 		// Create separate load file for each table
@@ -442,7 +458,14 @@ func processStagingFile(job PayloadT, workerIndex int) (loadFileUploadOutputs []
 			dataTypeInSchema, ok := job.UploadSchema[tableName][columnName]
 			violatedConstraints := ViolatedConstraints(job.DestinationType, &batchRouterEvent, columnName)
 			if ok && ((columnType != dataTypeInSchema) || (violatedConstraints.isViolated)) {
-				newColumnVal, ok := handleSchemaChange(dataTypeInSchema, columnType, columnVal)
+				var newColumnVal interface{}
+				ok := false
+				if !violatedConstraints.isViolated {
+					newColumnVal, ok, err = resolveTypeConflict(schemaDriftPolicy, tableName, columnName, dataTypeInSchema, columnType, columnVal)
+					if err != nil {
+						return nil, err
+					}
+				}
 				if !ok || violatedConstraints.isViolated {
 					if violatedConstraints.isViolated {
 						eventLoader.AddColumn(columnName, job.UploadSchema[tableName][columnName], violatedConstraints.violatedIdentifier)
@@ -760,6 +783,7 @@ func (jobRun *JobRunT) handleDiscardTypes(tableName string, columnName string, c
 			pkgLogger.Errorf("[WH]: Failed to write event to discards table: %v", err)
 			return err
 		}
+		jobRun.recordDiscard(tableName, columnName, columnVal)
 	}
 	return nil
 }
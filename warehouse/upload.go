@@ -1550,6 +1550,21 @@ func (job *UploadJobT) deleteLoadFiles(stagingFiles []*StagingFileT) {
 	}
 }
 
+// recordPIIRulesVersion saves the version of pii rules applied while generating load files in upload metadata for audits
+func (job *UploadJobT) recordPIIRulesVersion() error {
+	piiRules := GetPIIRules(job.warehouse.Destination.Config)
+	if piiRules.IsEmpty() {
+		return nil
+	}
+	sqlStatement := fmt.Sprintf(`UPDATE %s SET metadata = jsonb_set(COALESCE(metadata, '{}'::jsonb), '{pii_rules_version}', to_jsonb($2::text)) WHERE id=$1`, warehouseutils.WarehouseUploadsTable)
+	pkgLogger.Infof(`[WH]: Recording pii rules version %q for upload:%d`, piiRules.Version, job.upload.ID)
+	_, err := job.dbHandle.Exec(sqlStatement, job.upload.ID, piiRules.Version)
+	if err != nil {
+		return fmt.Errorf("Failed to record pii rules version for upload:%d : %w", job.upload.ID, err)
+	}
+	return nil
+}
+
 func (job *UploadJobT) createLoadFiles(generateAll bool) (startLoadFileID int64, endLoadFileID int64, err error) {
 	destID := job.upload.DestinationID
 	destType := job.upload.DestinationType
@@ -1576,6 +1591,10 @@ func (job *UploadJobT) createLoadFiles(generateAll bool) (startLoadFileID int64,
 	job.deleteLoadFiles(toProcessStagingFiles)
 
 	job.setStagingFilesStatus(toProcessStagingFiles, warehouseutils.StagingFileExecutingState)
+	err = job.recordPIIRulesVersion()
+	if err != nil {
+		return 0, 0, err
+	}
 
 	saveLoadFileErrs := []error{}
 	var sampleError error
@@ -1661,7 +1680,10 @@ func (job *UploadJobT) createLoadFiles(generateAll bool) (startLoadFileID int64,
 		}
 
 		pkgLogger.Infof("[WH]: Publishing %d staging files for %s:%s to PgNotifier", len(messages), destType, destID)
-		ch, err := job.pgNotifier.Publish(messages, job.upload.Priority)
+		ch, err := job.pgNotifier.Publish(messages, job.upload.Priority, pgnotifier.FairShareT{
+			Key:    GetFairShareKey(job.warehouse),
+			Weight: GetFairShareWeight(job.warehouse),
+		})
 		if err != nil {
 			panic(err)
 		}
@@ -1776,6 +1798,18 @@ func (job *UploadJobT) bulkInsertLoadFileRecords(loadFiles []loadFileUploadOutpu
 
 	for _, loadFile := range loadFiles {
 		metadata := fmt.Sprintf(`{"content_length": %d}`, loadFile.ContentLength)
+		if len(loadFile.DiscardedColumns) > 0 {
+			var metadataJSON []byte
+			metadataJSON, err = json.Marshal(map[string]interface{}{
+				"content_length":    loadFile.ContentLength,
+				"discarded_columns": loadFile.DiscardedColumns,
+			})
+			if err != nil {
+				txn.Rollback()
+				return
+			}
+			metadata = string(metadataJSON)
+		}
 		_, err = stmt.Exec(loadFile.StagingFileID, loadFile.Location, job.upload.SourceID, job.upload.DestinationID, job.upload.DestinationType, loadFile.TableName, loadFile.TotalRows, timeutil.Now(), metadata)
 		if err != nil {
 			pkgLogger.Errorf(`[WH]: Error copying row in pq.CopyIn for loadFules: %v Error: %v`, loadFile, err)
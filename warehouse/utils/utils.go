@@ -55,6 +55,7 @@ const (
 	WarehouseUploadsTable      = "wh_uploads"
 	WarehouseTableUploadsTable = "wh_table_uploads"
 	WarehouseSchemasTable      = "wh_schemas"
+	WarehouseBackfillsTable    = "wh_backfills"
 )
 
 const (
@@ -66,6 +67,10 @@ const (
 	ExcludeWindow           = "excludeWindow"
 	ExcludeWindowStartTime  = "excludeWindowStartTime"
 	ExcludeWindowEndTime    = "excludeWindowEndTime"
+	SyncCronExpression      = "syncCronExpression"
+	SyncTimezone            = "syncTimezone"
+	SyncMinEvents           = "syncMinEvents"
+	SyncMinBytes            = "syncMinBytes"
 )
 
 const (
@@ -155,6 +160,7 @@ type StagingFileT struct {
 	FirstEventAt     string
 	LastEventAt      string
 	TotalEvents      int
+	TotalBytes       int64
 	UseRudderStorage bool
 	// cloud sources specific info
 	SourceBatchID   string
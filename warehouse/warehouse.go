@@ -83,6 +83,10 @@ var (
 	columnCountThreshold                int
 	ShouldForceSetLowerVersion          bool
 	useParquetLoadFilesRS               bool
+	maxDiscardSampleValues              int
+	backfillUploadPriority              int
+	backfillArchiveListMaxItems         int
+	backfillStatusTrackFrequency        time.Duration
 )
 
 var (
@@ -125,6 +129,8 @@ type HandleT struct {
 	activeWorkerCountLock             sync.RWMutex
 	maxConcurrentUploadJobs           int
 	allowMultipleSourcesForJobsPickup bool
+	maxConcurrentUploadsPerCluster    int
+	clusterInProgressMap              map[string]int
 
 	backgroundCancel context.CancelFunc
 	backgroundGroup  errgroup.Group
@@ -180,6 +186,10 @@ func loadConfig() {
 	config.RegisterDurationConfigVariable(time.Duration(5), &waitForWorkerSleep, false, time.Second, []string{"Warehouse.waitForWorkerSleep", "Warehouse.waitForWorkerSleepInS"}...)
 	config.RegisterBoolConfigVariable(true, &ShouldForceSetLowerVersion, false, "SQLMigrator.forceSetLowerVersion")
 	config.RegisterBoolConfigVariable(false, &useParquetLoadFilesRS, true, "Warehouse.useParquetLoadFilesRS")
+	config.RegisterIntConfigVariable(5, &maxDiscardSampleValues, true, 1, "Warehouse.maxDiscardSampleValues")
+	config.RegisterIntConfigVariable(200, &backfillUploadPriority, true, 1, "Warehouse.backfillUploadPriority")
+	config.RegisterIntConfigVariable(10000, &backfillArchiveListMaxItems, true, 1, "Warehouse.backfillArchiveListMaxItems")
+	config.RegisterDurationConfigVariable(time.Duration(1), &backfillStatusTrackFrequency, false, time.Minute, "Warehouse.backfillStatusTrackFrequency")
 }
 
 // get name of the worker (`destID_namespace`) to be stored in map wh.workerChannelMap
@@ -344,12 +354,12 @@ func (wh *HandleT) getNamespace(config interface{}, source backendconfig.SourceT
 	return namespace
 }
 
-func (wh *HandleT) getStagingFiles(warehouse warehouseutils.WarehouseT, startID int64, endID int64) ([]*StagingFileT, error) {
+func (wh *HandleT) getStagingFiles(warehouse warehouseutils.WarehouseT, startID int64, endID int64, backfillID int64) ([]*StagingFileT, error) {
 	sqlStatement := fmt.Sprintf(`SELECT id, location, status, metadata->>'time_window_year', metadata->>'time_window_month', metadata->>'time_window_day', metadata->>'time_window_hour'
                                 FROM %[1]s
-								WHERE %[1]s.id >= %[2]v AND %[1]s.id <= %[3]v AND %[1]s.source_id='%[4]s' AND %[1]s.destination_id='%[5]s'
+								WHERE %[1]s.id >= %[2]v AND %[1]s.id <= %[3]v AND %[1]s.source_id='%[4]s' AND %[1]s.destination_id='%[5]s' AND %[6]s
 								ORDER BY id ASC`,
-		warehouseutils.WarehouseStagingFilesTable, startID, endID, warehouse.Source.ID, warehouse.Destination.ID)
+		warehouseutils.WarehouseStagingFilesTable, startID, endID, warehouse.Source.ID, warehouse.Destination.ID, backfillFilterSQL(backfillID))
 	rows, err := wh.dbHandle.Query(sqlStatement)
 	if err != nil && err != sql.ErrNoRows {
 		panic(fmt.Errorf("Query: %s failed with Error : %w", sqlStatement, err))
@@ -373,7 +383,7 @@ func (wh *HandleT) getStagingFiles(warehouse warehouseutils.WarehouseT, startID
 
 func (wh *HandleT) getPendingStagingFiles(warehouse warehouseutils.WarehouseT) ([]*StagingFileT, error) {
 	var lastStagingFileID int64
-	sqlStatement := fmt.Sprintf(`SELECT end_staging_file_id FROM %[1]s WHERE %[1]s.destination_type='%[2]s' AND %[1]s.source_id='%[3]s' AND %[1]s.destination_id='%[4]s' ORDER BY %[1]s.id DESC`, warehouseutils.WarehouseUploadsTable, warehouse.Type, warehouse.Source.ID, warehouse.Destination.ID)
+	sqlStatement := fmt.Sprintf(`SELECT end_staging_file_id FROM %[1]s WHERE %[1]s.destination_type='%[2]s' AND %[1]s.source_id='%[3]s' AND %[1]s.destination_id='%[4]s' AND %[5]s ORDER BY %[1]s.id DESC`, warehouseutils.WarehouseUploadsTable, warehouse.Type, warehouse.Source.ID, warehouse.Destination.ID, notBackfillSQL)
 
 	err := wh.dbHandle.QueryRow(sqlStatement).Scan(&lastStagingFileID)
 	if err != nil && err != sql.ErrNoRows {
@@ -382,9 +392,9 @@ func (wh *HandleT) getPendingStagingFiles(warehouse warehouseutils.WarehouseT) (
 
 	sqlStatement = fmt.Sprintf(`SELECT id, location, status, first_event_at, last_event_at, metadata->>'source_batch_id', metadata->>'source_task_id', metadata->>'source_task_run_id', metadata->>'source_job_id', metadata->>'source_job_run_id', metadata->>'use_rudder_storage', metadata->>'time_window_year', metadata->>'time_window_month', metadata->>'time_window_day', metadata->>'time_window_hour'
                                 FROM %[1]s
-								WHERE %[1]s.id > %[2]v AND %[1]s.source_id='%[3]s' AND %[1]s.destination_id='%[4]s'
+								WHERE %[1]s.id > %[2]v AND %[1]s.source_id='%[3]s' AND %[1]s.destination_id='%[4]s' AND %[5]s
 								ORDER BY id ASC`,
-		warehouseutils.WarehouseStagingFilesTable, lastStagingFileID, warehouse.Source.ID, warehouse.Destination.ID)
+		warehouseutils.WarehouseStagingFilesTable, lastStagingFileID, warehouse.Source.ID, warehouse.Destination.ID, notBackfillSQL)
 	rows, err := wh.dbHandle.Query(sqlStatement)
 	if err != nil && err != sql.ErrNoRows {
 		panic(fmt.Errorf("Query: %s failed with Error : %w", sqlStatement, err))
@@ -449,12 +459,13 @@ func (wh *HandleT) initUpload(warehouse warehouseutils.WarehouseT, jsonUploadsLi
 		"source_job_id":      jsonUploadsList[0].SourceJobID,
 		"source_job_run_id":  jsonUploadsList[0].SourceJobRunID,
 		"load_file_type":     getLoadFileType(wh.destType),
+		"priority":           priorityClassUploadPriority[GetPriorityClass(warehouse)],
 	}
 	if isUploadTriggered {
 		// set priority to 50 if the upload was manually triggered
 		metadataMap["priority"] = 50
 	}
-	if priority != 0 {
+	if priority != 0 && priority != defaultUploadPriority {
 		metadataMap["priority"] = priority
 	}
 	metadata, err := json.Marshal(metadataMap)
@@ -475,6 +486,7 @@ func (wh *HandleT) setDestInProgress(warehouse warehouseutils.WarehouseT, jobID
 	wh.inProgressMapLock.Lock()
 	defer wh.inProgressMapLock.Unlock()
 	wh.inProgressMap[WorkerIdentifierT(identifier)] = append(wh.inProgressMap[WorkerIdentifierT(identifier)], JobIDT(jobID))
+	wh.clusterInProgressMap[GetClusterIdentifier(warehouse)]++
 }
 
 func (wh *HandleT) removeDestInProgress(warehouse warehouseutils.WarehouseT, jobID int64) {
@@ -483,6 +495,7 @@ func (wh *HandleT) removeDestInProgress(warehouse warehouseutils.WarehouseT, job
 	if idx, inProgess := wh.isUploadJobInProgress(warehouse, jobID); inProgess {
 		identifier := wh.workerIdentifier(warehouse)
 		wh.inProgressMap[WorkerIdentifierT(identifier)] = removeFromJobsIDT(wh.inProgressMap[WorkerIdentifierT(identifier)], idx)
+		wh.clusterInProgressMap[GetClusterIdentifier(warehouse)]--
 	}
 }
 
@@ -559,7 +572,7 @@ func (wh *HandleT) createUploadJobsFromStagingFiles(warehouse warehouseutils.War
 }
 
 func (wh *HandleT) getLatestUploadStatus(warehouse warehouseutils.WarehouseT) (uploadID int64, status string, priority int) {
-	sqlStatement := fmt.Sprintf(`SELECT id, status, COALESCE(metadata->>'priority', '100')::int FROM %[1]s WHERE %[1]s.destination_type='%[2]s' AND %[1]s.source_id='%[3]s' AND %[1]s.destination_id='%[4]s' ORDER BY id DESC LIMIT 1`, warehouseutils.WarehouseUploadsTable, wh.destType, warehouse.Source.ID, warehouse.Destination.ID)
+	sqlStatement := fmt.Sprintf(`SELECT id, status, COALESCE(metadata->>'priority', '100')::int FROM %[1]s WHERE %[1]s.destination_type='%[2]s' AND %[1]s.source_id='%[3]s' AND %[1]s.destination_id='%[4]s' AND %[5]s ORDER BY id DESC LIMIT 1`, warehouseutils.WarehouseUploadsTable, wh.destType, warehouse.Source.ID, warehouse.Destination.ID, notBackfillSQL)
 	err := wh.dbHandle.QueryRow(sqlStatement).Scan(&uploadID, &status, &priority)
 	if err != nil && err != sql.ErrNoRows {
 		pkgLogger.Errorf(`Error getting latest upload status for warehouse: %v`, err)
@@ -816,7 +829,7 @@ func (wh *HandleT) getUploadsToProcess(availableWorkers int, skipIdentifiers []s
 		upload.SourceType = warehouse.Source.SourceDefinition.Name
 		upload.SourceCategory = warehouse.Source.SourceDefinition.Category
 
-		stagingFilesList, err := wh.getStagingFiles(warehouse, upload.StartStagingFileID, upload.EndStagingFileID)
+		stagingFilesList, err := wh.getStagingFiles(warehouse, upload.StartStagingFileID, upload.EndStagingFileID, gjson.GetBytes(upload.Metadata, "backfill_id").Int())
 		if err != nil {
 			return nil, err
 		}
@@ -883,12 +896,15 @@ loop:
 
 		inProgressNamespaces := wh.getInProgressNamespaces()
 		pkgLogger.Debugf(`Current inProgress namespace identifiers for %s: %v`, wh.destType, inProgressNamespaces)
+		// skip warehouses whose cluster is already running max allowed uploads
+		inProgressNamespaces = append(inProgressNamespaces, wh.getClustersAtAdmissionLimit()...)
 
 		uploadJobsToProcess, err := wh.getUploadsToProcess(availableWorkers, inProgressNamespaces)
 		if err != nil {
 			pkgLogger.Errorf(`Error executing getUploadsToProcess: %v`, err)
 			panic(err)
 		}
+		uploadJobsToProcess = wh.admitUploadJobs(uploadJobsToProcess)
 
 		for _, uploadJob := range uploadJobsToProcess {
 			wh.setDestInProgress(uploadJob.warehouse, uploadJob.upload.ID)
@@ -1018,9 +1034,11 @@ func (wh *HandleT) Setup(whType string, whName string) {
 	wh.Enable()
 	wh.workerChannelMap = make(map[string]chan *UploadJobT)
 	wh.inProgressMap = make(map[WorkerIdentifierT][]JobIDT)
+	wh.clusterInProgressMap = make(map[string]int)
 	config.RegisterIntConfigVariable(8, &wh.noOfWorkers, true, 1, fmt.Sprintf(`Warehouse.%v.noOfWorkers`, whName), "Warehouse.noOfWorkers")
 	config.RegisterIntConfigVariable(1, &wh.maxConcurrentUploadJobs, false, 1, fmt.Sprintf(`Warehouse.%v.maxConcurrentUploadJobs`, whName))
 	config.RegisterBoolConfigVariable(false, &wh.allowMultipleSourcesForJobsPickup, false, fmt.Sprintf(`Warehouse.%v.allowMultipleSourcesForJobsPickup`, whName))
+	config.RegisterIntConfigVariable(0, &wh.maxConcurrentUploadsPerCluster, true, 1, fmt.Sprintf(`Warehouse.%v.maxConcurrentUploadsPerCluster`, whName), "Warehouse.maxConcurrentUploadsPerCluster")
 
 	ctx, cancel := context.WithCancel(context.Background())
 	g, ctx := errgroup.WithContext(ctx)
@@ -1255,6 +1273,7 @@ func processHandler(w http.ResponseWriter, r *http.Request) {
 		"time_window_month":  stagingFile.TimeWindow.Month(),
 		"time_window_day":    stagingFile.TimeWindow.Day(),
 		"time_window_hour":   stagingFile.TimeWindow.Hour(),
+		"total_bytes":        stagingFile.TotalBytes,
 	}
 	metadata, err := json.Marshal(metadataMap)
 	if err != nil {
@@ -1401,7 +1420,7 @@ func getPendingStagingFileCount(sourceOrDestId string, isSourceId bool) (fileCou
 		sourceOrDestColumn = "destination_id"
 	}
 	var lastStagingFileID int64
-	sqlStatement := fmt.Sprintf(`SELECT MAX(end_staging_file_id) FROM %[1]s WHERE %[1]s.%[3]s='%[2]s'`, warehouseutils.WarehouseUploadsTable, sourceOrDestId, sourceOrDestColumn)
+	sqlStatement := fmt.Sprintf(`SELECT MAX(end_staging_file_id) FROM %[1]s WHERE %[1]s.%[3]s='%[2]s' AND %[4]s`, warehouseutils.WarehouseUploadsTable, sourceOrDestId, sourceOrDestColumn, notBackfillSQL)
 
 	err = dbHandle.QueryRow(sqlStatement).Scan(&lastStagingFileID)
 	if err != nil && err != sql.ErrNoRows {
@@ -1411,8 +1430,8 @@ func getPendingStagingFileCount(sourceOrDestId string, isSourceId bool) (fileCou
 
 	sqlStatement = fmt.Sprintf(`SELECT COUNT(*)
                                 FROM %[1]s
-								WHERE %[1]s.id > %[2]v AND %[1]s.%[4]s='%[3]s'`,
-		warehouseutils.WarehouseStagingFilesTable, lastStagingFileID, sourceOrDestId, sourceOrDestColumn)
+								WHERE %[1]s.id > %[2]v AND %[1]s.%[4]s='%[3]s' AND %[5]s`,
+		warehouseutils.WarehouseStagingFilesTable, lastStagingFileID, sourceOrDestId, sourceOrDestColumn, notBackfillSQL)
 
 	err = dbHandle.QueryRow(sqlStatement).Scan(&fileCount)
 	if err != nil && err != sql.ErrNoRows {
@@ -1432,8 +1451,8 @@ func getPendingUploadCount(sourceOrDestId string, isSourceId bool) (uploadCount
 	}
 	sqlStatement := fmt.Sprintf(`SELECT COUNT(*)
 								FROM %[1]s
-								WHERE %[1]s.status NOT IN ('%[2]s', '%[3]s') AND %[1]s.%[5]s='%[4]s'
-	`, warehouseutils.WarehouseUploadsTable, ExportedData, Aborted, sourceOrDestId, sourceOrDestColumn)
+								WHERE %[1]s.status NOT IN ('%[2]s', '%[3]s') AND %[1]s.%[5]s='%[4]s' AND %[6]s
+	`, warehouseutils.WarehouseUploadsTable, ExportedData, Aborted, sourceOrDestId, sourceOrDestColumn, notBackfillSQL)
 
 	err = dbHandle.QueryRow(sqlStatement).Scan(&uploadCount)
 	if err != nil && err != sql.ErrNoRows {
@@ -1597,6 +1616,8 @@ func startWebHandler(ctx context.Context) error {
 			mux.HandleFunc("/v1/warehouse/pending-events", pendingEventsHandler)
 			// triggers uploads for a source
 			mux.HandleFunc("/v1/warehouse/trigger-upload", triggerUploadHandler)
+			// re-syncs archived and existing staging files of a source and destination for a time range
+			mux.HandleFunc("/v1/warehouse/backfill", backfillHandler)
 			mux.HandleFunc("/databricksVersion", databricksVersionHandler)
 			pkgLogger.Infof("WH: Starting warehouse master service in %d", webPort)
 		} else {
@@ -1751,6 +1772,10 @@ func Start(ctx context.Context, app app.Interface) error {
 			runArchiver(ctx, dbHandle)
 			return nil
 		}))
+		g.Go(misc.WithBugsnag(func() error {
+			runBackfillStatusTracker(ctx)
+			return nil
+		}))
 		InitWarehouseAPI(dbHandle, pkgLogger.Child("upload_api"))
 	}
 